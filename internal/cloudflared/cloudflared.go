@@ -16,9 +16,12 @@ package cloudflared
 
 import (
 	"cfui/internal/logger"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -32,11 +35,23 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// ErrInitFailed wraps every error caused by a failed library initialization.
+// Because tunnel.Init runs only once per process, it is permanent until the
+// process restarts.
+var ErrInitFailed = errors.New("cloudflared initialization failed")
+
 var (
-	initOnce     sync.Once
-	initErr      error
+	initOnce = new(sync.Once)
+	initErr  error
+	// initOK is set inside initOnce only after every init step returned, so a
+	// panic anywhere in initLibrary leaves it false.
+	initOK       bool
 	shutdownOnce sync.Once
 
+	// initLibrary performs the one-time library setup; replaced in tests to
+	// simulate init failures.
+	initLibrary = defaultInitLibrary
+
 	// gracefulShutdownC is handed to tunnel.Init and shared by all tunnel
 	// runs. cloudflared closes it from its own signal handler on
 	// SIGTERM/SIGINT, and ShutdownProcess closes it on app shutdown.
@@ -47,6 +62,10 @@ var (
 	// that re-registrations from restarted or parallel instances are
 	// ignored instead of panicking.
 	metricsRegistry = prometheus.NewRegistry()
+	// registerer is the wrapper installed as the default registerer. It
+	// remembers what tunnel runs registered so a later run's collectors can
+	// replace them after ResetMetrics.
+	registerer = newSafeRegisterer(metricsRegistry)
 )
 
 // EnsureInit initializes the embedded cloudflared library. It is safe to call
@@ -58,7 +77,7 @@ func EnsureInit(softwareName string) error {
 	initOnce.Do(func() {
 		defer func() {
 			if rec := recover(); rec != nil {
-				initErr = fmt.Errorf("cloudflared init panic: %v", rec)
+				initErr = fmt.Errorf("%w: %w", ErrInitFailed, recoveredError("panic in tunnel.Init", rec))
 				logErrorf("Panic during cloudflared initialization: %v", rec)
 			}
 		}()
@@ -66,31 +85,39 @@ func EnsureInit(softwareName string) error {
 		if strings.TrimSpace(softwareName) == "" {
 			softwareName = "cfui"
 		}
-		version.ChangeSoftName(softwareName)
-		buildInfo := cliutil.GetBuildInfo("dockers-x", version.GetFullVersion())
-
-		updater.Init(buildInfo)
-		tunnel.Init(buildInfo, gracefulShutdownC)
-
-		// Route every registration through one duplicate-tolerant registry.
-		// cloudflared re-registers collectors on each tunnel start; with a
-		// plain registry the second start would panic.
-		prometheus.DefaultRegisterer = newSafeRegisterer(metricsRegistry)
-
-		// cloudflared's CLI calls os.Exit on fatal errors, which would kill
-		// the whole control panel. Intercept it once for the process.
-		cli.OsExiter = func(exitCode int) {
-			logWarnf("cloudflared CLI attempted to exit with code %d (intercepted)", exitCode)
-			if exitCode != 0 {
-				panic(fmt.Sprintf("CLI exit with code %d", exitCode))
-			}
-		}
+		initLibrary(softwareName)
+		initOK = true
 
 		logInfof("Cloudflared library initialized (software: %s, version: %s)", softwareName, version.GetFullVersion())
 	})
+	if !initOK && initErr == nil {
+		initErr = ErrInitFailed
+	}
 	return initErr
 }
 
+func defaultInitLibrary(softwareName string) {
+	version.ChangeSoftName(softwareName)
+	buildInfo := cliutil.GetBuildInfo("dockers-x", version.GetFullVersion())
+
+	updater.Init(buildInfo)
+	tunnel.Init(buildInfo, gracefulShutdownC)
+
+	// Route every registration through one duplicate-tolerant registry.
+	// cloudflared re-registers collectors on each tunnel start; with a
+	// plain registry the second start would panic.
+	prometheus.DefaultRegisterer = registerer
+
+	// cloudflared's CLI calls os.Exit on fatal errors, which would kill
+	// the whole control panel. Intercept it once for the process.
+	cli.OsExiter = func(exitCode int) {
+		logWarnf("cloudflared CLI attempted to exit with code %d (intercepted)", exitCode)
+		if exitCode != 0 {
+			panic(fmt.Sprintf("CLI exit with code %d", exitCode))
+		}
+	}
+}
+
 // ShutdownProcess broadcasts a graceful shutdown to every tunnel instance by
 // closing the shared shutdown channel. Call this only on application exit:
 // once closed, no tunnel can be started again in this process.
@@ -113,6 +140,35 @@ func MetricsRegistry() *prometheus.Registry {
 	return metricsRegistry
 }
 
+// MetricsGatherer gathers from the shared registry plus the original default
+// registry, which holds the collectors cloudflared registers from package
+// init functions before EnsureInit swaps the default registerer.
+func MetricsGatherer() prometheus.Gatherer {
+	return prometheus.Gatherers{metricsRegistry, prometheus.DefaultGatherer}
+}
+
+// libraryModule is the module path of the embedded cloudflared library.
+const libraryModule = "github.com/cloudflare/cloudflared"
+
+// LibraryVersion reports the version of the embedded cloudflared module from
+// the binary's build info, or "unknown" when it is unavailable.
+func LibraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != libraryModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return "unknown"
+}
+
 // Process signal ownership.
 //
 // Every tunnel run spawns an upstream waitForSignal goroutine that closes the
@@ -191,13 +247,37 @@ func scheduleSignalReclaim() {
 	}()
 }
 
+// ResetMetrics marks the collectors tunnel runs registered so far as stale
+// and returns how many there are. A restarted run registers fresh
+// collectors, which the registry would otherwise refuse as duplicates while
+// the stale ones, or ones with different const labels, linger; a fresh
+// collector now takes the place of the stale ones sharing its metric names.
+// Collectors cloudflared registers once per process are never registered
+// again and so stay. Call it only while no tunnel runs.
+func ResetMetrics() int {
+	return registerer.markStale()
+}
+
 // safeRegisterer wraps a Prometheus registerer and ignores duplicate
 // registrations, which cloudflared produces whenever a tunnel is restarted.
 type safeRegisterer struct {
 	prometheus.Registerer
+
+	mu         sync.Mutex
+	registered []registeredCollector
+	// run counts ResetMetrics calls; collectors registered in an earlier
+	// run are stale.
+	run int
+}
+
+// registeredCollector is a collector registered through safeRegisterer.
+type registeredCollector struct {
+	collector prometheus.Collector
+	names     []string
+	run       int
 }
 
-func newSafeRegisterer(reg prometheus.Registerer) prometheus.Registerer {
+func newSafeRegisterer(reg prometheus.Registerer) *safeRegisterer {
 	return &safeRegisterer{Registerer: reg}
 }
 
@@ -210,14 +290,66 @@ func isDuplicateRegistration(err error) bool {
 }
 
 func (s *safeRegisterer) Register(c prometheus.Collector) error {
+	names := metricNames(c)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unregisterStale(names)
 	err := s.Registerer.Register(c)
 	if isDuplicateRegistration(err) {
 		logDebugf("Collector already registered (ignored): %v", err)
 		return nil
 	}
+	if err == nil {
+		s.registered = append(s.registered, registeredCollector{collector: c, names: names, run: s.run})
+	}
 	return err
 }
 
+// unregisterStale removes the collectors of earlier runs that export any of
+// names, making room for the collector about to replace them. Callers hold
+// s.mu.
+func (s *safeRegisterer) unregisterStale(names []string) {
+	kept := s.registered[:0]
+	for _, rc := range s.registered {
+		if rc.run < s.run && slices.ContainsFunc(rc.names, func(name string) bool { return slices.Contains(names, name) }) {
+			s.Unregister(rc.collector)
+			logDebugf("Replaced metrics collector of an earlier tunnel run: %v", rc.names)
+			continue
+		}
+		kept = append(kept, rc)
+	}
+	clear(s.registered[len(kept):])
+	s.registered = kept
+}
+
+// markStale starts a new run and returns how many collectors earlier runs
+// registered.
+func (s *safeRegisterer) markStale() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.run++
+	return len(s.registered)
+}
+
+// metricNames returns the fully-qualified metric names c describes.
+func metricNames(c prometheus.Collector) []string {
+	descs := make(chan *prometheus.Desc)
+	go func() {
+		c.Describe(descs)
+		close(descs)
+	}()
+	var names []string
+	for desc := range descs {
+		// Desc exposes its name only through String:
+		// Desc{fqName: "name", help: ...}.
+		_, rest, _ := strings.Cut(desc.String(), `fqName: "`)
+		if name, _, ok := strings.Cut(rest, `"`); ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func (s *safeRegisterer) MustRegister(cs ...prometheus.Collector) {
 	for _, c := range cs {
 		if err := s.Register(c); err != nil {
@@ -227,28 +359,29 @@ func (s *safeRegisterer) MustRegister(cs ...prometheus.Collector) {
 }
 
 // Logging helpers tolerate an uninitialized global logger so the package can
-// be exercised from unit tests without logger setup.
+// be exercised from unit tests without logger setup. They log as the
+// cloudflared component.
 
 func logDebugf(format string, args ...any) {
-	if logger.Sugar != nil {
-		logger.Sugar.Debugf(format, args...)
+	if l := logger.Named(logger.ComponentCloudflared); l != nil {
+		l.Debugf(format, args...)
 	}
 }
 
 func logInfof(format string, args ...any) {
-	if logger.Sugar != nil {
-		logger.Sugar.Infof(format, args...)
+	if l := logger.Named(logger.ComponentCloudflared); l != nil {
+		l.Infof(format, args...)
 	}
 }
 
 func logWarnf(format string, args ...any) {
-	if logger.Sugar != nil {
-		logger.Sugar.Warnf(format, args...)
+	if l := logger.Named(logger.ComponentCloudflared); l != nil {
+		l.Warnf(format, args...)
 	}
 }
 
 func logErrorf(format string, args ...any) {
-	if logger.Sugar != nil {
-		logger.Sugar.Errorf(format, args...)
+	if l := logger.Named(logger.ComponentCloudflared); l != nil {
+		l.Errorf(format, args...)
 	}
 }
@@ -3,9 +3,26 @@ package cloudflared
 import (
 	"context"
 	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"os"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"cfui/internal/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestBuildArgsMinimal(t *testing.T) {
@@ -29,17 +46,17 @@ func TestBuildArgsFull(t *testing.T) {
 		LogJSON:         true,
 		EdgeIPVersion:   "4",
 		EdgeBindAddress: "192.0.2.1",
-		PostQuantum:     true,
+		PostQuantumMode: PostQuantumRequire,
 		NoTLSVerify:     true,
 		ExtraArgs:       `--ha-connections 8 --tag "a b"`,
 	}
-	args := BuildArgs(opts, "http2", "/tmp/cfg.yaml")
+	args := BuildArgs(opts, "quic", "/tmp/cfg.yaml")
 	want := []string{
 		"cloudflared", "tunnel",
 		"--config", "/tmp/cfg.yaml",
 		"--no-autoupdate",
 		"run", "--token", "tok",
-		"--protocol", "http2",
+		"--protocol", "quic",
 		"--grace-period", "10s",
 		"--region", "us",
 		"--retries", "3",
@@ -58,6 +75,54 @@ func TestBuildArgsFull(t *testing.T) {
 	}
 }
 
+func TestBuildArgsSoleLogSinkOmitsLogfile(t *testing.T) {
+	args := BuildArgs(Options{Token: "tok", LogFile: "/tmp/t.log", SoleLogSink: true}, "quic", "")
+	if slices.Contains(args, "--logfile") {
+		t.Fatalf("BuildArgs = %v, want no --logfile when cfui is the sole log sink", args)
+	}
+}
+
+func TestManagedFlagsMatchBuildArgs(t *testing.T) {
+	opts := Options{
+		Token:           "tok",
+		GracePeriod:     "10s",
+		Region:          "us",
+		Retries:         3,
+		MetricsEnable:   true,
+		MetricsPort:     60123,
+		LogLevel:        "debug",
+		LogFile:         "/tmp/t.log",
+		LogJSON:         true,
+		EdgeIPVersion:   "4",
+		EdgeBindAddress: "192.0.2.1",
+		PostQuantumMode: PostQuantumRequire,
+		NoTLSVerify:     true,
+	}
+	args := BuildArgs(opts, "quic", "")
+	// Everything after "run --token tok" comes from the table.
+	var built []string
+	for _, arg := range args[slices.Index(args, "run")+3:] {
+		if strings.HasPrefix(arg, "--") {
+			built = append(built, strings.TrimPrefix(arg, "--"))
+		}
+	}
+	var listed []string
+	for _, flag := range ManagedFlags {
+		listed = append(listed, flag.Name)
+		if len(flag.Fields) == 0 {
+			t.Errorf("flag %q names no config field", flag.Name)
+		}
+	}
+	if !slices.Equal(built, listed) {
+		t.Fatalf("BuildArgs passed %v, ManagedFlags lists %v", built, listed)
+	}
+	for _, flag := range ManagedFlags {
+		if _, ok := flag.Arg(Options{Token: "tok"}, "auto"); ok {
+			t.Errorf("flag %q is passed for default options", flag.Name)
+		}
+	}
+}
+
 func TestBuildArgsDefaultsOmitted(t *testing.T) {
 	// Default values must not produce flags.
 	opts := Options{
@@ -74,6 +139,83 @@ func TestBuildArgsDefaultsOmitted(t *testing.T) {
 	}
 }
 
+func TestBuildArgsPostQuantumModes(t *testing.T) {
+	cases := []struct {
+		mode     string
+		protocol string
+		want     bool
+	}{
+		{mode: "", protocol: "quic", want: false},
+		{mode: "off", protocol: "quic", want: false},
+		{mode: "prefer", protocol: "quic", want: false},
+		{mode: PostQuantumRequire, protocol: "quic", want: true},
+		{mode: PostQuantumRequire, protocol: "auto", want: true},
+		{mode: PostQuantumRequire, protocol: "http2", want: false},
+	}
+	for _, tc := range cases {
+		args := BuildArgs(Options{Token: "tok", PostQuantumMode: tc.mode}, tc.protocol, "")
+		got := false
+		for _, arg := range args {
+			got = got || arg == "--post-quantum"
+		}
+		if got != tc.want {
+			t.Errorf("mode %q over %s: --post-quantum = %v, want %v (%v)", tc.mode, tc.protocol, got, tc.want, args)
+		}
+	}
+	if err := (Options{Token: "tok", Protocol: "http2", PostQuantumMode: PostQuantumRequire}).Validate(); err == nil {
+		t.Error("require over explicit http2 should fail validation")
+	}
+}
+
+func TestBuildArgsEdgeInterface(t *testing.T) {
+	orig := interfaceAddrs
+	t.Cleanup(func() { interfaceAddrs = orig })
+	addr := "192.0.2.10"
+	interfaceAddrs = func(name string) ([]net.Addr, error) {
+		if name != "eth-test" {
+			return nil, errors.New("no such network interface")
+		}
+		return []net.Addr{
+			&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+			&net.IPNet{IP: net.ParseIP("2001:db8::10"), Mask: net.CIDRMask(64, 128)},
+			&net.IPNet{IP: net.ParseIP(addr), Mask: net.CIDRMask(24, 32)},
+		}, nil
+	}
+	bindArg := func(o Options) string {
+		t.Helper()
+		resolved, err := o.ResolveEdgeInterface()
+		if err != nil {
+			t.Fatalf("ResolveEdgeInterface(%+v): %v", o, err)
+		}
+		args := BuildArgs(resolved, "auto", "")
+		for n, arg := range args {
+			if arg == "--edge-bind-address" {
+				return args[n+1]
+			}
+		}
+		return ""
+	}
+
+	opts := Options{Token: "tok", EdgeInterface: "eth-test"}
+	if got := bindArg(opts); got != addr {
+		t.Fatalf("auto bind address = %q, want %q", got, addr)
+	}
+	opts.EdgeIPVersion = "6"
+	if got := bindArg(opts); got != "2001:db8::10" {
+		t.Fatalf("IPv6 bind address = %q, want the global address", got)
+	}
+	// A DHCP renewal is picked up by the next resolve.
+	addr = "192.0.2.20"
+	opts.EdgeIPVersion = "4"
+	if got := bindArg(opts); got != addr {
+		t.Fatalf("bind address after renewal = %q, want %q", got, addr)
+	}
+
+	if _, err := (Options{Token: "tok", EdgeInterface: "missing0"}).ResolveEdgeInterface(); err == nil {
+		t.Fatal("resolving a missing interface should fail")
+	}
+}
+
 func TestParseExtraArgs(t *testing.T) {
 	cases := []struct {
 		in   string
@@ -103,6 +245,20 @@ func TestOptionsValidate(t *testing.T) {
 	}
 }
 
+func TestRestartRequiredCoversRunStartSettings(t *testing.T) {
+	started := Options{Token: "t", MaxRuntime: 24 * time.Hour}
+	next := started
+	if changed := started.RestartRequired(next); len(changed) != 0 {
+		t.Fatalf("unchanged options need a restart for %v", changed)
+	}
+	next.MaxRuntime = 12 * time.Hour
+	next.EdgeIPFallback = true
+	want := []string{"edge_ip_fallback", "max_runtime"}
+	if changed := started.RestartRequired(next); !slices.Equal(changed, want) {
+		t.Fatalf("changed = %v, want %v", changed, want)
+	}
+}
+
 func TestIsRetryableError(t *testing.T) {
 	cases := []struct {
 		err  error
@@ -123,6 +279,32 @@ func TestIsRetryableError(t *testing.T) {
 	}
 }
 
+func TestCustomErrorPatternsFlipRetryDecision(t *testing.T) {
+	t.Cleanup(func() { SetErrorPatterns(ErrorPatterns{}) })
+
+	quota := errors.New("edge said: Quota Exceeded for account")
+	token := errors.New("invalid token")
+	if !IsRetryableError(quota) || IsRetryableError(token) {
+		t.Fatal("built-in classification changed")
+	}
+
+	SetErrorPatterns(ErrorPatterns{Retryable: []string{"Invalid Token"}, NonRetryable: []string{" quota exceeded "}})
+	if IsRetryableError(quota) {
+		t.Error("custom non-retryable pattern did not stop the retry")
+	}
+	if got := NonRetryableReason(quota); got != "custom" {
+		t.Errorf("NonRetryableReason = %q, want custom", got)
+	}
+	if !IsRetryableError(token) {
+		t.Error("custom retryable pattern did not override the built-in list")
+	}
+
+	SetErrorPatterns(ErrorPatterns{})
+	if !IsRetryableError(quota) || IsRetryableError(token) {
+		t.Error("clearing custom patterns did not restore the built-in classification")
+	}
+}
+
 func TestIsProtocolRelatedError(t *testing.T) {
 	cases := []struct {
 		err  error
@@ -226,16 +408,16 @@ func TestInstanceProtocolSelection(t *testing.T) {
 
 	inst.mu.Lock()
 	// Explicit protocol always wins.
-	if got := inst.selectProtocol("http2"); got != "http2" {
+	if got := inst.selectProtocol("http2", nil, 0); got != "http2" {
 		t.Fatalf("explicit protocol = %q, want http2", got)
 	}
 	// Back to auto: keeps current until failures accumulate.
 	inst.currentProtocol = "quic"
-	if got := inst.selectProtocol("auto"); got != "quic" {
+	if got := inst.selectProtocol("auto", nil, 0); got != "quic" {
 		t.Fatalf("auto protocol = %q, want quic", got)
 	}
 	inst.protocolFailures["quic"] = maxProtocolFailuresBeforeSwitch
-	if got := inst.selectProtocol("auto"); got != "http2" {
+	if got := inst.selectProtocol("auto", nil, 0); got != "http2" {
 		t.Fatalf("after failures protocol = %q, want http2", got)
 	}
 	if inst.protocolFailures["quic"] != 0 {
@@ -244,6 +426,120 @@ func TestInstanceProtocolSelection(t *testing.T) {
 	inst.mu.Unlock()
 }
 
+func TestInstanceProtocolOrder(t *testing.T) {
+	inst := NewInstance("test", func() (Options, error) { return Options{Token: "tok"}, nil })
+	order := []string{"http2", "quic"}
+
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	if got := inst.selectProtocol("auto", order, 0); got != "http2" {
+		t.Fatalf("first auto protocol = %q, want http2 from the custom order", got)
+	}
+	inst.protocolFailures["http2"] = maxProtocolFailuresBeforeSwitch
+	if got := inst.selectProtocol("auto", order, 0); got != "quic" {
+		t.Fatalf("fallback protocol = %q, want quic", got)
+	}
+	inst.protocolFailures["quic"] = maxProtocolFailuresBeforeSwitch
+	if got := inst.selectProtocol("auto", order, 0); got != "http2" {
+		t.Fatalf("second fallback protocol = %q, want http2", got)
+	}
+
+	// A single-entry order stays put instead of switching.
+	inst.protocolFailures["http2"] = maxProtocolFailuresBeforeSwitch
+	if got := inst.selectProtocol("auto", []string{"http2"}, 0); got != "http2" || inst.protocolSwitchCount != 2 {
+		t.Fatalf("single-entry order = %q after %d switches, want http2 after 2", got, inst.protocolSwitchCount)
+	}
+}
+
+func TestInstanceProtocolSwitchesStopAtCap(t *testing.T) {
+	inst := NewInstance("test", func() (Options, error) { return Options{Token: "tok"}, nil })
+
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	protocol := inst.selectProtocol("auto", nil, 2)
+	for want := 1; want <= 2; want++ {
+		inst.protocolFailures[protocol] = maxProtocolFailuresBeforeSwitch
+		next := inst.selectProtocol("auto", nil, 2)
+		if next == protocol || inst.protocolSwitchCount != want {
+			t.Fatalf("switch %d: %s -> %s after %d switches", want, protocol, next, inst.protocolSwitchCount)
+		}
+		protocol = next
+	}
+	inst.protocolFailures[protocol] = maxProtocolFailuresBeforeSwitch
+	if got := inst.selectProtocol("auto", nil, 2); got != protocol || inst.protocolSwitchCount != 2 || !inst.protocolThrashing {
+		t.Fatalf("past the cap: protocol %s (was %s), %d switches, thrashing %v; want it kept after 2 switches", got, protocol, inst.protocolSwitchCount, inst.protocolThrashing)
+	}
+
+	// A stable run clears the cap.
+	inst.stableRunAfter = time.Millisecond
+	inst.mu.Unlock()
+	inst.recordProtocolSuccess(time.Second)
+	inst.mu.Lock()
+	if inst.protocolSwitchCount != 0 || inst.protocolThrashing {
+		t.Fatalf("after a stable run: %d switches, thrashing %v; want both reset", inst.protocolSwitchCount, inst.protocolThrashing)
+	}
+}
+
+func TestStartInitPanicKeepsUnderlyingError(t *testing.T) {
+	origOnce, origErr, origOK, origInit := initOnce, initErr, initOK, initLibrary
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary = origOnce, origErr, origOK, origInit
+	})
+	cause := errors.New("open /etc/ssl/certs: permission denied")
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) { panic(cause) }
+
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok"}, nil })
+	err := inst.Start()
+	if !errors.Is(err, ErrInitFailed) || !errors.Is(err, cause) {
+		t.Fatalf("Start error = %v, want ErrInitFailed wrapping the panic value", err)
+	}
+	st := inst.Status()
+	if !errors.Is(st.LastError, cause) || st.LastErrorAt.IsZero() {
+		t.Fatalf("status last error = %v at %v, want the wrapped cause with a time", st.LastError, st.LastErrorAt)
+	}
+	if stack := ErrorStack(st.LastError); !strings.Contains(string(stack), "EnsureInit") {
+		t.Fatalf("stack of the recovered panic missing EnsureInit:\n%s", stack)
+	}
+}
+
+func TestRunPanicModeCrashPropagatesPanic(t *testing.T) {
+	origRun := runApp
+	t.Cleanup(func() {
+		runApp = origRun
+		SetPanicMode(PanicRecover)
+	})
+	runApp = func(context.Context, *cli.App, []string) error { panic("boom") }
+
+	opts := Options{Token: "tok"}
+	run := func() (inst *Instance, propagated any) {
+		inst = NewInstance("home", func() (Options, error) { return opts, nil })
+		defer func() { propagated = recover() }()
+		inst.runTunnel(context.Background(), opts, make(chan struct{}))
+		return inst, nil
+	}
+
+	for raw, want := range map[string]PanicMode{"": PanicRecover, "Crash": PanicCrash, "bogus": PanicRecover} {
+		if mode, _ := ParsePanicMode(raw); mode != want {
+			t.Fatalf("ParsePanicMode(%q) = %q, want %q", raw, mode, want)
+		}
+	}
+
+	SetPanicMode(PanicRecover)
+	inst, rec := run()
+	if rec != nil {
+		t.Fatalf("recover mode let the panic through: %v", rec)
+	}
+	if st := inst.Status(); st.Running || st.LastError == nil || !strings.Contains(st.LastError.Error(), "boom") {
+		t.Fatalf("status after recovered panic = %+v, want stopped with the panic as last error", st)
+	}
+
+	SetPanicMode(PanicCrash)
+	if _, rec := run(); rec != "boom" {
+		t.Fatalf("crash mode propagated %v, want the original panic value", rec)
+	}
+}
+
 func TestInstanceStartValidation(t *testing.T) {
 	inst := NewInstance("test", func() (Options, error) { return Options{}, nil })
 	if err := inst.Start(); err == nil {
@@ -260,9 +556,809 @@ func TestInstanceStartValidation(t *testing.T) {
 	}
 }
 
+func TestInstanceStartReportsInitFailure(t *testing.T) {
+	origOnce, origErr, origOK, origInit := initOnce, initErr, initOK, initLibrary
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary = origOnce, origErr, origOK, origInit
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) { panic("simulated tunnel.Init failure") }
+
+	inst := NewInstance("test", func() (Options, error) { return Options{Token: "tok"}, nil })
+	err := inst.Start()
+	if !errors.Is(err, ErrInitFailed) {
+		t.Fatalf("Start error = %v, want ErrInitFailed", err)
+	}
+	if !strings.Contains(err.Error(), "simulated tunnel.Init failure") {
+		t.Fatalf("Start error %q does not describe the init failure", err)
+	}
+	st := inst.Status()
+	if st.Running {
+		t.Fatal("instance must not be running after init failure")
+	}
+	if !errors.Is(st.LastError, ErrInitFailed) {
+		t.Fatalf("Status.LastError = %v, want ErrInitFailed", st.LastError)
+	}
+
+	// The failure is sticky: later starts fail fast with the same error.
+	if err := inst.Start(); !errors.Is(err, ErrInitFailed) {
+		t.Fatalf("second Start error = %v, want ErrInitFailed", err)
+	}
+}
+
+func TestInstanceEmitsStartAndStopEvents(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	var mu sync.Mutex
+	var events []EventType
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok", TunnelName: "home-nas"}, nil })
+	inst.OnEvent(func(typ EventType, _ string) {
+		mu.Lock()
+		events = append(events, typ)
+		mu.Unlock()
+	})
+
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := inst.Start(); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("second Start error = %v, want ErrAlreadyRunning", err)
+	}
+	if err := inst.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []EventType{EventStart, EventStop}; !reflect.DeepEqual(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+}
+
+func TestInstanceStopWithinWaitsForRunOrTimesOut(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	release := make(chan struct{})
+	var stuck atomic.Bool
+	runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+		<-ctx.Done()
+		if stuck.Load() {
+			// A run stuck in the library outlives its canceled context.
+			<-release
+		}
+		return ctx.Err()
+	}
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok"}, nil })
+
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := inst.StopWithin(StopUser, time.Second); err != nil {
+		t.Fatalf("StopWithin on a run that exits: %v", err)
+	}
+
+	stuck.Store(true)
+	if err := inst.Start(); err != nil {
+		t.Fatalf("second Start: %v", err)
+	}
+	inst.mu.Lock()
+	done := inst.done
+	inst.mu.Unlock()
+	defer func() {
+		// Let the stuck run finish before the cleanup restores runApp.
+		close(release)
+		<-done
+	}()
+	begin := time.Now()
+	if err := inst.StopWithin(StopUser, 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("StopWithin on a stuck run = %v, want a timeout error", err)
+	}
+	if waited := time.Since(begin); waited > time.Second {
+		t.Fatalf("StopWithin waited %v, want about 50ms", waited)
+	}
+	if inst.Status().Running {
+		t.Fatal("instance still reports running after the stop timed out")
+	}
+}
+
+func TestInstanceEdgeIPFallbackSwitchesToIPv4(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	versions := make(chan string, 16)
+	runApp = func(ctx context.Context, _ *cli.App, args []string) error {
+		version := args[slices.Index(args, "--edge-ip-version")+1]
+		versions <- version
+		if version == "6" {
+			return errors.New("dial tcp [2606:4700:a0::1]:7844: connect: network is unreachable")
+		}
+		<-ctx.Done()
+		return nil
+	}
+
+	inst := NewInstance("home", func() (Options, error) {
+		return Options{Token: "tok", AutoRestart: true, EdgeIPVersion: "6", EdgeIPFallback: true}, nil
+	})
+	inst.restartBackoff = NewBackoff(time.Millisecond, time.Millisecond, time.Minute, true)
+	var mu sync.Mutex
+	var switches []string
+	inst.OnEvent(func(typ EventType, detail string) {
+		if typ == EventEdgeIPSwitch {
+			mu.Lock()
+			switches = append(switches, detail)
+			mu.Unlock()
+		}
+	})
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	nextRun := func() string {
+		t.Helper()
+		select {
+		case v := <-versions:
+			return v
+		case <-time.After(5 * time.Second):
+			t.Fatal("tunnel did not run again")
+			return ""
+		}
+	}
+	var got []string
+	for range maxEdgeIPFailuresBeforeSwitch + 1 {
+		got = append(got, nextRun())
+	}
+	if want := []string{"6", "6", "6", "4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("edge IP versions per run = %v, want %v", got, want)
+	}
+	mu.Lock()
+	if want := []string{"6 -> 4"}; !reflect.DeepEqual(switches, want) {
+		t.Fatalf("edge IP switch events = %v, want %v", switches, want)
+	}
+	mu.Unlock()
+	if st := inst.Status(); !st.Running || st.EdgeIPVersion != "4" {
+		t.Fatalf("status = running %v on edge IP version %q, want running on 4", st.Running, st.EdgeIPVersion)
+	}
+
+	// A manual start tries IPv6 again.
+	if err := inst.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if v := nextRun(); v != "6" {
+		t.Fatalf("edge IP version after a manual start = %q, want 6", v)
+	}
+}
+
+func TestInstanceStatusReportsNextRestartDuringBackoff(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	runApp = func(context.Context, *cli.App, []string) error {
+		return errors.New("connection refused")
+	}
+
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok", AutoRestart: true}, nil })
+	before := time.Now()
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	deadline := time.Now().Add(5 * time.Second)
+	var st Status
+	for st = inst.Status(); st.NextRestartAt.IsZero(); st = inst.Status() {
+		if time.Now().After(deadline) {
+			t.Fatal("Status never reported a pending restart")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if st.Running {
+		t.Fatal("instance reports running while in backoff")
+	}
+	if !st.NextRestartAt.After(before.Add(restartBackoffBaseDelay / 2)) {
+		t.Fatalf("NextRestartAt = %v, want about %v after start", st.NextRestartAt, restartBackoffBaseDelay)
+	}
+
+	if err := inst.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if at := inst.Status().NextRestartAt; !at.IsZero() {
+		t.Fatalf("NextRestartAt after Stop = %v, want zero", at)
+	}
+}
+
+func TestInstanceCancelRestartPreventsScheduledRestart(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	var runs atomic.Int32
+	runApp = func(context.Context, *cli.App, []string) error {
+		runs.Add(1)
+		return errors.New("connection refused")
+	}
+
+	const delay = 100 * time.Millisecond
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok", AutoRestart: true}, nil })
+	inst.restartBackoff = NewBackoff(delay, delay, time.Minute, true)
+	if err := inst.CancelRestart(); !errors.Is(err, ErrNoPendingRestart) {
+		t.Fatalf("CancelRestart before start = %v, want ErrNoPendingRestart", err)
+	}
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for inst.Status().NextRestartAt.IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatal("no restart was scheduled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := inst.CancelRestart(); err != nil {
+		t.Fatalf("CancelRestart: %v", err)
+	}
+
+	time.Sleep(3 * delay)
+	if n := runs.Load(); n != 1 {
+		t.Fatalf("tunnel ran %d times, want 1 (restart should be canceled)", n)
+	}
+	if st := inst.Status(); st.Running || !st.NextRestartAt.IsZero() {
+		t.Fatalf("status after cancel = %+v, want stopped with no pending restart", st)
+	}
+	if err := inst.CancelRestart(); !errors.Is(err, ErrNoPendingRestart) {
+		t.Fatalf("second CancelRestart = %v, want ErrNoPendingRestart", err)
+	}
+}
+
+func TestInstanceStopAfterRestartBackoffPreventsRestart(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	var runs atomic.Int32
+	runApp = func(context.Context, *cli.App, []string) error {
+		runs.Add(1)
+		return errors.New("connection refused")
+	}
+
+	// The options are read on start, by the restart policy, and by the
+	// restart's own start. Stopping on that third read lands the stop after
+	// the backoff wait, just before the new run would launch.
+	var inst *Instance
+	var reads atomic.Int32
+	stopped := make(chan error, 1)
+	inst = NewInstance("home", func() (Options, error) {
+		if reads.Add(1) == 3 {
+			stopped <- inst.Stop()
+		}
+		return Options{Token: "tok", AutoRestart: true}, nil
+	})
+	inst.restartBackoff = NewBackoff(time.Millisecond, time.Millisecond, time.Minute, true)
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the auto-restart never reached its start")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		history := inst.RestartHistory()
+		if len(history) == 1 && history[0].Outcome == RestartCanceled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("restart history = %+v, want one canceled attempt", history)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := runs.Load(); n != 1 {
+		t.Fatalf("tunnel ran %d times, want 1 (the stop should win)", n)
+	}
+	if st := inst.Status(); st.Running {
+		t.Fatal("tunnel is running after Stop")
+	}
+}
+
+func TestInstanceStopReason(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	fail := make(chan error)
+	runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-fail:
+			return err
+		}
+	}
+
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok"}, nil })
+	if got := inst.Status().StopReason; got != StopNeverStarted {
+		t.Fatalf("before start: StopReason = %q, want %q", got, StopNeverStarted)
+	}
+
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if got := inst.Status().StopReason; got != "" {
+		t.Fatalf("running: StopReason = %q, want none", got)
+	}
+	if err := inst.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if got := inst.Status().StopReason; got != StopUser {
+		t.Fatalf("after Stop: StopReason = %q, want %q", got, StopUser)
+	}
+
+	// Auto-restart is off, so a failed run leaves the tunnel stopped.
+	if err := inst.Start(); err != nil {
+		t.Fatalf("second Start: %v", err)
+	}
+	fail <- errors.New("connection refused")
+	deadline := time.Now().Add(5 * time.Second)
+	for inst.Status().Running {
+		if time.Now().After(deadline) {
+			t.Fatal("tunnel kept running after its run failed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := inst.Status().StopReason; got != StopError {
+		t.Fatalf("after failed run: StopReason = %q, want %q", got, StopError)
+	}
+}
+
+func TestInstanceRecordsRestartHistory(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	var runs atomic.Int32
+	runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+		if runs.Add(1) <= 2 {
+			return errors.New("connection refused")
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	const delay = 10 * time.Millisecond
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok", AutoRestart: true}, nil })
+	inst.restartBackoff = NewBackoff(delay, delay, time.Minute, true)
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runs.Load() < 3 || len(inst.RestartHistory()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("runs = %d, history = %+v; want 3 runs and 2 restarts", runs.Load(), inst.RestartHistory())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	history := inst.RestartHistory()
+	if len(history) != 2 {
+		t.Fatalf("history = %+v, want 2 attempts", history)
+	}
+	for n, attempt := range history {
+		if attempt.Attempt != n+1 || attempt.Reason != "connection refused" || attempt.Backoff != delay || attempt.Outcome != RestartStarted || attempt.Time.IsZero() {
+			t.Errorf("attempt %d = %+v, want attempt %d after connection refused, started with %v backoff", n, attempt, n+1, delay)
+		}
+	}
+	if !history[0].Time.Before(history[1].Time) {
+		t.Errorf("attempts out of order: %v then %v", history[0].Time, history[1].Time)
+	}
+}
+
+func TestInstanceResetsRestartCountOnlyAfterSustainedRun(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	const stable = 50 * time.Millisecond
+	for _, tc := range []struct {
+		name        string
+		ranFor      time.Duration
+		wantAttempt int
+	}{
+		// A clean exit right after launch proved nothing: keep counting.
+		{name: "quick clean exit", ranFor: 0, wantAttempt: 4},
+		{name: "sustained run", ranFor: 2 * stable, wantAttempt: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			initOnce, initErr, initOK = new(sync.Once), nil, false
+			initLibrary = func(string) {}
+			var runs atomic.Int32
+			runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+				if runs.Add(1) == 1 {
+					time.Sleep(tc.ranFor)
+					return nil
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			const delay = 10 * time.Millisecond
+			inst := NewInstance("home", func() (Options, error) {
+				return Options{Token: "tok", Protocol: "quic", AutoRestart: true}, nil
+			})
+			inst.restartBackoff = NewBackoff(delay, delay, time.Minute, true)
+			inst.stableRunAfter = stable
+			// Three restarts of an ongoing incident happened before.
+			inst.restartCount, inst.lastRestart = 3, time.Now()
+			if err := inst.Start(); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			t.Cleanup(func() { _ = inst.Stop() })
+
+			deadline := time.Now().Add(5 * time.Second)
+			for len(inst.RestartHistory()) < 1 {
+				if time.Now().After(deadline) {
+					t.Fatal("no restart was recorded")
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+			if got := inst.RestartHistory()[0].Attempt; got != tc.wantAttempt {
+				t.Fatalf("restart attempt = %d, want %d", got, tc.wantAttempt)
+			}
+		})
+	}
+}
+
 func TestInstanceStopWhenNotRunning(t *testing.T) {
 	inst := NewInstance("test", func() (Options, error) { return Options{Token: "tok"}, nil })
 	if err := inst.Stop(); err != nil {
 		t.Fatalf("Stop on idle instance returned error: %v", err)
 	}
 }
+
+func TestSafeRegistererIgnoresDuplicates(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	safe := newSafeRegisterer(reg)
+	newGauge := func() prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{Name: "cfui_test_duplicate"})
+	}
+	safe.MustRegister(newGauge())
+	// A restarted instance registers an identical collector again.
+	if err := safe.Register(newGauge()); err != nil {
+		t.Fatalf("duplicate registration should be ignored, got %v", err)
+	}
+	families, err := reg.Gather()
+	if err != nil || len(families) != 1 || len(families[0].GetMetric()) != 1 {
+		t.Fatalf("registry should hold one sample, got %v (err %v)", families, err)
+	}
+}
+
+func TestInstanceLifecycleLogIncludesTunnelName(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	prev := logger.Sugar
+	logger.Sugar = zap.New(core).Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	inst := NewInstance("home", func() (Options, error) { return Options{TunnelName: "home-nas"}, nil })
+	if err := inst.Start(); err == nil {
+		t.Fatal("expected validation error for missing token")
+	}
+	if logs.FilterMessageSnippet("home-nas").Len() == 0 {
+		t.Fatalf("lifecycle log lacks the tunnel name: %v", logs.All())
+	}
+}
+
+func TestTagsRenderIntoTempConfig(t *testing.T) {
+	opts := Options{Tags: map[string]string{"team": "infra", "env": "prod", "version": "v2"}}
+	path, err := createTempConfig(opts.TagPairs())
+	if err != nil {
+		t.Fatalf("createTempConfig: %v", err)
+	}
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read temp config: %v", err)
+	}
+	want := "tag:\n  - env=prod\n  - team=infra\n  - version=v2\n"
+	if string(data) != want {
+		t.Fatalf("temp config =\n%s\nwant\n%s", data, want)
+	}
+
+	// The deprecated CustomTag still yields the version tag on its own ...
+	if got := tagsYAML(Options{CustomTag: "v1"}.TagPairs()); got != "tag:\n  - version=v1\n" {
+		t.Fatalf("legacy custom tag rendered %q", got)
+	}
+	// ... and never overrides an explicit version tag.
+	legacy := Options{CustomTag: "v1", Tags: map[string]string{"version": "v2"}}
+	if got := legacy.TagPairs(); len(got) != 1 || got[0] != "version=v2" {
+		t.Fatalf("TagPairs = %v, want [version=v2]", got)
+	}
+}
+
+func TestInstanceHourlyRestartLimitTripsBreaker(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	var runs atomic.Int32
+	seeded := make(chan struct{})
+	runApp = func(context.Context, *cli.App, []string) error {
+		<-seeded
+		runs.Add(1)
+		return errors.New("connection refused")
+	}
+
+	inst := NewInstance("home", func() (Options, error) {
+		return Options{Token: "tok", AutoRestart: true, MaxRestartsPerHour: 3}, nil
+	})
+	inst.restartBackoff = NewBackoff(time.Millisecond, time.Millisecond, time.Minute, true)
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	// Seed earlier restarts after Start, which clears them, but before the
+	// first run ends. The last one
+	// is old enough to reset the short backoff, so only the hourly count
+	// can stop the next restarts; the two-hour-old one has left the window.
+	now := time.Now()
+	inst.mu.Lock()
+	inst.restartTimes = []time.Time{now.Add(-2 * time.Hour), now.Add(-20 * time.Minute), now.Add(-10 * time.Minute)}
+	inst.lastRestart = now.Add(-10 * time.Minute)
+	inst.mu.Unlock()
+	close(seeded)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for inst.Status().StopReason != StopFlapping {
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %+v after %d runs, want stop reason flapping", inst.Status(), runs.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	// Two seeded restarts are in the window, so one more is allowed.
+	if n := runs.Load(); n != 2 {
+		t.Fatalf("tunnel ran %d times, want 2", n)
+	}
+	if st := inst.Status(); st.Running || !st.NextRestartAt.IsZero() {
+		t.Fatalf("status = %+v, want stopped with no restart pending", st)
+	}
+}
+
+func TestInstanceMaxRuntimeTriggersScheduledRestart(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	var runs atomic.Int32
+	runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+		runs.Add(1)
+		<-ctx.Done()
+		return nil
+	}
+
+	// Auto-restart is off: a scheduled restart must not depend on it.
+	inst := NewInstance("home", func() (Options, error) {
+		return Options{Token: "tok", MaxRuntime: time.Minute}, nil
+	})
+	expiries := make(chan chan time.Time, 2)
+	inst.after = func(d time.Duration) <-chan time.Time {
+		if d != time.Minute {
+			t.Errorf("max runtime timer armed for %v, want 1m", d)
+		}
+		c := make(chan time.Time, 1)
+		expiries <- c
+		return c
+	}
+	var restarts atomic.Int32
+	inst.OnEvent(func(typ EventType, _ string) {
+		if typ == EventRestart {
+			restarts.Add(1)
+		}
+	})
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+
+	(<-expiries) <- time.Now()
+	// The second run arms its own timer.
+	select {
+	case <-expiries:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("tunnel did not restart after its maximum runtime; status = %+v", inst.Status())
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for runs.Load() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("tunnel ran %d times, want 2", runs.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := restarts.Load(); n != 1 {
+		t.Fatalf("restart events = %d, want 1", n)
+	}
+	if !inst.Status().Running {
+		t.Fatalf("status = %+v, want running", inst.Status())
+	}
+	inst.mu.Lock()
+	counted := len(inst.restartTimes)
+	inst.mu.Unlock()
+	if counted != 0 {
+		t.Fatalf("scheduled restart counted toward the hourly limit (%d)", counted)
+	}
+}
+
+func TestResetMetricsKeepsRegistryBoundedAcrossRuns(t *testing.T) {
+	origOnce, origErr, origOK, origInit, origRun, origReg := initOnce, initErr, initOK, initLibrary, runApp, registerer
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp, registerer = origOnce, origErr, origOK, origInit, origRun, origReg
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	reg := prometheus.NewRegistry()
+	registerer = newSafeRegisterer(reg)
+	// Like cloudflared's per-run collectors, each run registers a fresh
+	// gauge; its const label differs per run, so the registry does not see
+	// it as a duplicate. A collector behind a sync.Once is registered by the
+	// first run only, like cloudflared's tunnel and QUIC client metrics.
+	var runs atomic.Int32
+	var once sync.Once
+	sent := prometheus.NewCounter(prometheus.CounterOpts{Name: "cfui_test_sent_bytes"})
+	registeredC := make(chan struct{}, 1)
+	runApp = func(ctx context.Context, _ *cli.App, _ []string) error {
+		n := runs.Add(1)
+		registerer.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "cfui_test_run",
+			ConstLabels: prometheus.Labels{"run": strconv.Itoa(int(n))},
+		}))
+		once.Do(func() { registerer.MustRegister(sent) })
+		sent.Inc()
+		registeredC <- struct{}{}
+		<-ctx.Done()
+		return nil
+	}
+
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok"}, nil })
+	for i := 0; i < 20; i++ {
+		ResetMetrics()
+		if err := inst.Start(); err != nil {
+			t.Fatalf("cycle %d: Start: %v", i, err)
+		}
+		<-registeredC
+		if err := inst.Stop(); err != nil {
+			t.Fatalf("cycle %d: Stop: %v", i, err)
+		}
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	samples := make(map[string][]*dto.Metric)
+	for _, family := range families {
+		samples[family.GetName()] = family.GetMetric()
+	}
+	if got := samples["cfui_test_run"]; len(got) != 1 || got[0].GetLabel()[0].GetValue() != "20" {
+		t.Fatalf("registry holds %v after 20 runs, want only the last run's sample", got)
+	}
+	if got := samples["cfui_test_sent_bytes"]; len(got) != 1 || got[0].GetCounter().GetValue() != 20 {
+		t.Fatalf("once-registered collector gathers %v after 20 runs, want one sample of 20", got)
+	}
+}
+
+func TestResetMetricsReplacesStaleDuplicate(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	r := newSafeRegisterer(reg)
+	first := prometheus.NewGauge(prometheus.GaugeOpts{Name: "cfui_test_ha_connections"})
+	r.MustRegister(first)
+	first.Set(4)
+
+	// Within a run, a duplicate is ignored and the first collector stays.
+	r.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "cfui_test_ha_connections"}))
+	r.markStale()
+	next := prometheus.NewGauge(prometheus.GaugeOpts{Name: "cfui_test_ha_connections"})
+	r.MustRegister(next)
+	next.Set(1)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	if len(families) != 1 || families[0].GetMetric()[0].GetGauge().GetValue() != 1 {
+		t.Fatalf("gathered %v, want the next run's gauge only", families)
+	}
+}
+
+func TestInstanceRestartJitterStaysInRange(t *testing.T) {
+	inst := NewInstance("home", func() (Options, error) { return Options{Token: "tok"}, nil })
+	inst.jitterRand = rand.New(rand.NewPCG(1, 2)).Float64
+
+	if got := inst.jittered(10*time.Second, 0); got != 10*time.Second {
+		t.Fatalf("zero jitter changed the delay to %v", got)
+	}
+	lo, hi := time.Duration(math.MaxInt64), time.Duration(0)
+	for n := 0; n < 500; n++ {
+		d := inst.jittered(10*time.Second, 20)
+		if d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("draw %d: delay %v outside 10s ±20%%", n, d)
+		}
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	if lo > 9*time.Second || hi < 11*time.Second {
+		t.Fatalf("delays spanned only %v..%v, want them spread across the range", lo, hi)
+	}
+
+	// The auto-restart schedule uses the jittered delay.
+	origOnce, origErr, origOK, origInit, origRun := initOnce, initErr, initOK, initLibrary, runApp
+	t.Cleanup(func() {
+		initOnce, initErr, initOK, initLibrary, runApp = origOnce, origErr, origOK, origInit, origRun
+	})
+	initOnce, initErr, initOK = new(sync.Once), nil, false
+	initLibrary = func(string) {}
+	runApp = func(context.Context, *cli.App, []string) error { return errors.New("connection refused") }
+
+	inst = NewInstance("home", func() (Options, error) {
+		return Options{Token: "tok", AutoRestart: true, RestartJitter: 20}, nil
+	})
+	inst.restartBackoff = NewBackoff(time.Hour, time.Hour, time.Minute, true)
+	inst.jitterRand = func() float64 { return 0.75 }
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { _ = inst.Stop() })
+	deadline := time.Now().Add(5 * time.Second)
+	for inst.Status().NextRestartAt.IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatalf("no restart scheduled; status = %+v", inst.Status())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	inst.mu.Lock()
+	delay := inst.nextRestart.Sub(inst.lastRestart)
+	inst.mu.Unlock()
+	if want := time.Hour + 6*time.Minute; delay != want {
+		t.Fatalf("restart delay = %v, want %v", delay, want)
+	}
+}
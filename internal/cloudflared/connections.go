@@ -0,0 +1,100 @@
+package cloudflared
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connection describes one registered edge connection of a tunnel, as
+// reported by cloudflared when the connection is established.
+type Connection struct {
+	Index         int       `json:"index"`
+	ID            string    `json:"id,omitempty"`
+	Location      string    `json:"location"`
+	IP            string    `json:"ip,omitempty"`
+	Protocol      string    `json:"protocol"`
+	EstablishedAt time.Time `json:"established_at"`
+}
+
+// ConnectionEvent is a parsed connection (un)registration log line.
+type ConnectionEvent struct {
+	Registered bool
+	Connection Connection
+}
+
+const (
+	registeredConnectionMsg   = "Registered tunnel connection"
+	unregisteredConnectionMsg = "Unregistered tunnel connection"
+)
+
+// ParseConnectionEvent recognizes cloudflared's connection registration and
+// unregistration log lines in either console ("key=value") or JSON format.
+// now is used when the line carries no parsable timestamp.
+func ParseConnectionEvent(line string, now time.Time) (ConnectionEvent, bool) {
+	var registered bool
+	switch {
+	case strings.Contains(line, unregisteredConnectionMsg):
+		registered = false
+	case strings.Contains(line, registeredConnectionMsg):
+		registered = true
+	default:
+		return ConnectionEvent{}, false
+	}
+
+	fields := connectionLogFields(line)
+	index, err := strconv.Atoi(fields["connIndex"])
+	if err != nil {
+		return ConnectionEvent{}, false
+	}
+	conn := Connection{
+		Index:         index,
+		ID:            fields["connection"],
+		Location:      fields["location"],
+		IP:            fields["ip"],
+		Protocol:      fields["protocol"],
+		EstablishedAt: now,
+	}
+	if ts, err := time.Parse(time.RFC3339, fields["time"]); err == nil {
+		conn.EstablishedAt = ts
+	}
+	return ConnectionEvent{Registered: registered, Connection: conn}, true
+}
+
+// connectionLogFields extracts key/value pairs from a JSON log object or from
+// the key=value tokens of a console log line. A leading RFC 3339 timestamp in
+// console output is reported under "time".
+func connectionLogFields(line string) map[string]string {
+	fields := make(map[string]string)
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var raw map[string]any
+		if err := json.Unmarshal([]byte(trimmed), &raw); err == nil {
+			for k, v := range raw {
+				switch v := v.(type) {
+				case string:
+					fields[k] = v
+				case float64:
+					fields[k] = strconv.FormatFloat(v, 'f', -1, 64)
+				}
+			}
+			return fields
+		}
+	}
+
+	tokens := strings.Fields(trimmed)
+	if len(tokens) > 0 {
+		if _, err := time.Parse(time.RFC3339, tokens[0]); err == nil {
+			fields["time"] = tokens[0]
+		}
+	}
+	for _, token := range tokens {
+		key, value, ok := strings.Cut(token, "=")
+		if !ok || key == "" {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"`)
+	}
+	return fields
+}
@@ -1,6 +1,12 @@
 package cloudflared
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+)
 
 // Error classification drives the auto-restart and protocol-fallback
 // decisions. Matching is substring-based because the embedded library
@@ -22,6 +28,15 @@ var (
 		"connection timeout",
 	}
 
+	// Errors that point at the IP family rather than the transport, e.g. a
+	// host without working IPv6 routes.
+	edgeIPFamilyErrorPatterns = []string{
+		"network is unreachable",
+		"no route to host",
+		"address family not supported",
+		"cannot assign requested address",
+	}
+
 	// Network errors that are retryable.
 	retryableErrorPatterns = []string{
 		"connection refused",
@@ -48,6 +63,50 @@ var (
 	}
 )
 
+// ErrorPatterns are operator-supplied substrings that extend the built-in
+// retry classification.
+type ErrorPatterns struct {
+	Retryable    []string
+	NonRetryable []string
+}
+
+// customErrorPatterns holds the lowercased operator patterns; nil means
+// none are configured.
+var customErrorPatterns atomic.Pointer[ErrorPatterns]
+
+// SetErrorPatterns installs operator patterns for IsRetryableError. They
+// are lowercased once here, matched case-insensitively, and checked before
+// the built-in lists so they can override them.
+func SetErrorPatterns(p ErrorPatterns) {
+	if len(p.Retryable) == 0 && len(p.NonRetryable) == 0 {
+		customErrorPatterns.Store(nil)
+		return
+	}
+	customErrorPatterns.Store(&ErrorPatterns{
+		Retryable:    lowerPatterns(p.Retryable),
+		NonRetryable: lowerPatterns(p.NonRetryable),
+	})
+}
+
+func lowerPatterns(patterns []string) []string {
+	lowered := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" {
+			lowered = append(lowered, pattern)
+		}
+	}
+	return lowered
+}
+
+func containsAny(errMsg string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(errMsg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsProtocolRelatedError reports whether an error looks like a transport
 // problem worth counting against the current protocol in auto mode.
 func IsProtocolRelatedError(err error) bool {
@@ -70,10 +129,37 @@ func IsProtocolRelatedError(err error) bool {
 	return false
 }
 
+// IsEdgeIPFamilyError reports whether an error looks like the edge IP
+// family is unusable, worth counting against IPv6 with EdgeIPFallback.
+func IsEdgeIPFamilyError(err error) bool {
+	return err != nil && containsAny(strings.ToLower(err.Error()), edgeIPFamilyErrorPatterns)
+}
+
+// NonRetryableReason returns the built-in pattern that makes err
+// non-retryable, such as "invalid token", "custom" when an operator pattern
+// decided it, or "" when err is retryable. Unlike the error text it never
+// carries user data.
+func NonRetryableReason(err error) string {
+	if IsRetryableError(err) || err == nil {
+		return ""
+	}
+	errMsg := strings.ToLower(err.Error())
+	if p := customErrorPatterns.Load(); p != nil && containsAny(errMsg, p.NonRetryable) {
+		return "custom"
+	}
+	for _, pattern := range nonRetryableErrorPatterns {
+		if strings.Contains(errMsg, pattern) {
+			return pattern
+		}
+	}
+	return ""
+}
+
 // IsRetryableError reports whether an error should trigger auto-restart.
 // Network errors are retryable; configuration and authentication errors are
 // not. Unknown errors default to retryable so transient edge problems
-// recover without operator intervention.
+// recover without operator intervention. Patterns installed with
+// SetErrorPatterns are consulted first.
 func IsRetryableError(err error) bool {
 	if err == nil {
 		return false
@@ -81,6 +167,15 @@ func IsRetryableError(err error) bool {
 
 	errMsg := strings.ToLower(err.Error())
 
+	if p := customErrorPatterns.Load(); p != nil {
+		if containsAny(errMsg, p.Retryable) {
+			return true
+		}
+		if containsAny(errMsg, p.NonRetryable) {
+			return false
+		}
+	}
+
 	for _, pattern := range retryableErrorPatterns {
 		if strings.Contains(errMsg, pattern) {
 			return true
@@ -93,3 +188,68 @@ func IsRetryableError(err error) bool {
 	}
 	return true
 }
+
+// panicError is an error recovered from a panic, carrying the stack of the
+// goroutine that panicked.
+type panicError struct {
+	err   error
+	stack []byte
+}
+
+func (e *panicError) Error() string { return e.err.Error() }
+func (e *panicError) Unwrap() error { return e.err }
+
+// recoveredError turns a recovered panic value into an error prefixed with
+// what was running. A panic value that is an error stays in the chain, so
+// errors.Is and errors.As still reach it.
+func recoveredError(prefix string, rec any) error {
+	var err error
+	if e, ok := rec.(error); ok {
+		err = fmt.Errorf("%s: %w", prefix, e)
+	} else {
+		err = fmt.Errorf("%s: %v", prefix, rec)
+	}
+	return &panicError{err: err, stack: debug.Stack()}
+}
+
+// ErrorStack returns the stack captured when err, or an error it wraps,
+// was recovered from a panic, or nil.
+func ErrorStack(err error) []byte {
+	var pe *panicError
+	if errors.As(err, &pe) {
+		return pe.stack
+	}
+	return nil
+}
+
+// PanicMode decides what happens to a panic inside a tunnel run.
+type PanicMode string
+
+const (
+	// PanicRecover logs the panic, records it as the tunnel's last error
+	// and keeps cfui running. It is the default.
+	PanicRecover PanicMode = "recover"
+	// PanicCrash logs the panic and re-panics, so a supervisor or debugger
+	// sees the crash.
+	PanicCrash PanicMode = "crash"
+)
+
+// crashOnPanic is set by SetPanicMode(PanicCrash).
+var crashOnPanic atomic.Bool
+
+// SetPanicMode selects how tunnel runs handle panics from now on.
+func SetPanicMode(mode PanicMode) {
+	crashOnPanic.Store(mode == PanicCrash)
+}
+
+// ParsePanicMode parses a CFUI_PANIC_MODE value. Empty means PanicRecover.
+func ParsePanicMode(raw string) (PanicMode, error) {
+	switch mode := PanicMode(strings.ToLower(strings.TrimSpace(raw))); mode {
+	case "":
+		return PanicRecover, nil
+	case PanicRecover, PanicCrash:
+		return mode, nil
+	default:
+		return PanicRecover, fmt.Errorf("invalid panic mode %q: must be recover or crash", raw)
+	}
+}
@@ -0,0 +1,37 @@
+package cloudflared
+
+// EventType names a tunnel lifecycle event.
+type EventType string
+
+// Lifecycle events reported through an instance's EventFunc. EventConnected
+// is not emitted by instances; it is derived from connection log lines.
+const (
+	EventStart EventType = "start"
+	EventStop  EventType = "stop"
+	EventError EventType = "error"
+	// EventFatal is a run that failed with a non-retryable error, after
+	// which auto-restart gives up.
+	EventFatal          EventType = "fatal"
+	EventRestart        EventType = "restart"
+	EventProtocolSwitch EventType = "protocol_switch"
+	EventEdgeIPSwitch   EventType = "edge_ip_switch"
+	EventConnected      EventType = "connected"
+)
+
+// EventFunc receives the lifecycle events of one instance together with a
+// short human-readable detail, which may be empty. It may be called with the
+// instance lock held, so it must neither block nor call back into the
+// instance.
+type EventFunc func(typ EventType, detail string)
+
+// OnEvent installs the lifecycle event callback. Call it before the first
+// Start.
+func (i *Instance) OnEvent(fn EventFunc) {
+	i.onEvent = fn
+}
+
+func (i *Instance) emit(typ EventType, detail string) {
+	if i.onEvent != nil {
+		i.onEvent(typ, detail)
+	}
+}
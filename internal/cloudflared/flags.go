@@ -0,0 +1,65 @@
+package cloudflared
+
+import "strconv"
+
+// ManagedFlag is one cloudflared run flag cfui sets from tunnel settings.
+// BuildArgs passes the flags in table order, so this list is also what the
+// API reports as supported.
+type ManagedFlag struct {
+	// Name is the flag without dashes, e.g. "protocol".
+	Name string
+	// Fields are the config JSON keys the flag is derived from.
+	Fields []string
+	// arg returns the flag's argument for o and the concrete protocol, or
+	// false when the flag is left out. Boolean flags return "".
+	arg func(o Options, protocol string) (string, bool)
+}
+
+// Arg returns the argument BuildArgs passes for the flag, or false when the
+// options leave the flag out. Boolean flags return "".
+func (f ManagedFlag) Arg(o Options, protocol string) (string, bool) {
+	return f.arg(o, protocol)
+}
+
+// ManagedFlags lists the run flags cfui derives from tunnel settings, in the
+// order BuildArgs passes them. --token, --config (for tags), and
+// --no-autoupdate are always handled separately.
+var ManagedFlags = []ManagedFlag{
+	{Name: "protocol", Fields: []string{"protocol"}, arg: func(_ Options, protocol string) (string, bool) {
+		return protocol, protocol != "" && protocol != "auto"
+	}},
+	{Name: "grace-period", Fields: []string{"grace_period"}, arg: func(o Options, _ string) (string, bool) {
+		return o.GracePeriod, o.GracePeriod != "" && o.GracePeriod != "30s"
+	}},
+	{Name: "region", Fields: []string{"region"}, arg: func(o Options, _ string) (string, bool) {
+		return o.Region, o.Region != ""
+	}},
+	{Name: "retries", Fields: []string{"retries"}, arg: func(o Options, _ string) (string, bool) {
+		return strconv.Itoa(o.Retries), o.Retries > 0 && o.Retries != 5
+	}},
+	{Name: "metrics", Fields: []string{"metrics_enable", "metrics_port"}, arg: func(o Options, _ string) (string, bool) {
+		return "localhost:" + strconv.Itoa(o.MetricsPort), o.MetricsEnable
+	}},
+	{Name: "loglevel", Fields: []string{"log_level"}, arg: func(o Options, _ string) (string, bool) {
+		return o.LogLevel, o.LogLevel != "" && o.LogLevel != "info"
+	}},
+	{Name: "logfile", Fields: []string{"log_file", "sole_log_sink"}, arg: func(o Options, _ string) (string, bool) {
+		return o.LogFile, o.LogFile != "" && !o.SoleLogSink
+	}},
+	{Name: "log-format", Fields: []string{"log_json"}, arg: func(o Options, _ string) (string, bool) {
+		return "json", o.LogJSON
+	}},
+	{Name: "edge-ip-version", Fields: []string{"edge_ip_version"}, arg: func(o Options, _ string) (string, bool) {
+		return o.EdgeIPVersion, o.EdgeIPVersion != "" && o.EdgeIPVersion != "auto"
+	}},
+	{Name: "edge-bind-address", Fields: []string{"edge_bind_address", "edge_interface"}, arg: func(o Options, _ string) (string, bool) {
+		return o.EdgeBindAddress, o.EdgeBindAddress != ""
+	}},
+	// cloudflared refuses --post-quantum on HTTP/2.
+	{Name: "post-quantum", Fields: []string{"post_quantum_mode"}, arg: func(o Options, protocol string) (string, bool) {
+		return "", o.PostQuantumMode == PostQuantumRequire && protocol != "http2"
+	}},
+	{Name: "no-tls-verify", Fields: []string{"no_tls_verify"}, arg: func(o Options, _ string) (string, bool) {
+		return "", o.NoTLSVerify
+	}},
+}
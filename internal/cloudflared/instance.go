@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudflare/backoff"
@@ -18,15 +22,40 @@ const (
 	restartBackoffMaxDelay   = 60 * time.Second
 	restartBackoffResetAfter = 5 * time.Minute
 	maxRestartAttempts       = 10
+	// flapWindow is the rolling window Options.MaxRestartsPerHour counts
+	// restarts in.
+	flapWindow = time.Hour
+	// defaultStableRunAfter is how long a run must last before its clean
+	// exit counts as a successful connection.
+	defaultStableRunAfter = time.Minute
 
 	defaultStopTimeout = 30 * time.Second
 
 	maxProtocolFailuresBeforeSwitch = 3
+	maxEdgeIPFailuresBeforeSwitch   = 3
 )
 
+// runApp runs one cloudflared CLI invocation until it exits or ctx is
+// canceled; replaced in tests since real runs need the Cloudflare edge.
+var runApp = func(ctx context.Context, app *cli.App, args []string) error {
+	return app.RunContext(ctx, args)
+}
+
 // ErrAlreadyRunning is returned by Start when the instance is running.
 var ErrAlreadyRunning = errors.New("already running")
 
+// ErrNotRunning reports a stop request for a tunnel that is neither running
+// nor waiting to auto-restart. Stop itself treats that case as a no-op.
+var ErrNotRunning = errors.New("not running")
+
+// ErrNoPendingRestart is returned by CancelRestart when the instance is not
+// waiting out an auto-restart backoff.
+var ErrNoPendingRestart = errors.New("no pending restart")
+
+// errRestartStopped is returned to an auto-restart that lost the race with
+// Stop or CancelRestart.
+var errRestartStopped = errors.New("tunnel was stopped")
+
 // OptionsProvider returns fresh launch options. It is called on every start
 // and auto-restart so configuration changes apply without recreating the
 // instance. Returning an error blocks the (re)start.
@@ -39,34 +68,110 @@ type Status struct {
 	// Protocol is the transport currently selected by the fallback logic
 	// (quic, http2, or auto before the first start).
 	Protocol string
+	// NextRestartAt is when the pending auto-restart fires. It is zero unless
+	// the tunnel is waiting out a restart backoff.
+	NextRestartAt time.Time
+	// StopReason says why the tunnel is stopped. It is empty while the
+	// tunnel runs or waits out a restart backoff.
+	StopReason StopReason
+	// LastErrorAt is when LastError was recorded.
+	LastErrorAt time.Time
+	// ProtocolThrashing is set once auto mode used up
+	// Options.MaxProtocolSwitches and stuck to Protocol.
+	ProtocolThrashing bool
+	// EdgeIPVersion is the --edge-ip-version of the latest run; with
+	// Options.EdgeIPFallback it may be "4" although "6" is configured.
+	EdgeIPVersion string
+	// RestartCount is the number of auto-restarts of the current incident;
+	// it returns to zero after a stable run. LastRestartAt is when the
+	// latest auto-restart was scheduled.
+	RestartCount  int
+	LastRestartAt time.Time
+	// StartedAt is when the current run launched; zero while stopped.
+	StartedAt time.Time
 }
 
+// StopReason names why a tunnel is not running.
+type StopReason string
+
+const (
+	StopNeverStarted StopReason = "never_started"
+	// StopUser is a stop or canceled restart requested through the API.
+	StopUser StopReason = "user"
+	// StopError is a run or start that failed without a restart to follow:
+	// a non-retryable error, or auto-restart being off.
+	StopError StopReason = "error"
+	// StopExited is a clean exit with auto-restart off.
+	StopExited StopReason = "exited"
+	// StopCrashLoop is auto-restart giving up after maxRestartAttempts.
+	StopCrashLoop StopReason = "crash_loop"
+	// StopFlapping is auto-restart giving up after Options.MaxRestartsPerHour
+	// restarts within an hour. Only a manual start clears it.
+	StopFlapping StopReason = "flapping"
+	StopIdle     StopReason = "idle"
+	StopShutdown StopReason = "shutdown"
+)
+
 // Instance manages the lifecycle of one cloudflared tunnel: start, stop,
 // protocol fallback, and auto-restart with exponential backoff. Each tunnel
 // profile gets its own Instance; all instances share the process-wide
 // cloudflared runtime set up by EnsureInit.
 type Instance struct {
-	name   string
-	optsFn OptionsProvider
-
-	mu          sync.Mutex
-	ctx         context.Context
-	cancel      context.CancelFunc
-	done        chan struct{} // closed when the current run's goroutine exits
-	running     bool
+	name    string
+	optsFn  OptionsProvider
+	onEvent EventFunc
+
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{} // closed when the current run's goroutine exits
+	running bool
+	// wantRunning is the desired state: Start sets it, Stop and
+	// CancelRestart clear it. An auto-restart only launches while it is
+	// set, so a stop that lands after the backoff wait still wins.
+	wantRunning bool
+	stopReason  StopReason // why the last run ended; empty while running
 	lastError   error
+	lastErrorAt time.Time
+	startedOpts Options   // options of the current run, for restart advisories
+	startedAt   time.Time // launch time of the current run
 	configFile  string
 	stopTimeout time.Duration
 
+	// restartCount counts auto-restarts of the current incident. It resets
+	// when a run that lasted stableRunAfter exits cleanly, or when the next
+	// exit comes more than restartBackoffResetAfter after the last restart.
+	// A quick exit, clean or not, keeps counting toward maxRestartAttempts.
 	restartCount   int
+	stableRunAfter time.Duration
 	lastRestart    time.Time
+	nextRestart    time.Time // zero unless an auto-restart is pending
 	restartBackoff *backoff.Backoff
+	restarts       []RestartAttempt
+	// restartTimes are the auto-restarts within flapWindow. Unlike
+	// restartCount they survive the backoff reset; a manual start clears
+	// them.
+	restartTimes []time.Time
+	// after arms the Options.MaxRuntime timer; tests replace it to expire
+	// a run without waiting.
+	after func(time.Duration) <-chan time.Time
+	// jitterRand draws the Options.RestartJitter factor in [0, 1); tests
+	// install a seeded source.
+	jitterRand func() float64
 
 	// Protocol fallback management (for auto mode).
 	currentProtocol     string
 	protocolFailures    map[string]int
 	lastProtocolSwitch  time.Time
 	protocolSwitchCount int
+	// protocolThrashing is set when protocolSwitchCount reached the
+	// configured cap and auto mode stopped switching.
+	protocolThrashing bool
+
+	// Edge IP version fallback (Options.EdgeIPFallback). edgeIPVersion is
+	// the version the latest run was launched with.
+	edgeIPVersion  string
+	edgeIPFailures int
 }
 
 // NewInstance creates an instance named after its tunnel profile. The name
@@ -76,9 +181,13 @@ func NewInstance(name string, optsFn OptionsProvider) *Instance {
 		name:             name,
 		optsFn:           optsFn,
 		stopTimeout:      defaultStopTimeout,
+		stableRunAfter:   defaultStableRunAfter,
+		stopReason:       StopNeverStarted,
 		protocolFailures: make(map[string]int),
 		restartBackoff:   NewRestartBackoff(),
 		currentProtocol:  "auto",
+		after:            time.After,
+		jitterRand:       rand.Float64,
 	}
 }
 
@@ -110,7 +219,13 @@ func (i *Instance) Name() string {
 
 // Start launches the tunnel. It returns ErrAlreadyRunning when called twice
 // without an intervening stop or exit.
-func (i *Instance) Start() (err error) {
+func (i *Instance) Start() error {
+	return i.start(false)
+}
+
+// start launches a run. A restart returns errRestartStopped instead when
+// the tunnel has been stopped since the run it replaces ended.
+func (i *Instance) start(restart bool) (err error) {
 	// Outermost panic guard: a failure inside the embedded library during
 	// launch must not take down the whole control panel.
 	defer func() {
@@ -123,19 +238,55 @@ func (i *Instance) Start() (err error) {
 	opts, err := i.optsFn()
 	if err != nil {
 		logErrorf("Cannot start tunnel %q: %v", i.name, err)
+		i.emit(EventError, err.Error())
 		return err
 	}
 	if err := opts.Validate(); err != nil {
-		logErrorf("Cannot start tunnel %q: %v", i.name, err)
+		logErrorf("Cannot start tunnel %q (name: %s): %v", i.name, opts.TunnelName, err)
+		i.emit(EventError, err.Error())
 		return err
 	}
 	if err := EnsureInit(opts.SoftwareName); err != nil {
+		// Record the failure so Status reports it instead of a plain
+		// "stopped"; no run goroutine is launched.
+		logErrorf("Cannot start tunnel %q (name: %s): %v", i.name, opts.TunnelName, err)
+		i.mu.Lock()
+		i.setLastError(err)
+		i.stopReason = StopError
+		i.mu.Unlock()
+		i.emit(EventError, err.Error())
 		return err
 	}
+	i.mu.Lock()
+	if !restart && !i.running {
+		// A manual start gives a fallen-back IPv6 tunnel another chance.
+		i.edgeIPVersion, i.edgeIPFailures = "", 0
+	}
+	launch := opts
+	launch.EdgeIPVersion = i.selectEdgeIPVersion(opts.EdgeIPVersion, opts.EdgeIPFallback)
+	i.mu.Unlock()
+	// Resolved here rather than stored, so startedOpts keeps the interface
+	// name and every start, auto-restarts included, sees the current address.
+	launch, err = launch.ResolveEdgeInterface()
+	if err != nil {
+		logErrorf("Cannot start tunnel %q (name: %s): %v", i.name, opts.TunnelName, err)
+		i.mu.Lock()
+		i.setLastError(err)
+		i.stopReason = StopError
+		i.mu.Unlock()
+		i.emit(EventError, err.Error())
+		return err
+	}
+	if opts.EdgeInterface != "" {
+		logInfof("Tunnel %q binding edge connections to %s on interface %s", i.name, launch.EdgeBindAddress, opts.EdgeInterface)
+	}
 
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
+	if restart && !i.wantRunning {
+		return errRestartStopped
+	}
 	if i.running {
 		logWarnf("Attempted to start tunnel %q that is already running", i.name)
 		return ErrAlreadyRunning
@@ -150,27 +301,56 @@ func (i *Instance) Start() (err error) {
 	done := make(chan struct{})
 	i.ctx, i.cancel, i.done = ctx, cancel, done
 	i.running = true
+	i.wantRunning = true
+	i.stopReason = ""
 	i.lastError = nil
+	i.lastErrorAt = time.Time{}
+	i.nextRestart = time.Time{}
+	i.startedOpts = opts
+	i.startedAt = time.Now()
+	if !restart {
+		i.restartTimes = nil
+	}
 
-	logInfof("Starting cloudflared tunnel %q", i.name)
-	go i.runTunnel(ctx, opts, done)
+	logInfof("Starting cloudflared tunnel %q (name: %s)", i.name, opts.TunnelName)
+	i.emit(EventStart, opts.TunnelName)
+	go i.runTunnel(ctx, launch, done)
 
 	return nil
 }
 
 // Stop terminates the tunnel via context cancellation and waits for the run
-// goroutine to exit. Individual instances must not touch the shared graceful
-// shutdown channel: cloudflared closes it on SIGTERM (so sending could panic)
-// and a stray token could stop an unrelated instance.
+// goroutine to exit, recording StopUser as the reason; see StopWithReason.
+// Individual instances must not touch the shared graceful shutdown channel:
+// cloudflared closes it on SIGTERM (so sending could panic) and a stray
+// token could stop an unrelated instance.
 func (i *Instance) Stop() error {
+	return i.StopWithReason(StopUser)
+}
+
+// StopWithReason is Stop for callers other than a user request, such as
+// the idle timeout or process shutdown, so Status can tell them apart.
+func (i *Instance) StopWithReason(reason StopReason) error {
+	return i.StopWithin(reason, 0)
+}
+
+// StopWithin is StopWithReason with a custom wait for the run goroutine;
+// zero or less keeps the default. A short wait suits restarts after a
+// transport failure, where the connections are usually already gone and a
+// full graceful drain only delays reconnecting.
+func (i *Instance) StopWithin(reason StopReason, timeout time.Duration) error {
 	i.mu.Lock()
+	i.wantRunning = false
+	i.stopReason = reason
 	if !i.running {
 		cancel := i.cancel
 		i.cancel = nil
+		i.nextRestart = time.Time{}
 		i.mu.Unlock()
 		if cancel != nil {
 			cancel()
 			logDebugf("Canceled pending restart of tunnel %q", i.name)
+			i.emit(EventStop, "pending restart canceled")
 			return nil
 		}
 		logDebugf("Stop called but tunnel %q is not running", i.name)
@@ -181,7 +361,9 @@ func (i *Instance) Stop() error {
 	cancel := i.cancel
 	i.cancel = nil
 	done := i.done
-	timeout := i.stopTimeout
+	if timeout <= 0 {
+		timeout = i.stopTimeout
+	}
 	i.mu.Unlock()
 
 	if cancel != nil {
@@ -193,6 +375,7 @@ func (i *Instance) Stop() error {
 	select {
 	case <-done:
 		logInfof("Tunnel %q stopped gracefully", i.name)
+		i.emit(EventStop, "")
 		return nil
 	case <-timer.C:
 		logWarnf("Tunnel %q stop timeout exceeded (%v)", i.name, timeout)
@@ -202,37 +385,115 @@ func (i *Instance) Stop() error {
 		i.running = false
 		i.mu.Unlock()
 		i.cleanupConfigFile()
-		return fmt.Errorf("timeout waiting for tunnel %q to stop", i.name)
+		err := fmt.Errorf("timeout waiting for tunnel %q to stop", i.name)
+		i.emit(EventError, err.Error())
+		return err
 	}
 }
 
+// CancelRestart aborts a pending auto-restart so the tunnel stays stopped.
+// It returns ErrNoPendingRestart when the tunnel is running or no restart
+// is scheduled.
+func (i *Instance) CancelRestart() error {
+	i.mu.Lock()
+	if i.running || i.nextRestart.IsZero() || i.cancel == nil {
+		i.mu.Unlock()
+		return ErrNoPendingRestart
+	}
+	cancel := i.cancel
+	i.cancel = nil
+	i.nextRestart = time.Time{}
+	i.wantRunning = false
+	i.stopReason = StopUser
+	i.mu.Unlock()
+
+	cancel()
+	logInfof("Canceled pending restart of tunnel %q", i.name)
+	i.emit(EventStop, "pending restart canceled")
+	return nil
+}
+
+// Stoppable reports whether Stop has anything to do: the tunnel is running
+// or an auto-restart is pending.
+func (i *Instance) Stoppable() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.running || i.cancel != nil
+}
+
 // Status returns a snapshot of the instance state.
 func (i *Instance) Status() Status {
 	i.mu.Lock()
 	defer i.mu.Unlock()
-	return Status{
-		Running:   i.running,
-		LastError: i.lastError,
-		Protocol:  i.currentProtocol,
+	st := Status{
+		Running:           i.running,
+		LastError:         i.lastError,
+		LastErrorAt:       i.lastErrorAt,
+		Protocol:          i.currentProtocol,
+		NextRestartAt:     i.nextRestart,
+		ProtocolThrashing: i.protocolThrashing,
+		EdgeIPVersion:     i.edgeIPVersion,
+		RestartCount:      i.restartCount,
+		LastRestartAt:     i.lastRestart,
 	}
+	if i.running {
+		st.StartedAt = i.startedAt
+	}
+	if !i.running && i.nextRestart.IsZero() {
+		st.StopReason = i.stopReason
+	}
+	return st
+}
+
+// setLastError records err as the latest failure. Callers must hold i.mu.
+func (i *Instance) setLastError(err error) {
+	i.lastError = err
+	i.lastErrorAt = time.Now()
+}
+
+// RunningOptions returns the options the current run was started with. ok is
+// false when the tunnel is not running.
+func (i *Instance) RunningOptions() (opts Options, ok bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if !i.running {
+		return Options{}, false
+	}
+	return i.startedOpts, true
 }
 
 // selectProtocol determines which protocol to use based on configuration and
-// failure history. Callers must hold i.mu.
-func (i *Instance) selectProtocol(configProtocol string) string {
+// failure history. order is the auto-mode preference; empty means
+// DefaultProtocolOrder. Once maxSwitches (zero: no limit) switches happened
+// without a stable run, it keeps the current protocol. Callers must hold
+// i.mu.
+func (i *Instance) selectProtocol(configProtocol string, order []string, maxSwitches int) string {
 	// If the user explicitly chose a protocol, always use it.
 	if configProtocol != "" && configProtocol != "auto" {
 		i.currentProtocol = configProtocol
 		return configProtocol
 	}
+	if len(order) == 0 {
+		order = DefaultProtocolOrder
+	}
 
-	// Auto mode: cycle quic -> http2 -> quic after repeated failures.
+	// Auto mode: cycle through order after repeated failures. A protocol
+	// the order no longer lists moves on to its first entry.
 	if i.protocolFailures[i.currentProtocol] >= maxProtocolFailuresBeforeSwitch {
-		var nextProtocol string
-		if i.currentProtocol == "quic" || i.currentProtocol == "auto" {
-			nextProtocol = "http2"
-		} else {
-			nextProtocol = "quic"
+		nextProtocol := order[(slices.Index(order, i.currentProtocol)+1)%len(order)]
+		if nextProtocol == i.currentProtocol {
+			// A single-entry order has nothing to fall back to.
+			i.protocolFailures[i.currentProtocol] = 0
+			return nextProtocol
+		}
+		if maxSwitches > 0 && i.protocolSwitchCount >= maxSwitches {
+			if !i.protocolThrashing {
+				logWarnf("Tunnel %q: protocol switched %d times without a stable run, staying on %s",
+					i.name, i.protocolSwitchCount, i.currentProtocol)
+				i.protocolThrashing = true
+			}
+			i.protocolFailures[i.currentProtocol] = 0
+			return i.currentProtocol
 		}
 
 		logWarnf("Tunnel %q: protocol %s has failed %d times, switching to %s",
@@ -242,6 +503,7 @@ func (i *Instance) selectProtocol(configProtocol string) string {
 		// ever switch back.
 		i.protocolFailures[i.currentProtocol] = 0
 
+		i.emit(EventProtocolSwitch, i.currentProtocol+" -> "+nextProtocol)
 		i.currentProtocol = nextProtocol
 		i.lastProtocolSwitch = time.Now()
 		i.protocolSwitchCount++
@@ -250,17 +512,60 @@ func (i *Instance) selectProtocol(configProtocol string) string {
 	}
 
 	if i.currentProtocol == "" || i.currentProtocol == "auto" {
-		i.currentProtocol = "quic"
+		i.currentProtocol = order[0]
 	}
 	return i.currentProtocol
 }
 
+// selectEdgeIPVersion returns the edge IP version for the next run. With
+// fallback and "6" configured it moves to "4" once IPv6 failed
+// maxEdgeIPFailuresBeforeSwitch times without a stable run, and stays on
+// IPv4 until a manual start resets edgeIPVersion. Callers must hold i.mu.
+func (i *Instance) selectEdgeIPVersion(configVersion string, fallback bool) string {
+	if !fallback || configVersion != "6" {
+		i.edgeIPVersion = configVersion
+		i.edgeIPFailures = 0
+		return configVersion
+	}
+	if i.edgeIPVersion == "4" {
+		return i.edgeIPVersion
+	}
+	i.edgeIPVersion = "6"
+	if i.edgeIPFailures >= maxEdgeIPFailuresBeforeSwitch {
+		logWarnf("Tunnel %q: IPv6 edge connections have failed %d times, falling back to IPv4", i.name, i.edgeIPFailures)
+		i.edgeIPFailures = 0
+		i.emit(EventEdgeIPSwitch, "6 -> 4")
+		i.edgeIPVersion = "4"
+	}
+	return i.edgeIPVersion
+}
+
+// recordEdgeIPFailure counts a failed IPv6 run against the IP family when
+// the error points at it.
+func (i *Instance) recordEdgeIPFailure(err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.edgeIPVersion == "6" && IsEdgeIPFamilyError(err) {
+		i.edgeIPFailures++
+		logWarnf("Tunnel %q: IPv6 edge failure count: %d (error: %v)", i.name, i.edgeIPFailures, err)
+	}
+}
+
 // recordProtocolSuccess clears failure history after a clean exit so no
-// protocol stays blacklisted forever.
-func (i *Instance) recordProtocolSuccess() {
+// protocol stays blacklisted forever. Only a run that lasted stableRunAfter
+// counts: a clean exit right after launch proves no connection, and
+// resetting then would let a crash loop restart forever. It runs before the
+// deferred maybeAutoRestart of the same run, so the restart that follows
+// already sees the reset.
+func (i *Instance) recordProtocolSuccess(ranFor time.Duration) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
+	if ranFor < i.stableRunAfter {
+		logDebugf("Tunnel %q exited after %v, keeping restart count %d", i.name, ranFor.Round(time.Millisecond), i.restartCount)
+		return
+	}
+	i.edgeIPFailures = 0
 	if i.currentProtocol != "" && i.currentProtocol != "auto" {
 		logInfof("Tunnel %q: protocol %s connected successfully, resetting failure counts", i.name, i.currentProtocol)
 
@@ -271,6 +576,8 @@ func (i *Instance) recordProtocolSuccess() {
 		for proto := range i.protocolFailures {
 			i.protocolFailures[proto] = 0
 		}
+		i.protocolSwitchCount = 0
+		i.protocolThrashing = false
 	}
 }
 
@@ -293,22 +600,43 @@ func (i *Instance) recordProtocolFailure(err error) {
 
 func (i *Instance) runTunnel(ctx context.Context, opts Options, done chan struct{}) {
 	restartAllowed := true
+	// expired is set when the run ended because it reached MaxRuntime.
+	var expired atomic.Bool
 	defer close(done)
 	defer func() {
 		if rec := recover(); rec != nil {
+			if crashOnPanic.Load() {
+				logErrorf("Panic in tunnel %q, crashing (panic mode crash): %v", i.name, rec)
+				panic(rec)
+			}
 			logErrorf("Recovered from panic in tunnel %q: %v", i.name, rec)
+			panicErr := recoveredError("tunnel panic", rec)
 			i.mu.Lock()
-			i.lastError = fmt.Errorf("tunnel panic: %v", rec)
+			i.setLastError(panicErr)
 			i.mu.Unlock()
+			i.emit(EventError, panicErr.Error())
 		}
 
 		i.cleanupConfigFile()
 
 		i.mu.Lock()
 		i.running = false
+		// A canceled run already has the reason its stop recorded; the
+		// restart policy below may refine this one.
+		if ctx.Err() == nil {
+			if i.lastError != nil {
+				i.stopReason = StopError
+			} else {
+				i.stopReason = StopExited
+			}
+		}
 		i.mu.Unlock()
 
-		if ctx.Err() == nil && restartAllowed {
+		switch {
+		case ctx.Err() != nil:
+		case expired.Load():
+			i.scheduledRestart(opts.MaxRuntime)
+		case restartAllowed:
 			logWarnf("Tunnel %q exited unexpectedly, checking auto-restart policy", i.name)
 			i.maybeAutoRestart(ctx)
 		}
@@ -326,21 +654,26 @@ func (i *Instance) runTunnel(ctx context.Context, opts Options, done chan struct
 	}
 
 	var configFile string
-	if opts.CustomTag != "" {
-		file, err := createTempConfig(opts.CustomTag)
+	if tags := opts.TagPairs(); len(tags) > 0 {
+		file, err := createTempConfig(tags)
 		if err != nil {
-			logWarnf("Tunnel %q: failed to create config file for custom tag: %v", i.name, err)
+			logWarnf("Tunnel %q: failed to create config file for tags: %v", i.name, err)
 		} else {
 			configFile = file
 			i.mu.Lock()
 			i.configFile = file
 			i.mu.Unlock()
-			logInfof("Tunnel %q using custom identifier tag: %s", i.name, opts.CustomTag)
+			logInfof("Tunnel %q using tags: %s", i.name, strings.Join(tags, ", "))
 		}
 	}
 
+	configProtocol := opts.Protocol
+	if opts.PostQuantumMode == PostQuantumRequire && (configProtocol == "" || configProtocol == "auto") {
+		// Required post-quantum only works over QUIC; never fall back.
+		configProtocol = "quic"
+	}
 	i.mu.Lock()
-	selectedProtocol := i.selectProtocol(opts.Protocol)
+	selectedProtocol := i.selectProtocol(configProtocol, opts.ProtocolOrder, opts.MaxProtocolSwitches)
 	if opts.Protocol == "auto" {
 		logDebugf("Tunnel %q protocol failure counts: quic=%d, http2=%d",
 			i.name, i.protocolFailures["quic"], i.protocolFailures["http2"])
@@ -356,7 +689,25 @@ func (i *Instance) runTunnel(ctx context.Context, opts Options, done chan struct
 	// schedule pulses that strip it (and any stale ones) again.
 	scheduleSignalReclaim()
 
-	err := app.RunContext(ctx, args)
+	// runCtx ends the run without touching ctx, so Stop can still tell a
+	// scheduled restart from a user request.
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+	if opts.MaxRuntime > 0 {
+		expiry := i.after(opts.MaxRuntime)
+		go func() {
+			select {
+			case <-runCtx.Done():
+			case <-expiry:
+				logInfof("Tunnel %q reached its maximum runtime of %v, restarting", i.name, opts.MaxRuntime)
+				expired.Store(true)
+				cancelRun()
+			}
+		}()
+	}
+
+	runStart := time.Now()
+	err := runApp(runCtx, app, args)
 	restartAllowed = shouldAutoRestartAfterRun(ctx, err)
 
 	// Context cancellation means a user-requested stop.
@@ -364,22 +715,29 @@ func (i *Instance) runTunnel(ctx context.Context, opts Options, done chan struct
 		logInfof("Tunnel %q stopped by user request", i.name)
 		return
 	}
+	if expired.Load() {
+		return
+	}
 
 	if err != nil {
 		logErrorf("Tunnel %q error: %v", i.name, err)
 		i.mu.Lock()
-		i.lastError = err
+		i.setLastError(err)
 		i.mu.Unlock()
 
 		i.recordProtocolFailure(err)
+		i.recordEdgeIPFailure(err)
 
 		if !restartAllowed {
 			logWarnf("Tunnel %q: non-retryable error detected: %v", i.name, err)
+			i.emit(EventFatal, err.Error())
 			return
 		}
+		i.emit(EventError, err.Error())
 	} else {
-		i.recordProtocolSuccess()
+		i.recordProtocolSuccess(time.Since(runStart))
 		logInfof("Tunnel %q exited cleanly", i.name)
+		i.emit(EventStop, "exited")
 	}
 }
 
@@ -423,47 +781,120 @@ func (i *Instance) maybeAutoRestart(ctx context.Context) {
 
 	if i.restartCount >= maxRestartAttempts {
 		logWarnf("Tunnel %q: maximum restart attempts reached (%d), stopping auto-restart", i.name, i.restartCount)
+		i.stopReason = StopCrashLoop
 		i.mu.Unlock()
 		return
 	}
 
-	delay := i.restartBackoff.Duration()
+	now := time.Now()
+	if recent := i.recentRestarts(now); opts.MaxRestartsPerHour > 0 && recent >= opts.MaxRestartsPerHour {
+		logErrorf("Tunnel %q is flapping: restarted %d times in the last hour, stopping auto-restart until it is started manually", i.name, recent)
+		i.stopReason = StopFlapping
+		i.mu.Unlock()
+		i.emit(EventError, fmt.Sprintf("flapping: %d restarts in the last hour, manual intervention required", recent))
+		return
+	}
+
+	delay := i.jittered(i.restartBackoff.Duration(), opts.RestartJitter)
 	i.restartCount++
-	i.lastRestart = time.Now()
+	i.lastRestart = now
+	i.restartTimes = append(i.restartTimes, now)
+	i.nextRestart = i.lastRestart.Add(delay)
+	attempt := RestartAttempt{Attempt: i.restartCount, Time: i.lastRestart, Reason: "exited", Backoff: delay}
+	if i.lastError != nil {
+		attempt.Reason = i.lastError.Error()
+	}
 	attemptNum := i.restartCount
 	i.mu.Unlock()
 
 	logInfof("Tunnel %q auto-restarting in %v (attempt %d)...", i.name, delay, attemptNum)
+	i.emit(EventRestart, fmt.Sprintf("attempt %d in %v", attemptNum, delay))
 	timer := time.NewTimer(delay)
 	defer timer.Stop()
 
 	select {
 	case <-ctx.Done():
 		logInfof("Tunnel %q auto-restart canceled before attempt %d: %v", i.name, attemptNum, ctx.Err())
+		attempt.Outcome = RestartCanceled
+		i.recordRestart(attempt)
 		return
 	case <-timer.C:
 	}
 
+	i.mu.Lock()
+	i.nextRestart = time.Time{}
+	i.mu.Unlock()
 	if err := ctx.Err(); err != nil {
 		logInfof("Tunnel %q auto-restart canceled before attempt %d: %v", i.name, attemptNum, err)
+		attempt.Outcome = RestartCanceled
+		i.recordRestart(attempt)
 		return
 	}
-	if err := i.Start(); err != nil {
+	// Record before starting: a run that fails at once records the next
+	// attempt from its own goroutine.
+	attempt.Outcome = RestartStarted
+	i.recordRestart(attempt)
+	switch err := i.start(true); {
+	case errors.Is(err, errRestartStopped):
+		logInfof("Tunnel %q auto-restart canceled before attempt %d: stopped", i.name, attemptNum)
+		i.setRestartOutcome(attempt.Time, RestartCanceled, nil)
+	case err != nil:
 		logErrorf("Failed to restart tunnel %q: %v", i.name, err)
+		i.mu.Lock()
+		i.stopReason = StopError
+		i.mu.Unlock()
+		i.setRestartOutcome(attempt.Time, RestartFailed, err)
 	}
 }
 
-// createTempConfig writes a temporary YAML config carrying the custom tag
+// scheduledRestart starts the tunnel again right after a run ended at
+// Options.MaxRuntime. Unlike an auto-restart it has no backoff and leaves the
+// crash-loop and flapping counters alone. A Stop that lands first wins.
+func (i *Instance) scheduledRestart(maxRuntime time.Duration) {
+	i.emit(EventRestart, fmt.Sprintf("scheduled after %v", maxRuntime))
+	switch err := i.start(true); {
+	case errors.Is(err, errRestartStopped):
+		logInfof("Tunnel %q scheduled restart canceled: stopped", i.name)
+	case err != nil:
+		logErrorf("Failed to restart tunnel %q after its maximum runtime: %v", i.name, err)
+		i.mu.Lock()
+		i.stopReason = StopError
+		i.mu.Unlock()
+	}
+}
+
+// jittered spreads delay uniformly over ±percent. The caller holds i.mu.
+func (i *Instance) jittered(delay time.Duration, percent int) time.Duration {
+	if percent <= 0 {
+		return delay
+	}
+	spread := float64(delay) * float64(percent) / 100
+	return delay + time.Duration((2*i.jitterRand()-1)*spread)
+}
+
+// recentRestarts drops restart times older than flapWindow and counts the
+// rest. The caller holds i.mu.
+func (i *Instance) recentRestarts(now time.Time) int {
+	kept := i.restartTimes[:0]
+	for _, t := range i.restartTimes {
+		if now.Sub(t) < flapWindow {
+			kept = append(kept, t)
+		}
+	}
+	i.restartTimes = kept
+	return len(kept)
+}
+
+// createTempConfig writes a temporary YAML config carrying the key=value tags
 // (cloudflared expects tags as a string slice).
-func createTempConfig(customTag string) (string, error) {
+func createTempConfig(tags []string) (string, error) {
 	tempFile, err := os.CreateTemp("", "cloudflared-*.yaml")
 	if err != nil {
 		return "", err
 	}
 	defer tempFile.Close()
 
-	configContent := fmt.Sprintf("tag:\n  - version=%s\n", customTag)
-	if _, err := tempFile.WriteString(configContent); err != nil {
+	if _, err := tempFile.WriteString(tagsYAML(tags)); err != nil {
 		os.Remove(tempFile.Name())
 		return "", err
 	}
@@ -471,6 +902,16 @@ func createTempConfig(customTag string) (string, error) {
 	return tempFile.Name(), nil
 }
 
+// tagsYAML renders key=value tags as the config file's "tag" list.
+func tagsYAML(tags []string) string {
+	var b strings.Builder
+	b.WriteString("tag:\n")
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "  - %s\n", tag)
+	}
+	return b.String()
+}
+
 // cleanupConfigFile removes the temporary config file if one exists.
 func (i *Instance) cleanupConfigFile() {
 	i.mu.Lock()
@@ -1,8 +1,13 @@
 package cloudflared
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Options describes one tunnel launch. It mirrors the cloudflared CLI flags
@@ -10,6 +15,9 @@ import (
 // store, so callers can derive it from any source (active profile, a specific
 // profile for multi-instance use, tests, ...).
 type Options struct {
+	// TunnelName is the cfui-side label used in log lines; cloudflared
+	// never sees it.
+	TunnelName      string
 	Token           string
 	CustomTag       string
 	SoftwareName    string
@@ -24,23 +32,183 @@ type Options struct {
 	LogJSON         bool
 	EdgeIPVersion   string // auto, 4, 6
 	EdgeBindAddress string
-	PostQuantum     bool
+	// EdgeInterface names a network interface whose address is bound
+	// instead of EdgeBindAddress. It is resolved on every start, so a
+	// restart follows DHCP changes.
+	EdgeInterface   string
+	PostQuantumMode string // off, prefer, require
 	NoTLSVerify     bool
 	ExtraArgs       string
+	// Tags are written to the temporary config as key=value dashboard tags.
+	// A non-empty CustomTag still adds version=<CustomTag> when Tags has no
+	// "version" entry.
+	Tags map[string]string
 
 	// AutoRestart controls whether the instance restarts itself with
 	// exponential backoff after an unexpected exit.
 	AutoRestart bool
+
+	// MaxRestartsPerHour stops auto-restart once the tunnel has restarted
+	// this many times within an hour, however the backoff resets; zero
+	// means no limit. Like AutoRestart it is re-read on every exit.
+	MaxRestartsPerHour int
+
+	// MaxRuntime gracefully restarts the tunnel once a run has lasted this
+	// long; zero disables it. Scheduled restarts skip the backoff and do
+	// not count toward MaxRestartsPerHour.
+	MaxRuntime time.Duration
+
+	// RestartJitter spreads each auto-restart delay by up to this many
+	// percent either way; zero keeps the exact backoff.
+	RestartJitter int
+	// SoleLogSink leaves --logfile out so cfui's capture is the only copy
+	// of cloudflared's output.
+	SoleLogSink bool
+
+	// MaxProtocolSwitches caps auto-mode transport switches until a run
+	// stays up; zero means no limit. Re-read on every start.
+	MaxProtocolSwitches int
+
+	// EdgeIPFallback switches an EdgeIPVersion "6" tunnel to IPv4 after
+	// repeated IP family failures; it stays there until a manual start.
+	// Re-read on every start.
+	EdgeIPFallback bool
+
+	// ProtocolOrder is the transport order auto mode starts with and falls
+	// back through; empty means DefaultProtocolOrder. Like AutoRestart it
+	// is re-read on every start, so changing it needs no restart.
+	ProtocolOrder []string
+}
+
+// DefaultProtocolOrder is the auto-mode order when none is configured.
+var DefaultProtocolOrder = []string{"quic", "http2"}
+
+// PostQuantumRequire is the PostQuantumMode that passes --post-quantum.
+// Other modes leave cloudflared on its default, which prefers post-quantum
+// key agreement but falls back to classical curves.
+const PostQuantumRequire = "require"
+
+// RestartRequired lists the settings that differ between o, the options a
+// tunnel was started with, and next. Names follow the config JSON keys. Only
+// fields baked into the running cloudflared process or fixed when the run
+// starts, such as the MaxRuntime timer, are compared: TunnelName is a log
+// label and AutoRestart is re-read on every exit.
+func (o Options) RestartRequired(next Options) []string {
+	var changed []string
+	add := func(field string, differs bool) {
+		if differs {
+			changed = append(changed, field)
+		}
+	}
+	add("token", o.Token != next.Token)
+	add("tags", !slices.Equal(o.TagPairs(), next.TagPairs()))
+	add("software_name", o.SoftwareName != next.SoftwareName)
+	add("protocol", o.Protocol != next.Protocol)
+	add("grace_period", o.GracePeriod != next.GracePeriod)
+	add("region", o.Region != next.Region)
+	add("retries", o.Retries != next.Retries)
+	add("metrics_enable", o.MetricsEnable != next.MetricsEnable)
+	add("metrics_port", o.MetricsPort != next.MetricsPort)
+	add("log_level", o.LogLevel != next.LogLevel)
+	add("log_file", o.LogFile != next.LogFile)
+	add("sole_log_sink", o.SoleLogSink != next.SoleLogSink)
+	add("log_json", o.LogJSON != next.LogJSON)
+	add("edge_ip_version", o.EdgeIPVersion != next.EdgeIPVersion)
+	add("edge_ip_fallback", o.EdgeIPFallback != next.EdgeIPFallback)
+	add("edge_bind_address", o.EdgeBindAddress != next.EdgeBindAddress)
+	add("edge_interface", o.EdgeInterface != next.EdgeInterface)
+	add("post_quantum_mode", o.PostQuantumMode != next.PostQuantumMode)
+	add("no_tls_verify", o.NoTLSVerify != next.NoTLSVerify)
+	add("extra_args", o.ExtraArgs != next.ExtraArgs)
+	add("max_runtime", o.MaxRuntime != next.MaxRuntime)
+	return changed
 }
 
+// TagPairs returns the dashboard tags as sorted key=value strings.
+func (o Options) TagPairs() []string {
+	tags := maps.Clone(o.Tags)
+	if _, ok := tags["version"]; !ok && o.CustomTag != "" {
+		if tags == nil {
+			tags = make(map[string]string, 1)
+		}
+		tags["version"] = o.CustomTag
+	}
+	pairs := make([]string, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, key+"="+tags[key])
+	}
+	return pairs
+}
+
+// ErrTokenMissing is returned when a tunnel is started without a token.
+var ErrTokenMissing = errors.New("token is required")
+
 // Validate reports whether the options are sufficient to launch a tunnel.
 func (o Options) Validate() error {
 	if strings.TrimSpace(o.Token) == "" {
-		return fmt.Errorf("token is required")
+		return ErrTokenMissing
+	}
+	if o.PostQuantumMode == PostQuantumRequire && o.Protocol == "http2" {
+		return fmt.Errorf("post-quantum is only supported with the quic protocol")
 	}
 	return nil
 }
 
+// interfaceAddrs lists the addresses of a network interface; tests replace
+// it with a fake interface.
+var interfaceAddrs = func(name string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return iface.Addrs()
+}
+
+// ResolveEdgeInterface returns o with EdgeBindAddress set to the current
+// address of EdgeInterface. EdgeIPVersion picks the family; auto prefers
+// IPv4. Link-local addresses cannot reach the edge and are skipped. Options
+// without an EdgeInterface are returned unchanged.
+func (o Options) ResolveEdgeInterface() (Options, error) {
+	if o.EdgeInterface == "" {
+		return o, nil
+	}
+	addrs, err := interfaceAddrs(o.EdgeInterface)
+	if err != nil {
+		return o, fmt.Errorf("edge interface %q: %w", o.EdgeInterface, err)
+	}
+	var v4, v6 net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() || ipNet.IP.IsLoopback() {
+			continue
+		}
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			if v4 == nil {
+				v4 = ip4
+			}
+		} else if v6 == nil {
+			v6 = ipNet.IP
+		}
+	}
+	var ip net.IP
+	switch o.EdgeIPVersion {
+	case "4":
+		ip = v4
+	case "6":
+		ip = v6
+	default:
+		ip = v4
+		if ip == nil {
+			ip = v6
+		}
+	}
+	if ip == nil {
+		return o, fmt.Errorf("edge interface %q has no usable address for edge_ip_version %q", o.EdgeInterface, o.EdgeIPVersion)
+	}
+	o.EdgeBindAddress = ip.String()
+	return o, nil
+}
+
 // BuildArgs assembles the cloudflared CLI invocation for the given options.
 // protocol is the concrete protocol chosen by the fallback logic (may differ
 // from o.Protocol in auto mode); configFile is the optional temporary YAML
@@ -57,41 +225,15 @@ func BuildArgs(o Options, protocol, configFile string) []string {
 	args = append(args, "--no-autoupdate")
 	args = append(args, "run", "--token", o.Token)
 
-	if protocol != "" && protocol != "auto" {
-		args = append(args, "--protocol", protocol)
-	}
-	if o.GracePeriod != "" && o.GracePeriod != "30s" {
-		args = append(args, "--grace-period", o.GracePeriod)
-	}
-	if o.Region != "" {
-		args = append(args, "--region", o.Region)
-	}
-	if o.Retries > 0 && o.Retries != 5 {
-		args = append(args, "--retries", fmt.Sprintf("%d", o.Retries))
-	}
-	if o.MetricsEnable {
-		args = append(args, "--metrics", fmt.Sprintf("localhost:%d", o.MetricsPort))
-	}
-	if o.LogLevel != "" && o.LogLevel != "info" {
-		args = append(args, "--loglevel", o.LogLevel)
-	}
-	if o.LogFile != "" {
-		args = append(args, "--logfile", o.LogFile)
-	}
-	if o.LogJSON {
-		args = append(args, "--log-format", "json")
-	}
-	if o.EdgeIPVersion != "" && o.EdgeIPVersion != "auto" {
-		args = append(args, "--edge-ip-version", o.EdgeIPVersion)
-	}
-	if o.EdgeBindAddress != "" {
-		args = append(args, "--edge-bind-address", o.EdgeBindAddress)
-	}
-	if o.PostQuantum {
-		args = append(args, "--post-quantum")
-	}
-	if o.NoTLSVerify {
-		args = append(args, "--no-tls-verify")
+	for _, flag := range ManagedFlags {
+		value, ok := flag.arg(o, protocol)
+		if !ok {
+			continue
+		}
+		args = append(args, "--"+flag.Name)
+		if value != "" {
+			args = append(args, value)
+		}
 	}
 	if o.ExtraArgs != "" {
 		args = append(args, ParseExtraArgs(o.ExtraArgs)...)
@@ -0,0 +1,63 @@
+package cloudflared
+
+import "time"
+
+// maxRestartHistory bounds the restart attempts kept per instance.
+const maxRestartHistory = 50
+
+// Outcomes of an auto-restart attempt.
+const (
+	RestartStarted  = "started"
+	RestartFailed   = "failed"
+	RestartCanceled = "canceled"
+)
+
+// RestartAttempt records one auto-restart: why the previous run ended, how
+// long the backoff was, and whether the restart got the tunnel running.
+type RestartAttempt struct {
+	// Attempt counts restarts since the backoff was last reset, from 1.
+	Attempt int       `json:"attempt"`
+	Time    time.Time `json:"time"`
+	// Reason is the error that ended the previous run, or "exited" for a
+	// clean exit.
+	Reason  string        `json:"reason"`
+	Backoff time.Duration `json:"backoff_ns"`
+	Outcome string        `json:"outcome"`
+	// Error is why the restart failed; empty unless Outcome is "failed".
+	Error string `json:"error,omitempty"`
+}
+
+// recordRestart appends an attempt, dropping the oldest beyond
+// maxRestartHistory.
+func (i *Instance) recordRestart(attempt RestartAttempt) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.restarts) == maxRestartHistory {
+		copy(i.restarts, i.restarts[1:])
+		i.restarts = i.restarts[:maxRestartHistory-1]
+	}
+	i.restarts = append(i.restarts, attempt)
+}
+
+// setRestartOutcome corrects the outcome of the attempt scheduled at t when
+// it did not start after all: RestartFailed with err, or RestartCanceled.
+func (i *Instance) setRestartOutcome(t time.Time, outcome string, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for n := len(i.restarts) - 1; n >= 0; n-- {
+		if i.restarts[n].Time.Equal(t) {
+			i.restarts[n].Outcome = outcome
+			if err != nil {
+				i.restarts[n].Error = err.Error()
+			}
+			return
+		}
+	}
+}
+
+// RestartHistory returns the recorded auto-restart attempts, oldest first.
+func (i *Instance) RestartHistory() []RestartAttempt {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]RestartAttempt(nil), i.restarts...)
+}
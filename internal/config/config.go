@@ -9,12 +9,22 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"go.uber.org/zap"
 )
 
+// log returns the package logger, named cfui.config so broadcast lines can
+// be filtered by component.
+func log() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentConfig)
+}
+
 const DefaultDDNSRecordComment = "cfui"
 
 const (
@@ -30,6 +40,104 @@ const (
 
 const DefaultTunnelProfileKey = "default"
 
+// Post-quantum key agreement modes for QUIC connections. cloudflared already
+// prefers post-quantum curves by default and only offers a flag to require
+// them, so "off" and "prefer" both leave the flag out.
+const (
+	PostQuantumModeOff     = "off"
+	PostQuantumModePrefer  = "prefer"
+	PostQuantumModeRequire = "require"
+)
+
+const (
+	DefaultMetricsPollInterval = 15 * time.Second
+	MinMetricsPollInterval     = 5 * time.Second
+)
+
+const (
+	DefaultStatsPersistInterval = time.Minute
+	MinStatsPersistInterval     = 10 * time.Second
+)
+
+// MinIdleTimeout is the shortest accepted IdleTimeout; shorter windows would
+// stop a tunnel between ordinary requests.
+const MinIdleTimeout = time.Minute
+
+// IdleTimeoutDuration parses IdleTimeout. Zero means the tunnel never stops
+// for inactivity; Validate rejects unparsable values before they are saved.
+func (p TunnelProfileConfig) IdleTimeoutDuration() time.Duration {
+	d, err := time.ParseDuration(p.IdleTimeout)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// MaxRestartJitter caps RestartJitter; a wider spread could shrink a
+// backoff delay to almost nothing.
+const MaxRestartJitter = 50
+
+// MinMaxRuntime is the shortest accepted MaxRuntime; a tunnel restarted
+// more often would spend much of its life reconnecting.
+const MinMaxRuntime = time.Hour
+
+// MaxRuntimeDuration parses MaxRuntime. Zero means tunnels never restart on
+// a schedule; Validate rejects unparsable values before they are saved.
+func (c Config) MaxRuntimeDuration() time.Duration {
+	d, err := time.ParseDuration(c.MaxRuntime)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// MetricsPollDuration parses MetricsPollInterval. Zero disables polling.
+// Validate rejects unparsable values and ones below MinMetricsPollInterval;
+// should one be stored anyway, it falls back to the default or is raised to
+// the minimum.
+func (c Config) MetricsPollDuration() time.Duration {
+	raw := strings.TrimSpace(c.MetricsPollInterval)
+	if raw == "" {
+		return DefaultMetricsPollInterval
+	}
+	if raw == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return DefaultMetricsPollInterval
+	}
+	if d == 0 {
+		return 0
+	}
+	if d < MinMetricsPollInterval {
+		return MinMetricsPollInterval
+	}
+	return d
+}
+
+// StatsPersistDuration parses StatsPersistInterval the way
+// MetricsPollDuration parses MetricsPollInterval: zero disables persisting,
+// and Validate rejects unparsable values and ones below
+// MinStatsPersistInterval.
+func (c Config) StatsPersistDuration() time.Duration {
+	raw := strings.TrimSpace(c.StatsPersistInterval)
+	if raw == "" {
+		return DefaultStatsPersistInterval
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return DefaultStatsPersistInterval
+	}
+	if d == 0 {
+		return 0
+	}
+	if d < MinStatsPersistInterval {
+		return MinStatsPersistInterval
+	}
+	return d
+}
+
 func NormalizeDDNSRecordComment(comment string) string {
 	comment = strings.TrimSpace(comment)
 	if comment == "" {
@@ -39,15 +147,21 @@ func NormalizeDDNSRecordComment(comment string) string {
 }
 
 type Config struct {
+	// SchemaVersion is the config layout version; see CurrentSchemaVersion.
+	SchemaVersion int `json:"schema_version"`
+
 	Token        string `json:"token"`
 	AutoStart    bool   `json:"auto_start"`    // Auto-start tunnel when service starts
+	LazyStart    bool   `json:"lazy_start"`    // Start on the first wake request instead of at launch
 	AutoRestart  bool   `json:"auto_restart"`  // Auto-restart tunnel on abnormal exit
-	CustomTag    string `json:"custom_tag"`    // Custom identifier tag shown in Cloudflare dashboard (displayed as "version=xxx" tag)
+	CustomTag    string `json:"custom_tag"`    // Deprecated: alias of Tags["version"], shown in the Cloudflare dashboard as "version=xxx"
 	SoftwareName string `json:"software_name"` // Software name shown in Cloudflare dashboard (default: "cfui")
+	TunnelName   string `json:"tunnel_name"`   // cfui-only label of the active tunnel profile, shown in status and logs
 
 	// Advanced cloudflared parameters
 	Protocol      string `json:"protocol"`     // auto, http2, quic
 	GracePeriod   string `json:"grace_period"` // e.g., "30s"
+	IdleTimeout   string `json:"idle_timeout"` // stop after no traffic for this long, e.g. "30m"; empty disables
 	Region        string `json:"region"`       // empty or "us"
 	Retries       int    `json:"retries"`      // max retries
 	MetricsEnable bool   `json:"metrics_enable"`
@@ -59,12 +173,18 @@ type Config struct {
 	LogJSON         bool   `json:"log_json"`          // Output logs in JSON format (available since 2025.6.1)
 	EdgeIPVersion   string `json:"edge_ip_version"`   // auto, 4, 6
 	EdgeBindAddress string `json:"edge_bind_address"` // IP address to bind for outgoing connections to Cloudflare edge
-	PostQuantum     bool   `json:"post_quantum"`      // Enable PQC for QUIC
+	EdgeInterface   string `json:"edge_interface"`    // network interface whose address is bound instead, re-resolved on every start
+	PostQuantum     bool   `json:"post_quantum"`      // Legacy switch; true when PostQuantumMode is "require"
+	PostQuantumMode string `json:"post_quantum_mode"` // off, prefer, require (QUIC only)
 	NoTLSVerify     bool   `json:"no_tls_verify"`     // Disable TLS verification for backend services
 
 	// Custom extra arguments (space-separated: "--key1 val1 --key2 val2")
 	ExtraArgs string `json:"extra_args"`
 
+	// Tags are key=value pairs shown on the tunnel's connectors in the
+	// Cloudflare dashboard.
+	Tags map[string]string `json:"tags,omitempty"`
+
 	// ActiveTunnelKey is the legacy/default profile used by old single-tunnel
 	// endpoints and features that still need an implicit tunnel profile.
 	ActiveTunnelKey string `json:"active_tunnel_key"`
@@ -92,6 +212,74 @@ type Config struct {
 	// OAuthClientID overrides CFUI_OAUTH_CLIENT_ID when that environment
 	// variable is not set. Client IDs are public OAuth metadata, not secrets.
 	OAuthClientID string `json:"oauth_client_id"`
+
+	// MetricsPollInterval is how often the internal poller gathers tunnel
+	// metrics (e.g. "15s"). "0" disables polling; metrics are then only
+	// gathered on demand.
+	MetricsPollInterval string `json:"metrics_poll_interval"`
+
+	// StatsPersistInterval is how often lifetime tunnel stats are written to
+	// stats.json in the data directory (e.g. "1m"). Writes only happen when
+	// the stats changed; "0" keeps them in memory only.
+	StatsPersistInterval string `json:"stats_persist_interval"`
+
+	// RetryablePatterns and NonRetryablePatterns are case-insensitive
+	// substrings that extend cfui's built-in tunnel error classification,
+	// e.g. for localized or newer cloudflared messages. They are checked
+	// before the built-ins, so they can also override them.
+	RetryablePatterns    []string `json:"retryable_patterns,omitempty"`
+	NonRetryablePatterns []string `json:"non_retryable_patterns,omitempty"`
+
+	// ProtocolOrder is the order auto-protocol tunnels try transports in,
+	// e.g. ["http2", "quic"] on networks that block QUIC. Empty means
+	// quic first, then http2.
+	ProtocolOrder []string `json:"protocol_order,omitempty"`
+
+	// ListenAddr and ListenPort set the HTTP listen address when the
+	// CFUI_BIND_ADDR (or BIND_HOST) and PORT environment variables are
+	// unset. Zero values mean 0.0.0.0 and 14333. Changes apply on restart.
+	ListenAddr string `json:"listen_addr"`
+	ListenPort int    `json:"listen_port"`
+
+	// RequireConnectedForReady holds /readyz at not ready until a tunnel
+	// has registered an edge connection, not merely started.
+	RequireConnectedForReady bool `json:"require_connected_for_ready"`
+
+	// StrictExtraArgs rejects extra_args that repeat a flag cfui already
+	// passes to cloudflared, such as --protocol or --token.
+	StrictExtraArgs bool `json:"strict_extra_args"`
+
+	// MaxRestartsPerHour stops a tunnel's auto-restart once it has
+	// restarted this many times within an hour, leaving it stopped as
+	// "flapping" until started by hand. Zero means no limit.
+	MaxRestartsPerHour int `json:"max_restarts_per_hour"`
+
+	// MaxRuntime gracefully restarts a tunnel once it has run this long,
+	// e.g. "24h" for a daily restart. Empty disables scheduled restarts.
+	MaxRuntime string `json:"max_runtime"`
+
+	// RequireConfirm makes control requests that take a tunnel down, such
+	// as stop, repeat the tunnel key in a "confirm" field.
+	RequireConfirm bool `json:"require_confirm"`
+
+	// RestartJitter spreads each auto-restart delay by up to this many
+	// percent either way, so instances that failed together do not
+	// reconnect in lockstep. Zero keeps the exact backoff.
+	RestartJitter int `json:"restart_jitter"`
+
+	// SoleLogSink makes cfui the only log sink: cloudflared is not given
+	// --logfile, since cfui already captures and stores its output.
+	SoleLogSink bool `json:"sole_log_sink"`
+
+	// MaxProtocolSwitches caps how often auto mode may switch transports
+	// before it sticks to the current one and reports protocol thrashing;
+	// a run that stays up resets the count. Zero means no limit.
+	MaxProtocolSwitches int `json:"max_protocol_switches"`
+
+	// EdgeIPFallback lets profiles with edge_ip_version "6" fall back to
+	// IPv4 after repeated failures that point at the IP family, the way
+	// auto protocol falls back from QUIC to HTTP/2.
+	EdgeIPFallback bool `json:"edge_ip_fallback"`
 }
 
 // DDNSConfig stores settings for the built-in DDNS client.
@@ -125,31 +313,36 @@ type DDNSRecord struct {
 // TunnelProfileConfig stores one Cloudflare Tunnel profile. A profile can be
 // used for local running, remote ingress management, or both.
 type TunnelProfileConfig struct {
-	Key                     string `json:"key"`
-	Name                    string `json:"name"`
-	Token                   string `json:"token"`
-	LocalEnabled            bool   `json:"local_enabled"`
-	RemoteManagementEnabled bool   `json:"remote_management_enabled"`
-	AccountID               string `json:"account_id"`
-	TunnelID                string `json:"tunnel_id"`
-	AutoStart               bool   `json:"auto_start"`
-	AutoRestart             bool   `json:"auto_restart"`
-	CustomTag               string `json:"custom_tag"`
-	SoftwareName            string `json:"software_name"`
-	Protocol                string `json:"protocol"`
-	GracePeriod             string `json:"grace_period"`
-	Region                  string `json:"region"`
-	Retries                 int    `json:"retries"`
-	MetricsEnable           bool   `json:"metrics_enable"`
-	MetricsPort             int    `json:"metrics_port"`
-	LogLevel                string `json:"log_level"`
-	LogFile                 string `json:"log_file"`
-	LogJSON                 bool   `json:"log_json"`
-	EdgeIPVersion           string `json:"edge_ip_version"`
-	EdgeBindAddress         string `json:"edge_bind_address"`
-	PostQuantum             bool   `json:"post_quantum"`
-	NoTLSVerify             bool   `json:"no_tls_verify"`
-	ExtraArgs               string `json:"extra_args"`
+	Key                     string            `json:"key"`
+	Name                    string            `json:"name"`
+	Token                   string            `json:"token"`
+	LocalEnabled            bool              `json:"local_enabled"`
+	RemoteManagementEnabled bool              `json:"remote_management_enabled"`
+	AccountID               string            `json:"account_id"`
+	TunnelID                string            `json:"tunnel_id"`
+	AutoStart               bool              `json:"auto_start"`
+	LazyStart               bool              `json:"lazy_start"`
+	AutoRestart             bool              `json:"auto_restart"`
+	CustomTag               string            `json:"custom_tag"`
+	SoftwareName            string            `json:"software_name"`
+	Protocol                string            `json:"protocol"`
+	GracePeriod             string            `json:"grace_period"`
+	IdleTimeout             string            `json:"idle_timeout"`
+	Region                  string            `json:"region"`
+	Retries                 int               `json:"retries"`
+	MetricsEnable           bool              `json:"metrics_enable"`
+	MetricsPort             int               `json:"metrics_port"`
+	LogLevel                string            `json:"log_level"`
+	LogFile                 string            `json:"log_file"`
+	LogJSON                 bool              `json:"log_json"`
+	EdgeIPVersion           string            `json:"edge_ip_version"`
+	EdgeBindAddress         string            `json:"edge_bind_address"`
+	EdgeInterface           string            `json:"edge_interface"`
+	PostQuantum             bool              `json:"post_quantum"`
+	PostQuantumMode         string            `json:"post_quantum_mode"`
+	NoTLSVerify             bool              `json:"no_tls_verify"`
+	ExtraArgs               string            `json:"extra_args"`
+	Tags                    map[string]string `json:"tags,omitempty"`
 }
 
 // DefaultDDNSConfig returns sensible defaults.
@@ -242,6 +435,7 @@ type S3WebDAVMountConfig struct {
 func DefaultConfig() Config {
 	defaultTunnel := DefaultTunnelProfileConfig()
 	return Config{
+		SchemaVersion:   CurrentSchemaVersion,
 		AutoRestart:     true, // Enable auto-restart by default
 		CustomTag:       "",
 		SoftwareName:    "cfui", // Default software name
@@ -257,6 +451,7 @@ func DefaultConfig() Config {
 		EdgeIPVersion:   "auto",
 		EdgeBindAddress: "",
 		PostQuantum:     false,
+		PostQuantumMode: PostQuantumModeOff,
 		NoTLSVerify:     false, // Verify TLS by default for security
 		ExtraArgs:       "",
 		ActiveTunnelKey: defaultTunnel.Key,
@@ -273,6 +468,8 @@ func DefaultConfig() Config {
 			DedicatedDomainMode: S3WebDAVDomainModeNone,
 			Mounts:              []S3WebDAVMountConfig{DefaultS3WebDAVMountConfig()},
 		},
+		MetricsPollInterval:  DefaultMetricsPollInterval.String(),
+		StatsPersistInterval: DefaultStatsPersistInterval.String(),
 	}
 }
 
@@ -290,6 +487,7 @@ func DefaultTunnelProfileConfig() TunnelProfileConfig {
 		MetricsPort:             60123,
 		LogLevel:                "info",
 		EdgeIPVersion:           "auto",
+		PostQuantumMode:         PostQuantumModeOff,
 	}
 }
 
@@ -440,6 +638,14 @@ type Manager struct {
 	saveMu sync.Mutex
 	mu     sync.RWMutex
 	cfg    Config
+	// configFile is the legacy file name the config was imported from. Save
+	// writes the config back to it in the same format. Guarded by saveMu.
+	configFile string
+	// newerSchema is the schema version of a stored config written by a
+	// newer cfui, or zero. Such a config is served but never written, so a
+	// downgrade cannot clobber fields this build does not know. Guarded by
+	// saveMu.
+	newerSchema int
 }
 
 func NewManager(dir string) (*Manager, error) {
@@ -456,14 +662,14 @@ func NewManager(dir string) (*Manager, error) {
 
 	if err := m.Load(); err != nil {
 		if logger.Sugar != nil {
-			logger.Sugar.Errorf("Failed to load config: %v", err)
+			log().Errorf("Failed to load config: %v", err)
 		}
 		_ = client.Close()
 		return nil, err
 	}
 
 	if logger.Sugar != nil {
-		logger.Sugar.Infof("Loaded configuration from %s", persist.DBPath(dir))
+		log().Infof("Loaded configuration from %s", persist.DBPath(dir))
 	}
 
 	return m, nil
@@ -501,9 +707,32 @@ func (m *Manager) Save(cfg Config) error {
 	if cfg.Tunnels == nil {
 		cfg.Tunnels = cloneSlice(current.Tunnels)
 	}
+	if cfg.RetryablePatterns == nil {
+		cfg.RetryablePatterns = cloneSlice(current.RetryablePatterns)
+	}
+	if cfg.NonRetryablePatterns == nil {
+		cfg.NonRetryablePatterns = cloneSlice(current.NonRetryablePatterns)
+	}
+	if cfg.ProtocolOrder == nil {
+		cfg.ProtocolOrder = cloneSlice(current.ProtocolOrder)
+	}
 	if cfg.ActiveTunnelKey == "" {
 		cfg.ActiveTunnelKey = current.ActiveTunnelKey
 	}
+	if cfg.PostQuantum != current.PostQuantum && cfg.PostQuantumMode == current.PostQuantumMode {
+		// Clients that only know the legacy boolean toggled it.
+		cfg.PostQuantumMode = ""
+	}
+	cfg.PostQuantumMode, cfg.PostQuantum = reconcilePostQuantum(cfg.PostQuantumMode, cfg.PostQuantum)
+	switch {
+	case !maps.Equal(cfg.Tags, current.Tags):
+		cfg.CustomTag = cfg.Tags[LegacyCustomTagKey]
+	case cfg.CustomTag != current.CustomTag:
+		// Clients that only know the legacy field edited it.
+		cfg.Tags = withCustomTag(cfg.Tags, cfg.CustomTag)
+	}
+	cfg.Tags, cfg.CustomTag = reconcileTags(cfg.Tags, cfg.CustomTag)
+	cfg.SchemaVersion = CurrentSchemaVersion
 	if cfg.ActiveTunnelKey == current.ActiveTunnelKey && topLevelTunnelFieldsChanged(cfg, current) {
 		cfg = syncActiveTunnelFromTopLevel(cfg)
 	} else if cfg.ActiveTunnelKey == current.ActiveTunnelKey && topLevelTunnelManagementFieldsChanged(cfg, current) {
@@ -512,10 +741,16 @@ func (m *Manager) Save(cfg Config) error {
 		cfg = applyActiveTunnelToTopLevel(cfg)
 	}
 	cfg = cloneConfig(cfg)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateChanges(current); err != nil {
+		return err
+	}
 
 	if err := m.saveConfig(context.Background(), cfg); err != nil {
 		if logger.Sugar != nil {
-			logger.Sugar.Errorf("Failed to write config: %v", err)
+			log().Errorf("Failed to write config: %v", err)
 		}
 		return err
 	}
@@ -523,8 +758,11 @@ func (m *Manager) Save(cfg Config) error {
 	m.mu.Lock()
 	m.cfg = cloneConfig(cfg)
 	m.mu.Unlock()
+	if err := m.writeConfigFile(cfg); err != nil && logger.Sugar != nil {
+		log().Warnf("Failed to write config file %s: %v", m.configFile, err)
+	}
 	if logger.Sugar != nil {
-		logger.Sugar.Debugf("Configuration saved successfully to %s", persist.DBPath(m.dir))
+		log().Debugf("Configuration saved successfully to %s", persist.DBPath(m.dir))
 	}
 	return nil
 }
@@ -547,6 +785,12 @@ func (m *Manager) ListTunnelProfiles() []TunnelProfileConfig {
 func (m *Manager) SaveTunnelProfile(key string, tunnel TunnelProfileConfig) (Config, error) {
 	cfg := normalizeTunnelProfiles(m.Get())
 	key = normalizeTunnelKey(key)
+	if tunnel.Tags == nil {
+		// Legacy clients send only custom_tag; keep the profile's other tags.
+		if existing, ok := cfg.TunnelProfile(firstNonEmpty(key, tunnel.Key)); ok {
+			tunnel.Tags = withCustomTag(existing.Tags, tunnel.CustomTag)
+		}
+	}
 	tunnel = normalizeTunnelProfile(tunnel, len(cfg.Tunnels))
 	if key != "" {
 		tunnel.Key = key
@@ -628,11 +872,21 @@ func (m *Manager) ActivateTunnelProfile(key string) (Config, error) {
 	return m.Get(), nil
 }
 
+// cloneConfig deep-copies every slice and map of cfg, so what Get returns
+// can be encoded or modified while a Save runs. New reference fields must
+// be added here; TestCloneConfigCopiesEveryReferenceField enforces it.
 func cloneConfig(cfg Config) Config {
 	cfg.Tunnels = cloneSlice(cfg.Tunnels)
 	cfg.DDNS.IPSources = cloneSlice(cfg.DDNS.IPSources)
 	cfg.DDNS.Records = cloneSlice(cfg.DDNS.Records)
 	cfg.S3WebDAV.Mounts = cloneSlice(cfg.S3WebDAV.Mounts)
+	cfg.RetryablePatterns = cloneSlice(cfg.RetryablePatterns)
+	cfg.NonRetryablePatterns = cloneSlice(cfg.NonRetryablePatterns)
+	cfg.ProtocolOrder = cloneSlice(cfg.ProtocolOrder)
+	cfg.Tags = maps.Clone(cfg.Tags)
+	for i := range cfg.Tunnels {
+		cfg.Tunnels[i].Tags = maps.Clone(cfg.Tunnels[i].Tags)
+	}
 	return cfg
 }
 
@@ -646,13 +900,16 @@ func cloneSlice[T any](in []T) []T {
 }
 
 func topLevelTunnelFieldsChanged(next, current Config) bool {
-	return next.Token != current.Token ||
+	return next.TunnelName != current.TunnelName ||
+		next.Token != current.Token ||
 		next.AutoStart != current.AutoStart ||
+		next.LazyStart != current.LazyStart ||
 		next.AutoRestart != current.AutoRestart ||
 		next.CustomTag != current.CustomTag ||
 		next.SoftwareName != current.SoftwareName ||
 		next.Protocol != current.Protocol ||
 		next.GracePeriod != current.GracePeriod ||
+		next.IdleTimeout != current.IdleTimeout ||
 		next.Region != current.Region ||
 		next.Retries != current.Retries ||
 		next.MetricsEnable != current.MetricsEnable ||
@@ -662,9 +919,12 @@ func topLevelTunnelFieldsChanged(next, current Config) bool {
 		next.LogJSON != current.LogJSON ||
 		next.EdgeIPVersion != current.EdgeIPVersion ||
 		next.EdgeBindAddress != current.EdgeBindAddress ||
+		next.EdgeInterface != current.EdgeInterface ||
 		next.PostQuantum != current.PostQuantum ||
+		next.PostQuantumMode != current.PostQuantumMode ||
 		next.NoTLSVerify != current.NoTLSVerify ||
-		next.ExtraArgs != current.ExtraArgs
+		next.ExtraArgs != current.ExtraArgs ||
+		!maps.Equal(next.Tags, current.Tags)
 }
 
 func topLevelTunnelManagementFieldsChanged(next, current Config) bool {
@@ -714,7 +974,7 @@ func normalizeTunnelProfile(tunnel TunnelProfileConfig, index int) TunnelProfile
 	tunnel.Token = strings.TrimSpace(tunnel.Token)
 	tunnel.AccountID = strings.TrimSpace(tunnel.AccountID)
 	tunnel.TunnelID = strings.TrimSpace(tunnel.TunnelID)
-	tunnel.CustomTag = strings.TrimSpace(tunnel.CustomTag)
+	tunnel.Tags, tunnel.CustomTag = reconcileTags(tunnel.Tags, tunnel.CustomTag)
 	tunnel.SoftwareName = strings.TrimSpace(tunnel.SoftwareName)
 	if tunnel.SoftwareName == "" {
 		tunnel.SoftwareName = "cfui"
@@ -723,6 +983,7 @@ func normalizeTunnelProfile(tunnel TunnelProfileConfig, index int) TunnelProfile
 	if strings.TrimSpace(tunnel.GracePeriod) == "" {
 		tunnel.GracePeriod = "30s"
 	}
+	tunnel.IdleTimeout = strings.TrimSpace(tunnel.IdleTimeout)
 	if tunnel.Retries <= 0 {
 		tunnel.Retries = 5
 	}
@@ -738,10 +999,27 @@ func normalizeTunnelProfile(tunnel TunnelProfileConfig, index int) TunnelProfile
 	tunnel.Region = strings.TrimSpace(tunnel.Region)
 	tunnel.LogFile = strings.TrimSpace(tunnel.LogFile)
 	tunnel.EdgeBindAddress = strings.TrimSpace(tunnel.EdgeBindAddress)
+	tunnel.EdgeInterface = strings.TrimSpace(tunnel.EdgeInterface)
 	tunnel.ExtraArgs = strings.TrimSpace(tunnel.ExtraArgs)
+	tunnel.PostQuantumMode, tunnel.PostQuantum = reconcilePostQuantum(tunnel.PostQuantumMode, tunnel.PostQuantum)
 	return tunnel
 }
 
+// reconcilePostQuantum fills an empty mode from the legacy boolean and keeps
+// the boolean in step with the mode. The boolean has always emitted
+// --post-quantum, which cloudflared enforces strictly, so true maps to
+// "require". Unknown modes are returned as-is for Validate to reject.
+func reconcilePostQuantum(mode string, legacy bool) (string, bool) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = PostQuantumModeOff
+		if legacy {
+			mode = PostQuantumModeRequire
+		}
+	}
+	return mode, mode == PostQuantumModeRequire
+}
+
 func normalizeTunnelProtocol(v string) string {
 	switch strings.TrimSpace(v) {
 	case "http2", "quic":
@@ -776,14 +1054,17 @@ func tunnelProfileExists(tunnels []TunnelProfileConfig, key string) bool {
 
 func tunnelProfileFromTopLevel(cfg Config, base TunnelProfileConfig, index int) TunnelProfileConfig {
 	tunnel := base
+	tunnel.Name = cfg.TunnelName
 	tunnel.Token = cfg.Token
 	tunnel.LocalEnabled = true
 	tunnel.AutoStart = cfg.AutoStart
+	tunnel.LazyStart = cfg.LazyStart
 	tunnel.AutoRestart = cfg.AutoRestart
 	tunnel.CustomTag = cfg.CustomTag
 	tunnel.SoftwareName = cfg.SoftwareName
 	tunnel.Protocol = cfg.Protocol
 	tunnel.GracePeriod = cfg.GracePeriod
+	tunnel.IdleTimeout = cfg.IdleTimeout
 	tunnel.Region = cfg.Region
 	tunnel.Retries = cfg.Retries
 	tunnel.MetricsEnable = cfg.MetricsEnable
@@ -793,9 +1074,12 @@ func tunnelProfileFromTopLevel(cfg Config, base TunnelProfileConfig, index int)
 	tunnel.LogJSON = cfg.LogJSON
 	tunnel.EdgeIPVersion = cfg.EdgeIPVersion
 	tunnel.EdgeBindAddress = cfg.EdgeBindAddress
+	tunnel.EdgeInterface = cfg.EdgeInterface
 	tunnel.PostQuantum = cfg.PostQuantum
+	tunnel.PostQuantumMode = cfg.PostQuantumMode
 	tunnel.NoTLSVerify = cfg.NoTLSVerify
 	tunnel.ExtraArgs = cfg.ExtraArgs
+	tunnel.Tags = maps.Clone(cfg.Tags)
 	tunnel.RemoteManagementEnabled = cfg.TunnelManagement.Enabled
 	tunnel.AccountID = cfg.TunnelManagement.AccountID
 	tunnel.TunnelID = cfg.TunnelManagement.TunnelID
@@ -830,13 +1114,16 @@ func syncActiveTunnelManagementFromTopLevel(cfg Config) Config {
 func applyActiveTunnelToTopLevel(cfg Config) Config {
 	cfg = normalizeTunnelProfiles(cfg)
 	tunnel := cfg.ActiveTunnelProfile()
+	cfg.TunnelName = tunnel.Name
 	cfg.Token = tunnel.Token
 	cfg.AutoStart = tunnel.AutoStart
+	cfg.LazyStart = tunnel.LazyStart
 	cfg.AutoRestart = tunnel.AutoRestart
 	cfg.CustomTag = tunnel.CustomTag
 	cfg.SoftwareName = tunnel.SoftwareName
 	cfg.Protocol = tunnel.Protocol
 	cfg.GracePeriod = tunnel.GracePeriod
+	cfg.IdleTimeout = tunnel.IdleTimeout
 	cfg.Region = tunnel.Region
 	cfg.Retries = tunnel.Retries
 	cfg.MetricsEnable = tunnel.MetricsEnable
@@ -846,9 +1133,12 @@ func applyActiveTunnelToTopLevel(cfg Config) Config {
 	cfg.LogJSON = tunnel.LogJSON
 	cfg.EdgeIPVersion = tunnel.EdgeIPVersion
 	cfg.EdgeBindAddress = tunnel.EdgeBindAddress
+	cfg.EdgeInterface = tunnel.EdgeInterface
 	cfg.PostQuantum = tunnel.PostQuantum
+	cfg.PostQuantumMode = tunnel.PostQuantumMode
 	cfg.NoTLSVerify = tunnel.NoTLSVerify
 	cfg.ExtraArgs = tunnel.ExtraArgs
+	cfg.Tags = maps.Clone(tunnel.Tags)
 	cfg.TunnelManagement.Enabled = tunnel.RemoteManagementEnabled
 	cfg.TunnelManagement.AccountID = tunnel.AccountID
 	cfg.TunnelManagement.TunnelID = tunnel.TunnelID
@@ -3,9 +3,15 @@ package config
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"cfui/internal/persist"
 
@@ -164,6 +170,113 @@ func TestManagerGetReturnsIndependentConfigSlices(t *testing.T) {
 	}
 }
 
+// TestCloneConfigCopiesEveryReferenceField fills every slice and map in
+// Config, including those nested in slice elements, and fails when
+// cloneConfig leaves one shared with its input. A new slice or map field
+// must be added to cloneConfig before this passes.
+func TestCloneConfigCopiesEveryReferenceField(t *testing.T) {
+	var cfg Config
+	fillReferenceFields(reflect.ValueOf(&cfg).Elem())
+	clone := cloneConfig(cfg)
+	assertNoSharedReferences(t, "Config", reflect.ValueOf(cfg), reflect.ValueOf(clone))
+}
+
+func fillReferenceFields(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fillReferenceFields(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillReferenceFields(v.Index(0))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		key := reflect.New(v.Type().Key()).Elem()
+		value := reflect.New(v.Type().Elem()).Elem()
+		fillReferenceFields(value)
+		v.SetMapIndex(key, value)
+	}
+}
+
+func assertNoSharedReferences(t *testing.T, path string, a, b reflect.Value) {
+	t.Helper()
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if field := a.Type().Field(i); field.IsExported() {
+				assertNoSharedReferences(t, path+"."+field.Name, a.Field(i), b.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if a.Len() > 0 && a.Pointer() == b.Pointer() {
+			t.Errorf("%s shares its backing array with the clone", path)
+		}
+		for i := 0; i < a.Len() && i < b.Len(); i++ {
+			assertNoSharedReferences(t, fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+	case reflect.Map:
+		if a.Len() > 0 && a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared with the clone", path)
+		}
+	}
+}
+
+func TestManagerConcurrentGetAndSave(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				cfg := mgr.Get()
+				if _, err := json.Marshal(cfg); err != nil {
+					t.Errorf("Marshal: %v", err)
+					return
+				}
+				// Callers may scribble on what Get returned.
+				if cfg.Tags != nil {
+					cfg.Tags["reader"] = "x"
+				}
+				if cfg.Tunnels[0].Tags != nil {
+					cfg.Tunnels[0].Tags["reader"] = "x"
+				}
+				cfg.RetryablePatterns = append(cfg.RetryablePatterns[:0], "reader")
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		cfg := mgr.Get()
+		cfg.Tags = map[string]string{"round": fmt.Sprint(i)}
+		cfg.RetryablePatterns = []string{fmt.Sprintf("pattern %d", i)}
+		cfg.DDNS.Records = []DDNSRecord{{Name: fmt.Sprintf("r%d.example.com", i), ZoneID: "zone-1", ZoneName: "example.com", Type: "A", Value: "{IPV4}", TTL: 1}}
+		if err := mgr.Save(cfg); err != nil {
+			t.Fatalf("Save %d: %v", i, err)
+		}
+	}
+	close(stop)
+	readers.Wait()
+
+	got := mgr.Get()
+	if got.Tags["round"] != "19" || got.Tags["reader"] != "" || len(got.RetryablePatterns) != 1 || got.RetryablePatterns[0] != "pattern 19" {
+		t.Fatalf("final config = tags %v patterns %v, want round 19 untouched by readers", got.Tags, got.RetryablePatterns)
+	}
+}
+
 func TestS3WebDAVPersistsInDatabase(t *testing.T) {
 	dir := t.TempDir()
 	mgr, err := NewManager(dir)
@@ -588,3 +701,158 @@ func TestNormalizeDuplicateTunnelAndS3Keys(t *testing.T) {
 		t.Fatalf("unexpected S3 mount keys after normalization: %#v", s3.Mounts)
 	}
 }
+
+func TestMetricsPollDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":      DefaultMetricsPollInterval,
+		"0":     0,
+		"0s":    0,
+		"30s":   30 * time.Second,
+		"1s":    MinMetricsPollInterval,
+		"bogus": DefaultMetricsPollInterval,
+		"-5s":   DefaultMetricsPollInterval,
+	}
+	for raw, want := range cases {
+		cfg := Config{MetricsPollInterval: raw}
+		if got := cfg.MetricsPollDuration(); got != want {
+			t.Errorf("MetricsPollDuration(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestTunnelTagsPersistAndLegacyCustomTagStaysAnAlias(t *testing.T) {
+	dir := t.TempDir()
+	mgr, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	// Legacy clients only set custom_tag; it becomes the version tag.
+	cfg := mgr.Get()
+	cfg.CustomTag = "v1"
+	if err := mgr.Save(cfg); err != nil {
+		t.Fatalf("Save custom_tag: %v", err)
+	}
+	if got := mgr.Get().Tags; len(got) != 1 || got["version"] != "v1" {
+		t.Fatalf("tags after custom_tag save = %v, want version=v1", got)
+	}
+
+	cfg = mgr.Get()
+	cfg.Tags = map[string]string{"version": "v2", "env": "prod", "team": "infra"}
+	if err := mgr.Save(cfg); err != nil {
+		t.Fatalf("Save tags: %v", err)
+	}
+	if got := mgr.Get(); got.CustomTag != "v2" {
+		t.Fatalf("custom_tag = %q, want it to mirror tags[version]", got.CustomTag)
+	}
+
+	// A legacy profile update touches only the version tag.
+	profile := mgr.Get().ActiveTunnelProfile()
+	profile.Tags = nil
+	profile.CustomTag = "v3"
+	if _, err := mgr.SaveTunnelProfile(profile.Key, profile); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+
+	reloaded, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("reload NewManager: %v", err)
+	}
+	want := map[string]string{"version": "v3", "env": "prod", "team": "infra"}
+	got := reloaded.Get()
+	if !maps.Equal(got.Tags, want) || !maps.Equal(got.ActiveTunnelProfile().Tags, want) || got.CustomTag != "v3" {
+		t.Fatalf("reloaded tags = %v / %v (custom_tag %q), want %v", got.Tags, got.ActiveTunnelProfile().Tags, got.CustomTag, want)
+	}
+
+	got.Tags["env"] = "mutated"
+	if reloaded.Get().Tags["env"] != "prod" {
+		t.Fatal("Get returned a tags map shared with the manager")
+	}
+}
+
+func TestNewManagerUpgradesV1ConfigWithNewDefaults(t *testing.T) {
+	dir := t.TempDir()
+	// A v1 file: no schema_version, and profiles written before
+	// local_enabled, auto_restart and grace_period existed, except that
+	// "office" explicitly turned auto-restart off.
+	v1 := `{
+		"token": "legacy-token",
+		"active_tunnel_key": "home",
+		"tunnels": [
+			{"key": "home", "name": "Home", "token": "home-token", "protocol": "quic"},
+			{"key": "office", "name": "Office", "token": "office-token", "auto_restart": false, "region": ""}
+		]
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(v1), 0644); err != nil {
+		t.Fatalf("Write legacy config: %v", err)
+	}
+
+	mgr, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	got := mgr.Get()
+	if got.SchemaVersion != CurrentSchemaVersion {
+		t.Fatalf("SchemaVersion = %d, want %d", got.SchemaVersion, CurrentSchemaVersion)
+	}
+	home, _ := got.TunnelProfile("home")
+	if !home.LocalEnabled || !home.AutoRestart || home.GracePeriod != "30s" || home.Protocol != "quic" {
+		t.Fatalf("home profile did not get new defaults: %+v", home)
+	}
+	office, _ := got.TunnelProfile("office")
+	if office.AutoRestart {
+		t.Fatal("explicit auto_restart=false was overwritten by the default")
+	}
+	if !office.LocalEnabled || office.Retries != 5 {
+		t.Fatalf("office profile did not get new defaults: %+v", office)
+	}
+	if !got.AutoRestart {
+		t.Fatal("top-level auto_restart should mirror the active profile")
+	}
+}
+
+func TestManagerDoesNotOverwriteNewerSchema(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	cfg := m.Get()
+	cfg.Protocol = "http2"
+	if err := m.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// Simulate a newer cfui having written the config.
+	future := CurrentSchemaVersion + 1
+	if err := m.client.AppSetting.Update().SetSchemaVersion(future).Exec(t.Context()); err != nil {
+		t.Fatalf("stamp future schema: %v", err)
+	}
+
+	m, err = NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager on a newer config: %v", err)
+	}
+	if got := m.Get(); got.Protocol != "http2" {
+		t.Fatalf("Protocol = %q, want the stored http2 to be served", got.Protocol)
+	}
+	cfg = m.Get()
+	cfg.Protocol = "quic"
+	if err := m.Save(cfg); !errors.Is(err, ErrConfigReadOnly) {
+		t.Fatalf("Save = %v, want ErrConfigReadOnly", err)
+	}
+
+	m, err = NewManager(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if got := m.Get(); got.SchemaVersion != future || got.Protocol != "http2" {
+		t.Fatalf("stored config = v%d protocol %q, want it untouched (v%d, http2)", got.SchemaVersion, got.Protocol, future)
+	}
+}
+
+func TestUnmarshalConfigFileRejectsNewerSchema(t *testing.T) {
+	payload := []byte(fmt.Sprintf(`{"schema_version": %d}`, CurrentSchemaVersion+1))
+	if _, err := UnmarshalConfigFile(payload, FormatJSON); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("UnmarshalConfigFile error = %v, want ErrInvalidConfig", err)
+	}
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config file formats. The database remains the source of truth; a config file
+// is read once during migration and, when the config came from one, rewritten
+// in the same format on every Save.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// FormatFromPath detects the config file format from its extension. Unknown
+// extensions fall back to JSON, the historical format.
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// MarshalConfigFile encodes cfg in the given format. YAML and TOML use the
+// same snake_case keys as the JSON API so files are interchangeable.
+func MarshalConfigFile(cfg Config, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON, "":
+		return json.MarshalIndent(cfg, "", "  ")
+	case FormatYAML, FormatTOML:
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+
+	tree, err := configTree(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatYAML {
+		return yaml.Marshal(tree)
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(tree); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalConfigFile decodes a config file payload in the given format on top
+// of DefaultConfig, matching the legacy config.json import semantics.
+func UnmarshalConfigFile(payload []byte, format string) (Config, error) {
+	payload, err := configFileJSON(payload, format)
+	if err != nil {
+		return Config{}, err
+	}
+	return decodeConfig(payload)
+}
+
+// configFileJSON converts a YAML or TOML payload into the equivalent JSON
+// document so a single decoder (keyed by the json struct tags) handles all
+// formats.
+func configFileJSON(payload []byte, format string) ([]byte, error) {
+	var tree map[string]any
+	switch format {
+	case FormatJSON, "":
+		return payload, nil
+	case FormatYAML:
+		if err := yaml.Unmarshal(payload, &tree); err != nil {
+			return nil, fmt.Errorf("parse yaml config: %w", err)
+		}
+	case FormatTOML:
+		if err := toml.Unmarshal(payload, &tree); err != nil {
+			return nil, fmt.Errorf("parse toml config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+	if tree == nil {
+		tree = map[string]any{}
+	}
+	return json.Marshal(tree)
+}
+
+// configTree converts cfg into a generic map keyed by json tags. Numbers keep
+// their integer type and nulls are dropped because TOML cannot represent them.
+func configTree(cfg Config) (map[string]any, error) {
+	payload, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var tree map[string]any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return normalizeConfigTree(tree).(map[string]any), nil
+}
+
+func normalizeConfigTree(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if value == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = normalizeConfigTree(value)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = normalizeConfigTree(v[i])
+		}
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
@@ -0,0 +1,223 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cfui/internal/persist"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	cases := map[string]string{
+		"config.json":      FormatJSON,
+		"config.yaml":      FormatYAML,
+		"/data/config.YML": FormatYAML,
+		"config.toml":      FormatTOML,
+		"config":           FormatJSON,
+	}
+	for path, want := range cases {
+		if got := FormatFromPath(path); got != want {
+			t.Errorf("FormatFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestConfigFileRoundTripsThroughEachFormat(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Token = "round-trip-token"
+	cfg.AutoStart = true
+	cfg.Retries = 7
+	cfg.MetricsPort = 61000
+	// Keep the active profile in sync with the top-level fields, as stored.
+	cfg.Tunnels[0].Token = cfg.Token
+	cfg.Tunnels[0].AutoStart = cfg.AutoStart
+	cfg.Tunnels[0].Retries = cfg.Retries
+	cfg.Tunnels[0].MetricsPort = cfg.MetricsPort
+	cfg.DDNS.IntervalMins = 11
+	cfg.DDNS.Records = []DDNSRecord{{
+		Name: "home.example.com", ZoneID: "zone-1", ZoneName: "example.com",
+		Type: "A", Value: "{IPV4}", Comment: "cfui", Proxied: true, TTL: 1,
+	}}
+	backup := DefaultTunnelProfileConfig()
+	backup.Key = "backup"
+	backup.Name = "Backup"
+	backup.Protocol = "http2"
+	cfg.Tunnels = append(cfg.Tunnels, backup)
+	cfg.Tunnels[1].ExtraArgs = `--tag "a b"`
+
+	for _, format := range []string{FormatJSON, FormatYAML, FormatTOML} {
+		t.Run(format, func(t *testing.T) {
+			data, err := MarshalConfigFile(cfg, format)
+			if err != nil {
+				t.Fatalf("MarshalConfigFile: %v", err)
+			}
+			got, err := UnmarshalConfigFile(data, format)
+			if err != nil {
+				t.Fatalf("UnmarshalConfigFile: %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(got, cfg) {
+				t.Fatalf("round trip mismatch:\n got %#v\nwant %#v\nfile:\n%s", got, cfg, data)
+			}
+		})
+	}
+}
+
+func TestMarshalConfigFileOmitsS3Secrets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.S3WebDAV.Mounts[0].SecretAccessKey = "s3-secret-value"
+	cfg.S3WebDAV.Mounts[0].WebDAVPasswordHash = "webdav-hash-value"
+
+	for _, format := range []string{FormatJSON, FormatYAML, FormatTOML} {
+		data, err := MarshalConfigFile(cfg, format)
+		if err != nil {
+			t.Fatalf("MarshalConfigFile(%s): %v", format, err)
+		}
+		for _, secret := range []string{"s3-secret-value", "webdav-hash-value"} {
+			if strings.Contains(string(data), secret) {
+				t.Fatalf("%s output contains secret %q:\n%s", format, secret, data)
+			}
+		}
+	}
+}
+
+func TestManagerSaveWritesBackInImportedFormat(t *testing.T) {
+	for _, name := range []string{"config.json", "config.yaml", "config.toml"} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			payload := map[string]string{
+				"config.json": `{"token":"imported"}`,
+				"config.yaml": "token: imported\n",
+				"config.toml": "token = \"imported\"\n",
+			}[name]
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(payload), 0644); err != nil {
+				t.Fatalf("Write legacy config: %v", err)
+			}
+
+			mgr, err := NewManager(dir)
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			cfg := mgr.Get()
+			cfg.Retries = 9
+			cfg.TunnelManagement.APIKey = "global-api-key"
+			if err := mgr.Save(cfg); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+
+			// Reopen so the file name is read back from the database.
+			first := mgr
+			mgr, err = NewManager(dir)
+			if err != nil {
+				t.Fatalf("reopen NewManager: %v", err)
+			}
+			cfg = mgr.Get()
+			cfg.Retries = 4
+			if err := mgr.Save(cfg); err != nil {
+				t.Fatalf("Save after reopen: %v", err)
+			}
+
+			export := exportFileName(name)
+			data, err := os.ReadFile(filepath.Join(dir, export))
+			if err != nil {
+				t.Fatalf("expected %s to be written: %v", export, err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+				t.Fatalf("export recreated the imported %s, stat err = %v", name, err)
+			}
+			if !strings.Contains(string(data), exportFileNotice[1]) {
+				t.Fatalf("written %s does not say edits are ignored:\n%s", export, data)
+			}
+			got, err := UnmarshalConfigFile(data, FormatFromPath(export))
+			if err != nil {
+				t.Fatalf("written %s is not valid: %v\n%s", export, err, data)
+			}
+			if got.Retries != 4 {
+				t.Fatalf("written %s = %#v", export, got)
+			}
+			if strings.Contains(string(data), "imported") || strings.Contains(string(data), "global-api-key") {
+				t.Fatalf("written %s contains secrets:\n%s", export, data)
+			}
+			if mgr.Get().Token != "imported" {
+				t.Fatalf("stored token = %q, want imported", mgr.Get().Token)
+			}
+
+			// With the database lost, the tokenless export is not imported.
+			for _, m := range []*Manager{first, mgr} {
+				if err := m.client.Close(); err != nil {
+					t.Fatalf("close database: %v", err)
+				}
+			}
+			for _, suffix := range []string{"", "-wal", "-shm"} {
+				if err := os.Remove(persist.DBPath(dir) + suffix); err != nil && !os.IsNotExist(err) {
+					t.Fatalf("remove database: %v", err)
+				}
+			}
+			mgr, err = NewManager(dir)
+			if err != nil {
+				t.Fatalf("NewManager after database loss: %v", err)
+			}
+			if got := mgr.Get(); got.Retries == 4 {
+				t.Fatalf("export was re-imported after database loss: %#v", got)
+			}
+		})
+	}
+}
+
+func TestManagerSaveWithoutImportedFileWritesNoFile(t *testing.T) {
+	dir := t.TempDir()
+	mgr, err := NewManager(dir)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	if err := mgr.Save(mgr.Get()); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	for _, name := range []string{"config.json", "config.yaml", "config.toml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Fatalf("unexpected %s after Save, stat err = %v", name, err)
+		}
+	}
+}
+
+func TestUnmarshalConfigFileRejectsUnknownFormat(t *testing.T) {
+	if _, err := UnmarshalConfigFile([]byte("{}"), "ini"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func TestNewManagerMigratesLegacyConfigYAMLAndTOML(t *testing.T) {
+	cases := []struct {
+		file    string
+		payload string
+	}{
+		{file: "config.yml", payload: "token: yaml-token\nauto_start: true\nprotocol: http2\nretries: 3\n"},
+		{file: "config.toml", payload: "token = \"toml-token\"\nauto_start = true\nprotocol = \"http2\"\nretries = 3\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			dir := t.TempDir()
+			legacyPath := filepath.Join(dir, tc.file)
+			if err := os.WriteFile(legacyPath, []byte(tc.payload), 0644); err != nil {
+				t.Fatalf("Write legacy config: %v", err)
+			}
+
+			mgr, err := NewManager(dir)
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			got := mgr.Get()
+			if got.Token == "" || !got.AutoStart || got.Protocol != "http2" || got.Retries != 3 {
+				t.Fatalf("legacy %s config not migrated correctly: %#v", tc.file, got)
+			}
+			if got.SoftwareName != "cfui" || got.GracePeriod != "30s" {
+				t.Fatalf("omitted fields should keep defaults: %#v", got)
+			}
+			if _, err := os.Stat(legacyPath + ".migrated"); err != nil {
+				t.Fatalf("expected migrated backup to exist: %v", err)
+			}
+		})
+	}
+}
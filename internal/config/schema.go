@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CurrentSchemaVersion is the config layout written by this build. Configs
+// without a schema_version predate versioning and count as version 1.
+//
+// Version 2 stamps schema_version and fills tunnel profile fields that an
+// older file omits with their defaults.
+const CurrentSchemaVersion = 2
+
+// ErrConfigReadOnly is returned by every save while the stored config comes
+// from a newer cfui than this build.
+var ErrConfigReadOnly = errors.New("config is read-only")
+
+// schemaMigrations[i] upgrades a config document from version i+1 to i+2.
+// Migrations work on the raw JSON object so they can tell a field the user
+// left empty from one that did not exist when the file was written.
+var schemaMigrations = []func(doc map[string]json.RawMessage) error{
+	fillTunnelProfileDefaults,
+}
+
+// upgradeConfigDocument runs the migrations a config document needs and
+// stamps it with CurrentSchemaVersion. It returns the version the document
+// started at.
+func upgradeConfigDocument(doc map[string]json.RawMessage) (int, error) {
+	from := 1
+	if raw, ok := doc["schema_version"]; ok {
+		if err := json.Unmarshal(raw, &from); err != nil {
+			return 0, fmt.Errorf("%w: schema_version: %v", ErrInvalidConfig, err)
+		}
+	}
+	if from > CurrentSchemaVersion {
+		return 0, fmt.Errorf("%w: schema_version %d is newer than this build supports (%d)", ErrInvalidConfig, from, CurrentSchemaVersion)
+	}
+	for version := max(from, 1); version < CurrentSchemaVersion; version++ {
+		if err := schemaMigrations[version-1](doc); err != nil {
+			return 0, fmt.Errorf("migrate config schema v%d: %w", version, err)
+		}
+	}
+	doc["schema_version"] = json.RawMessage(fmt.Sprint(CurrentSchemaVersion))
+	return from, nil
+}
+
+// fillTunnelProfileDefaults adds default values for the tunnel profile fields
+// a profile object lacks. Fields that are present keep the user's value, even
+// when it is empty or false. Key and name are left to normalization, which
+// derives them from the profile's position.
+func fillTunnelProfileDefaults(doc map[string]json.RawMessage) error {
+	raw, ok := doc["tunnels"]
+	if !ok {
+		return nil
+	}
+	var tunnels []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &tunnels); err != nil {
+		return fmt.Errorf("tunnels: %w", err)
+	}
+
+	payload, err := json.Marshal(DefaultTunnelProfileConfig())
+	if err != nil {
+		return err
+	}
+	var defaults map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &defaults); err != nil {
+		return err
+	}
+	delete(defaults, "key")
+	delete(defaults, "name")
+
+	for _, tunnel := range tunnels {
+		if tunnel == nil {
+			continue
+		}
+		for field, value := range defaults {
+			if _, present := tunnel[field]; !present {
+				tunnel[field] = value
+			}
+		}
+	}
+	if doc["tunnels"], err = json.Marshal(tunnels); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -24,11 +28,28 @@ import (
 const defaultConfigKey = "default"
 
 func (m *Manager) loadConfig(ctx context.Context) (Config, error) {
+	m.newerSchema = 0
 	if cfg, ok, err := m.loadStructuredConfig(ctx); err != nil {
 		return Config{}, err
 	} else if ok {
 		if err := configmigrate.Cleanup(ctx, m.dir, configmigrate.SourceLegacyAppTable); err != nil && logger.Sugar != nil {
-			logger.Sugar.Warnf("Failed to delete migrated legacy app_configs table: %v", err)
+			log().Warnf("Failed to delete migrated legacy app_configs table: %v", err)
+		}
+		if cfg.SchemaVersion > CurrentSchemaVersion {
+			m.newerSchema = cfg.SchemaVersion
+			if logger.Sugar != nil {
+				log().Errorf("Config in %s has schema v%d, newer than this build supports (v%d); it was written by a newer cfui. Running read-only so it is not overwritten: upgrade cfui, or restore a backup, to change settings", persist.DBPath(m.dir), cfg.SchemaVersion, CurrentSchemaVersion)
+			}
+		}
+		if cfg.SchemaVersion < CurrentSchemaVersion {
+			// New columns were filled with their defaults by the schema
+			// migration; only the recorded version needs to move.
+			from := cfg.SchemaVersion
+			cfg.SchemaVersion = CurrentSchemaVersion
+			if err := m.saveConfig(ctx, cfg); err != nil {
+				return Config{}, err
+			}
+			logSchemaUpgrade(from)
 		}
 		return cfg, nil
 	}
@@ -38,14 +59,18 @@ func (m *Manager) loadConfig(ctx context.Context) (Config, error) {
 		return Config{}, err
 	}
 	if legacy.Source != configmigrate.SourceNone {
-		cfg, err := decodeConfig(legacy.Payload)
+		cfg, err := UnmarshalConfigFile(legacy.Payload, legacy.Source.Format())
 		if err != nil {
 			return Config{}, err
 		}
+		if legacy.Path != "" {
+			m.configFile = filepath.Base(legacy.Path)
+		}
 		if err := m.saveConfig(ctx, cfg); err != nil {
 			return Config{}, err
 		}
 		logLegacyMigration(legacy.Source, m.dir)
+		logSkippedLegacyFiles(legacy.Skipped)
 		cleanupLegacyMigration(ctx, m.dir, legacy.Source)
 		return cfg, nil
 	}
@@ -55,12 +80,15 @@ func (m *Manager) loadConfig(ctx context.Context) (Config, error) {
 		return Config{}, err
 	}
 	if logger.Sugar != nil {
-		logger.Sugar.Infof("Initialized default configuration in %s", persist.DBPath(m.dir))
+		log().Infof("Initialized default configuration in %s", persist.DBPath(m.dir))
 	}
 	return cfg, nil
 }
 
 func (m *Manager) saveConfig(ctx context.Context, cfg Config) error {
+	if m.newerSchema > 0 {
+		return fmt.Errorf("%w: it has schema v%d, written by a newer cfui than this build (v%d)", ErrConfigReadOnly, m.newerSchema, CurrentSchemaVersion)
+	}
 	if len(cfg.Tunnels) == 0 {
 		cfg = syncActiveTunnelFromTopLevel(cfg)
 	} else {
@@ -77,7 +105,7 @@ func (m *Manager) saveConfig(ctx context.Context, cfg Config) error {
 		}
 	}()
 
-	if err = saveAppSetting(ctx, tx, cfg); err != nil {
+	if err = saveAppSetting(ctx, tx, cfg, m.configFile); err != nil {
 		return err
 	}
 	if err = saveTunnelToken(ctx, tx, cfg.Token); err != nil {
@@ -116,6 +144,8 @@ func (m *Manager) loadStructuredConfig(ctx context.Context) (Config, bool, error
 		return Config{}, false, err
 	}
 
+	m.configFile = settingsRow.ConfigFile
+
 	cfg := DefaultConfig()
 	cfg.DDNS.IPSources = []IPSource{}
 	cfg.DDNS.Records = []DDNSRecord{}
@@ -135,7 +165,9 @@ func (m *Manager) loadStructuredConfig(ctx context.Context) (Config, bool, error
 	cfg.LogJSON = settingsRow.LogJSON
 	cfg.EdgeIPVersion = settingsRow.EdgeIPVersion
 	cfg.EdgeBindAddress = settingsRow.EdgeBindAddress
+	cfg.EdgeInterface = settingsRow.EdgeInterface
 	cfg.PostQuantum = settingsRow.PostQuantum
+	cfg.PostQuantumMode = settingsRow.PostQuantumMode
 	cfg.NoTLSVerify = settingsRow.NoTLSVerify
 	cfg.ExtraArgs = settingsRow.ExtraArgs
 	cfg.ActiveTunnelKey = settingsRow.ActiveTunnelKey
@@ -151,6 +183,26 @@ func (m *Manager) loadStructuredConfig(ctx context.Context) (Config, bool, error
 	cfg.S3WebDAV.DedicatedDomainMode = normalizeS3WebDAVDomainMode(settingsRow.S3WebdavDedicatedDomainMode)
 	cfg.S3WebDAV.DedicatedCustomDomain = strings.TrimSpace(settingsRow.S3WebdavDedicatedCustomDomain)
 	cfg.S3WebDAV.DedicatedTunnelHostname = normalizeS3WebDAVTunnelHostname(settingsRow.S3WebdavDedicatedTunnelHostname)
+	cfg.MetricsPollInterval = settingsRow.MetricsPollInterval
+	cfg.Tags = settingsRow.Tags
+	cfg.IdleTimeout = settingsRow.IdleTimeout
+	cfg.SchemaVersion = settingsRow.SchemaVersion
+	cfg.LazyStart = settingsRow.LazyStart
+	cfg.RetryablePatterns = settingsRow.RetryablePatterns
+	cfg.NonRetryablePatterns = settingsRow.NonRetryablePatterns
+	cfg.ProtocolOrder = settingsRow.ProtocolOrder
+	cfg.ListenPort = settingsRow.ListenPort
+	cfg.ListenAddr = settingsRow.ListenAddr
+	cfg.RequireConnectedForReady = settingsRow.RequireConnectedForReady
+	cfg.StrictExtraArgs = settingsRow.StrictExtraArgs
+	cfg.MaxRestartsPerHour = settingsRow.MaxRestartsPerHour
+	cfg.MaxRuntime = settingsRow.MaxRuntime
+	cfg.RequireConfirm = settingsRow.RequireConfirm
+	cfg.RestartJitter = settingsRow.RestartJitter
+	cfg.SoleLogSink = settingsRow.SoleLogSink
+	cfg.MaxProtocolSwitches = settingsRow.MaxProtocolSwitches
+	cfg.StatsPersistInterval = settingsRow.StatsPersistInterval
+	cfg.EdgeIPFallback = settingsRow.EdgeIPFallback
 
 	if tokenRow, err := m.client.TunnelToken.Query().Where(tunneltoken.Key(defaultConfigKey)).Only(ctx); err == nil {
 		cfg.Token = tokenRow.Token
@@ -188,11 +240,13 @@ func (m *Manager) loadStructuredConfig(ctx context.Context) (Config, bool, error
 			AccountID:               row.AccountID,
 			TunnelID:                row.TunnelID,
 			AutoStart:               row.AutoStart,
+			LazyStart:               row.LazyStart,
 			AutoRestart:             row.AutoRestart,
 			CustomTag:               row.CustomTag,
 			SoftwareName:            row.SoftwareName,
 			Protocol:                row.Protocol,
 			GracePeriod:             row.GracePeriod,
+			IdleTimeout:             row.IdleTimeout,
 			Region:                  row.Region,
 			Retries:                 row.Retries,
 			MetricsEnable:           row.MetricsEnable,
@@ -202,9 +256,12 @@ func (m *Manager) loadStructuredConfig(ctx context.Context) (Config, bool, error
 			LogJSON:                 row.LogJSON,
 			EdgeIPVersion:           row.EdgeIPVersion,
 			EdgeBindAddress:         row.EdgeBindAddress,
+			EdgeInterface:           row.EdgeInterface,
 			PostQuantum:             row.PostQuantum,
+			PostQuantumMode:         row.PostQuantumMode,
 			NoTLSVerify:             row.NoTLSVerify,
 			ExtraArgs:               row.ExtraArgs,
+			Tags:                    row.Tags,
 		})
 	}
 	if len(cfg.Tunnels) == 0 {
@@ -290,7 +347,7 @@ func (m *Manager) loadStructuredConfig(ctx context.Context) (Config, bool, error
 	return cfg, true, nil
 }
 
-func saveAppSetting(ctx context.Context, tx *ent.Tx, cfg Config) error {
+func saveAppSetting(ctx context.Context, tx *ent.Tx, cfg Config, configFile string) error {
 	s3Cfg := normalizeS3WebDAVConfig(cfg.S3WebDAV)
 	row, err := tx.AppSetting.Query().Where(appsetting.Key(defaultConfigKey)).Only(ctx)
 	if ent.IsNotFound(err) {
@@ -311,7 +368,9 @@ func saveAppSetting(ctx context.Context, tx *ent.Tx, cfg Config) error {
 			SetLogJSON(cfg.LogJSON).
 			SetEdgeIPVersion(cfg.EdgeIPVersion).
 			SetEdgeBindAddress(cfg.EdgeBindAddress).
+			SetEdgeInterface(cfg.EdgeInterface).
 			SetPostQuantum(cfg.PostQuantum).
+			SetPostQuantumMode(cfg.PostQuantumMode).
 			SetNoTLSVerify(cfg.NoTLSVerify).
 			SetExtraArgs(cfg.ExtraArgs).
 			SetActiveTunnelKey(cfg.ActiveTunnelKey).
@@ -327,6 +386,27 @@ func saveAppSetting(ctx context.Context, tx *ent.Tx, cfg Config) error {
 			SetS3WebdavDedicatedDomainMode(s3Cfg.DedicatedDomainMode).
 			SetS3WebdavDedicatedCustomDomain(s3Cfg.DedicatedCustomDomain).
 			SetS3WebdavDedicatedTunnelHostname(s3Cfg.DedicatedTunnelHostname).
+			SetMetricsPollInterval(cfg.MetricsPollInterval).
+			SetTags(cfg.Tags).
+			SetIdleTimeout(cfg.IdleTimeout).
+			SetSchemaVersion(cfg.SchemaVersion).
+			SetLazyStart(cfg.LazyStart).
+			SetRetryablePatterns(cfg.RetryablePatterns).
+			SetNonRetryablePatterns(cfg.NonRetryablePatterns).
+			SetProtocolOrder(cfg.ProtocolOrder).
+			SetListenPort(cfg.ListenPort).
+			SetListenAddr(cfg.ListenAddr).
+			SetRequireConnectedForReady(cfg.RequireConnectedForReady).
+			SetStrictExtraArgs(cfg.StrictExtraArgs).
+			SetMaxRestartsPerHour(cfg.MaxRestartsPerHour).
+			SetMaxRuntime(cfg.MaxRuntime).
+			SetRequireConfirm(cfg.RequireConfirm).
+			SetRestartJitter(cfg.RestartJitter).
+			SetSoleLogSink(cfg.SoleLogSink).
+			SetMaxProtocolSwitches(cfg.MaxProtocolSwitches).
+			SetStatsPersistInterval(cfg.StatsPersistInterval).
+			SetEdgeIPFallback(cfg.EdgeIPFallback).
+			SetConfigFile(configFile).
 			Save(ctx)
 		return err
 	}
@@ -350,7 +430,9 @@ func saveAppSetting(ctx context.Context, tx *ent.Tx, cfg Config) error {
 		SetLogJSON(cfg.LogJSON).
 		SetEdgeIPVersion(cfg.EdgeIPVersion).
 		SetEdgeBindAddress(cfg.EdgeBindAddress).
+		SetEdgeInterface(cfg.EdgeInterface).
 		SetPostQuantum(cfg.PostQuantum).
+		SetPostQuantumMode(cfg.PostQuantumMode).
 		SetNoTLSVerify(cfg.NoTLSVerify).
 		SetExtraArgs(cfg.ExtraArgs).
 		SetActiveTunnelKey(cfg.ActiveTunnelKey).
@@ -366,6 +448,27 @@ func saveAppSetting(ctx context.Context, tx *ent.Tx, cfg Config) error {
 		SetS3WebdavDedicatedDomainMode(s3Cfg.DedicatedDomainMode).
 		SetS3WebdavDedicatedCustomDomain(s3Cfg.DedicatedCustomDomain).
 		SetS3WebdavDedicatedTunnelHostname(s3Cfg.DedicatedTunnelHostname).
+		SetMetricsPollInterval(cfg.MetricsPollInterval).
+		SetTags(cfg.Tags).
+		SetIdleTimeout(cfg.IdleTimeout).
+		SetSchemaVersion(cfg.SchemaVersion).
+		SetLazyStart(cfg.LazyStart).
+		SetRetryablePatterns(cfg.RetryablePatterns).
+		SetNonRetryablePatterns(cfg.NonRetryablePatterns).
+		SetProtocolOrder(cfg.ProtocolOrder).
+		SetListenPort(cfg.ListenPort).
+		SetListenAddr(cfg.ListenAddr).
+		SetRequireConnectedForReady(cfg.RequireConnectedForReady).
+		SetStrictExtraArgs(cfg.StrictExtraArgs).
+		SetMaxRestartsPerHour(cfg.MaxRestartsPerHour).
+		SetMaxRuntime(cfg.MaxRuntime).
+		SetRequireConfirm(cfg.RequireConfirm).
+		SetRestartJitter(cfg.RestartJitter).
+		SetSoleLogSink(cfg.SoleLogSink).
+		SetMaxProtocolSwitches(cfg.MaxProtocolSwitches).
+		SetStatsPersistInterval(cfg.StatsPersistInterval).
+		SetEdgeIPFallback(cfg.EdgeIPFallback).
+		SetConfigFile(configFile).
 		Save(ctx)
 	return err
 }
@@ -435,11 +538,13 @@ func saveTunnelProfiles(ctx context.Context, tx *ent.Tx, cfg Config) error {
 			SetAccountID(tunnel.AccountID).
 			SetTunnelID(tunnel.TunnelID).
 			SetAutoStart(tunnel.AutoStart).
+			SetLazyStart(tunnel.LazyStart).
 			SetAutoRestart(tunnel.AutoRestart).
 			SetCustomTag(tunnel.CustomTag).
 			SetSoftwareName(tunnel.SoftwareName).
 			SetProtocol(tunnel.Protocol).
 			SetGracePeriod(tunnel.GracePeriod).
+			SetIdleTimeout(tunnel.IdleTimeout).
 			SetRegion(tunnel.Region).
 			SetRetries(tunnel.Retries).
 			SetMetricsEnable(tunnel.MetricsEnable).
@@ -449,9 +554,12 @@ func saveTunnelProfiles(ctx context.Context, tx *ent.Tx, cfg Config) error {
 			SetLogJSON(tunnel.LogJSON).
 			SetEdgeIPVersion(tunnel.EdgeIPVersion).
 			SetEdgeBindAddress(tunnel.EdgeBindAddress).
+			SetEdgeInterface(tunnel.EdgeInterface).
 			SetPostQuantum(tunnel.PostQuantum).
+			SetPostQuantumMode(tunnel.PostQuantumMode).
 			SetNoTLSVerify(tunnel.NoTLSVerify).
-			SetExtraArgs(tunnel.ExtraArgs))
+			SetExtraArgs(tunnel.ExtraArgs).
+			SetTags(tunnel.Tags))
 	}
 	if len(builders) == 0 {
 		return nil
@@ -737,16 +845,28 @@ func replaceDDNSRecords(ctx context.Context, tx *ent.Tx, records []DDNSRecord) e
 }
 
 func decodeConfig(payload []byte) (Config, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return Config{}, err
+	}
+	from, err := upgradeConfigDocument(raw)
+	if err != nil {
+		return Config{}, err
+	}
+	if payload, err = json.Marshal(raw); err != nil {
+		return Config{}, err
+	}
+
 	cfg := DefaultConfig()
 	if err := json.Unmarshal(payload, &cfg); err != nil {
 		return Config{}, err
 	}
-	var raw map[string]json.RawMessage
-	if err := json.Unmarshal(payload, &raw); err == nil {
-		if _, ok := raw["tunnels"]; !ok {
-			cfg.Tunnels = nil
-			cfg.ActiveTunnelKey = ""
-		}
+	if _, ok := raw["tunnels"]; !ok {
+		cfg.Tunnels = nil
+		cfg.ActiveTunnelKey = ""
+	}
+	if from < CurrentSchemaVersion {
+		logSchemaUpgrade(from)
 	}
 	return cfg, nil
 }
@@ -763,9 +883,9 @@ func cleanupLegacyMigration(ctx context.Context, dir string, source configmigrat
 
 	switch source {
 	case configmigrate.SourceLegacyAppTable:
-		logger.Sugar.Warnf("Failed to delete migrated legacy app_configs table: %v", err)
-	case configmigrate.SourceLegacyJSON:
-		logger.Sugar.Warnf("Failed to rename migrated legacy config.json in %s: %v", dir, err)
+		log().Warnf("Failed to delete migrated legacy app_configs table: %v", err)
+	case configmigrate.SourceLegacyJSON, configmigrate.SourceLegacyYAML, configmigrate.SourceLegacyTOML:
+		log().Warnf("Failed to rename migrated legacy %s config in %s: %v", source.Format(), dir, err)
 	}
 }
 
@@ -776,8 +896,111 @@ func logLegacyMigration(source configmigrate.Source, dir string) {
 
 	switch source {
 	case configmigrate.SourceLegacyAppTable:
-		logger.Sugar.Infof("Migrated legacy config from app_configs to structured tables in %s", persist.DBPath(dir))
-	case configmigrate.SourceLegacyJSON:
-		logger.Sugar.Infof("Migrated legacy config from config.json to structured tables in %s", persist.DBPath(dir))
+		log().Infof("Migrated legacy config from app_configs to structured tables in %s", persist.DBPath(dir))
+	case configmigrate.SourceLegacyJSON, configmigrate.SourceLegacyYAML, configmigrate.SourceLegacyTOML:
+		log().Infof("Migrated legacy %s config file to structured tables in %s", source.Format(), persist.DBPath(dir))
+	}
+}
+
+func logSchemaUpgrade(from int) {
+	if logger.Sugar == nil {
+		return
 	}
+	log().Infof("Upgraded config schema from v%d to v%d", from, CurrentSchemaVersion)
+}
+
+func logSkippedLegacyFiles(paths []string) {
+	if len(paths) == 0 || logger.Sugar == nil {
+		return
+	}
+	log().Warnf("Ignored additional legacy config files (only one source is migrated): %s", strings.Join(paths, ", "))
+}
+
+// exportFileNotice tells anyone who opens a config file export that
+// editing it changes nothing. YAML and TOML carry it as header comment
+// lines, JSON as a leading "_comment" key.
+var exportFileNotice = []string{
+	"Exported by cfui on every save; cfui does not read this file back.",
+	"Edits here are ignored: change settings in the web UI or the API.",
+	"Tunnel tokens and Cloudflare API credentials are left out.",
+}
+
+// exportFileName names the export of a config imported from configFile:
+// config.yaml is exported to config.export.yaml. The legacy import never
+// reads that name, so a lost database cannot re-import the export, which
+// lacks the secrets.
+func exportFileName(configFile string) string {
+	ext := filepath.Ext(configFile)
+	return strings.TrimSuffix(configFile, ext) + ".export" + ext
+}
+
+// writeConfigFile mirrors cfg to an export next to the file the
+// configuration was originally imported from (see exportFileName), keeping
+// that file's format. It is a no-op when the config did not come from a
+// file. The file is an export only: the database wins on load, so edits to
+// it are never read back, and secrets are left out of it.
+func (m *Manager) writeConfigFile(cfg Config) error {
+	if m.configFile == "" {
+		return nil
+	}
+	name := exportFileName(m.configFile)
+	path := filepath.Join(m.dir, name)
+	format := FormatFromPath(path)
+	data, err := MarshalConfigFile(withoutSecrets(cfg), format)
+	if err != nil {
+		return err
+	}
+	data = withExportNotice(data, format)
+	tmp, err := os.CreateTemp(m.dir, "."+name+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// withExportNotice adds exportFileNotice to an encoded config export.
+func withExportNotice(data []byte, format string) []byte {
+	if format == FormatYAML || format == FormatTOML {
+		header := "# " + strings.Join(exportFileNotice, "\n# ") + "\n\n"
+		return append([]byte(header), data...)
+	}
+	// MarshalIndent output opens with "{\n"; the notice becomes the first key.
+	notice, _ := json.Marshal(strings.Join(exportFileNotice, " "))
+	rest, ok := bytes.CutPrefix(data, []byte("{\n"))
+	if !ok {
+		return data
+	}
+	return slices.Concat([]byte(`{
+  "_comment": `), notice, []byte(",\n"), rest)
+}
+
+// withoutSecrets returns cfg with the tunnel tokens and Cloudflare API
+// credentials cleared, for the exported config file.
+func withoutSecrets(cfg Config) Config {
+	cfg.Token = ""
+	cfg.Tunnels = slices.Clone(cfg.Tunnels)
+	for i := range cfg.Tunnels {
+		cfg.Tunnels[i].Token = ""
+	}
+	cfg.TunnelManagement.APIToken = ""
+	cfg.TunnelManagement.APIKey = ""
+	return cfg
 }
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"maps"
+	"strings"
+)
+
+// LegacyCustomTagKey is the dashboard tag the deprecated CustomTag field
+// stands for: cloudflared has always received it as "version=<CustomTag>".
+const LegacyCustomTagKey = "version"
+
+// reconcileTags keeps Tags and the deprecated CustomTag alias in step. Tags
+// win; a custom tag only seeds Tags when no "version" tag is set. Keys and
+// values are trimmed, and an empty set is stored as nil.
+func reconcileTags(tags map[string]string, customTag string) (map[string]string, string) {
+	out := make(map[string]string, len(tags)+1)
+	for key, value := range tags {
+		out[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if _, ok := out[LegacyCustomTagKey]; !ok {
+		if customTag = strings.TrimSpace(customTag); customTag != "" {
+			out[LegacyCustomTagKey] = customTag
+		}
+	}
+	if len(out) == 0 {
+		return nil, ""
+	}
+	return out, out[LegacyCustomTagKey]
+}
+
+// withCustomTag applies an edit made through the legacy custom_tag field to
+// a copy of tags, leaving the other tags alone.
+func withCustomTag(tags map[string]string, customTag string) map[string]string {
+	out := maps.Clone(tags)
+	if out == nil {
+		out = make(map[string]string, 1)
+	}
+	if customTag = strings.TrimSpace(customTag); customTag == "" {
+		delete(out, LegacyCustomTagKey)
+	} else {
+		out[LegacyCustomTagKey] = customTag
+	}
+	return out
+}
@@ -0,0 +1,452 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxDashboardLabelLength caps SoftwareName and CustomTag. cloudflared sends
+// both to the Cloudflare dashboard, which truncates long labels.
+const MaxDashboardLabelLength = 32
+
+// MaxTunnelNameLength caps the cfui-side tunnel label.
+const MaxTunnelNameLength = 64
+
+// MaxExtraArgsLength and MaxExtraArgs bound extra_args. cloudflared runs in
+// process, so no OS limit applies; the caps only stop malformed input.
+const (
+	MaxExtraArgsLength = 4096
+	MaxExtraArgs       = 64
+)
+
+// ErrInvalidConfig is wrapped by every error returned from Validate.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Validate rejects values cloudflared or the Cloudflare dashboard would
+// reject or mangle. It checks the top-level fields and every tunnel profile.
+// Dashboard labels are checked by ValidateChanges instead, so values stored
+// before they were restricted keep loading and saving.
+func (c Config) Validate() error {
+	if err := validateIdleTimeout("", c.IdleTimeout); err != nil {
+		return err
+	}
+	if err := validatePostQuantum("", c.PostQuantumMode, c.Protocol); err != nil {
+		return err
+	}
+	if err := validateEdgeInterface("", c.EdgeInterface, c.EdgeBindAddress); err != nil {
+		return err
+	}
+	if err := validateErrorPatterns("retryable_patterns", c.RetryablePatterns); err != nil {
+		return err
+	}
+	if err := validateErrorPatterns("non_retryable_patterns", c.NonRetryablePatterns); err != nil {
+		return err
+	}
+	if err := validateProtocolOrder(c.ProtocolOrder); err != nil {
+		return err
+	}
+	if err := validateListen(c.ListenAddr, c.ListenPort); err != nil {
+		return err
+	}
+	if c.MaxRestartsPerHour < 0 {
+		return fmt.Errorf("%w: max_restarts_per_hour must not be negative, got %d", ErrInvalidConfig, c.MaxRestartsPerHour)
+	}
+	if c.RestartJitter < 0 || c.RestartJitter > MaxRestartJitter {
+		return fmt.Errorf("%w: restart_jitter must be between 0 and %d percent, got %d", ErrInvalidConfig, MaxRestartJitter, c.RestartJitter)
+	}
+	if c.MaxProtocolSwitches < 0 {
+		return fmt.Errorf("%w: max_protocol_switches must not be negative, got %d", ErrInvalidConfig, c.MaxProtocolSwitches)
+	}
+	if err := validateMaxRuntime(c.MaxRuntime); err != nil {
+		return err
+	}
+	if err := validateInterval("metrics_poll_interval", c.MetricsPollInterval, MinMetricsPollInterval); err != nil {
+		return err
+	}
+	if err := validateInterval("stats_persist_interval", c.StatsPersistInterval, MinStatsPersistInterval); err != nil {
+		return err
+	}
+	if err := validateExtraArgsSize("", c.ExtraArgs); err != nil {
+		return err
+	}
+	if c.StrictExtraArgs {
+		if err := validateExtraArgs("", c.ExtraArgs); err != nil {
+			return err
+		}
+	}
+	for _, tunnel := range c.Tunnels {
+		if err := validateTunnelName(tunnel.Key, tunnel.Name); err != nil {
+			return err
+		}
+		if err := validateIdleTimeout(tunnel.Key, tunnel.IdleTimeout); err != nil {
+			return err
+		}
+		if err := validatePostQuantum(tunnel.Key, tunnel.PostQuantumMode, tunnel.Protocol); err != nil {
+			return err
+		}
+		if err := validateEdgeInterface(tunnel.Key, tunnel.EdgeInterface, tunnel.EdgeBindAddress); err != nil {
+			return err
+		}
+		if err := validateExtraArgsSize(tunnel.Key, tunnel.ExtraArgs); err != nil {
+			return err
+		}
+		if c.StrictExtraArgs {
+			if err := validateExtraArgs(tunnel.Key, tunnel.ExtraArgs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateChanges checks the fields of c that differ from prev, the stored
+// config, where a stored value may predate the current rules or depend on
+// the host: software_name, custom_tag, tags and edge_interface. A profile
+// missing from prev is checked in full.
+func (c Config) ValidateChanges(prev Config) error {
+	if err := validateChangedLabels("", c.SoftwareName, c.CustomTag, c.Tags, prev.SoftwareName, prev.CustomTag, prev.Tags); err != nil {
+		return err
+	}
+	if c.EdgeInterface != prev.EdgeInterface {
+		if err := validateEdgeInterfaceExists("", c.EdgeInterface); err != nil {
+			return err
+		}
+	}
+	for _, tunnel := range c.Tunnels {
+		var old TunnelProfileConfig
+		if i := slices.IndexFunc(prev.Tunnels, func(t TunnelProfileConfig) bool { return t.Key == tunnel.Key }); i >= 0 {
+			old = prev.Tunnels[i]
+		}
+		if err := validateChangedLabels(tunnel.Key, tunnel.SoftwareName, tunnel.CustomTag, tunnel.Tags, old.SoftwareName, old.CustomTag, old.Tags); err != nil {
+			return err
+		}
+		if tunnel.EdgeInterface != old.EdgeInterface {
+			if err := validateEdgeInterfaceExists(tunnel.Key, tunnel.EdgeInterface); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateChangedLabels applies the dashboard label rules to the labels
+// that differ from their previous values.
+func validateChangedLabels(tunnelKey, softwareName, customTag string, tags map[string]string, prevSoftwareName, prevCustomTag string, prevTags map[string]string) error {
+	prefix := tunnelErrorPrefix(tunnelKey)
+	if softwareName != prevSoftwareName {
+		if err := validateDashboardLabel("software_name", softwareName, false); err != nil {
+			return fmt.Errorf("%w: %s%v", ErrInvalidConfig, prefix, err)
+		}
+	}
+	if customTag != prevCustomTag {
+		if err := validateDashboardLabel("custom_tag", customTag, true); err != nil {
+			return fmt.Errorf("%w: %s%v", ErrInvalidConfig, prefix, err)
+		}
+	}
+	if !maps.Equal(tags, prevTags) {
+		return validateTags(tunnelKey, tags)
+	}
+	return nil
+}
+
+// Warnings reports settings that are valid but likely unintended. A
+// profile with log_file set writes cloudflared's output twice, once to its
+// own file and once through cfui's capture, unless sole_log_sink is on.
+func (c Config) Warnings() []string {
+	if c.SoleLogSink {
+		return nil
+	}
+	var warnings []string
+	for _, tunnel := range c.Tunnels {
+		if tunnel.LogFile != "" {
+			warnings = append(warnings, fmt.Sprintf("%slog_file duplicates the output cfui already captures; enable sole_log_sink to keep only cfui's copy", tunnelErrorPrefix(tunnel.Key)))
+		}
+	}
+	return warnings
+}
+
+// validatePostQuantum accepts an empty mode, which normalization maps from
+// the legacy boolean. Requiring post-quantum is QUIC-only in cloudflared.
+func validatePostQuantum(tunnelKey, mode, protocol string) error {
+	prefix := tunnelErrorPrefix(tunnelKey)
+	switch mode {
+	case "", PostQuantumModeOff, PostQuantumModePrefer:
+	case PostQuantumModeRequire:
+		if protocol == "http2" {
+			return fmt.Errorf("%w: %spost_quantum_mode %q requires the quic or auto protocol", ErrInvalidConfig, prefix, mode)
+		}
+	default:
+		return fmt.Errorf("%w: %spost_quantum_mode must be off, prefer or require, got %q", ErrInvalidConfig, prefix, mode)
+	}
+	return nil
+}
+
+// interfaceByName looks up a network interface; tests replace it.
+var interfaceByName = net.InterfaceByName
+
+// validateEdgeInterface rejects setting edge_interface together with
+// edge_bind_address, which it replaces.
+func validateEdgeInterface(tunnelKey, name, bindAddress string) error {
+	if name != "" && bindAddress != "" {
+		return fmt.Errorf("%w: %sset edge_interface or edge_bind_address, not both", ErrInvalidConfig, tunnelErrorPrefix(tunnelKey))
+	}
+	return nil
+}
+
+// validateEdgeInterfaceExists checks that the interface exists on this
+// host. Interfaces come and go, so it only runs when edge_interface
+// changes; a start resolves the interface again and reports it missing.
+func validateEdgeInterfaceExists(tunnelKey, name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := interfaceByName(name); err != nil {
+		return fmt.Errorf("%w: %sedge_interface %q does not exist on this host", ErrInvalidConfig, tunnelErrorPrefix(tunnelKey), name)
+	}
+	return nil
+}
+
+// managedFlags are the cloudflared flags, aliases included, that cfui sets
+// itself from dedicated fields. Passing one again in extra_args leaves
+// cloudflared to pick one of the two values.
+var managedFlags = map[string]bool{
+	"config":            true,
+	"no-autoupdate":     true,
+	"token":             true,
+	"protocol":          true,
+	"p":                 true,
+	"grace-period":      true,
+	"region":            true,
+	"retries":           true,
+	"metrics":           true,
+	"loglevel":          true,
+	"logfile":           true,
+	"log-format":        true,
+	"edge-ip-version":   true,
+	"edge-bind-address": true,
+	"post-quantum":      true,
+	"pq":                true,
+	"no-tls-verify":     true,
+}
+
+// validateExtraArgs rejects extra_args that set a managed flag, written as
+// -flag, --flag, or --flag=value.
+func validateExtraArgs(tunnelKey, extraArgs string) error {
+	for _, arg := range splitExtraArgs(extraArgs) {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if managedFlags[name] {
+			return fmt.Errorf("%w: %sextra_args must not set --%s; cfui manages it, use its own setting", ErrInvalidConfig, tunnelErrorPrefix(tunnelKey), name)
+		}
+	}
+	return nil
+}
+
+// validateExtraArgsSize caps extra_args at MaxExtraArgsLength bytes and
+// MaxExtraArgs arguments.
+func validateExtraArgsSize(tunnelKey, extraArgs string) error {
+	prefix := tunnelErrorPrefix(tunnelKey)
+	if len(extraArgs) > MaxExtraArgsLength {
+		return fmt.Errorf("%w: %sextra_args must be at most %d bytes, got %d", ErrInvalidConfig, prefix, MaxExtraArgsLength, len(extraArgs))
+	}
+	if n := len(splitExtraArgs(extraArgs)); n > MaxExtraArgs {
+		return fmt.Errorf("%w: %sextra_args must have at most %d arguments, got %d", ErrInvalidConfig, prefix, MaxExtraArgs, n)
+	}
+	return nil
+}
+
+// splitExtraArgs splits extra_args the way cloudflared.ParseExtraArgs does:
+// on spaces, keeping double-quoted runs together.
+func splitExtraArgs(extraArgs string) []string {
+	var args []string
+	var current strings.Builder
+	inQuote := false
+	for i := 0; i < len(extraArgs); i++ {
+		switch c := extraArgs[i]; {
+		case c == '"':
+			inQuote = !inQuote
+		case c == ' ' && !inQuote:
+			if current.Len() > 0 {
+				args = append(args, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// MaxErrorPatternLength bounds one error classification pattern.
+const MaxErrorPatternLength = 200
+
+// validateErrorPatterns rejects blank and overly long patterns; a blank one
+// would match every error.
+func validateErrorPatterns(field string, patterns []string) error {
+	for i, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("%w: %s[%d] must not be blank", ErrInvalidConfig, field, i)
+		}
+		if len(pattern) > MaxErrorPatternLength {
+			return fmt.Errorf("%w: %s[%d] must be at most %d characters", ErrInvalidConfig, field, i, MaxErrorPatternLength)
+		}
+	}
+	return nil
+}
+
+// validateProtocolOrder accepts an empty order or distinct concrete
+// protocols; "auto" is what the order configures, so it cannot appear in it.
+func validateProtocolOrder(order []string) error {
+	for i, protocol := range order {
+		if protocol != "quic" && protocol != "http2" {
+			return fmt.Errorf("%w: protocol_order[%d] %q must be quic or http2", ErrInvalidConfig, i, protocol)
+		}
+		if slices.Contains(order[:i], protocol) {
+			return fmt.Errorf("%w: protocol_order lists %q more than once", ErrInvalidConfig, protocol)
+		}
+	}
+	return nil
+}
+
+// validateListen accepts an empty address and a zero port, which keep the
+// defaults. The address is a bare host; the port has its own field.
+func validateListen(addr string, port int) error {
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("%w: listen_port must be between 1 and 65535, got %d", ErrInvalidConfig, port)
+	}
+	if addr == "" {
+		return nil
+	}
+	if strings.TrimSpace(addr) != addr || strings.ContainsAny(addr, " \t/") {
+		return fmt.Errorf("%w: listen_addr %q must be a host name or IP address", ErrInvalidConfig, addr)
+	}
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return fmt.Errorf("%w: listen_addr %q must not include a port; set listen_port", ErrInvalidConfig, addr)
+	}
+	return nil
+}
+
+// validateIdleTimeout accepts an empty value (never stop for inactivity) or
+// a duration of at least MinIdleTimeout.
+func validateIdleTimeout(tunnelKey, value string) error {
+	if value == "" {
+		return nil
+	}
+	prefix := tunnelErrorPrefix(tunnelKey)
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%w: %sidle_timeout %q is not a duration like 30m", ErrInvalidConfig, prefix, value)
+	}
+	if d < MinIdleTimeout {
+		return fmt.Errorf("%w: %sidle_timeout must be empty or at least %s", ErrInvalidConfig, prefix, MinIdleTimeout)
+	}
+	return nil
+}
+
+// validateMaxRuntime accepts an empty value (no scheduled restart) or a
+// duration of at least MinMaxRuntime.
+func validateMaxRuntime(value string) error {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%w: max_runtime %q is not a duration like 24h", ErrInvalidConfig, value)
+	}
+	if d < MinMaxRuntime {
+		return fmt.Errorf("%w: max_runtime must be empty or at least %s", ErrInvalidConfig, MinMaxRuntime)
+	}
+	return nil
+}
+
+// validateInterval accepts an empty value (the default), zero (disabled), or
+// a duration of at least min, so nothing saved is changed at runtime.
+func validateInterval(field, value string, min time.Duration) error {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s %q is not a duration like 30s", ErrInvalidConfig, field, value)
+	}
+	if d != 0 && d < min {
+		return fmt.Errorf("%w: %s must be 0 (off) or at least %s, got %s", ErrInvalidConfig, field, min, value)
+	}
+	return nil
+}
+
+// validateTunnelName checks a profile's display name. Empty names never get
+// here: normalization replaces them with "Tunnel N".
+func validateTunnelName(tunnelKey, name string) error {
+	prefix := tunnelErrorPrefix(tunnelKey)
+	if utf8.RuneCountInString(name) > MaxTunnelNameLength {
+		return fmt.Errorf("%w: %sname must be at most %d characters", ErrInvalidConfig, prefix, MaxTunnelNameLength)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: %sname must not contain control characters", ErrInvalidConfig, prefix)
+		}
+	}
+	return nil
+}
+
+// tunnelErrorPrefix names the tunnel profile an error belongs to; top-level
+// fields have no prefix.
+func tunnelErrorPrefix(tunnelKey string) string {
+	if tunnelKey == "" {
+		return ""
+	}
+	return fmt.Sprintf("tunnel %q: ", tunnelKey)
+}
+
+// validateTags applies the dashboard label rules to every tag key and value.
+// Both must be non-empty; cloudflared splits each tag on its first '='.
+func validateTags(tunnelKey string, tags map[string]string) error {
+	prefix := tunnelErrorPrefix(tunnelKey)
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		value := tags[key]
+		if key == "" || value == "" {
+			return fmt.Errorf("%w: %stag keys and values must not be empty (%q=%q)", ErrInvalidConfig, prefix, key, value)
+		}
+		if err := validateDashboardLabel("tag key", key, false); err != nil {
+			return fmt.Errorf("%w: %s%v", ErrInvalidConfig, prefix, err)
+		}
+		if err := validateDashboardLabel("tag "+key, value, true); err != nil {
+			return fmt.Errorf("%w: %s%v", ErrInvalidConfig, prefix, err)
+		}
+	}
+	return nil
+}
+
+// validateDashboardLabel allows empty values; callers apply their own
+// defaults for those. allowDot admits '.' for version-like values such as
+// custom_tag "1.2.3".
+func validateDashboardLabel(field, value string, allowDot bool) error {
+	if len(value) > MaxDashboardLabelLength {
+		return fmt.Errorf("%s must be at most %d characters, got %d", field, MaxDashboardLabelLength, len(value))
+	}
+	for _, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		case r == '.' && allowDot:
+		case allowDot:
+			return fmt.Errorf("%s %q may only contain letters, digits, '.', '-' and '_'", field, value)
+		default:
+			return fmt.Errorf("%s %q may only contain letters, digits, '-' and '_'", field, value)
+		}
+	}
+	return nil
+}
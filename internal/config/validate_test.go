@@ -0,0 +1,275 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestValidateDashboardLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*Config) {}},
+		{name: "empty tag", mutate: func(c *Config) { c.CustomTag = "" }},
+		{name: "dash and underscore", mutate: func(c *Config) { c.SoftwareName = "cfui-home_01"; c.CustomTag = "v2_edge-1" }},
+		{name: "max length", mutate: func(c *Config) { c.SoftwareName = strings.Repeat("a", MaxDashboardLabelLength) }},
+		{name: "too long", mutate: func(c *Config) { c.SoftwareName = strings.Repeat("a", MaxDashboardLabelLength+1) }, wantErr: "software_name must be at most"},
+		{name: "space", mutate: func(c *Config) { c.SoftwareName = "my cfui" }, wantErr: "software_name"},
+		{name: "dot in tag", mutate: func(c *Config) { c.CustomTag = "v1.2.3" }},
+		{name: "dot in software name", mutate: func(c *Config) { c.SoftwareName = "cfui.home" }, wantErr: "software_name"},
+		{name: "non ascii", mutate: func(c *Config) { c.CustomTag = "标签" }, wantErr: "custom_tag"},
+		{name: "profile", mutate: func(c *Config) { c.Tunnels[0].CustomTag = "a/b" }, wantErr: `tunnel "default": custom_tag`},
+		{name: "empty tag value", mutate: func(c *Config) { c.Tags = map[string]string{"env": ""} }, wantErr: "must not be empty"},
+		{name: "tag key with equals", mutate: func(c *Config) { c.Tunnels[0].Tags = map[string]string{"a=b": "c"} }, wantErr: `tunnel "default": tag key`},
+		{name: "tag value with newline", mutate: func(c *Config) { c.Tags = map[string]string{"env": "prod\n- x"} }, wantErr: "tag env"},
+		{name: "idle timeout", mutate: func(c *Config) { c.Tunnels[0].IdleTimeout = "30m" }},
+		{name: "idle timeout not a duration", mutate: func(c *Config) { c.IdleTimeout = "soon" }, wantErr: "idle_timeout"},
+		{name: "idle timeout too short", mutate: func(c *Config) { c.Tunnels[0].IdleTimeout = "10s" }, wantErr: `tunnel "default": idle_timeout must be`},
+		{name: "restart jitter", mutate: func(c *Config) { c.RestartJitter = 20 }},
+		{name: "protocol switch cap", mutate: func(c *Config) { c.MaxProtocolSwitches = 4 }},
+		{name: "negative protocol switch cap", mutate: func(c *Config) { c.MaxProtocolSwitches = -1 }, wantErr: "max_protocol_switches must not be negative"},
+		{name: "restart jitter too wide", mutate: func(c *Config) { c.RestartJitter = 80 }, wantErr: "restart_jitter must be between"},
+		{name: "max runtime", mutate: func(c *Config) { c.MaxRuntime = "24h" }},
+		{name: "metrics poll interval", mutate: func(c *Config) { c.MetricsPollInterval = "30s" }},
+		{name: "metrics polling off", mutate: func(c *Config) { c.MetricsPollInterval = "0s" }},
+		{name: "metrics poll interval not a duration", mutate: func(c *Config) { c.MetricsPollInterval = "often" }, wantErr: "metrics_poll_interval"},
+		{name: "negative metrics poll interval", mutate: func(c *Config) { c.MetricsPollInterval = "-5s" }, wantErr: "metrics_poll_interval must be"},
+		{name: "metrics poll interval too short", mutate: func(c *Config) { c.MetricsPollInterval = "1s" }, wantErr: "metrics_poll_interval must be"},
+		{name: "stats persist interval", mutate: func(c *Config) { c.StatsPersistInterval = "5m" }},
+		{name: "stats kept in memory", mutate: func(c *Config) { c.StatsPersistInterval = "0" }},
+		{name: "stats persist interval not a duration", mutate: func(c *Config) { c.StatsPersistInterval = "hourly" }, wantErr: "stats_persist_interval"},
+		{name: "stats persist interval too short", mutate: func(c *Config) { c.StatsPersistInterval = "2s" }, wantErr: "stats_persist_interval must be"},
+		{name: "max runtime too short", mutate: func(c *Config) { c.MaxRuntime = "5m" }, wantErr: "max_runtime must be"},
+		{name: "protocol order", mutate: func(c *Config) { c.ProtocolOrder = []string{"http2", "quic"} }},
+		{name: "unknown protocol in order", mutate: func(c *Config) { c.ProtocolOrder = []string{"auto"} }, wantErr: "protocol_order[0]"},
+		{name: "duplicate protocol in order", mutate: func(c *Config) { c.ProtocolOrder = []string{"quic", "quic"} }, wantErr: "more than once"},
+		{name: "extra args too long", mutate: func(c *Config) { c.ExtraArgs = "--label " + strings.Repeat("x", MaxExtraArgsLength) }, wantErr: "extra_args must be at most"},
+		{name: "too many extra args", mutate: func(c *Config) { c.Tunnels[0].ExtraArgs = strings.Repeat("--x ", MaxExtraArgs+1) }, wantErr: `tunnel "default": extra_args must have at most`},
+		{name: "error patterns", mutate: func(c *Config) { c.RetryablePatterns = []string{"quota exceeded"} }},
+		{name: "blank error pattern", mutate: func(c *Config) { c.NonRetryablePatterns = []string{" "} }, wantErr: "non_retryable_patterns[0]"},
+		{name: "error pattern too long", mutate: func(c *Config) { c.RetryablePatterns = []string{strings.Repeat("a", MaxErrorPatternLength+1)} }, wantErr: "retryable_patterns[0] must be at most"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if err == nil {
+				// A config checked against an empty one has every label
+				// changed.
+				err = cfg.ValidateChanges(Config{})
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %v, want ErrInvalidConfig containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSaveRejectsInvalidSoftwareName(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	cfg := mgr.Get()
+	cfg.SoftwareName = "bad name!"
+	if err := mgr.Save(cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Save error = %v, want ErrInvalidConfig", err)
+	}
+	if got := mgr.Get().SoftwareName; got != "cfui" {
+		t.Fatalf("rejected save changed software_name to %q", got)
+	}
+}
+
+func TestSaveKeepsStoredLabelsThatPredateTheRules(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	cfg := mgr.Get()
+	cfg.CustomTag = "beta build"
+	cfg.Tunnels[0].CustomTag = cfg.CustomTag
+	cfg.Tunnels[0].Tags = map[string]string{LegacyCustomTagKey: cfg.CustomTag}
+	cfg.Tags = cfg.Tunnels[0].Tags
+	// Store it the way an older cfui would have, without the label rules.
+	if err := mgr.saveConfig(context.Background(), cfg); err != nil {
+		t.Fatalf("saveConfig: %v", err)
+	}
+	mgr, err = NewManager(mgr.dir)
+	if err != nil {
+		t.Fatalf("reopen NewManager: %v", err)
+	}
+
+	cfg = mgr.Get()
+	cfg.Retries = 7
+	if err := mgr.Save(cfg); err != nil {
+		t.Fatalf("unrelated save with a stored legacy custom_tag: %v", err)
+	}
+	cfg = mgr.Get()
+	cfg.CustomTag = "still bad"
+	if err := mgr.Save(cfg); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("changing custom_tag to an invalid value: err = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestValidatePostQuantumMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PostQuantumMode = "maybe"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("unknown mode error = %v, want ErrInvalidConfig", err)
+	}
+	cfg = DefaultConfig()
+	cfg.PostQuantumMode, cfg.Protocol = PostQuantumModeRequire, "http2"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "quic") {
+		t.Fatalf("require over http2 error = %v, want quic-only error", err)
+	}
+	cfg.Protocol = "quic"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("require over quic: %v", err)
+	}
+}
+
+func TestValidateEdgeInterface(t *testing.T) {
+	orig := interfaceByName
+	t.Cleanup(func() { interfaceByName = orig })
+	interfaceByName = func(name string) (*net.Interface, error) {
+		if name != "eth-test" {
+			return nil, errors.New("no such network interface")
+		}
+		return &net.Interface{Name: name}, nil
+	}
+
+	prev := DefaultConfig()
+	cfg := DefaultConfig()
+	cfg.EdgeInterface = "eth-test"
+	if err := cfg.ValidateChanges(prev); err != nil {
+		t.Fatalf("existing interface: %v", err)
+	}
+	cfg.EdgeInterface = "missing0"
+	if err := cfg.ValidateChanges(prev); !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "missing0") {
+		t.Fatalf("missing interface error = %v, want ErrInvalidConfig naming it", err)
+	}
+	// A stored interface that has since gone away does not block saves.
+	prev.EdgeInterface = "missing0"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate with a stored missing interface: %v", err)
+	}
+	if err := cfg.ValidateChanges(prev); err != nil {
+		t.Fatalf("unchanged missing interface: %v", err)
+	}
+	cfg.EdgeInterface, cfg.EdgeBindAddress = "eth-test", "192.0.2.1"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("interface plus bind address error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestValidateListen(t *testing.T) {
+	for _, tc := range []struct {
+		addr string
+		port int
+		ok   bool
+	}{
+		{"", 0, true},
+		{"127.0.0.1", 8080, true},
+		{"::1", 8080, true},
+		{"127.0.0.1:8080", 0, false},
+		{"", 70000, false},
+	} {
+		cfg := DefaultConfig()
+		cfg.ListenAddr, cfg.ListenPort = tc.addr, tc.port
+		if err := cfg.Validate(); (err == nil) != tc.ok {
+			t.Errorf("listen %q:%d: Validate = %v, want ok=%v", tc.addr, tc.port, err, tc.ok)
+		}
+	}
+}
+
+func TestSaveMapsLegacyPostQuantumBoolean(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	cfg := mgr.Get()
+	if cfg.PostQuantumMode != PostQuantumModeOff {
+		t.Fatalf("default mode = %q, want off", cfg.PostQuantumMode)
+	}
+
+	// A client that only knows the boolean keeps getting --post-quantum.
+	cfg.PostQuantum = true
+	if err := mgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if got := mgr.Get(); got.PostQuantumMode != PostQuantumModeRequire || got.ActiveTunnelProfile().PostQuantumMode != PostQuantumModeRequire {
+		t.Fatalf("legacy true mapped to %q / profile %q, want require", got.PostQuantumMode, got.ActiveTunnelProfile().PostQuantumMode)
+	}
+
+	cfg = mgr.Get()
+	cfg.PostQuantumMode = PostQuantumModePrefer
+	if err := mgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if got := mgr.Get(); got.PostQuantumMode != PostQuantumModePrefer || got.PostQuantum {
+		t.Fatalf("prefer saved as %q (legacy %v), want prefer/false", got.PostQuantumMode, got.PostQuantum)
+	}
+}
+
+func TestValidateTunnelName(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Tunnels[0].Name = strings.Repeat("n", MaxTunnelNameLength+1)
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("long name error = %v, want ErrInvalidConfig", err)
+	}
+	cfg.Tunnels[0].Name = "home\nnas"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("control character error = %v, want ErrInvalidConfig", err)
+	}
+	cfg.Tunnels[0].Name = "家里的 NAS"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unicode name: %v", err)
+	}
+}
+
+func TestValidateStrictExtraArgs(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ExtraArgs = `--ha-connections 8 --protocol http2`
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("lenient mode: %v", err)
+	}
+
+	cfg.StrictExtraArgs = true
+	for _, extra := range []string{`--protocol http2`, `--protocol=quic`, `-p http2`} {
+		cfg.ExtraArgs = extra
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "extra_args") {
+			t.Errorf("extra_args %q: Validate = %v, want ErrInvalidConfig about extra_args", extra, err)
+		}
+	}
+	cfg.ExtraArgs = `--ha-connections 8 --tag "note=--protocol http2"`
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unmanaged flags: %v", err)
+	}
+}
+
+func TestWarningsFlagDoubleLogging(t *testing.T) {
+	cfg := DefaultConfig()
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Fatalf("default config warnings = %v, want none", warnings)
+	}
+	cfg.Tunnels[0].LogFile = "/tmp/cloudflared.log"
+	if warnings := cfg.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "sole_log_sink") {
+		t.Fatalf("log_file warnings = %v, want one about sole_log_sink", warnings)
+	}
+	cfg.SoleLogSink = true
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Fatalf("sole sink warnings = %v, want none", warnings)
+	}
+}
@@ -14,41 +14,73 @@ const (
 	legacyConfigFile     = "config.json"
 )
 
+// legacyYAMLConfigFiles lists the accepted YAML file names in lookup order.
+var legacyYAMLConfigFiles = []string{"config.yaml", "config.yml"}
+
+const legacyTOMLConfigFile = "config.toml"
+
 type Source string
 
 const (
 	SourceNone           Source = ""
 	SourceLegacyJSON     Source = "legacy_json"
+	SourceLegacyYAML     Source = "legacy_yaml"
+	SourceLegacyTOML     Source = "legacy_toml"
 	SourceLegacyAppTable Source = "legacy_app_configs"
 )
 
+// Format returns the payload encoding of a source: "json", "yaml", or
+// "toml". The legacy app_configs table stores JSON payloads.
+func (s Source) Format() string {
+	switch s {
+	case SourceLegacyYAML:
+		return "yaml"
+	case SourceLegacyTOML:
+		return "toml"
+	default:
+		return "json"
+	}
+}
+
 type Result struct {
 	Source  Source
 	Payload []byte
+	// Path is the imported file for file-based sources.
+	Path string
+	// Skipped lists other legacy config files that were present but not
+	// imported because a higher-priority source won.
+	Skipped []string
 }
 
 // Load returns the first available legacy config source in migration priority:
-// deprecated app_configs table first, then legacy config.json.
+// deprecated app_configs table first, then legacy config.json, config.yaml
+// (or config.yml), and config.toml.
 func Load(ctx context.Context, dir, key string) (Result, error) {
+	var result Result
 	if payload, ok, err := loadLegacyAppConfigPayload(ctx, dir, key); err != nil {
 		return Result{}, err
 	} else if ok {
-		return Result{
-			Source:  SourceLegacyAppTable,
-			Payload: payload,
-		}, nil
+		result.Source = SourceLegacyAppTable
+		result.Payload = payload
 	}
 
-	if payload, ok, err := loadLegacyJSON(filepath.Join(dir, legacyConfigFile)); err != nil {
-		return Result{}, err
-	} else if ok {
-		return Result{
-			Source:  SourceLegacyJSON,
-			Payload: payload,
-		}, nil
+	for _, path := range legacyConfigFiles(dir) {
+		if result.Source != SourceNone {
+			result.Skipped = append(result.Skipped, path)
+			continue
+		}
+		payload, ok, err := loadLegacyFile(path)
+		if err != nil {
+			return Result{}, err
+		}
+		if ok {
+			result.Source = sourceForPath(path)
+			result.Payload = payload
+			result.Path = path
+		}
 	}
 
-	return Result{}, nil
+	return result, nil
 }
 
 // Cleanup finalizes a successful migration by removing or renaming the legacy
@@ -57,8 +89,12 @@ func Cleanup(ctx context.Context, dir string, source Source) error {
 	switch source {
 	case SourceNone:
 		return nil
-	case SourceLegacyJSON:
-		return persist.MarkLegacyMigrated(filepath.Join(dir, legacyConfigFile))
+	case SourceLegacyJSON, SourceLegacyYAML, SourceLegacyTOML:
+		path, ok := legacyConfigPath(dir, source)
+		if !ok {
+			return os.ErrNotExist
+		}
+		return persist.MarkLegacyMigrated(path)
 	case SourceLegacyAppTable:
 		return dropLegacyAppConfigTable(ctx, dir)
 	default:
@@ -66,7 +102,58 @@ func Cleanup(ctx context.Context, dir string, source Source) error {
 	}
 }
 
-func loadLegacyJSON(path string) ([]byte, bool, error) {
+// legacyConfigFiles returns the legacy config files present in dir, in
+// migration priority order.
+func legacyConfigFiles(dir string) []string {
+	var paths []string
+	for _, source := range []Source{SourceLegacyJSON, SourceLegacyYAML, SourceLegacyTOML} {
+		for _, name := range legacyFileNames(source) {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
+// legacyConfigPath resolves the file backing a file-based source. YAML accepts
+// both extensions; the first existing name wins.
+func legacyConfigPath(dir string, source Source) (string, bool) {
+	for _, name := range legacyFileNames(source) {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func legacyFileNames(source Source) []string {
+	switch source {
+	case SourceLegacyJSON:
+		return []string{legacyConfigFile}
+	case SourceLegacyYAML:
+		return legacyYAMLConfigFiles
+	case SourceLegacyTOML:
+		return []string{legacyTOMLConfigFile}
+	default:
+		return nil
+	}
+}
+
+func sourceForPath(path string) Source {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return SourceLegacyYAML
+	case ".toml":
+		return SourceLegacyTOML
+	default:
+		return SourceLegacyJSON
+	}
+}
+
+func loadLegacyFile(path string) ([]byte, bool, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"cfui/internal/persist"
@@ -49,6 +50,43 @@ func TestLoadPrefersLegacyAppTableOverJSON(t *testing.T) {
 	}
 }
 
+func TestLoadReportsSkippedLegacyFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.json": `{"token":"json-token"}`,
+		"config.yaml": "token: yaml-token\n",
+		"config.yml":  "token: yml-token\n",
+		"config.toml": "token = \"toml-token\"\n",
+	}
+	for name, payload := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(payload), 0644); err != nil {
+			t.Fatalf("Write legacy %s: %v", name, err)
+		}
+	}
+
+	result, err := Load(context.Background(), dir, "default")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if result.Source != SourceLegacyJSON || result.Path != filepath.Join(dir, "config.json") {
+		t.Fatalf("expected config.json to be imported, got source %q path %q", result.Source, result.Path)
+	}
+	want := []string{
+		filepath.Join(dir, "config.yaml"),
+		filepath.Join(dir, "config.yml"),
+		filepath.Join(dir, "config.toml"),
+	}
+	if !reflect.DeepEqual(result.Skipped, want) {
+		t.Fatalf("Skipped = %v, want %v", result.Skipped, want)
+	}
+}
+
+func TestCleanupMissingYAMLReturnsNotExist(t *testing.T) {
+	if err := Cleanup(context.Background(), t.TempDir(), SourceLegacyYAML); !os.IsNotExist(err) {
+		t.Fatalf("Cleanup without YAML file err = %v, want not-exist", err)
+	}
+}
+
 func TestCleanupRenamesLegacyJSON(t *testing.T) {
 	dir := t.TempDir()
 	legacyPath := filepath.Join(dir, "config.json")
@@ -14,8 +14,15 @@ import (
 
 	"github.com/cloudflare/backoff"
 	cloudflare "github.com/cloudflare/cloudflare-go"
+	"go.uber.org/zap"
 )
 
+// log returns the package logger, named cfui.ddns so broadcast lines can
+// be filtered by component.
+func log() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentDDNS)
+}
+
 const (
 	ipSourceRetryBaseDelay = 1 * time.Second
 	ipSourceRetryMaxDelay  = 10 * time.Second
@@ -137,7 +144,7 @@ func (s *Service) Start() {
 		defer close(loopDone)
 		s.loop(loopCtx, stopCh)
 	}()
-	logger.Sugar.Info("DDNS service started")
+	log().Info("DDNS service started")
 }
 
 // Stop halts the background loop.
@@ -172,7 +179,7 @@ func (s *Service) Stop() {
 	if loopDone != nil {
 		<-loopDone
 	}
-	logger.Sugar.Info("DDNS service stopped")
+	log().Info("DDNS service stopped")
 }
 
 // Restart stops and starts the service with the latest config.
@@ -321,7 +328,7 @@ func (s *Service) detectIP(ctx context.Context, sources []config.IPSource, targe
 		}
 
 		if lastErr != nil {
-			logger.Sugar.Debugf("DDNS %s source %s exhausted after %d attempts: %v", targetType, src.URL, maxRetries, lastErr)
+			log().Debugf("DDNS %s source %s exhausted after %d attempts: %v", targetType, src.URL, maxRetries, lastErr)
 		}
 	}
 	if lastErr != nil {
@@ -370,7 +377,7 @@ func (s *Service) checkAndSync(parent context.Context) {
 	if err != nil {
 		s.lastError = err.Error()
 		s.mu.Unlock()
-		logger.Sugar.Warnf("DDNS IP detection failed: %v", err)
+		log().Warnf("DDNS IP detection failed: %v", err)
 		return
 	}
 	s.lastError = ""
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Sources of lines captured from the process's standard streams.
+const (
+	SourceStdout = "stdout"
+	SourceStderr = "stderr"
+)
+
+// BroadcastFrom broadcasts a line captured from source. Subscribers and the
+// buffers get it tagged (JSON lines gain a "source" field, other lines a
+// "[source] " prefix); observers get the raw line so their parsers keep
+// working. Captured lines are ComponentCloudflared: cfui itself logs through
+// zap, so only the cloudflared library writes to the standard streams.
+func (b *LogBroadcaster) BroadcastFrom(source, line string) {
+	for _, fn := range b.deliver(tagLine(source, line), ComponentCloudflared) {
+		fn(line)
+	}
+}
+
+func tagLine(source, line string) string {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "{"))
+		if rest != "}" {
+			rest = "," + rest
+		}
+		return `{"source":"` + source + `"` + rest + "\n"
+	}
+	return "[" + source + "] " + line
+}
+
+// CaptureStdio swaps os.Stdout and os.Stderr for pipes that copy every line
+// to the original stream and, tagged with its source, to b. The cloudflared
+// library writes some diagnostics straight to stderr, bypassing zap.
+//
+// Only the Go variables are swapped, never file descriptors: the runtime
+// writes fatal panics and crash traces to fd 2 directly, so they still reach
+// the real stderr even when the process dies mid-line. Call it after
+// Initialize, whose console output is bound to the original os.Stdout and
+// so is not broadcast twice. restore undoes the swap and drains the pipes.
+func CaptureStdio(b *LogBroadcaster) (restore func(), err error) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return nil, err
+	}
+
+	origOut, origErr := os.Stdout, os.Stderr
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go pumpStdio(&wg, outR, origOut, b, SourceStdout)
+	go pumpStdio(&wg, errR, origErr, b, SourceStderr)
+	os.Stdout, os.Stderr = outW, errW
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			os.Stdout, os.Stderr = origOut, origErr
+			outW.Close()
+			errW.Close()
+			wg.Wait()
+		})
+	}, nil
+}
+
+// pumpStdio forwards lines from a capture pipe until its write end closes.
+func pumpStdio(wg *sync.WaitGroup, r *os.File, orig io.Writer, b *LogBroadcaster, source string) {
+	defer wg.Done()
+	defer r.Close()
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			_, _ = io.WriteString(orig, line)
+			b.BroadcastFrom(source, line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCaptureStdioBroadcastsStderrTaggedBySource(t *testing.T) {
+	b := NewLogBroadcaster(10)
+	defer b.Close()
+	var (
+		mu       sync.Mutex
+		observed []string
+	)
+	b.Observe(func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		observed = append(observed, line)
+	})
+
+	restore, err := CaptureStdio(b)
+	if err != nil {
+		t.Fatalf("CaptureStdio: %v", err)
+	}
+	// What cloudflared's console logger emits when it bypasses zap.
+	const line = "2026-03-01T10:00:00Z ERR Failed to dial edge error=\"no route to host\"\n"
+	fmt.Fprint(os.Stderr, line)
+	fmt.Fprint(os.Stdout, `{"level":"info","message":"hello"}`+"\n")
+	restore()
+
+	recent := b.GetRecentLogs()
+	want := []string{"[stderr] " + line, `{"source":"stdout","level":"info","message":"hello"}` + "\n"}
+	found := map[string]bool{}
+	for _, got := range recent {
+		found[got] = true
+	}
+	for _, w := range want {
+		if !found[w] {
+			t.Fatalf("recent logs %q missing %q", recent, w)
+		}
+	}
+	if errs := b.ErrorEntries(); len(errs) != 1 || errs[0].Line != want[0] {
+		t.Fatalf("error ring = %+v, want the stderr line", errs)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// stdout and stderr are drained by separate goroutines, so the order
+	// of the two lines is not fixed.
+	if !slices.Contains(observed, line) {
+		t.Fatalf("observers got %q, want the raw stderr line among them", observed)
+	}
+}
+
+func TestTagLine(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"plain text\n", "[stderr] plain text\n"},
+		{`{"level":"warn"}` + "\n", `{"source":"stderr","level":"warn"}` + "\n"},
+		{"{}\n", `{"source":"stderr"}` + "\n"},
+		{"{not json\n", "[stderr] {not json\n"},
+	} {
+		if got := tagLine(SourceStderr, tt.in); got != tt.want {
+			t.Errorf("tagLine(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Components tag broadcast lines by origin so the UI can filter and colour
+// them. cfui's own loggers are named after them (see Named); lines captured
+// from the process's standard streams come from the cloudflared library.
+const (
+	ComponentCFUI        = "cfui"
+	ComponentServer      = "cfui.server"
+	ComponentRunner      = "cfui.runner"
+	ComponentConfig      = "cfui.config"
+	ComponentDDNS        = "cfui.ddns"
+	ComponentTunnelMgr   = "cfui.tunnelmgr"
+	ComponentMCP         = "cfui.mcp"
+	ComponentCloudflared = "cloudflared"
+	ComponentHTTP        = "http"
+)
+
+// Named returns the global logger named component, or nil before
+// Initialize. Call it per use rather than keeping the result: Initialize
+// replaces the global logger.
+func Named(component string) *zap.SugaredLogger {
+	if Sugar == nil {
+		return nil
+	}
+	return Sugar.Named(component)
+}
+
+// componentKey prefixes the logger name in a JSON log line.
+const componentKey = `"component":"`
+
+// lineComponent reads the logger name zap wrote into a JSON line. Lines from
+// the unnamed root logger are ComponentCFUI.
+func lineComponent(line string) string {
+	i := strings.Index(line, componentKey)
+	if i < 0 {
+		return ComponentCFUI
+	}
+	rest := line[i+len(componentKey):]
+	if end := strings.IndexByte(rest, '"'); end > 0 {
+		return rest[:end]
+	}
+	return ComponentCFUI
+}
+
+// ComponentMatches reports whether component is want or one of its
+// children, so "cfui" matches "cfui.runner".
+func ComponentMatches(component, want string) bool {
+	return component == want || strings.HasPrefix(component, want+".")
+}
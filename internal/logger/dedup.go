@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dedupState collapses a run of identical lines into the first line plus a
+// "last message repeated N times" summary. Lines are compared without their
+// timestamp, so a message logged in a loop still counts as a repeat.
+type dedupState struct {
+	window time.Duration
+
+	// key identifies the last delivered line; start is when it was
+	// delivered and repeats how many copies have been held back since.
+	key     string
+	start   time.Time
+	repeats int
+	// component is the last delivered line's component; its summary
+	// carries the same one.
+	component string
+}
+
+// admit reports whether line should be delivered. When it ends a run of
+// suppressed repeats, summary is the line to deliver before it.
+func (d *dedupState) admit(line string, now time.Time) (ok bool, summary string) {
+	key := dedupKey(line)
+	if key == d.key && now.Sub(d.start) < d.window {
+		d.repeats++
+		return false, ""
+	}
+	summary = d.flush()
+	d.key, d.start = key, now
+	return true, summary
+}
+
+// flush returns the summary for the held-back repeats, if any, and resets
+// the count.
+func (d *dedupState) flush() string {
+	if d.repeats == 0 {
+		return ""
+	}
+	summary := fmt.Sprintf("last message repeated %d times\n", d.repeats)
+	d.repeats = 0
+	return summary
+}
+
+// dedupKey is line without its timestamp: the "time" field of a JSON line,
+// or a leading RFC 3339 token of a console line, possibly after a
+// "[source]" tag.
+func dedupKey(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
+			delete(fields, "time")
+			if key, err := json.Marshal(fields); err == nil {
+				return string(key)
+			}
+		}
+		return trimmed
+	}
+	tokens := strings.Fields(trimmed)
+	for i := 0; i < len(tokens) && i < 2; i++ {
+		if _, err := time.Parse(time.RFC3339, tokens[i]); err == nil {
+			return strings.Join(append(tokens[:i:i], tokens[i+1:]...), " ")
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is how lumberjack stamps rotated file names.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// maxExportLineBytes bounds one log line read back from disk; zap stack
+// traces make some lines long.
+const maxExportLineBytes = 1 << 20
+
+// WriteRange copies the JSON lines of the log file at path and its rotated
+// backups whose time falls within [from, to] to w, oldest first. Gzipped
+// backups are read transparently. Backups rotated before from are skipped
+// unread, and reading stops at the first line after to. Lines without a
+// parsable time are dropped. A file that cannot be read, such as a gzip
+// backup truncated by a crash mid-rotation, is skipped with a warning after
+// whatever lines it yielded; only a failed write to w is returned.
+func WriteRange(w io.Writer, path string, from, to time.Time) error {
+	return writeRange(w, path, from, to, nil)
+}
+
+// WriteNDJSON is WriteRange for log-analysis tools: every line written is
+// one compact JSON object with a "time" field. Lines that are not JSON
+// objects, such as console-formatted output, are skipped. A zero from
+// exports the whole history.
+func WriteNDJSON(w io.Writer, path string, from, to time.Time) error {
+	return writeRange(w, path, from, to, compactObject)
+}
+
+// compactObject returns line as compact JSON, or false when it is not a
+// JSON object.
+func compactObject(line []byte) ([]byte, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '{' {
+		return nil, false
+	}
+	var out bytes.Buffer
+	if err := json.Compact(&out, line); err != nil {
+		return nil, false
+	}
+	return out.Bytes(), true
+}
+
+// writeRange is WriteRange with an optional transform that rewrites or,
+// by returning false, drops each in-range line.
+func writeRange(w io.Writer, path string, from, to time.Time, transform func([]byte) ([]byte, bool)) error {
+	for _, file := range logFilesSince(path, from) {
+		past, err := writeFileRange(w, file, from, to, transform)
+		var werr writeError
+		if errors.As(err, &werr) {
+			return werr.err
+		}
+		if err != nil {
+			if Sugar != nil {
+				Sugar.Warnf("Skipping unreadable log file %s: %v", file, err)
+			}
+			continue
+		}
+		if past {
+			return nil
+		}
+	}
+	return nil
+}
+
+// writeError marks a failed write to the export destination, which ends the
+// export, as opposed to a read error, which only skips the file.
+type writeError struct{ err error }
+
+func (e writeError) Error() string { return e.err.Error() }
+
+// logFilesSince lists the backups of path rotated at or after from, oldest
+// first, followed by path itself.
+func logFilesSince(path string, from time.Time) []string {
+	dir := filepath.Dir(path)
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+	entries, _ := os.ReadDir(dir)
+
+	type backup struct {
+		name    string
+		rotated time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		stamp, ok := strings.CutPrefix(strings.TrimSuffix(name, ".gz"), prefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		stamp, ok = strings.CutSuffix(stamp, ext)
+		if !ok {
+			continue
+		}
+		rotated, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil || rotated.Before(from) {
+			continue
+		}
+		backups = append(backups, backup{name: name, rotated: rotated})
+	}
+	sort.Slice(backups, func(a, b int) bool { return backups[a].rotated.Before(backups[b].rotated) })
+
+	files := make([]string, 0, len(backups)+1)
+	for _, b := range backups {
+		files = append(files, filepath.Join(dir, b.name))
+	}
+	return append(files, path)
+}
+
+// writeFileRange copies the in-range lines of one file. past reports that a
+// line after to was reached, so later files need not be read. A missing
+// file has no lines.
+func writeFileRange(w io.Writer, path string, from, to time.Time, transform func([]byte) ([]byte, bool)) (past bool, err error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return false, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxExportLineBytes)
+	for scanner.Scan() {
+		t, ok := lineTime(scanner.Bytes())
+		switch {
+		case !ok || t.Before(from):
+			continue
+		case t.After(to):
+			return true, nil
+		}
+		line := scanner.Bytes()
+		if transform != nil {
+			if line, ok = transform(line); !ok {
+				continue
+			}
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return false, writeError{err}
+		}
+	}
+	return false, scanner.Err()
+}
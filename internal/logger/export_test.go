@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteRangeReturnsOnlyInRangeLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfui.log")
+	line := func(stamp, msg string) string {
+		return `{"time":"` + stamp + `","msg":"` + msg + `"}` + "\n"
+	}
+	rotated := func(at time.Time) string {
+		return filepath.Join(dir, "cfui-"+at.Local().Format(backupTimeFormat)+".log")
+	}
+
+	// A backup rotated before the range is skipped without being read.
+	old := rotated(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err := os.WriteFile(old, []byte(line("2026-03-01T11:00:00.000Z", "old")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte(line("2026-03-01T13:59:00.000Z", "before") + line("2026-03-01T14:20:00.000Z", "backup in range")))
+	zw.Close()
+	if err := os.WriteFile(rotated(time.Date(2026, 3, 1, 14, 25, 0, 0, time.UTC))+".gz", gz.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	active := line("2026-03-01T14:30:00.000Z", "active in range") +
+		"not json\n" +
+		line("2026-03-01T14:40:00.000Z", "after") +
+		line("2026-03-01T14:35:00.000Z", "after the stop")
+	if err := os.WriteFile(path, []byte(active), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	from := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 14, 30, 0, 0, time.UTC)
+	if err := WriteRange(&out, path, from, to); err != nil {
+		t.Fatalf("WriteRange: %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(got) != 2 || !strings.Contains(got[0], "backup in range") || !strings.Contains(got[1], "active in range") {
+		t.Fatalf("lines = %q, want the two in-range lines", got)
+	}
+}
+
+func TestWriteNDJSONEmitsCompactJSONWithTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfui.log")
+	lines := `{ "time": "2026-03-01T14:30:00.000Z", "level": "INFO", "msg": "spaced" }` + "\n" +
+		"2026-03-01T14:31:00.000Z\tINFO\tconsole noise\n" +
+		`["2026-03-01T14:32:00.000Z"]` + "\n" +
+		`{"level":"INFO","msg":"no time"}` + "\n" +
+		`{"time":"2026-03-01T14:33:00.000Z","level":"ERROR","msg":"kept"}` + "\n"
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteNDJSON(&out, path, time.Time{}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteNDJSON: %v", err)
+	}
+	got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(got) != 2 {
+		t.Fatalf("lines = %q, want the two JSON lines with a time", got)
+	}
+	for _, line := range got {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		if _, ok := fields["time"].(string); !ok {
+			t.Fatalf("line %q has no time field", line)
+		}
+		if strings.Contains(line, ": ") {
+			t.Fatalf("line %q was not compacted", line)
+		}
+	}
+}
+
+func TestWriteRangeSkipsCorruptBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfui.log")
+	line := func(stamp, msg string) string {
+		return `{"time":"` + stamp + `","msg":"` + msg + `"}` + "\n"
+	}
+	gzipped := func(text string) []byte {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(text))
+		zw.Close()
+		return buf.Bytes()
+	}
+	backup := func(hour int, data []byte) {
+		at := time.Date(2026, 3, 1, hour, 0, 0, 0, time.UTC)
+		name := filepath.Join(dir, "cfui-"+at.Local().Format(backupTimeFormat)+".log.gz")
+		if err := os.WriteFile(name, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backup(10, gzipped(line("2026-03-01T09:30:00.000Z", "good backup")))
+	// Cut off mid-stream, as by a crash during rotation: the first line is
+	// intact but the gzip trailer is missing.
+	truncated := gzipped(line("2026-03-01T10:30:00.000Z", "before the cut") + strings.Repeat(line("2026-03-01T10:31:00.000Z", "lost"), 50))
+	backup(11, truncated[:len(truncated)/2])
+	backup(12, nil)
+	backup(13, []byte("not gzip at all"))
+	if err := os.WriteFile(path, []byte(line("2026-03-01T13:30:00.000Z", "active")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteRange(&out, path, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteRange: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"good backup", "active"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output lost %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "not gzip") {
+		t.Fatalf("corrupt backup leaked into the output:\n%s", got)
+	}
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// errorBufferSize bounds the ring of error-level lines kept separately from
+// the recent-log buffer.
+const errorBufferSize = 100
+
+// consoleErrorLevels are the level tokens of error-or-worse lines in zap's
+// and cloudflared's console formats.
+var consoleErrorLevels = map[string]bool{
+	"ERROR": true, "ERR": true,
+	"DPANIC": true, "PANIC": true, "PNC": true,
+	"FATAL": true, "FTL": true,
+}
+
+// isErrorLine reports whether a broadcast line is logged at error level or
+// worse. JSON lines are judged by their "level" field; console lines by the
+// first level token, which may carry ANSI colour codes.
+func isErrorLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var fields struct {
+			Level string `json:"level"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
+			return consoleErrorLevels[strings.ToUpper(fields.Level)]
+		}
+	}
+	for i, token := range strings.Fields(trimmed) {
+		if i > 2 {
+			break
+		}
+		if consoleErrorLevels[stripANSI(token)] {
+			return true
+		}
+	}
+	return false
+}
+
+func stripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x1b {
+			for i < len(s) && s[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -3,10 +3,12 @@ package logger
 import (
 	"bytes"
 	"container/ring"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -19,16 +21,40 @@ var (
 	Sugar         *zap.SugaredLogger
 	broadcaster   *LogBroadcaster
 	broadcasterMu sync.RWMutex
+	// fileLogger writes the active log file; nil until Initialize.
+	fileLogger *lumberjack.Logger
+	// activeConfig is the configuration the last Initialize applied.
+	activeConfig atomic.Pointer[Config]
 )
 
+// DefaultLogFileName is the log file used when Config.LogFileName is empty.
+const DefaultLogFileName = "cfui.log"
+
+// RecentBufferSize is how many recent lines the broadcaster keeps for the
+// UI and for replay to new log streams.
+const RecentBufferSize = 500
+
 // Config holds logger configuration
 type Config struct {
-	LogDir     string
-	MaxSize    int  // megabytes
-	MaxBackups int  // number of backups
-	MaxAge     int  // days
-	Compress   bool // compress rotated files
-	LogLevel   string
+	LogDir string
+	// LogFileName is the base name of the log file inside LogDir, e.g.
+	// "cfui-home.log". Rotated backups are named after it. A name without
+	// an extension gets ".log"; empty means DefaultLogFileName.
+	LogFileName string
+	MaxSize     int  // megabytes
+	MaxBackups  int  // number of backups
+	MaxAge      int  // days
+	Compress    bool // compress rotated files
+	LogLevel    string
+	// MaxFileAge force-rotates the active log file once its oldest line is
+	// this old, so MaxAge retention also holds when the file never reaches
+	// MaxSize. Zero disables it.
+	MaxFileAge time.Duration
+	// DedupWindow collapses consecutive identical lines seen within this
+	// window into one "last message repeated N times" entry for the UI
+	// and the recent-logs buffer. The file still gets every line. Zero
+	// disables it.
+	DedupWindow time.Duration
 }
 
 // DefaultConfig returns default logger configuration
@@ -47,20 +73,41 @@ func DefaultConfig() *Config {
 	}
 
 	return &Config{
-		LogDir:     logDir,
-		MaxSize:    10,   // 10 MB
-		MaxBackups: 10,   // keep 10 backups
-		MaxAge:     7,    // 7 days
-		Compress:   true, // compress old logs
-		LogLevel:   "info",
+		LogDir:      logDir,
+		LogFileName: os.Getenv("LOG_FILE_NAME"),
+		MaxSize:     10,   // 10 MB
+		MaxBackups:  10,   // keep 10 backups
+		MaxAge:      7,    // 7 days
+		Compress:    true, // compress old logs
+		LogLevel:    "info",
 	}
 }
 
+// FileName returns the log file base name after applying the defaults. It
+// rejects names that would place the file outside LogDir.
+func (c *Config) FileName() (string, error) {
+	name := c.LogFileName
+	if name == "" {
+		return DefaultLogFileName, nil
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." || filepath.IsAbs(name) {
+		return "", fmt.Errorf("invalid log file name %q: must be a file name without directories", name)
+	}
+	if filepath.Ext(name) == "" {
+		name += ".log"
+	}
+	return name, nil
+}
+
 // Initialize sets up the global logger with file rotation
 func Initialize(cfg *Config) error {
 	if cfg == nil {
 		cfg = DefaultConfig()
 	}
+	fileName, err := cfg.FileName()
+	if err != nil {
+		return err
+	}
 
 	// Create log directory if it doesn't exist
 	if err := os.MkdirAll(cfg.LogDir, 0755); err != nil {
@@ -75,8 +122,9 @@ func Initialize(cfg *Config) error {
 		}
 	}
 
-	// Setup lumberjack for log rotation
-	logFile := filepath.Join(cfg.LogDir, "cfui.log")
+	// Setup lumberjack for log rotation. Backups reuse the file name, e.g.
+	// cfui-home-2024-01-02T15-04-05.000.log.gz.
+	logFile := filepath.Join(cfg.LogDir, fileName)
 	lumberjackLogger := &lumberjack.Logger{
 		Filename:   logFile,
 		MaxSize:    cfg.MaxSize,
@@ -86,16 +134,30 @@ func Initialize(cfg *Config) error {
 		LocalTime:  true,
 	}
 
-	// Initialize broadcaster with buffer for 500 recent log lines
+	fileLogger = lumberjackLogger
+
+	if cfg.MaxFileAge > 0 {
+		setAgeRotator(newAgeRotator(lumberjackLogger, cfg.MaxFileAge))
+	} else {
+		setAgeRotator(nil)
+	}
+
+	// Initialize broadcaster with buffer for RecentBufferSize recent log
+	// lines. A broadcaster from an earlier Initialize is closed so its
+	// cleanup goroutine does not leak.
 	broadcasterMu.Lock()
-	broadcaster = NewLogBroadcaster(500)
+	if broadcaster != nil {
+		broadcaster.Close()
+	}
+	broadcaster = NewLogBroadcaster(RecentBufferSize)
+	broadcaster.SetDedupWindow(cfg.DedupWindow)
 	broadcasterMu.Unlock()
 
 	// Create encoder config
 	encoderConfig := zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
-		NameKey:        "logger",
+		NameKey:        "component",
 		CallerKey:      "caller",
 		MessageKey:     "msg",
 		StacktraceKey:  "stacktrace",
@@ -136,10 +198,34 @@ func Initialize(cfg *Config) error {
 	Logger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
 	Sugar = Logger.Sugar()
 
+	active := *cfg
+	active.LogFileName = fileName
+	active.LogLevel = level.String()
+	activeConfig.Store(&active)
+
 	// Sync on shutdown
 	return nil
 }
 
+// ActiveConfig returns the configuration the last Initialize applied, with
+// the file name and level resolved to what is in effect. ok is false before
+// Initialize.
+func ActiveConfig() (cfg Config, ok bool) {
+	active := activeConfig.Load()
+	if active == nil {
+		return Config{}, false
+	}
+	return *active, true
+}
+
+// FilePath returns the active log file, or "" before Initialize.
+func FilePath() string {
+	if fileLogger == nil {
+		return ""
+	}
+	return fileLogger.Filename
+}
+
 // Sync flushes any buffered log entries
 func Sync() {
 	if Logger != nil {
@@ -152,6 +238,8 @@ func Sync() {
 
 // Shutdown performs graceful shutdown of logger and broadcaster
 func Shutdown() {
+	setAgeRotator(nil)
+
 	// Close broadcaster to stop background goroutine
 	broadcasterMu.Lock()
 	if broadcaster != nil {
@@ -187,19 +275,36 @@ func RecoverPanicWithHandler(handler func(interface{})) {
 	}
 }
 
+// LogEntry is a broadcast log line with its sequence number. Seq starts at 1
+// and increases by one per line for the lifetime of the broadcaster.
+// Entries sent with BroadcastEvent carry an Event name instead of a Seq and
+// are never buffered; Line then holds the event payload. Component is the
+// line's origin, one of the Component constants.
+type LogEntry struct {
+	Seq       uint64
+	Event     string
+	Line      string
+	Component string
+}
+
 // LogBroadcaster broadcasts log lines to multiple subscribers
 type LogBroadcaster struct {
-	subscribers map[chan string]*subscriberInfo
+	subscribers map[chan LogEntry]*subscriberInfo
+	observers   []func(string)
 	buffer      *ring.Ring // Circular buffer for recent logs
+	errors      *ring.Ring // Circular buffer for error-level lines only
+	seq         uint64
 	mu          sync.RWMutex
 	bufferSize  int
+	dedup       *dedupState // nil unless SetDedupWindow enabled it
 	cleanupDone chan struct{}
+	closeOnce   sync.Once
 	wg          sync.WaitGroup
 }
 
 // subscriberInfo holds metadata about a subscriber
 type subscriberInfo struct {
-	ch         chan string
+	ch         chan LogEntry
 	lastActive time.Time
 	remoteAddr string // For debugging
 }
@@ -213,8 +318,9 @@ const (
 // NewLogBroadcaster creates a new log broadcaster with a circular buffer
 func NewLogBroadcaster(bufferSize int) *LogBroadcaster {
 	b := &LogBroadcaster{
-		subscribers: make(map[chan string]*subscriberInfo),
+		subscribers: make(map[chan LogEntry]*subscriberInfo),
 		buffer:      ring.New(bufferSize),
+		errors:      ring.New(errorBufferSize),
 		bufferSize:  bufferSize,
 		cleanupDone: make(chan struct{}),
 	}
@@ -252,9 +358,10 @@ func (b *LogBroadcaster) cleanupInactiveSubscribers() {
 	}
 }
 
-// Close stops the broadcaster and cleans up resources
+// Close stops the broadcaster and cleans up resources. It is safe to call
+// more than once, so tests can defer it alongside NewLogBroadcaster.
 func (b *LogBroadcaster) Close() {
-	close(b.cleanupDone)
+	b.closeOnce.Do(func() { close(b.cleanupDone) })
 	b.wg.Wait()
 
 	b.mu.Lock()
@@ -262,15 +369,15 @@ func (b *LogBroadcaster) Close() {
 	for ch := range b.subscribers {
 		close(ch)
 	}
-	b.subscribers = make(map[chan string]*subscriberInfo)
+	b.subscribers = make(map[chan LogEntry]*subscriberInfo)
 }
 
 // Subscribe creates a new subscriber channel
-func (b *LogBroadcaster) Subscribe(remoteAddr string) chan string {
+func (b *LogBroadcaster) Subscribe(remoteAddr string) chan LogEntry {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	ch := make(chan string, subscriberBufferSize)
+	ch := make(chan LogEntry, subscriberBufferSize)
 	b.subscribers[ch] = &subscriberInfo{
 		ch:         ch,
 		lastActive: time.Now(),
@@ -280,7 +387,7 @@ func (b *LogBroadcaster) Subscribe(remoteAddr string) chan string {
 }
 
 // MarkActive updates the last active time for a subscriber
-func (b *LogBroadcaster) MarkActive(ch chan string) {
+func (b *LogBroadcaster) MarkActive(ch chan LogEntry) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -290,7 +397,7 @@ func (b *LogBroadcaster) MarkActive(ch chan string) {
 }
 
 // Unsubscribe removes a subscriber
-func (b *LogBroadcaster) Unsubscribe(ch chan string) {
+func (b *LogBroadcaster) Unsubscribe(ch chan LogEntry) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -300,19 +407,72 @@ func (b *LogBroadcaster) Unsubscribe(ch chan string) {
 	}
 }
 
-// Broadcast sends a log line to all subscribers
+// Observe registers fn to be called synchronously with every broadcast line.
+// Unlike subscribers, observers never time out. fn runs outside the
+// broadcaster lock, so it may log.
+func (b *LogBroadcaster) Observe(fn func(line string)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.observers = append(b.observers, fn)
+}
+
+// SetDedupWindow collapses consecutive identical lines within d into a
+// single "last message repeated N times" entry. Observers still see every
+// line. Zero or less turns it off.
+func (b *LogBroadcaster) SetDedupWindow(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if d <= 0 {
+		b.dedup = nil
+		return
+	}
+	b.dedup = &dedupState{window: d}
+}
+
+// Broadcast sends a log line to all subscribers and observers
 func (b *LogBroadcaster) Broadcast(line string) {
+	for _, fn := range b.deliver(line, lineComponent(line)) {
+		fn(line)
+	}
+}
+
+// deliver buffers line and sends it to subscribers, returning the observers
+// to notify once the lock is released.
+func (b *LogBroadcaster) deliver(line, component string) []func(string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.dedup != nil {
+		runComponent := b.dedup.component
+		ok, summary := b.dedup.admit(line, time.Now())
+		if summary != "" {
+			b.send(summary, runComponent)
+		}
+		if !ok {
+			return b.observers
+		}
+		b.dedup.component = component
+	}
+	b.send(line, component)
+	return b.observers
+}
+
+// send buffers line and passes it to subscribers. The caller holds b.mu.
+func (b *LogBroadcaster) send(line, component string) {
 	// Store in circular buffer
-	b.buffer.Value = line
+	b.seq++
+	entry := LogEntry{Seq: b.seq, Line: line, Component: component}
+	b.buffer.Value = entry
 	b.buffer = b.buffer.Next()
+	if isErrorLine(line) {
+		b.errors.Value = entry
+		b.errors = b.errors.Next()
+	}
 
 	// Send to all subscribers (non-blocking)
 	for ch, info := range b.subscribers {
 		select {
-		case ch <- line:
+		case ch <- entry:
 			info.lastActive = time.Now() // Update activity on successful send
 		default:
 			// Skip if channel is full (client too slow)
@@ -321,20 +481,97 @@ func (b *LogBroadcaster) Broadcast(line string) {
 	}
 }
 
+// BroadcastEvent sends a named event to current subscribers only. Events are
+// not kept in the recent-log buffer and are not passed to observers.
+func (b *LogBroadcaster) BroadcastEvent(event, data string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry := LogEntry{Event: event, Line: data}
+	for ch, info := range b.subscribers {
+		select {
+		case ch <- entry:
+			info.lastActive = time.Now()
+		default:
+		}
+	}
+}
+
 // GetRecentLogs returns the recent logs from the circular buffer
 func (b *LogBroadcaster) GetRecentLogs() []string {
+	entries := b.RecentEntries()
+	logs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		logs = append(logs, entry.Line)
+	}
+	return logs
+}
+
+// RecentEntries returns the buffered log entries, oldest first.
+func (b *LogBroadcaster) RecentEntries() []LogEntry {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	logs := make([]string, 0, b.bufferSize)
+	entries := make([]LogEntry, 0, b.bufferSize)
 	b.buffer.Do(func(v interface{}) {
-		if v != nil {
-			if line, ok := v.(string); ok && line != "" {
-				logs = append(logs, line)
-			}
+		if entry, ok := v.(LogEntry); ok && entry.Line != "" {
+			entries = append(entries, entry)
 		}
 	})
-	return logs
+	return entries
+}
+
+// ErrorEntries returns the buffered error-level entries, oldest first. They
+// are kept apart from RecentEntries so info-level churn does not evict them.
+func (b *LogBroadcaster) ErrorEntries() []LogEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	entries := make([]LogEntry, 0, errorBufferSize)
+	b.errors.Do(func(v interface{}) {
+		if entry, ok := v.(LogEntry); ok {
+			entries = append(entries, entry)
+		}
+	})
+	return entries
+}
+
+// ErrorsSince counts the buffered error-level lines logged at or after
+// since. Lines without a parsable time are not counted.
+func (b *LogBroadcaster) ErrorsSince(since time.Time) int {
+	n := 0
+	for _, entry := range b.ErrorEntries() {
+		if t, ok := lineTime([]byte(entry.Line)); ok && !t.Before(since) {
+			n++
+		}
+	}
+	return n
+}
+
+// SubscriberCount returns the number of live log stream subscribers.
+func (b *LogBroadcaster) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// RecentEntriesAfter returns the buffered entries newer than seq. A seq the
+// broadcaster never issued (for example one from before a restart) replays
+// the whole buffer.
+func (b *LogBroadcaster) RecentEntriesAfter(seq uint64) []LogEntry {
+	entries := b.RecentEntries()
+	b.mu.RLock()
+	latest := b.seq
+	b.mu.RUnlock()
+	if seq > latest {
+		return entries
+	}
+	for i, entry := range entries {
+		if entry.Seq > seq {
+			return entries[i:]
+		}
+	}
+	return nil
 }
 
 // Write implements io.Writer interface
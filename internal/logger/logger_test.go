@@ -1,6 +1,14 @@
 package logger
 
-import "testing"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestLogBroadcasterUnsubscribeAfterCloseDoesNotPanic(t *testing.T) {
 	b := NewLogBroadcaster(10)
@@ -9,3 +17,171 @@ func TestLogBroadcasterUnsubscribeAfterCloseDoesNotPanic(t *testing.T) {
 	b.Close()
 	b.Unsubscribe(ch)
 }
+
+func TestLogBroadcasterObserversSeeEveryLine(t *testing.T) {
+	b := NewLogBroadcaster(10)
+	defer b.Close()
+
+	var seen []string
+	b.Observe(func(line string) {
+		seen = append(seen, line)
+		// Observers run outside the lock, so re-entering is safe.
+		_ = b.GetRecentLogs()
+	})
+	b.Broadcast("one\n")
+	b.Broadcast("two\n")
+
+	if len(seen) != 2 || seen[0] != "one\n" || seen[1] != "two\n" {
+		t.Fatalf("observer saw %q", seen)
+	}
+}
+
+func TestLogBroadcasterRecentEntriesAfter(t *testing.T) {
+	b := NewLogBroadcaster(3)
+	defer b.Close()
+
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n"} {
+		b.Broadcast(line)
+	}
+	// The buffer holds 2..4; seq 1 has been evicted.
+	if got := b.RecentEntriesAfter(2); len(got) != 2 || got[0].Seq != 3 || got[1].Line != "four\n" {
+		t.Fatalf("after 2 = %+v, want seqs 3 and 4", got)
+	}
+	if got := b.RecentEntriesAfter(4); len(got) != 0 {
+		t.Fatalf("after latest = %+v, want none", got)
+	}
+	if got := b.RecentEntriesAfter(0); len(got) != 3 {
+		t.Fatalf("after 0 = %+v, want whole buffer", got)
+	}
+	// An id from a previous process is unknown; replay everything.
+	if got := b.RecentEntriesAfter(99); len(got) != 3 {
+		t.Fatalf("after unknown id = %+v, want whole buffer", got)
+	}
+}
+
+func TestLogBroadcasterCloseStopsCleanupGoroutine(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		b := NewLogBroadcaster(10)
+		b.Close()
+		b.Close() // idempotent
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines grew from %d to %d after closing broadcasters", before, after)
+	}
+}
+
+func TestInitializeClosesPreviousBroadcaster(t *testing.T) {
+	prevLogger, prevSugar := Logger, Sugar
+	t.Cleanup(func() {
+		Shutdown()
+		Logger, Sugar = prevLogger, prevSugar
+	})
+
+	if err := Initialize(&Config{LogDir: t.TempDir(), LogLevel: "error"}); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	first := GetBroadcaster()
+	before := runtime.NumGoroutine()
+	if err := Initialize(&Config{LogDir: t.TempDir(), LogLevel: "error"}); err != nil {
+		t.Fatalf("re-Initialize: %v", err)
+	}
+	if GetBroadcaster() == first {
+		t.Fatal("re-Initialize should install a new broadcaster")
+	}
+	select {
+	case <-first.cleanupDone:
+	default:
+		t.Fatal("previous broadcaster was not closed")
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines grew from %d to %d across re-Initialize", before, after)
+	}
+}
+
+func TestLogBroadcasterKeepsErrorLinesSeparately(t *testing.T) {
+	b := NewLogBroadcaster(2)
+	defer b.Close()
+
+	lines := []string{
+		`{"level":"INFO","msg":"starting"}` + "\n",
+		`{"level":"ERROR","msg":"dial failed"}` + "\n",
+		"2026-03-01T10:00:00Z ERR Failed to serve tunnel connection\n",
+		"2026-03-01T10:00:01Z INF Registered tunnel connection\n",
+		"2026-03-01T10:00:02Z WRN error rate high\n", // warning despite the word
+		`{"level":"info","msg":"error budget ok"}` + "\n",
+	}
+	for _, line := range lines {
+		b.Broadcast(line)
+	}
+
+	got := b.ErrorEntries()
+	if len(got) != 2 || got[0].Line != lines[1] || got[1].Line != lines[2] {
+		t.Fatalf("error ring = %+v, want the two error lines", got)
+	}
+	// The small recent buffer has already evicted both errors.
+	for _, line := range b.GetRecentLogs() {
+		if line == lines[1] || line == lines[2] {
+			t.Fatalf("recent buffer should have rotated past %q", line)
+		}
+	}
+}
+
+func TestInitializeWritesConfiguredLogFile(t *testing.T) {
+	prevLogger, prevSugar := Logger, Sugar
+	t.Cleanup(func() {
+		Shutdown()
+		Logger, Sugar = prevLogger, prevSugar
+	})
+
+	dir := t.TempDir()
+	if err := Initialize(&Config{LogDir: dir, LogFileName: "cfui-home", LogLevel: "info"}); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	Sugar.Info("hello from home")
+	_ = Logger.Sync()
+
+	data, err := os.ReadFile(filepath.Join(dir, "cfui-home.log"))
+	if err != nil {
+		t.Fatalf("read configured log file: %v", err)
+	}
+	if !strings.Contains(string(data), "hello from home") {
+		t.Fatalf("log file = %q, want the logged line", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DefaultLogFileName)); !os.IsNotExist(err) {
+		t.Fatalf("default log file exists alongside the configured one (err = %v)", err)
+	}
+}
+
+func TestConfigFileNameRejectsDirectories(t *testing.T) {
+	for _, name := range []string{"../cfui.log", "logs/cfui.log", ".."} {
+		if _, err := (&Config{LogFileName: name}).FileName(); err == nil {
+			t.Errorf("FileName(%q) accepted a path", name)
+		}
+	}
+	if got, err := (&Config{}).FileName(); err != nil || got != DefaultLogFileName {
+		t.Errorf("empty FileName = %q, %v; want %q", got, err, DefaultLogFileName)
+	}
+}
+
+func TestLogBroadcasterCollapsesRepeatedLines(t *testing.T) {
+	b := NewLogBroadcaster(10)
+	defer b.Close()
+	b.SetDedupWindow(time.Minute)
+
+	observed := 0
+	b.Observe(func(string) { observed++ })
+	for i := 0; i < 4; i++ {
+		b.Broadcast(fmt.Sprintf(`{"level":"warn","time":"2024-01-02T15:04:0%d.000Z","msg":"retrying"}`+"\n", i))
+	}
+	b.Broadcast(`{"level":"info","time":"2024-01-02T15:04:05.000Z","msg":"connected"}` + "\n")
+
+	got := b.GetRecentLogs()
+	if len(got) != 3 || !strings.Contains(got[0], "retrying") ||
+		got[1] != "last message repeated 3 times\n" || !strings.Contains(got[2], "connected") {
+		t.Fatalf("recent logs = %q, want the first line, a summary, and the next line", got)
+	}
+	if observed != 5 {
+		t.Fatalf("observers saw %d lines, want all 5", observed)
+	}
+}
@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// ageRotateInterval is how often the active log file's age is checked.
+const ageRotateInterval = time.Minute
+
+// ageRotator force-rotates the active log file once its oldest line is older
+// than maxAge. lumberjack only rotates on size, and its MaxAge only prunes
+// backups, so a quiet install could otherwise keep one file forever.
+type ageRotator struct {
+	file   *lumberjack.Logger
+	maxAge time.Duration
+	now    func() time.Time
+
+	// since is when the oldest line of the active file was written; zero
+	// while the file is empty.
+	since time.Time
+
+	stopC chan struct{}
+	doneC chan struct{}
+}
+
+var (
+	activeAgeRotator   *ageRotator
+	activeAgeRotatorMu sync.Mutex
+)
+
+func newAgeRotator(file *lumberjack.Logger, maxAge time.Duration) *ageRotator {
+	r := &ageRotator{file: file, maxAge: maxAge, now: time.Now}
+	r.since = oldestLineTime(file.Filename)
+	return r
+}
+
+// start runs the periodic check until stop.
+func (r *ageRotator) start() {
+	r.stopC, r.doneC = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(r.doneC)
+		ticker := time.NewTicker(min(ageRotateInterval, r.maxAge))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stopC:
+				return
+			case <-ticker.C:
+				r.check()
+			}
+		}
+	}()
+}
+
+func (r *ageRotator) stop() {
+	close(r.stopC)
+	<-r.doneC
+}
+
+// check rotates the active file when it is too old. An empty file is never
+// rotated; its age starts with the first line seen.
+func (r *ageRotator) check() {
+	now := r.now()
+	if info, err := os.Stat(r.file.Filename); err != nil || info.Size() == 0 {
+		r.since = time.Time{}
+		return
+	}
+	if r.since.IsZero() {
+		r.since = now
+		return
+	}
+	if now.Sub(r.since) < r.maxAge {
+		return
+	}
+	if err := r.file.Rotate(); err != nil {
+		if Sugar != nil {
+			Sugar.Warnf("Failed to rotate log file %s by age: %v", r.file.Filename, err)
+		}
+		return
+	}
+	r.since = time.Time{}
+}
+
+// oldestLineTime reads the time of the first JSON log line in path, or
+// returns zero when the file is missing, empty, or unparsable.
+func oldestLineTime(path string) time.Time {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return time.Time{}
+	}
+	t, _ := lineTime(scanner.Bytes())
+	return t
+}
+
+// lineTime parses the "time" field of a JSON log line.
+func lineTime(line []byte) (time.Time, bool) {
+	var fields struct {
+		Time string `json:"time"`
+	}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return time.Time{}, false
+	}
+	// zapcore.ISO8601TimeEncoder's layout.
+	t, err := time.Parse("2006-01-02T15:04:05.000Z0700", fields.Time)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// setAgeRotator replaces the running age rotator; nil just stops it.
+func setAgeRotator(r *ageRotator) {
+	activeAgeRotatorMu.Lock()
+	defer activeAgeRotatorMu.Unlock()
+	if activeAgeRotator != nil {
+		activeAgeRotator.stop()
+	}
+	activeAgeRotator = r
+	if r != nil {
+		r.start()
+	}
+}
+
+// Rotate moves the active log file to a timestamped backup, as a size or
+// age rotation would, so later lines start a fresh file. It returns the
+// active file's path. The age rotator restarts its clock on the new file.
+func Rotate() (string, error) {
+	file := fileLogger
+	if file == nil {
+		return "", errors.New("logger is not initialized")
+	}
+	if err := file.Rotate(); err != nil {
+		return "", err
+	}
+	activeAgeRotatorMu.Lock()
+	r := activeAgeRotator
+	activeAgeRotatorMu.Unlock()
+	if r != nil {
+		setAgeRotator(newAgeRotator(file, r.maxAge))
+	}
+	return file.Filename, nil
+}
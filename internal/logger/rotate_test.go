@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func TestAgeRotatorRotatesOldActiveFile(t *testing.T) {
+	dir := t.TempDir()
+	file := &lumberjack.Logger{Filename: filepath.Join(dir, "cfui.log")}
+	t.Cleanup(func() { file.Close() })
+
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	if _, err := file.Write([]byte(`{"time":"2026-03-01T09:00:00.000Z","msg":"first"}` + "\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	r := newAgeRotator(file, 2*time.Hour)
+	r.now = func() time.Time { return now }
+	if want := now.Add(-time.Hour); !r.since.Equal(want) {
+		t.Fatalf("since = %v, want the first line's time %v", r.since, want)
+	}
+
+	backups := func() int {
+		t.Helper()
+		matches, err := filepath.Glob(filepath.Join(dir, "cfui-*.log"))
+		if err != nil {
+			t.Fatalf("Glob: %v", err)
+		}
+		return len(matches)
+	}
+
+	now = now.Add(59 * time.Minute)
+	r.check()
+	if n := backups(); n != 0 {
+		t.Fatalf("rotated a file younger than the max age (%d backups)", n)
+	}
+
+	now = now.Add(time.Minute)
+	r.check()
+	if n := backups(); n != 1 {
+		t.Fatalf("backups after max age = %d, want 1", n)
+	}
+	if info, err := os.Stat(file.Filename); err != nil || info.Size() != 0 {
+		t.Fatalf("active file after rotation: %v, %v; want a new empty file", info, err)
+	}
+
+	// A file that stays empty is not rotated again.
+	now = now.Add(24 * time.Hour)
+	r.check()
+	if n := backups(); n != 1 {
+		t.Fatalf("rotated an empty file (%d backups)", n)
+	}
+}
+
+func TestRotateStartsFreshFileAndLoggingContinues(t *testing.T) {
+	prevLogger, prevSugar := Logger, Sugar
+	t.Cleanup(func() {
+		Shutdown()
+		Logger, Sugar = prevLogger, prevSugar
+	})
+
+	dir := t.TempDir()
+	if err := Initialize(&Config{LogDir: dir, LogLevel: "info"}); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	Sugar.Info("before rotation")
+	active, err := Rotate()
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if active != filepath.Join(dir, DefaultLogFileName) {
+		t.Fatalf("Rotate returned %q, want the active file", active)
+	}
+	Sugar.Info("after rotation")
+	_ = Logger.Sync()
+
+	backups, err := filepath.Glob(filepath.Join(dir, "cfui-*.log"))
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("backups = %v (%v), want one", backups, err)
+	}
+	old, err := os.ReadFile(backups[0])
+	if err != nil || !strings.Contains(string(old), "before rotation") {
+		t.Fatalf("backup = %q (%v), want the line logged before rotating", old, err)
+	}
+	data, err := os.ReadFile(active)
+	if err != nil {
+		t.Fatalf("read active file: %v", err)
+	}
+	if !strings.Contains(string(data), "after rotation") || strings.Contains(string(data), "before rotation") {
+		t.Fatalf("active file = %q, want only the line logged after rotating", data)
+	}
+}
@@ -14,8 +14,15 @@ import (
 	"cfui/version"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
 )
 
+// log returns the package logger, named cfui.mcp so broadcast lines can
+// be filtered by component.
+func log() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentMCP)
+}
+
 type Service struct {
 	cfgMgr    *config.Manager
 	runner    *service.Runner
@@ -141,7 +148,7 @@ func (s *Service) newMCPServer() *mcp.Server {
 	}, s.startTunnel)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "cfui_stop_tunnel",
-		Description: "Stop the local cloudflared tunnel.",
+		Description: "Stop the local cloudflared tunnel. With require_confirm on, set confirm to the active tunnel key.",
 	}, s.stopTunnel)
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "cfui_get_recent_logs",
@@ -371,10 +378,20 @@ func (s *Service) startTunnel(ctx context.Context, req *mcp.CallToolRequest, in
 	return nil, ControlOutput{Success: true, Action: "start", Message: "Tunnel started successfully"}, nil
 }
 
-func (s *Service) stopTunnel(ctx context.Context, req *mcp.CallToolRequest, in EmptyInput) (*mcp.CallToolResult, ControlOutput, error) {
+type StopInput struct {
+	Confirm string `json:"confirm,omitempty" jsonschema:"the active tunnel key; required when require_confirm is on"`
+}
+
+func (s *Service) stopTunnel(ctx context.Context, req *mcp.CallToolRequest, in StopInput) (*mcp.CallToolResult, ControlOutput, error) {
 	if s.runner == nil {
 		return nil, ControlOutput{}, fmt.Errorf("runner is not available")
 	}
+	// Same rule as the HTTP control API: a stop must repeat the tunnel key.
+	if cfg := s.cfgMgr.Get(); cfg.RequireConfirm {
+		if key := cfg.ActiveTunnelProfile().Key; in.Confirm != key {
+			return nil, ControlOutput{}, fmt.Errorf("confirmation required: set \"confirm\" to the tunnel key %q to stop it", key)
+		}
+	}
 	if err := s.runner.Stop(); err != nil {
 		return nil, ControlOutput{}, err
 	}
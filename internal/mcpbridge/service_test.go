@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 
@@ -78,6 +79,23 @@ func TestMCPClientListsToolsWithToken(t *testing.T) {
 	}
 }
 
+func TestStopTunnelRequiresConfirmWhenEnabled(t *testing.T) {
+	svc := newTestService(t)
+	cfg := svc.cfgMgr.Get()
+	cfg.RequireConfirm = true
+	if err := svc.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+	key := svc.cfgMgr.Get().ActiveTunnelProfile().Key
+
+	if _, _, err := svc.stopTunnel(context.Background(), nil, StopInput{}); err == nil || !strings.Contains(err.Error(), "confirmation required") {
+		t.Fatalf("unconfirmed stop error = %v, want confirmation required", err)
+	}
+	if _, out, err := svc.stopTunnel(context.Background(), nil, StopInput{Confirm: key}); err != nil || !out.Success {
+		t.Fatalf("confirmed stop = %+v, %v; want success", out, err)
+	}
+}
+
 type bearerTransport struct {
 	token string
 }
@@ -275,11 +275,11 @@ func (s *TokenStore) migrateLegacyTokens(ctx context.Context) error {
 
 	if err := persist.MarkLegacyMigrated(legacyPath); err != nil && !os.IsNotExist(err) {
 		if logger.Sugar != nil {
-			logger.Sugar.Warnf("Failed to rename migrated legacy token file %s: %v", legacyPath, err)
+			log().Warnf("Failed to rename migrated legacy token file %s: %v", legacyPath, err)
 		}
 	} else {
 		if logger.Sugar != nil {
-			logger.Sugar.Infof("Migrated legacy MCP tokens from %s to %s", legacyPath, persist.DBPath(s.dir))
+			log().Infof("Migrated legacy MCP tokens from %s to %s", legacyPath, persist.DBPath(s.dir))
 		}
 	}
 
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"cfui/internal/persist/ent/appsetting"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -49,8 +50,12 @@ type AppSetting struct {
 	EdgeIPVersion string `json:"edge_ip_version,omitempty"`
 	// EdgeBindAddress holds the value of the "edge_bind_address" field.
 	EdgeBindAddress string `json:"edge_bind_address,omitempty"`
+	// EdgeInterface holds the value of the "edge_interface" field.
+	EdgeInterface string `json:"edge_interface,omitempty"`
 	// PostQuantum holds the value of the "post_quantum" field.
 	PostQuantum bool `json:"post_quantum,omitempty"`
+	// PostQuantumMode holds the value of the "post_quantum_mode" field.
+	PostQuantumMode string `json:"post_quantum_mode,omitempty"`
 	// NoTLSVerify holds the value of the "no_tls_verify" field.
 	NoTLSVerify bool `json:"no_tls_verify,omitempty"`
 	// ExtraArgs holds the value of the "extra_args" field.
@@ -81,6 +86,48 @@ type AppSetting struct {
 	S3WebdavDedicatedCustomDomain string `json:"s3_webdav_dedicated_custom_domain,omitempty"`
 	// S3WebdavDedicatedTunnelHostname holds the value of the "s3_webdav_dedicated_tunnel_hostname" field.
 	S3WebdavDedicatedTunnelHostname string `json:"s3_webdav_dedicated_tunnel_hostname,omitempty"`
+	// ConfigFile holds the value of the "config_file" field.
+	ConfigFile string `json:"config_file,omitempty"`
+	// MetricsPollInterval holds the value of the "metrics_poll_interval" field.
+	MetricsPollInterval string `json:"metrics_poll_interval,omitempty"`
+	// Tags holds the value of the "tags" field.
+	Tags map[string]string `json:"tags,omitempty"`
+	// IdleTimeout holds the value of the "idle_timeout" field.
+	IdleTimeout string `json:"idle_timeout,omitempty"`
+	// SchemaVersion holds the value of the "schema_version" field.
+	SchemaVersion int `json:"schema_version,omitempty"`
+	// LazyStart holds the value of the "lazy_start" field.
+	LazyStart bool `json:"lazy_start,omitempty"`
+	// RetryablePatterns holds the value of the "retryable_patterns" field.
+	RetryablePatterns []string `json:"retryable_patterns,omitempty"`
+	// NonRetryablePatterns holds the value of the "non_retryable_patterns" field.
+	NonRetryablePatterns []string `json:"non_retryable_patterns,omitempty"`
+	// ProtocolOrder holds the value of the "protocol_order" field.
+	ProtocolOrder []string `json:"protocol_order,omitempty"`
+	// ListenPort holds the value of the "listen_port" field.
+	ListenPort int `json:"listen_port,omitempty"`
+	// ListenAddr holds the value of the "listen_addr" field.
+	ListenAddr string `json:"listen_addr,omitempty"`
+	// RequireConnectedForReady holds the value of the "require_connected_for_ready" field.
+	RequireConnectedForReady bool `json:"require_connected_for_ready,omitempty"`
+	// StrictExtraArgs holds the value of the "strict_extra_args" field.
+	StrictExtraArgs bool `json:"strict_extra_args,omitempty"`
+	// MaxRestartsPerHour holds the value of the "max_restarts_per_hour" field.
+	MaxRestartsPerHour int `json:"max_restarts_per_hour,omitempty"`
+	// MaxRuntime holds the value of the "max_runtime" field.
+	MaxRuntime string `json:"max_runtime,omitempty"`
+	// RequireConfirm holds the value of the "require_confirm" field.
+	RequireConfirm bool `json:"require_confirm,omitempty"`
+	// RestartJitter holds the value of the "restart_jitter" field.
+	RestartJitter int `json:"restart_jitter,omitempty"`
+	// SoleLogSink holds the value of the "sole_log_sink" field.
+	SoleLogSink bool `json:"sole_log_sink,omitempty"`
+	// MaxProtocolSwitches holds the value of the "max_protocol_switches" field.
+	MaxProtocolSwitches int `json:"max_protocol_switches,omitempty"`
+	// StatsPersistInterval holds the value of the "stats_persist_interval" field.
+	StatsPersistInterval string `json:"stats_persist_interval,omitempty"`
+	// EdgeIPFallback holds the value of the "edge_ip_fallback" field.
+	EdgeIPFallback bool `json:"edge_ip_fallback,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -93,11 +140,13 @@ func (*AppSetting) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case appsetting.FieldAutoStart, appsetting.FieldAutoRestart, appsetting.FieldMetricsEnable, appsetting.FieldLogJSON, appsetting.FieldPostQuantum, appsetting.FieldNoTLSVerify, appsetting.FieldMcpEnabled, appsetting.FieldS3WebdavEnabled, appsetting.FieldS3WebdavDedicatedAutoStart:
+		case appsetting.FieldTags, appsetting.FieldRetryablePatterns, appsetting.FieldNonRetryablePatterns, appsetting.FieldProtocolOrder:
+			values[i] = new([]byte)
+		case appsetting.FieldAutoStart, appsetting.FieldAutoRestart, appsetting.FieldMetricsEnable, appsetting.FieldLogJSON, appsetting.FieldPostQuantum, appsetting.FieldNoTLSVerify, appsetting.FieldMcpEnabled, appsetting.FieldS3WebdavEnabled, appsetting.FieldS3WebdavDedicatedAutoStart, appsetting.FieldLazyStart, appsetting.FieldRequireConnectedForReady, appsetting.FieldStrictExtraArgs, appsetting.FieldRequireConfirm, appsetting.FieldSoleLogSink, appsetting.FieldEdgeIPFallback:
 			values[i] = new(sql.NullBool)
-		case appsetting.FieldID, appsetting.FieldRetries, appsetting.FieldMetricsPort, appsetting.FieldS3WebdavDedicatedPort:
+		case appsetting.FieldID, appsetting.FieldRetries, appsetting.FieldMetricsPort, appsetting.FieldS3WebdavDedicatedPort, appsetting.FieldSchemaVersion, appsetting.FieldListenPort, appsetting.FieldMaxRestartsPerHour, appsetting.FieldRestartJitter, appsetting.FieldMaxProtocolSwitches:
 			values[i] = new(sql.NullInt64)
-		case appsetting.FieldKey, appsetting.FieldCustomTag, appsetting.FieldSoftwareName, appsetting.FieldProtocol, appsetting.FieldGracePeriod, appsetting.FieldRegion, appsetting.FieldLogLevel, appsetting.FieldLogFile, appsetting.FieldEdgeIPVersion, appsetting.FieldEdgeBindAddress, appsetting.FieldExtraArgs, appsetting.FieldActiveTunnelKey, appsetting.FieldOauthClientID, appsetting.FieldOauthRelayCallbackURL, appsetting.FieldS3WebdavActiveKey, appsetting.FieldS3WebdavAccessMode, appsetting.FieldS3WebdavDedicatedBindHost, appsetting.FieldS3WebdavDedicatedDomainMode, appsetting.FieldS3WebdavDedicatedCustomDomain, appsetting.FieldS3WebdavDedicatedTunnelHostname:
+		case appsetting.FieldKey, appsetting.FieldCustomTag, appsetting.FieldSoftwareName, appsetting.FieldProtocol, appsetting.FieldGracePeriod, appsetting.FieldRegion, appsetting.FieldLogLevel, appsetting.FieldLogFile, appsetting.FieldEdgeIPVersion, appsetting.FieldEdgeBindAddress, appsetting.FieldEdgeInterface, appsetting.FieldPostQuantumMode, appsetting.FieldExtraArgs, appsetting.FieldActiveTunnelKey, appsetting.FieldOauthClientID, appsetting.FieldOauthRelayCallbackURL, appsetting.FieldS3WebdavActiveKey, appsetting.FieldS3WebdavAccessMode, appsetting.FieldS3WebdavDedicatedBindHost, appsetting.FieldS3WebdavDedicatedDomainMode, appsetting.FieldS3WebdavDedicatedCustomDomain, appsetting.FieldS3WebdavDedicatedTunnelHostname, appsetting.FieldConfigFile, appsetting.FieldMetricsPollInterval, appsetting.FieldIdleTimeout, appsetting.FieldListenAddr, appsetting.FieldMaxRuntime, appsetting.FieldStatsPersistInterval:
 			values[i] = new(sql.NullString)
 		case appsetting.FieldCreatedAt, appsetting.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -218,12 +267,24 @@ func (_m *AppSetting) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.EdgeBindAddress = value.String
 			}
+		case appsetting.FieldEdgeInterface:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field edge_interface", values[i])
+			} else if value.Valid {
+				_m.EdgeInterface = value.String
+			}
 		case appsetting.FieldPostQuantum:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field post_quantum", values[i])
 			} else if value.Valid {
 				_m.PostQuantum = value.Bool
 			}
+		case appsetting.FieldPostQuantumMode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field post_quantum_mode", values[i])
+			} else if value.Valid {
+				_m.PostQuantumMode = value.String
+			}
 		case appsetting.FieldNoTLSVerify:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field no_tls_verify", values[i])
@@ -314,6 +375,140 @@ func (_m *AppSetting) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.S3WebdavDedicatedTunnelHostname = value.String
 			}
+		case appsetting.FieldConfigFile:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field config_file", values[i])
+			} else if value.Valid {
+				_m.ConfigFile = value.String
+			}
+		case appsetting.FieldMetricsPollInterval:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field metrics_poll_interval", values[i])
+			} else if value.Valid {
+				_m.MetricsPollInterval = value.String
+			}
+		case appsetting.FieldTags:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field tags", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Tags); err != nil {
+					return fmt.Errorf("unmarshal field tags: %w", err)
+				}
+			}
+		case appsetting.FieldIdleTimeout:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field idle_timeout", values[i])
+			} else if value.Valid {
+				_m.IdleTimeout = value.String
+			}
+		case appsetting.FieldSchemaVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field schema_version", values[i])
+			} else if value.Valid {
+				_m.SchemaVersion = int(value.Int64)
+			}
+		case appsetting.FieldLazyStart:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field lazy_start", values[i])
+			} else if value.Valid {
+				_m.LazyStart = value.Bool
+			}
+		case appsetting.FieldRetryablePatterns:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field retryable_patterns", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.RetryablePatterns); err != nil {
+					return fmt.Errorf("unmarshal field retryable_patterns: %w", err)
+				}
+			}
+		case appsetting.FieldNonRetryablePatterns:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field non_retryable_patterns", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.NonRetryablePatterns); err != nil {
+					return fmt.Errorf("unmarshal field non_retryable_patterns: %w", err)
+				}
+			}
+		case appsetting.FieldProtocolOrder:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field protocol_order", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.ProtocolOrder); err != nil {
+					return fmt.Errorf("unmarshal field protocol_order: %w", err)
+				}
+			}
+		case appsetting.FieldListenPort:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field listen_port", values[i])
+			} else if value.Valid {
+				_m.ListenPort = int(value.Int64)
+			}
+		case appsetting.FieldListenAddr:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field listen_addr", values[i])
+			} else if value.Valid {
+				_m.ListenAddr = value.String
+			}
+		case appsetting.FieldRequireConnectedForReady:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_connected_for_ready", values[i])
+			} else if value.Valid {
+				_m.RequireConnectedForReady = value.Bool
+			}
+		case appsetting.FieldStrictExtraArgs:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field strict_extra_args", values[i])
+			} else if value.Valid {
+				_m.StrictExtraArgs = value.Bool
+			}
+		case appsetting.FieldMaxRestartsPerHour:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_restarts_per_hour", values[i])
+			} else if value.Valid {
+				_m.MaxRestartsPerHour = int(value.Int64)
+			}
+		case appsetting.FieldMaxRuntime:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field max_runtime", values[i])
+			} else if value.Valid {
+				_m.MaxRuntime = value.String
+			}
+		case appsetting.FieldRequireConfirm:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_confirm", values[i])
+			} else if value.Valid {
+				_m.RequireConfirm = value.Bool
+			}
+		case appsetting.FieldRestartJitter:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field restart_jitter", values[i])
+			} else if value.Valid {
+				_m.RestartJitter = int(value.Int64)
+			}
+		case appsetting.FieldSoleLogSink:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field sole_log_sink", values[i])
+			} else if value.Valid {
+				_m.SoleLogSink = value.Bool
+			}
+		case appsetting.FieldMaxProtocolSwitches:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_protocol_switches", values[i])
+			} else if value.Valid {
+				_m.MaxProtocolSwitches = int(value.Int64)
+			}
+		case appsetting.FieldStatsPersistInterval:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field stats_persist_interval", values[i])
+			} else if value.Valid {
+				_m.StatsPersistInterval = value.String
+			}
+		case appsetting.FieldEdgeIPFallback:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field edge_ip_fallback", values[i])
+			} else if value.Valid {
+				_m.EdgeIPFallback = value.Bool
+			}
 		case appsetting.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -410,9 +605,15 @@ func (_m *AppSetting) String() string {
 	builder.WriteString("edge_bind_address=")
 	builder.WriteString(_m.EdgeBindAddress)
 	builder.WriteString(", ")
+	builder.WriteString("edge_interface=")
+	builder.WriteString(_m.EdgeInterface)
+	builder.WriteString(", ")
 	builder.WriteString("post_quantum=")
 	builder.WriteString(fmt.Sprintf("%v", _m.PostQuantum))
 	builder.WriteString(", ")
+	builder.WriteString("post_quantum_mode=")
+	builder.WriteString(_m.PostQuantumMode)
+	builder.WriteString(", ")
 	builder.WriteString("no_tls_verify=")
 	builder.WriteString(fmt.Sprintf("%v", _m.NoTLSVerify))
 	builder.WriteString(", ")
@@ -458,6 +659,69 @@ func (_m *AppSetting) String() string {
 	builder.WriteString("s3_webdav_dedicated_tunnel_hostname=")
 	builder.WriteString(_m.S3WebdavDedicatedTunnelHostname)
 	builder.WriteString(", ")
+	builder.WriteString("config_file=")
+	builder.WriteString(_m.ConfigFile)
+	builder.WriteString(", ")
+	builder.WriteString("metrics_poll_interval=")
+	builder.WriteString(_m.MetricsPollInterval)
+	builder.WriteString(", ")
+	builder.WriteString("tags=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Tags))
+	builder.WriteString(", ")
+	builder.WriteString("idle_timeout=")
+	builder.WriteString(_m.IdleTimeout)
+	builder.WriteString(", ")
+	builder.WriteString("schema_version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.SchemaVersion))
+	builder.WriteString(", ")
+	builder.WriteString("lazy_start=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LazyStart))
+	builder.WriteString(", ")
+	builder.WriteString("retryable_patterns=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RetryablePatterns))
+	builder.WriteString(", ")
+	builder.WriteString("non_retryable_patterns=")
+	builder.WriteString(fmt.Sprintf("%v", _m.NonRetryablePatterns))
+	builder.WriteString(", ")
+	builder.WriteString("protocol_order=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ProtocolOrder))
+	builder.WriteString(", ")
+	builder.WriteString("listen_port=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ListenPort))
+	builder.WriteString(", ")
+	builder.WriteString("listen_addr=")
+	builder.WriteString(_m.ListenAddr)
+	builder.WriteString(", ")
+	builder.WriteString("require_connected_for_ready=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireConnectedForReady))
+	builder.WriteString(", ")
+	builder.WriteString("strict_extra_args=")
+	builder.WriteString(fmt.Sprintf("%v", _m.StrictExtraArgs))
+	builder.WriteString(", ")
+	builder.WriteString("max_restarts_per_hour=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxRestartsPerHour))
+	builder.WriteString(", ")
+	builder.WriteString("max_runtime=")
+	builder.WriteString(_m.MaxRuntime)
+	builder.WriteString(", ")
+	builder.WriteString("require_confirm=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireConfirm))
+	builder.WriteString(", ")
+	builder.WriteString("restart_jitter=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RestartJitter))
+	builder.WriteString(", ")
+	builder.WriteString("sole_log_sink=")
+	builder.WriteString(fmt.Sprintf("%v", _m.SoleLogSink))
+	builder.WriteString(", ")
+	builder.WriteString("max_protocol_switches=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MaxProtocolSwitches))
+	builder.WriteString(", ")
+	builder.WriteString("stats_persist_interval=")
+	builder.WriteString(_m.StatsPersistInterval)
+	builder.WriteString(", ")
+	builder.WriteString("edge_ip_fallback=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EdgeIPFallback))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -45,8 +45,12 @@ const (
 	FieldEdgeIPVersion = "edge_ip_version"
 	// FieldEdgeBindAddress holds the string denoting the edge_bind_address field in the database.
 	FieldEdgeBindAddress = "edge_bind_address"
+	// FieldEdgeInterface holds the string denoting the edge_interface field in the database.
+	FieldEdgeInterface = "edge_interface"
 	// FieldPostQuantum holds the string denoting the post_quantum field in the database.
 	FieldPostQuantum = "post_quantum"
+	// FieldPostQuantumMode holds the string denoting the post_quantum_mode field in the database.
+	FieldPostQuantumMode = "post_quantum_mode"
 	// FieldNoTLSVerify holds the string denoting the no_tls_verify field in the database.
 	FieldNoTLSVerify = "no_tls_verify"
 	// FieldExtraArgs holds the string denoting the extra_args field in the database.
@@ -77,6 +81,48 @@ const (
 	FieldS3WebdavDedicatedCustomDomain = "s3_webdav_dedicated_custom_domain"
 	// FieldS3WebdavDedicatedTunnelHostname holds the string denoting the s3_webdav_dedicated_tunnel_hostname field in the database.
 	FieldS3WebdavDedicatedTunnelHostname = "s3_webdav_dedicated_tunnel_hostname"
+	// FieldConfigFile holds the string denoting the config_file field in the database.
+	FieldConfigFile = "config_file"
+	// FieldMetricsPollInterval holds the string denoting the metrics_poll_interval field in the database.
+	FieldMetricsPollInterval = "metrics_poll_interval"
+	// FieldTags holds the string denoting the tags field in the database.
+	FieldTags = "tags"
+	// FieldIdleTimeout holds the string denoting the idle_timeout field in the database.
+	FieldIdleTimeout = "idle_timeout"
+	// FieldSchemaVersion holds the string denoting the schema_version field in the database.
+	FieldSchemaVersion = "schema_version"
+	// FieldLazyStart holds the string denoting the lazy_start field in the database.
+	FieldLazyStart = "lazy_start"
+	// FieldRetryablePatterns holds the string denoting the retryable_patterns field in the database.
+	FieldRetryablePatterns = "retryable_patterns"
+	// FieldNonRetryablePatterns holds the string denoting the non_retryable_patterns field in the database.
+	FieldNonRetryablePatterns = "non_retryable_patterns"
+	// FieldProtocolOrder holds the string denoting the protocol_order field in the database.
+	FieldProtocolOrder = "protocol_order"
+	// FieldListenPort holds the string denoting the listen_port field in the database.
+	FieldListenPort = "listen_port"
+	// FieldListenAddr holds the string denoting the listen_addr field in the database.
+	FieldListenAddr = "listen_addr"
+	// FieldRequireConnectedForReady holds the string denoting the require_connected_for_ready field in the database.
+	FieldRequireConnectedForReady = "require_connected_for_ready"
+	// FieldStrictExtraArgs holds the string denoting the strict_extra_args field in the database.
+	FieldStrictExtraArgs = "strict_extra_args"
+	// FieldMaxRestartsPerHour holds the string denoting the max_restarts_per_hour field in the database.
+	FieldMaxRestartsPerHour = "max_restarts_per_hour"
+	// FieldMaxRuntime holds the string denoting the max_runtime field in the database.
+	FieldMaxRuntime = "max_runtime"
+	// FieldRequireConfirm holds the string denoting the require_confirm field in the database.
+	FieldRequireConfirm = "require_confirm"
+	// FieldRestartJitter holds the string denoting the restart_jitter field in the database.
+	FieldRestartJitter = "restart_jitter"
+	// FieldSoleLogSink holds the string denoting the sole_log_sink field in the database.
+	FieldSoleLogSink = "sole_log_sink"
+	// FieldMaxProtocolSwitches holds the string denoting the max_protocol_switches field in the database.
+	FieldMaxProtocolSwitches = "max_protocol_switches"
+	// FieldStatsPersistInterval holds the string denoting the stats_persist_interval field in the database.
+	FieldStatsPersistInterval = "stats_persist_interval"
+	// FieldEdgeIPFallback holds the string denoting the edge_ip_fallback field in the database.
+	FieldEdgeIPFallback = "edge_ip_fallback"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
@@ -104,7 +150,9 @@ var Columns = []string{
 	FieldLogJSON,
 	FieldEdgeIPVersion,
 	FieldEdgeBindAddress,
+	FieldEdgeInterface,
 	FieldPostQuantum,
+	FieldPostQuantumMode,
 	FieldNoTLSVerify,
 	FieldExtraArgs,
 	FieldActiveTunnelKey,
@@ -120,6 +168,27 @@ var Columns = []string{
 	FieldS3WebdavDedicatedDomainMode,
 	FieldS3WebdavDedicatedCustomDomain,
 	FieldS3WebdavDedicatedTunnelHostname,
+	FieldConfigFile,
+	FieldMetricsPollInterval,
+	FieldTags,
+	FieldIdleTimeout,
+	FieldSchemaVersion,
+	FieldLazyStart,
+	FieldRetryablePatterns,
+	FieldNonRetryablePatterns,
+	FieldProtocolOrder,
+	FieldListenPort,
+	FieldListenAddr,
+	FieldRequireConnectedForReady,
+	FieldStrictExtraArgs,
+	FieldMaxRestartsPerHour,
+	FieldMaxRuntime,
+	FieldRequireConfirm,
+	FieldRestartJitter,
+	FieldSoleLogSink,
+	FieldMaxProtocolSwitches,
+	FieldStatsPersistInterval,
+	FieldEdgeIPFallback,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -167,8 +236,12 @@ var (
 	DefaultEdgeIPVersion string
 	// DefaultEdgeBindAddress holds the default value on creation for the "edge_bind_address" field.
 	DefaultEdgeBindAddress string
+	// DefaultEdgeInterface holds the default value on creation for the "edge_interface" field.
+	DefaultEdgeInterface string
 	// DefaultPostQuantum holds the default value on creation for the "post_quantum" field.
 	DefaultPostQuantum bool
+	// DefaultPostQuantumMode holds the default value on creation for the "post_quantum_mode" field.
+	DefaultPostQuantumMode string
 	// DefaultNoTLSVerify holds the default value on creation for the "no_tls_verify" field.
 	DefaultNoTLSVerify bool
 	// DefaultExtraArgs holds the default value on creation for the "extra_args" field.
@@ -199,6 +272,40 @@ var (
 	DefaultS3WebdavDedicatedCustomDomain string
 	// DefaultS3WebdavDedicatedTunnelHostname holds the default value on creation for the "s3_webdav_dedicated_tunnel_hostname" field.
 	DefaultS3WebdavDedicatedTunnelHostname string
+	// DefaultConfigFile holds the default value on creation for the "config_file" field.
+	DefaultConfigFile string
+	// DefaultMetricsPollInterval holds the default value on creation for the "metrics_poll_interval" field.
+	DefaultMetricsPollInterval string
+	// DefaultIdleTimeout holds the default value on creation for the "idle_timeout" field.
+	DefaultIdleTimeout string
+	// DefaultSchemaVersion holds the default value on creation for the "schema_version" field.
+	DefaultSchemaVersion int
+	// DefaultLazyStart holds the default value on creation for the "lazy_start" field.
+	DefaultLazyStart bool
+	// DefaultListenPort holds the default value on creation for the "listen_port" field.
+	DefaultListenPort int
+	// DefaultListenAddr holds the default value on creation for the "listen_addr" field.
+	DefaultListenAddr string
+	// DefaultRequireConnectedForReady holds the default value on creation for the "require_connected_for_ready" field.
+	DefaultRequireConnectedForReady bool
+	// DefaultStrictExtraArgs holds the default value on creation for the "strict_extra_args" field.
+	DefaultStrictExtraArgs bool
+	// DefaultMaxRestartsPerHour holds the default value on creation for the "max_restarts_per_hour" field.
+	DefaultMaxRestartsPerHour int
+	// DefaultMaxRuntime holds the default value on creation for the "max_runtime" field.
+	DefaultMaxRuntime string
+	// DefaultRequireConfirm holds the default value on creation for the "require_confirm" field.
+	DefaultRequireConfirm bool
+	// DefaultRestartJitter holds the default value on creation for the "restart_jitter" field.
+	DefaultRestartJitter int
+	// DefaultSoleLogSink holds the default value on creation for the "sole_log_sink" field.
+	DefaultSoleLogSink bool
+	// DefaultMaxProtocolSwitches holds the default value on creation for the "max_protocol_switches" field.
+	DefaultMaxProtocolSwitches int
+	// DefaultStatsPersistInterval holds the default value on creation for the "stats_persist_interval" field.
+	DefaultStatsPersistInterval string
+	// DefaultEdgeIPFallback holds the default value on creation for the "edge_ip_fallback" field.
+	DefaultEdgeIPFallback bool
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
@@ -295,11 +402,21 @@ func ByEdgeBindAddress(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldEdgeBindAddress, opts...).ToFunc()
 }
 
+// ByEdgeInterface orders the results by the edge_interface field.
+func ByEdgeInterface(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEdgeInterface, opts...).ToFunc()
+}
+
 // ByPostQuantum orders the results by the post_quantum field.
 func ByPostQuantum(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPostQuantum, opts...).ToFunc()
 }
 
+// ByPostQuantumMode orders the results by the post_quantum_mode field.
+func ByPostQuantumMode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPostQuantumMode, opts...).ToFunc()
+}
+
 // ByNoTLSVerify orders the results by the no_tls_verify field.
 func ByNoTLSVerify(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldNoTLSVerify, opts...).ToFunc()
@@ -375,6 +492,91 @@ func ByS3WebdavDedicatedTunnelHostname(opts ...sql.OrderTermOption) OrderOption
 	return sql.OrderByField(FieldS3WebdavDedicatedTunnelHostname, opts...).ToFunc()
 }
 
+// ByConfigFile orders the results by the config_file field.
+func ByConfigFile(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldConfigFile, opts...).ToFunc()
+}
+
+// ByMetricsPollInterval orders the results by the metrics_poll_interval field.
+func ByMetricsPollInterval(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMetricsPollInterval, opts...).ToFunc()
+}
+
+// ByIdleTimeout orders the results by the idle_timeout field.
+func ByIdleTimeout(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIdleTimeout, opts...).ToFunc()
+}
+
+// BySchemaVersion orders the results by the schema_version field.
+func BySchemaVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSchemaVersion, opts...).ToFunc()
+}
+
+// ByLazyStart orders the results by the lazy_start field.
+func ByLazyStart(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLazyStart, opts...).ToFunc()
+}
+
+// ByListenPort orders the results by the listen_port field.
+func ByListenPort(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldListenPort, opts...).ToFunc()
+}
+
+// ByListenAddr orders the results by the listen_addr field.
+func ByListenAddr(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldListenAddr, opts...).ToFunc()
+}
+
+// ByRequireConnectedForReady orders the results by the require_connected_for_ready field.
+func ByRequireConnectedForReady(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireConnectedForReady, opts...).ToFunc()
+}
+
+// ByStrictExtraArgs orders the results by the strict_extra_args field.
+func ByStrictExtraArgs(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStrictExtraArgs, opts...).ToFunc()
+}
+
+// ByMaxRestartsPerHour orders the results by the max_restarts_per_hour field.
+func ByMaxRestartsPerHour(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxRestartsPerHour, opts...).ToFunc()
+}
+
+// ByMaxRuntime orders the results by the max_runtime field.
+func ByMaxRuntime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxRuntime, opts...).ToFunc()
+}
+
+// ByRequireConfirm orders the results by the require_confirm field.
+func ByRequireConfirm(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireConfirm, opts...).ToFunc()
+}
+
+// ByRestartJitter orders the results by the restart_jitter field.
+func ByRestartJitter(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRestartJitter, opts...).ToFunc()
+}
+
+// BySoleLogSink orders the results by the sole_log_sink field.
+func BySoleLogSink(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSoleLogSink, opts...).ToFunc()
+}
+
+// ByMaxProtocolSwitches orders the results by the max_protocol_switches field.
+func ByMaxProtocolSwitches(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxProtocolSwitches, opts...).ToFunc()
+}
+
+// ByStatsPersistInterval orders the results by the stats_persist_interval field.
+func ByStatsPersistInterval(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatsPersistInterval, opts...).ToFunc()
+}
+
+// ByEdgeIPFallback orders the results by the edge_ip_fallback field.
+func ByEdgeIPFallback(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEdgeIPFallback, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -134,11 +134,21 @@ func EdgeBindAddress(v string) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldEdgeBindAddress, v))
 }
 
+// EdgeInterface applies equality check predicate on the "edge_interface" field. It's identical to EdgeInterfaceEQ.
+func EdgeInterface(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldEdgeInterface, v))
+}
+
 // PostQuantum applies equality check predicate on the "post_quantum" field. It's identical to PostQuantumEQ.
 func PostQuantum(v bool) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldPostQuantum, v))
 }
 
+// PostQuantumMode applies equality check predicate on the "post_quantum_mode" field. It's identical to PostQuantumModeEQ.
+func PostQuantumMode(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldPostQuantumMode, v))
+}
+
 // NoTLSVerify applies equality check predicate on the "no_tls_verify" field. It's identical to NoTLSVerifyEQ.
 func NoTLSVerify(v bool) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldNoTLSVerify, v))
@@ -214,6 +224,91 @@ func S3WebdavDedicatedTunnelHostname(v string) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldS3WebdavDedicatedTunnelHostname, v))
 }
 
+// ConfigFile applies equality check predicate on the "config_file" field. It's identical to ConfigFileEQ.
+func ConfigFile(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldConfigFile, v))
+}
+
+// MetricsPollInterval applies equality check predicate on the "metrics_poll_interval" field. It's identical to MetricsPollIntervalEQ.
+func MetricsPollInterval(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMetricsPollInterval, v))
+}
+
+// IdleTimeout applies equality check predicate on the "idle_timeout" field. It's identical to IdleTimeoutEQ.
+func IdleTimeout(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldIdleTimeout, v))
+}
+
+// SchemaVersion applies equality check predicate on the "schema_version" field. It's identical to SchemaVersionEQ.
+func SchemaVersion(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldSchemaVersion, v))
+}
+
+// LazyStart applies equality check predicate on the "lazy_start" field. It's identical to LazyStartEQ.
+func LazyStart(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldLazyStart, v))
+}
+
+// ListenPort applies equality check predicate on the "listen_port" field. It's identical to ListenPortEQ.
+func ListenPort(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldListenPort, v))
+}
+
+// ListenAddr applies equality check predicate on the "listen_addr" field. It's identical to ListenAddrEQ.
+func ListenAddr(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldListenAddr, v))
+}
+
+// RequireConnectedForReady applies equality check predicate on the "require_connected_for_ready" field. It's identical to RequireConnectedForReadyEQ.
+func RequireConnectedForReady(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldRequireConnectedForReady, v))
+}
+
+// StrictExtraArgs applies equality check predicate on the "strict_extra_args" field. It's identical to StrictExtraArgsEQ.
+func StrictExtraArgs(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldStrictExtraArgs, v))
+}
+
+// MaxRestartsPerHour applies equality check predicate on the "max_restarts_per_hour" field. It's identical to MaxRestartsPerHourEQ.
+func MaxRestartsPerHour(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRuntime applies equality check predicate on the "max_runtime" field. It's identical to MaxRuntimeEQ.
+func MaxRuntime(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMaxRuntime, v))
+}
+
+// RequireConfirm applies equality check predicate on the "require_confirm" field. It's identical to RequireConfirmEQ.
+func RequireConfirm(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldRequireConfirm, v))
+}
+
+// RestartJitter applies equality check predicate on the "restart_jitter" field. It's identical to RestartJitterEQ.
+func RestartJitter(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldRestartJitter, v))
+}
+
+// SoleLogSink applies equality check predicate on the "sole_log_sink" field. It's identical to SoleLogSinkEQ.
+func SoleLogSink(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldSoleLogSink, v))
+}
+
+// MaxProtocolSwitches applies equality check predicate on the "max_protocol_switches" field. It's identical to MaxProtocolSwitchesEQ.
+func MaxProtocolSwitches(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMaxProtocolSwitches, v))
+}
+
+// StatsPersistInterval applies equality check predicate on the "stats_persist_interval" field. It's identical to StatsPersistIntervalEQ.
+func StatsPersistInterval(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldStatsPersistInterval, v))
+}
+
+// EdgeIPFallback applies equality check predicate on the "edge_ip_fallback" field. It's identical to EdgeIPFallbackEQ.
+func EdgeIPFallback(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldEdgeIPFallback, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldCreatedAt, v))
@@ -994,6 +1089,71 @@ func EdgeBindAddressContainsFold(v string) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldContainsFold(FieldEdgeBindAddress, v))
 }
 
+// EdgeInterfaceEQ applies the EQ predicate on the "edge_interface" field.
+func EdgeInterfaceEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceNEQ applies the NEQ predicate on the "edge_interface" field.
+func EdgeInterfaceNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceIn applies the In predicate on the "edge_interface" field.
+func EdgeInterfaceIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldEdgeInterface, vs...))
+}
+
+// EdgeInterfaceNotIn applies the NotIn predicate on the "edge_interface" field.
+func EdgeInterfaceNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldEdgeInterface, vs...))
+}
+
+// EdgeInterfaceGT applies the GT predicate on the "edge_interface" field.
+func EdgeInterfaceGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceGTE applies the GTE predicate on the "edge_interface" field.
+func EdgeInterfaceGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceLT applies the LT predicate on the "edge_interface" field.
+func EdgeInterfaceLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceLTE applies the LTE predicate on the "edge_interface" field.
+func EdgeInterfaceLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceContains applies the Contains predicate on the "edge_interface" field.
+func EdgeInterfaceContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceHasPrefix applies the HasPrefix predicate on the "edge_interface" field.
+func EdgeInterfaceHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceHasSuffix applies the HasSuffix predicate on the "edge_interface" field.
+func EdgeInterfaceHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceEqualFold applies the EqualFold predicate on the "edge_interface" field.
+func EdgeInterfaceEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceContainsFold applies the ContainsFold predicate on the "edge_interface" field.
+func EdgeInterfaceContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldEdgeInterface, v))
+}
+
 // PostQuantumEQ applies the EQ predicate on the "post_quantum" field.
 func PostQuantumEQ(v bool) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldPostQuantum, v))
@@ -1004,6 +1164,71 @@ func PostQuantumNEQ(v bool) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldNEQ(FieldPostQuantum, v))
 }
 
+// PostQuantumModeEQ applies the EQ predicate on the "post_quantum_mode" field.
+func PostQuantumModeEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeNEQ applies the NEQ predicate on the "post_quantum_mode" field.
+func PostQuantumModeNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeIn applies the In predicate on the "post_quantum_mode" field.
+func PostQuantumModeIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldPostQuantumMode, vs...))
+}
+
+// PostQuantumModeNotIn applies the NotIn predicate on the "post_quantum_mode" field.
+func PostQuantumModeNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldPostQuantumMode, vs...))
+}
+
+// PostQuantumModeGT applies the GT predicate on the "post_quantum_mode" field.
+func PostQuantumModeGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeGTE applies the GTE predicate on the "post_quantum_mode" field.
+func PostQuantumModeGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeLT applies the LT predicate on the "post_quantum_mode" field.
+func PostQuantumModeLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeLTE applies the LTE predicate on the "post_quantum_mode" field.
+func PostQuantumModeLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeContains applies the Contains predicate on the "post_quantum_mode" field.
+func PostQuantumModeContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeHasPrefix applies the HasPrefix predicate on the "post_quantum_mode" field.
+func PostQuantumModeHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeHasSuffix applies the HasSuffix predicate on the "post_quantum_mode" field.
+func PostQuantumModeHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeEqualFold applies the EqualFold predicate on the "post_quantum_mode" field.
+func PostQuantumModeEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeContainsFold applies the ContainsFold predicate on the "post_quantum_mode" field.
+func PostQuantumModeContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldPostQuantumMode, v))
+}
+
 // NoTLSVerifyEQ applies the EQ predicate on the "no_tls_verify" field.
 func NoTLSVerifyEQ(v bool) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldNoTLSVerify, v))
@@ -1734,6 +1959,696 @@ func S3WebdavDedicatedTunnelHostnameContainsFold(v string) predicate.AppSetting
 	return predicate.AppSetting(sql.FieldContainsFold(FieldS3WebdavDedicatedTunnelHostname, v))
 }
 
+// ConfigFileEQ applies the EQ predicate on the "config_file" field.
+func ConfigFileEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldConfigFile, v))
+}
+
+// ConfigFileNEQ applies the NEQ predicate on the "config_file" field.
+func ConfigFileNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldConfigFile, v))
+}
+
+// ConfigFileIn applies the In predicate on the "config_file" field.
+func ConfigFileIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldConfigFile, vs...))
+}
+
+// ConfigFileNotIn applies the NotIn predicate on the "config_file" field.
+func ConfigFileNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldConfigFile, vs...))
+}
+
+// ConfigFileGT applies the GT predicate on the "config_file" field.
+func ConfigFileGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldConfigFile, v))
+}
+
+// ConfigFileGTE applies the GTE predicate on the "config_file" field.
+func ConfigFileGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldConfigFile, v))
+}
+
+// ConfigFileLT applies the LT predicate on the "config_file" field.
+func ConfigFileLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldConfigFile, v))
+}
+
+// ConfigFileLTE applies the LTE predicate on the "config_file" field.
+func ConfigFileLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldConfigFile, v))
+}
+
+// ConfigFileContains applies the Contains predicate on the "config_file" field.
+func ConfigFileContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldConfigFile, v))
+}
+
+// ConfigFileHasPrefix applies the HasPrefix predicate on the "config_file" field.
+func ConfigFileHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldConfigFile, v))
+}
+
+// ConfigFileHasSuffix applies the HasSuffix predicate on the "config_file" field.
+func ConfigFileHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldConfigFile, v))
+}
+
+// ConfigFileEqualFold applies the EqualFold predicate on the "config_file" field.
+func ConfigFileEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldConfigFile, v))
+}
+
+// ConfigFileContainsFold applies the ContainsFold predicate on the "config_file" field.
+func ConfigFileContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldConfigFile, v))
+}
+
+// MetricsPollIntervalEQ applies the EQ predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalNEQ applies the NEQ predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalIn applies the In predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldMetricsPollInterval, vs...))
+}
+
+// MetricsPollIntervalNotIn applies the NotIn predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldMetricsPollInterval, vs...))
+}
+
+// MetricsPollIntervalGT applies the GT predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalGTE applies the GTE predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalLT applies the LT predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalLTE applies the LTE predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalContains applies the Contains predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalHasPrefix applies the HasPrefix predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalHasSuffix applies the HasSuffix predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalEqualFold applies the EqualFold predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldMetricsPollInterval, v))
+}
+
+// MetricsPollIntervalContainsFold applies the ContainsFold predicate on the "metrics_poll_interval" field.
+func MetricsPollIntervalContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldMetricsPollInterval, v))
+}
+
+// TagsIsNil applies the IsNil predicate on the "tags" field.
+func TagsIsNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIsNull(FieldTags))
+}
+
+// TagsNotNil applies the NotNil predicate on the "tags" field.
+func TagsNotNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotNull(FieldTags))
+}
+
+// IdleTimeoutEQ applies the EQ predicate on the "idle_timeout" field.
+func IdleTimeoutEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutNEQ applies the NEQ predicate on the "idle_timeout" field.
+func IdleTimeoutNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutIn applies the In predicate on the "idle_timeout" field.
+func IdleTimeoutIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldIdleTimeout, vs...))
+}
+
+// IdleTimeoutNotIn applies the NotIn predicate on the "idle_timeout" field.
+func IdleTimeoutNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldIdleTimeout, vs...))
+}
+
+// IdleTimeoutGT applies the GT predicate on the "idle_timeout" field.
+func IdleTimeoutGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutGTE applies the GTE predicate on the "idle_timeout" field.
+func IdleTimeoutGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutLT applies the LT predicate on the "idle_timeout" field.
+func IdleTimeoutLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutLTE applies the LTE predicate on the "idle_timeout" field.
+func IdleTimeoutLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutContains applies the Contains predicate on the "idle_timeout" field.
+func IdleTimeoutContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutHasPrefix applies the HasPrefix predicate on the "idle_timeout" field.
+func IdleTimeoutHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutHasSuffix applies the HasSuffix predicate on the "idle_timeout" field.
+func IdleTimeoutHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutEqualFold applies the EqualFold predicate on the "idle_timeout" field.
+func IdleTimeoutEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutContainsFold applies the ContainsFold predicate on the "idle_timeout" field.
+func IdleTimeoutContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldIdleTimeout, v))
+}
+
+// SchemaVersionEQ applies the EQ predicate on the "schema_version" field.
+func SchemaVersionEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldSchemaVersion, v))
+}
+
+// SchemaVersionNEQ applies the NEQ predicate on the "schema_version" field.
+func SchemaVersionNEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldSchemaVersion, v))
+}
+
+// SchemaVersionIn applies the In predicate on the "schema_version" field.
+func SchemaVersionIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldSchemaVersion, vs...))
+}
+
+// SchemaVersionNotIn applies the NotIn predicate on the "schema_version" field.
+func SchemaVersionNotIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldSchemaVersion, vs...))
+}
+
+// SchemaVersionGT applies the GT predicate on the "schema_version" field.
+func SchemaVersionGT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldSchemaVersion, v))
+}
+
+// SchemaVersionGTE applies the GTE predicate on the "schema_version" field.
+func SchemaVersionGTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldSchemaVersion, v))
+}
+
+// SchemaVersionLT applies the LT predicate on the "schema_version" field.
+func SchemaVersionLT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldSchemaVersion, v))
+}
+
+// SchemaVersionLTE applies the LTE predicate on the "schema_version" field.
+func SchemaVersionLTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldSchemaVersion, v))
+}
+
+// LazyStartEQ applies the EQ predicate on the "lazy_start" field.
+func LazyStartEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldLazyStart, v))
+}
+
+// LazyStartNEQ applies the NEQ predicate on the "lazy_start" field.
+func LazyStartNEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldLazyStart, v))
+}
+
+// RetryablePatternsIsNil applies the IsNil predicate on the "retryable_patterns" field.
+func RetryablePatternsIsNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIsNull(FieldRetryablePatterns))
+}
+
+// RetryablePatternsNotNil applies the NotNil predicate on the "retryable_patterns" field.
+func RetryablePatternsNotNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotNull(FieldRetryablePatterns))
+}
+
+// NonRetryablePatternsIsNil applies the IsNil predicate on the "non_retryable_patterns" field.
+func NonRetryablePatternsIsNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIsNull(FieldNonRetryablePatterns))
+}
+
+// NonRetryablePatternsNotNil applies the NotNil predicate on the "non_retryable_patterns" field.
+func NonRetryablePatternsNotNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotNull(FieldNonRetryablePatterns))
+}
+
+// ProtocolOrderIsNil applies the IsNil predicate on the "protocol_order" field.
+func ProtocolOrderIsNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIsNull(FieldProtocolOrder))
+}
+
+// ProtocolOrderNotNil applies the NotNil predicate on the "protocol_order" field.
+func ProtocolOrderNotNil() predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotNull(FieldProtocolOrder))
+}
+
+// ListenPortEQ applies the EQ predicate on the "listen_port" field.
+func ListenPortEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldListenPort, v))
+}
+
+// ListenPortNEQ applies the NEQ predicate on the "listen_port" field.
+func ListenPortNEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldListenPort, v))
+}
+
+// ListenPortIn applies the In predicate on the "listen_port" field.
+func ListenPortIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldListenPort, vs...))
+}
+
+// ListenPortNotIn applies the NotIn predicate on the "listen_port" field.
+func ListenPortNotIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldListenPort, vs...))
+}
+
+// ListenPortGT applies the GT predicate on the "listen_port" field.
+func ListenPortGT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldListenPort, v))
+}
+
+// ListenPortGTE applies the GTE predicate on the "listen_port" field.
+func ListenPortGTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldListenPort, v))
+}
+
+// ListenPortLT applies the LT predicate on the "listen_port" field.
+func ListenPortLT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldListenPort, v))
+}
+
+// ListenPortLTE applies the LTE predicate on the "listen_port" field.
+func ListenPortLTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldListenPort, v))
+}
+
+// ListenAddrEQ applies the EQ predicate on the "listen_addr" field.
+func ListenAddrEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldListenAddr, v))
+}
+
+// ListenAddrNEQ applies the NEQ predicate on the "listen_addr" field.
+func ListenAddrNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldListenAddr, v))
+}
+
+// ListenAddrIn applies the In predicate on the "listen_addr" field.
+func ListenAddrIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldListenAddr, vs...))
+}
+
+// ListenAddrNotIn applies the NotIn predicate on the "listen_addr" field.
+func ListenAddrNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldListenAddr, vs...))
+}
+
+// ListenAddrGT applies the GT predicate on the "listen_addr" field.
+func ListenAddrGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldListenAddr, v))
+}
+
+// ListenAddrGTE applies the GTE predicate on the "listen_addr" field.
+func ListenAddrGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldListenAddr, v))
+}
+
+// ListenAddrLT applies the LT predicate on the "listen_addr" field.
+func ListenAddrLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldListenAddr, v))
+}
+
+// ListenAddrLTE applies the LTE predicate on the "listen_addr" field.
+func ListenAddrLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldListenAddr, v))
+}
+
+// ListenAddrContains applies the Contains predicate on the "listen_addr" field.
+func ListenAddrContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldListenAddr, v))
+}
+
+// ListenAddrHasPrefix applies the HasPrefix predicate on the "listen_addr" field.
+func ListenAddrHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldListenAddr, v))
+}
+
+// ListenAddrHasSuffix applies the HasSuffix predicate on the "listen_addr" field.
+func ListenAddrHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldListenAddr, v))
+}
+
+// ListenAddrEqualFold applies the EqualFold predicate on the "listen_addr" field.
+func ListenAddrEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldListenAddr, v))
+}
+
+// ListenAddrContainsFold applies the ContainsFold predicate on the "listen_addr" field.
+func ListenAddrContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldListenAddr, v))
+}
+
+// RequireConnectedForReadyEQ applies the EQ predicate on the "require_connected_for_ready" field.
+func RequireConnectedForReadyEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldRequireConnectedForReady, v))
+}
+
+// RequireConnectedForReadyNEQ applies the NEQ predicate on the "require_connected_for_ready" field.
+func RequireConnectedForReadyNEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldRequireConnectedForReady, v))
+}
+
+// StrictExtraArgsEQ applies the EQ predicate on the "strict_extra_args" field.
+func StrictExtraArgsEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldStrictExtraArgs, v))
+}
+
+// StrictExtraArgsNEQ applies the NEQ predicate on the "strict_extra_args" field.
+func StrictExtraArgsNEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldStrictExtraArgs, v))
+}
+
+// MaxRestartsPerHourEQ applies the EQ predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRestartsPerHourNEQ applies the NEQ predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourNEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRestartsPerHourIn applies the In predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldMaxRestartsPerHour, vs...))
+}
+
+// MaxRestartsPerHourNotIn applies the NotIn predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourNotIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldMaxRestartsPerHour, vs...))
+}
+
+// MaxRestartsPerHourGT applies the GT predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourGT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRestartsPerHourGTE applies the GTE predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourGTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRestartsPerHourLT applies the LT predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourLT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRestartsPerHourLTE applies the LTE predicate on the "max_restarts_per_hour" field.
+func MaxRestartsPerHourLTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldMaxRestartsPerHour, v))
+}
+
+// MaxRuntimeEQ applies the EQ predicate on the "max_runtime" field.
+func MaxRuntimeEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeNEQ applies the NEQ predicate on the "max_runtime" field.
+func MaxRuntimeNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeIn applies the In predicate on the "max_runtime" field.
+func MaxRuntimeIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldMaxRuntime, vs...))
+}
+
+// MaxRuntimeNotIn applies the NotIn predicate on the "max_runtime" field.
+func MaxRuntimeNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldMaxRuntime, vs...))
+}
+
+// MaxRuntimeGT applies the GT predicate on the "max_runtime" field.
+func MaxRuntimeGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeGTE applies the GTE predicate on the "max_runtime" field.
+func MaxRuntimeGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeLT applies the LT predicate on the "max_runtime" field.
+func MaxRuntimeLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeLTE applies the LTE predicate on the "max_runtime" field.
+func MaxRuntimeLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeContains applies the Contains predicate on the "max_runtime" field.
+func MaxRuntimeContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeHasPrefix applies the HasPrefix predicate on the "max_runtime" field.
+func MaxRuntimeHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeHasSuffix applies the HasSuffix predicate on the "max_runtime" field.
+func MaxRuntimeHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeEqualFold applies the EqualFold predicate on the "max_runtime" field.
+func MaxRuntimeEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldMaxRuntime, v))
+}
+
+// MaxRuntimeContainsFold applies the ContainsFold predicate on the "max_runtime" field.
+func MaxRuntimeContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldMaxRuntime, v))
+}
+
+// RequireConfirmEQ applies the EQ predicate on the "require_confirm" field.
+func RequireConfirmEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldRequireConfirm, v))
+}
+
+// RequireConfirmNEQ applies the NEQ predicate on the "require_confirm" field.
+func RequireConfirmNEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldRequireConfirm, v))
+}
+
+// RestartJitterEQ applies the EQ predicate on the "restart_jitter" field.
+func RestartJitterEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldRestartJitter, v))
+}
+
+// RestartJitterNEQ applies the NEQ predicate on the "restart_jitter" field.
+func RestartJitterNEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldRestartJitter, v))
+}
+
+// RestartJitterIn applies the In predicate on the "restart_jitter" field.
+func RestartJitterIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldRestartJitter, vs...))
+}
+
+// RestartJitterNotIn applies the NotIn predicate on the "restart_jitter" field.
+func RestartJitterNotIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldRestartJitter, vs...))
+}
+
+// RestartJitterGT applies the GT predicate on the "restart_jitter" field.
+func RestartJitterGT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldRestartJitter, v))
+}
+
+// RestartJitterGTE applies the GTE predicate on the "restart_jitter" field.
+func RestartJitterGTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldRestartJitter, v))
+}
+
+// RestartJitterLT applies the LT predicate on the "restart_jitter" field.
+func RestartJitterLT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldRestartJitter, v))
+}
+
+// RestartJitterLTE applies the LTE predicate on the "restart_jitter" field.
+func RestartJitterLTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldRestartJitter, v))
+}
+
+// SoleLogSinkEQ applies the EQ predicate on the "sole_log_sink" field.
+func SoleLogSinkEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldSoleLogSink, v))
+}
+
+// SoleLogSinkNEQ applies the NEQ predicate on the "sole_log_sink" field.
+func SoleLogSinkNEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldSoleLogSink, v))
+}
+
+// MaxProtocolSwitchesEQ applies the EQ predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldMaxProtocolSwitches, v))
+}
+
+// MaxProtocolSwitchesNEQ applies the NEQ predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesNEQ(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldMaxProtocolSwitches, v))
+}
+
+// MaxProtocolSwitchesIn applies the In predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldMaxProtocolSwitches, vs...))
+}
+
+// MaxProtocolSwitchesNotIn applies the NotIn predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesNotIn(vs ...int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldMaxProtocolSwitches, vs...))
+}
+
+// MaxProtocolSwitchesGT applies the GT predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesGT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldMaxProtocolSwitches, v))
+}
+
+// MaxProtocolSwitchesGTE applies the GTE predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesGTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldMaxProtocolSwitches, v))
+}
+
+// MaxProtocolSwitchesLT applies the LT predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesLT(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldMaxProtocolSwitches, v))
+}
+
+// MaxProtocolSwitchesLTE applies the LTE predicate on the "max_protocol_switches" field.
+func MaxProtocolSwitchesLTE(v int) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldMaxProtocolSwitches, v))
+}
+
+// StatsPersistIntervalEQ applies the EQ predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalNEQ applies the NEQ predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalNEQ(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalIn applies the In predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldIn(FieldStatsPersistInterval, vs...))
+}
+
+// StatsPersistIntervalNotIn applies the NotIn predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalNotIn(vs ...string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNotIn(FieldStatsPersistInterval, vs...))
+}
+
+// StatsPersistIntervalGT applies the GT predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalGT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGT(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalGTE applies the GTE predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalGTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldGTE(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalLT applies the LT predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalLT(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLT(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalLTE applies the LTE predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalLTE(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldLTE(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalContains applies the Contains predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalContains(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContains(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalHasPrefix applies the HasPrefix predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalHasPrefix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasPrefix(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalHasSuffix applies the HasSuffix predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalHasSuffix(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldHasSuffix(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalEqualFold applies the EqualFold predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalEqualFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEqualFold(FieldStatsPersistInterval, v))
+}
+
+// StatsPersistIntervalContainsFold applies the ContainsFold predicate on the "stats_persist_interval" field.
+func StatsPersistIntervalContainsFold(v string) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldContainsFold(FieldStatsPersistInterval, v))
+}
+
+// EdgeIPFallbackEQ applies the EQ predicate on the "edge_ip_fallback" field.
+func EdgeIPFallbackEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldEQ(FieldEdgeIPFallback, v))
+}
+
+// EdgeIPFallbackNEQ applies the NEQ predicate on the "edge_ip_fallback" field.
+func EdgeIPFallbackNEQ(v bool) predicate.AppSetting {
+	return predicate.AppSetting(sql.FieldNEQ(FieldEdgeIPFallback, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.AppSetting {
 	return predicate.AppSetting(sql.FieldEQ(FieldCreatedAt, v))
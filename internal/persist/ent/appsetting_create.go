@@ -236,6 +236,20 @@ func (_c *AppSettingCreate) SetNillableEdgeBindAddress(v *string) *AppSettingCre
 	return _c
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (_c *AppSettingCreate) SetEdgeInterface(v string) *AppSettingCreate {
+	_c.mutation.SetEdgeInterface(v)
+	return _c
+}
+
+// SetNillableEdgeInterface sets the "edge_interface" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableEdgeInterface(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetEdgeInterface(*v)
+	}
+	return _c
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (_c *AppSettingCreate) SetPostQuantum(v bool) *AppSettingCreate {
 	_c.mutation.SetPostQuantum(v)
@@ -250,6 +264,20 @@ func (_c *AppSettingCreate) SetNillablePostQuantum(v *bool) *AppSettingCreate {
 	return _c
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (_c *AppSettingCreate) SetPostQuantumMode(v string) *AppSettingCreate {
+	_c.mutation.SetPostQuantumMode(v)
+	return _c
+}
+
+// SetNillablePostQuantumMode sets the "post_quantum_mode" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillablePostQuantumMode(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetPostQuantumMode(*v)
+	}
+	return _c
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (_c *AppSettingCreate) SetNoTLSVerify(v bool) *AppSettingCreate {
 	_c.mutation.SetNoTLSVerify(v)
@@ -460,6 +488,268 @@ func (_c *AppSettingCreate) SetNillableS3WebdavDedicatedTunnelHostname(v *string
 	return _c
 }
 
+// SetConfigFile sets the "config_file" field.
+func (_c *AppSettingCreate) SetConfigFile(v string) *AppSettingCreate {
+	_c.mutation.SetConfigFile(v)
+	return _c
+}
+
+// SetNillableConfigFile sets the "config_file" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableConfigFile(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetConfigFile(*v)
+	}
+	return _c
+}
+
+// SetMetricsPollInterval sets the "metrics_poll_interval" field.
+func (_c *AppSettingCreate) SetMetricsPollInterval(v string) *AppSettingCreate {
+	_c.mutation.SetMetricsPollInterval(v)
+	return _c
+}
+
+// SetNillableMetricsPollInterval sets the "metrics_poll_interval" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableMetricsPollInterval(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetMetricsPollInterval(*v)
+	}
+	return _c
+}
+
+// SetTags sets the "tags" field.
+func (_c *AppSettingCreate) SetTags(v map[string]string) *AppSettingCreate {
+	_c.mutation.SetTags(v)
+	return _c
+}
+
+// SetIdleTimeout sets the "idle_timeout" field.
+func (_c *AppSettingCreate) SetIdleTimeout(v string) *AppSettingCreate {
+	_c.mutation.SetIdleTimeout(v)
+	return _c
+}
+
+// SetNillableIdleTimeout sets the "idle_timeout" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableIdleTimeout(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetIdleTimeout(*v)
+	}
+	return _c
+}
+
+// SetSchemaVersion sets the "schema_version" field.
+func (_c *AppSettingCreate) SetSchemaVersion(v int) *AppSettingCreate {
+	_c.mutation.SetSchemaVersion(v)
+	return _c
+}
+
+// SetNillableSchemaVersion sets the "schema_version" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableSchemaVersion(v *int) *AppSettingCreate {
+	if v != nil {
+		_c.SetSchemaVersion(*v)
+	}
+	return _c
+}
+
+// SetLazyStart sets the "lazy_start" field.
+func (_c *AppSettingCreate) SetLazyStart(v bool) *AppSettingCreate {
+	_c.mutation.SetLazyStart(v)
+	return _c
+}
+
+// SetNillableLazyStart sets the "lazy_start" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableLazyStart(v *bool) *AppSettingCreate {
+	if v != nil {
+		_c.SetLazyStart(*v)
+	}
+	return _c
+}
+
+// SetRetryablePatterns sets the "retryable_patterns" field.
+func (_c *AppSettingCreate) SetRetryablePatterns(v []string) *AppSettingCreate {
+	_c.mutation.SetRetryablePatterns(v)
+	return _c
+}
+
+// SetNonRetryablePatterns sets the "non_retryable_patterns" field.
+func (_c *AppSettingCreate) SetNonRetryablePatterns(v []string) *AppSettingCreate {
+	_c.mutation.SetNonRetryablePatterns(v)
+	return _c
+}
+
+// SetProtocolOrder sets the "protocol_order" field.
+func (_c *AppSettingCreate) SetProtocolOrder(v []string) *AppSettingCreate {
+	_c.mutation.SetProtocolOrder(v)
+	return _c
+}
+
+// SetListenPort sets the "listen_port" field.
+func (_c *AppSettingCreate) SetListenPort(v int) *AppSettingCreate {
+	_c.mutation.SetListenPort(v)
+	return _c
+}
+
+// SetNillableListenPort sets the "listen_port" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableListenPort(v *int) *AppSettingCreate {
+	if v != nil {
+		_c.SetListenPort(*v)
+	}
+	return _c
+}
+
+// SetListenAddr sets the "listen_addr" field.
+func (_c *AppSettingCreate) SetListenAddr(v string) *AppSettingCreate {
+	_c.mutation.SetListenAddr(v)
+	return _c
+}
+
+// SetNillableListenAddr sets the "listen_addr" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableListenAddr(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetListenAddr(*v)
+	}
+	return _c
+}
+
+// SetRequireConnectedForReady sets the "require_connected_for_ready" field.
+func (_c *AppSettingCreate) SetRequireConnectedForReady(v bool) *AppSettingCreate {
+	_c.mutation.SetRequireConnectedForReady(v)
+	return _c
+}
+
+// SetNillableRequireConnectedForReady sets the "require_connected_for_ready" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableRequireConnectedForReady(v *bool) *AppSettingCreate {
+	if v != nil {
+		_c.SetRequireConnectedForReady(*v)
+	}
+	return _c
+}
+
+// SetStrictExtraArgs sets the "strict_extra_args" field.
+func (_c *AppSettingCreate) SetStrictExtraArgs(v bool) *AppSettingCreate {
+	_c.mutation.SetStrictExtraArgs(v)
+	return _c
+}
+
+// SetNillableStrictExtraArgs sets the "strict_extra_args" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableStrictExtraArgs(v *bool) *AppSettingCreate {
+	if v != nil {
+		_c.SetStrictExtraArgs(*v)
+	}
+	return _c
+}
+
+// SetMaxRestartsPerHour sets the "max_restarts_per_hour" field.
+func (_c *AppSettingCreate) SetMaxRestartsPerHour(v int) *AppSettingCreate {
+	_c.mutation.SetMaxRestartsPerHour(v)
+	return _c
+}
+
+// SetNillableMaxRestartsPerHour sets the "max_restarts_per_hour" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableMaxRestartsPerHour(v *int) *AppSettingCreate {
+	if v != nil {
+		_c.SetMaxRestartsPerHour(*v)
+	}
+	return _c
+}
+
+// SetMaxRuntime sets the "max_runtime" field.
+func (_c *AppSettingCreate) SetMaxRuntime(v string) *AppSettingCreate {
+	_c.mutation.SetMaxRuntime(v)
+	return _c
+}
+
+// SetNillableMaxRuntime sets the "max_runtime" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableMaxRuntime(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetMaxRuntime(*v)
+	}
+	return _c
+}
+
+// SetRequireConfirm sets the "require_confirm" field.
+func (_c *AppSettingCreate) SetRequireConfirm(v bool) *AppSettingCreate {
+	_c.mutation.SetRequireConfirm(v)
+	return _c
+}
+
+// SetNillableRequireConfirm sets the "require_confirm" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableRequireConfirm(v *bool) *AppSettingCreate {
+	if v != nil {
+		_c.SetRequireConfirm(*v)
+	}
+	return _c
+}
+
+// SetRestartJitter sets the "restart_jitter" field.
+func (_c *AppSettingCreate) SetRestartJitter(v int) *AppSettingCreate {
+	_c.mutation.SetRestartJitter(v)
+	return _c
+}
+
+// SetNillableRestartJitter sets the "restart_jitter" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableRestartJitter(v *int) *AppSettingCreate {
+	if v != nil {
+		_c.SetRestartJitter(*v)
+	}
+	return _c
+}
+
+// SetSoleLogSink sets the "sole_log_sink" field.
+func (_c *AppSettingCreate) SetSoleLogSink(v bool) *AppSettingCreate {
+	_c.mutation.SetSoleLogSink(v)
+	return _c
+}
+
+// SetNillableSoleLogSink sets the "sole_log_sink" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableSoleLogSink(v *bool) *AppSettingCreate {
+	if v != nil {
+		_c.SetSoleLogSink(*v)
+	}
+	return _c
+}
+
+// SetMaxProtocolSwitches sets the "max_protocol_switches" field.
+func (_c *AppSettingCreate) SetMaxProtocolSwitches(v int) *AppSettingCreate {
+	_c.mutation.SetMaxProtocolSwitches(v)
+	return _c
+}
+
+// SetNillableMaxProtocolSwitches sets the "max_protocol_switches" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableMaxProtocolSwitches(v *int) *AppSettingCreate {
+	if v != nil {
+		_c.SetMaxProtocolSwitches(*v)
+	}
+	return _c
+}
+
+// SetStatsPersistInterval sets the "stats_persist_interval" field.
+func (_c *AppSettingCreate) SetStatsPersistInterval(v string) *AppSettingCreate {
+	_c.mutation.SetStatsPersistInterval(v)
+	return _c
+}
+
+// SetNillableStatsPersistInterval sets the "stats_persist_interval" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableStatsPersistInterval(v *string) *AppSettingCreate {
+	if v != nil {
+		_c.SetStatsPersistInterval(*v)
+	}
+	return _c
+}
+
+// SetEdgeIPFallback sets the "edge_ip_fallback" field.
+func (_c *AppSettingCreate) SetEdgeIPFallback(v bool) *AppSettingCreate {
+	_c.mutation.SetEdgeIPFallback(v)
+	return _c
+}
+
+// SetNillableEdgeIPFallback sets the "edge_ip_fallback" field if the given value is not nil.
+func (_c *AppSettingCreate) SetNillableEdgeIPFallback(v *bool) *AppSettingCreate {
+	if v != nil {
+		_c.SetEdgeIPFallback(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *AppSettingCreate) SetCreatedAt(v time.Time) *AppSettingCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -583,10 +873,18 @@ func (_c *AppSettingCreate) defaults() {
 		v := appsetting.DefaultEdgeBindAddress
 		_c.mutation.SetEdgeBindAddress(v)
 	}
+	if _, ok := _c.mutation.EdgeInterface(); !ok {
+		v := appsetting.DefaultEdgeInterface
+		_c.mutation.SetEdgeInterface(v)
+	}
 	if _, ok := _c.mutation.PostQuantum(); !ok {
 		v := appsetting.DefaultPostQuantum
 		_c.mutation.SetPostQuantum(v)
 	}
+	if _, ok := _c.mutation.PostQuantumMode(); !ok {
+		v := appsetting.DefaultPostQuantumMode
+		_c.mutation.SetPostQuantumMode(v)
+	}
 	if _, ok := _c.mutation.NoTLSVerify(); !ok {
 		v := appsetting.DefaultNoTLSVerify
 		_c.mutation.SetNoTLSVerify(v)
@@ -647,6 +945,74 @@ func (_c *AppSettingCreate) defaults() {
 		v := appsetting.DefaultS3WebdavDedicatedTunnelHostname
 		_c.mutation.SetS3WebdavDedicatedTunnelHostname(v)
 	}
+	if _, ok := _c.mutation.ConfigFile(); !ok {
+		v := appsetting.DefaultConfigFile
+		_c.mutation.SetConfigFile(v)
+	}
+	if _, ok := _c.mutation.MetricsPollInterval(); !ok {
+		v := appsetting.DefaultMetricsPollInterval
+		_c.mutation.SetMetricsPollInterval(v)
+	}
+	if _, ok := _c.mutation.IdleTimeout(); !ok {
+		v := appsetting.DefaultIdleTimeout
+		_c.mutation.SetIdleTimeout(v)
+	}
+	if _, ok := _c.mutation.SchemaVersion(); !ok {
+		v := appsetting.DefaultSchemaVersion
+		_c.mutation.SetSchemaVersion(v)
+	}
+	if _, ok := _c.mutation.LazyStart(); !ok {
+		v := appsetting.DefaultLazyStart
+		_c.mutation.SetLazyStart(v)
+	}
+	if _, ok := _c.mutation.ListenPort(); !ok {
+		v := appsetting.DefaultListenPort
+		_c.mutation.SetListenPort(v)
+	}
+	if _, ok := _c.mutation.ListenAddr(); !ok {
+		v := appsetting.DefaultListenAddr
+		_c.mutation.SetListenAddr(v)
+	}
+	if _, ok := _c.mutation.RequireConnectedForReady(); !ok {
+		v := appsetting.DefaultRequireConnectedForReady
+		_c.mutation.SetRequireConnectedForReady(v)
+	}
+	if _, ok := _c.mutation.StrictExtraArgs(); !ok {
+		v := appsetting.DefaultStrictExtraArgs
+		_c.mutation.SetStrictExtraArgs(v)
+	}
+	if _, ok := _c.mutation.MaxRestartsPerHour(); !ok {
+		v := appsetting.DefaultMaxRestartsPerHour
+		_c.mutation.SetMaxRestartsPerHour(v)
+	}
+	if _, ok := _c.mutation.MaxRuntime(); !ok {
+		v := appsetting.DefaultMaxRuntime
+		_c.mutation.SetMaxRuntime(v)
+	}
+	if _, ok := _c.mutation.RequireConfirm(); !ok {
+		v := appsetting.DefaultRequireConfirm
+		_c.mutation.SetRequireConfirm(v)
+	}
+	if _, ok := _c.mutation.RestartJitter(); !ok {
+		v := appsetting.DefaultRestartJitter
+		_c.mutation.SetRestartJitter(v)
+	}
+	if _, ok := _c.mutation.SoleLogSink(); !ok {
+		v := appsetting.DefaultSoleLogSink
+		_c.mutation.SetSoleLogSink(v)
+	}
+	if _, ok := _c.mutation.MaxProtocolSwitches(); !ok {
+		v := appsetting.DefaultMaxProtocolSwitches
+		_c.mutation.SetMaxProtocolSwitches(v)
+	}
+	if _, ok := _c.mutation.StatsPersistInterval(); !ok {
+		v := appsetting.DefaultStatsPersistInterval
+		_c.mutation.SetStatsPersistInterval(v)
+	}
+	if _, ok := _c.mutation.EdgeIPFallback(); !ok {
+		v := appsetting.DefaultEdgeIPFallback
+		_c.mutation.SetEdgeIPFallback(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := appsetting.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -712,9 +1078,15 @@ func (_c *AppSettingCreate) check() error {
 	if _, ok := _c.mutation.EdgeBindAddress(); !ok {
 		return &ValidationError{Name: "edge_bind_address", err: errors.New(`ent: missing required field "AppSetting.edge_bind_address"`)}
 	}
+	if _, ok := _c.mutation.EdgeInterface(); !ok {
+		return &ValidationError{Name: "edge_interface", err: errors.New(`ent: missing required field "AppSetting.edge_interface"`)}
+	}
 	if _, ok := _c.mutation.PostQuantum(); !ok {
 		return &ValidationError{Name: "post_quantum", err: errors.New(`ent: missing required field "AppSetting.post_quantum"`)}
 	}
+	if _, ok := _c.mutation.PostQuantumMode(); !ok {
+		return &ValidationError{Name: "post_quantum_mode", err: errors.New(`ent: missing required field "AppSetting.post_quantum_mode"`)}
+	}
 	if _, ok := _c.mutation.NoTLSVerify(); !ok {
 		return &ValidationError{Name: "no_tls_verify", err: errors.New(`ent: missing required field "AppSetting.no_tls_verify"`)}
 	}
@@ -760,6 +1132,57 @@ func (_c *AppSettingCreate) check() error {
 	if _, ok := _c.mutation.S3WebdavDedicatedTunnelHostname(); !ok {
 		return &ValidationError{Name: "s3_webdav_dedicated_tunnel_hostname", err: errors.New(`ent: missing required field "AppSetting.s3_webdav_dedicated_tunnel_hostname"`)}
 	}
+	if _, ok := _c.mutation.ConfigFile(); !ok {
+		return &ValidationError{Name: "config_file", err: errors.New(`ent: missing required field "AppSetting.config_file"`)}
+	}
+	if _, ok := _c.mutation.MetricsPollInterval(); !ok {
+		return &ValidationError{Name: "metrics_poll_interval", err: errors.New(`ent: missing required field "AppSetting.metrics_poll_interval"`)}
+	}
+	if _, ok := _c.mutation.IdleTimeout(); !ok {
+		return &ValidationError{Name: "idle_timeout", err: errors.New(`ent: missing required field "AppSetting.idle_timeout"`)}
+	}
+	if _, ok := _c.mutation.SchemaVersion(); !ok {
+		return &ValidationError{Name: "schema_version", err: errors.New(`ent: missing required field "AppSetting.schema_version"`)}
+	}
+	if _, ok := _c.mutation.LazyStart(); !ok {
+		return &ValidationError{Name: "lazy_start", err: errors.New(`ent: missing required field "AppSetting.lazy_start"`)}
+	}
+	if _, ok := _c.mutation.ListenPort(); !ok {
+		return &ValidationError{Name: "listen_port", err: errors.New(`ent: missing required field "AppSetting.listen_port"`)}
+	}
+	if _, ok := _c.mutation.ListenAddr(); !ok {
+		return &ValidationError{Name: "listen_addr", err: errors.New(`ent: missing required field "AppSetting.listen_addr"`)}
+	}
+	if _, ok := _c.mutation.RequireConnectedForReady(); !ok {
+		return &ValidationError{Name: "require_connected_for_ready", err: errors.New(`ent: missing required field "AppSetting.require_connected_for_ready"`)}
+	}
+	if _, ok := _c.mutation.StrictExtraArgs(); !ok {
+		return &ValidationError{Name: "strict_extra_args", err: errors.New(`ent: missing required field "AppSetting.strict_extra_args"`)}
+	}
+	if _, ok := _c.mutation.MaxRestartsPerHour(); !ok {
+		return &ValidationError{Name: "max_restarts_per_hour", err: errors.New(`ent: missing required field "AppSetting.max_restarts_per_hour"`)}
+	}
+	if _, ok := _c.mutation.MaxRuntime(); !ok {
+		return &ValidationError{Name: "max_runtime", err: errors.New(`ent: missing required field "AppSetting.max_runtime"`)}
+	}
+	if _, ok := _c.mutation.RequireConfirm(); !ok {
+		return &ValidationError{Name: "require_confirm", err: errors.New(`ent: missing required field "AppSetting.require_confirm"`)}
+	}
+	if _, ok := _c.mutation.RestartJitter(); !ok {
+		return &ValidationError{Name: "restart_jitter", err: errors.New(`ent: missing required field "AppSetting.restart_jitter"`)}
+	}
+	if _, ok := _c.mutation.SoleLogSink(); !ok {
+		return &ValidationError{Name: "sole_log_sink", err: errors.New(`ent: missing required field "AppSetting.sole_log_sink"`)}
+	}
+	if _, ok := _c.mutation.MaxProtocolSwitches(); !ok {
+		return &ValidationError{Name: "max_protocol_switches", err: errors.New(`ent: missing required field "AppSetting.max_protocol_switches"`)}
+	}
+	if _, ok := _c.mutation.StatsPersistInterval(); !ok {
+		return &ValidationError{Name: "stats_persist_interval", err: errors.New(`ent: missing required field "AppSetting.stats_persist_interval"`)}
+	}
+	if _, ok := _c.mutation.EdgeIPFallback(); !ok {
+		return &ValidationError{Name: "edge_ip_fallback", err: errors.New(`ent: missing required field "AppSetting.edge_ip_fallback"`)}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AppSetting.created_at"`)}
 	}
@@ -856,10 +1279,18 @@ func (_c *AppSettingCreate) createSpec() (*AppSetting, *sqlgraph.CreateSpec) {
 		_spec.SetField(appsetting.FieldEdgeBindAddress, field.TypeString, value)
 		_node.EdgeBindAddress = value
 	}
+	if value, ok := _c.mutation.EdgeInterface(); ok {
+		_spec.SetField(appsetting.FieldEdgeInterface, field.TypeString, value)
+		_node.EdgeInterface = value
+	}
 	if value, ok := _c.mutation.PostQuantum(); ok {
 		_spec.SetField(appsetting.FieldPostQuantum, field.TypeBool, value)
 		_node.PostQuantum = value
 	}
+	if value, ok := _c.mutation.PostQuantumMode(); ok {
+		_spec.SetField(appsetting.FieldPostQuantumMode, field.TypeString, value)
+		_node.PostQuantumMode = value
+	}
 	if value, ok := _c.mutation.NoTLSVerify(); ok {
 		_spec.SetField(appsetting.FieldNoTLSVerify, field.TypeBool, value)
 		_node.NoTLSVerify = value
@@ -920,6 +1351,90 @@ func (_c *AppSettingCreate) createSpec() (*AppSetting, *sqlgraph.CreateSpec) {
 		_spec.SetField(appsetting.FieldS3WebdavDedicatedTunnelHostname, field.TypeString, value)
 		_node.S3WebdavDedicatedTunnelHostname = value
 	}
+	if value, ok := _c.mutation.ConfigFile(); ok {
+		_spec.SetField(appsetting.FieldConfigFile, field.TypeString, value)
+		_node.ConfigFile = value
+	}
+	if value, ok := _c.mutation.MetricsPollInterval(); ok {
+		_spec.SetField(appsetting.FieldMetricsPollInterval, field.TypeString, value)
+		_node.MetricsPollInterval = value
+	}
+	if value, ok := _c.mutation.Tags(); ok {
+		_spec.SetField(appsetting.FieldTags, field.TypeJSON, value)
+		_node.Tags = value
+	}
+	if value, ok := _c.mutation.IdleTimeout(); ok {
+		_spec.SetField(appsetting.FieldIdleTimeout, field.TypeString, value)
+		_node.IdleTimeout = value
+	}
+	if value, ok := _c.mutation.SchemaVersion(); ok {
+		_spec.SetField(appsetting.FieldSchemaVersion, field.TypeInt, value)
+		_node.SchemaVersion = value
+	}
+	if value, ok := _c.mutation.LazyStart(); ok {
+		_spec.SetField(appsetting.FieldLazyStart, field.TypeBool, value)
+		_node.LazyStart = value
+	}
+	if value, ok := _c.mutation.RetryablePatterns(); ok {
+		_spec.SetField(appsetting.FieldRetryablePatterns, field.TypeJSON, value)
+		_node.RetryablePatterns = value
+	}
+	if value, ok := _c.mutation.NonRetryablePatterns(); ok {
+		_spec.SetField(appsetting.FieldNonRetryablePatterns, field.TypeJSON, value)
+		_node.NonRetryablePatterns = value
+	}
+	if value, ok := _c.mutation.ProtocolOrder(); ok {
+		_spec.SetField(appsetting.FieldProtocolOrder, field.TypeJSON, value)
+		_node.ProtocolOrder = value
+	}
+	if value, ok := _c.mutation.ListenPort(); ok {
+		_spec.SetField(appsetting.FieldListenPort, field.TypeInt, value)
+		_node.ListenPort = value
+	}
+	if value, ok := _c.mutation.ListenAddr(); ok {
+		_spec.SetField(appsetting.FieldListenAddr, field.TypeString, value)
+		_node.ListenAddr = value
+	}
+	if value, ok := _c.mutation.RequireConnectedForReady(); ok {
+		_spec.SetField(appsetting.FieldRequireConnectedForReady, field.TypeBool, value)
+		_node.RequireConnectedForReady = value
+	}
+	if value, ok := _c.mutation.StrictExtraArgs(); ok {
+		_spec.SetField(appsetting.FieldStrictExtraArgs, field.TypeBool, value)
+		_node.StrictExtraArgs = value
+	}
+	if value, ok := _c.mutation.MaxRestartsPerHour(); ok {
+		_spec.SetField(appsetting.FieldMaxRestartsPerHour, field.TypeInt, value)
+		_node.MaxRestartsPerHour = value
+	}
+	if value, ok := _c.mutation.MaxRuntime(); ok {
+		_spec.SetField(appsetting.FieldMaxRuntime, field.TypeString, value)
+		_node.MaxRuntime = value
+	}
+	if value, ok := _c.mutation.RequireConfirm(); ok {
+		_spec.SetField(appsetting.FieldRequireConfirm, field.TypeBool, value)
+		_node.RequireConfirm = value
+	}
+	if value, ok := _c.mutation.RestartJitter(); ok {
+		_spec.SetField(appsetting.FieldRestartJitter, field.TypeInt, value)
+		_node.RestartJitter = value
+	}
+	if value, ok := _c.mutation.SoleLogSink(); ok {
+		_spec.SetField(appsetting.FieldSoleLogSink, field.TypeBool, value)
+		_node.SoleLogSink = value
+	}
+	if value, ok := _c.mutation.MaxProtocolSwitches(); ok {
+		_spec.SetField(appsetting.FieldMaxProtocolSwitches, field.TypeInt, value)
+		_node.MaxProtocolSwitches = value
+	}
+	if value, ok := _c.mutation.StatsPersistInterval(); ok {
+		_spec.SetField(appsetting.FieldStatsPersistInterval, field.TypeString, value)
+		_node.StatsPersistInterval = value
+	}
+	if value, ok := _c.mutation.EdgeIPFallback(); ok {
+		_spec.SetField(appsetting.FieldEdgeIPFallback, field.TypeBool, value)
+		_node.EdgeIPFallback = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(appsetting.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
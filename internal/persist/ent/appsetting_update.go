@@ -12,6 +12,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 )
 
@@ -266,6 +267,20 @@ func (_u *AppSettingUpdate) SetNillableEdgeBindAddress(v *string) *AppSettingUpd
 	return _u
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (_u *AppSettingUpdate) SetEdgeInterface(v string) *AppSettingUpdate {
+	_u.mutation.SetEdgeInterface(v)
+	return _u
+}
+
+// SetNillableEdgeInterface sets the "edge_interface" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableEdgeInterface(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetEdgeInterface(*v)
+	}
+	return _u
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (_u *AppSettingUpdate) SetPostQuantum(v bool) *AppSettingUpdate {
 	_u.mutation.SetPostQuantum(v)
@@ -280,6 +295,20 @@ func (_u *AppSettingUpdate) SetNillablePostQuantum(v *bool) *AppSettingUpdate {
 	return _u
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (_u *AppSettingUpdate) SetPostQuantumMode(v string) *AppSettingUpdate {
+	_u.mutation.SetPostQuantumMode(v)
+	return _u
+}
+
+// SetNillablePostQuantumMode sets the "post_quantum_mode" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillablePostQuantumMode(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetPostQuantumMode(*v)
+	}
+	return _u
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (_u *AppSettingUpdate) SetNoTLSVerify(v bool) *AppSettingUpdate {
 	_u.mutation.SetNoTLSVerify(v)
@@ -497,6 +526,345 @@ func (_u *AppSettingUpdate) SetNillableS3WebdavDedicatedTunnelHostname(v *string
 	return _u
 }
 
+// SetConfigFile sets the "config_file" field.
+func (_u *AppSettingUpdate) SetConfigFile(v string) *AppSettingUpdate {
+	_u.mutation.SetConfigFile(v)
+	return _u
+}
+
+// SetNillableConfigFile sets the "config_file" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableConfigFile(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetConfigFile(*v)
+	}
+	return _u
+}
+
+// SetMetricsPollInterval sets the "metrics_poll_interval" field.
+func (_u *AppSettingUpdate) SetMetricsPollInterval(v string) *AppSettingUpdate {
+	_u.mutation.SetMetricsPollInterval(v)
+	return _u
+}
+
+// SetNillableMetricsPollInterval sets the "metrics_poll_interval" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableMetricsPollInterval(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetMetricsPollInterval(*v)
+	}
+	return _u
+}
+
+// SetTags sets the "tags" field.
+func (_u *AppSettingUpdate) SetTags(v map[string]string) *AppSettingUpdate {
+	_u.mutation.SetTags(v)
+	return _u
+}
+
+// ClearTags clears the value of the "tags" field.
+func (_u *AppSettingUpdate) ClearTags() *AppSettingUpdate {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// SetIdleTimeout sets the "idle_timeout" field.
+func (_u *AppSettingUpdate) SetIdleTimeout(v string) *AppSettingUpdate {
+	_u.mutation.SetIdleTimeout(v)
+	return _u
+}
+
+// SetNillableIdleTimeout sets the "idle_timeout" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableIdleTimeout(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetIdleTimeout(*v)
+	}
+	return _u
+}
+
+// SetSchemaVersion sets the "schema_version" field.
+func (_u *AppSettingUpdate) SetSchemaVersion(v int) *AppSettingUpdate {
+	_u.mutation.ResetSchemaVersion()
+	_u.mutation.SetSchemaVersion(v)
+	return _u
+}
+
+// SetNillableSchemaVersion sets the "schema_version" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableSchemaVersion(v *int) *AppSettingUpdate {
+	if v != nil {
+		_u.SetSchemaVersion(*v)
+	}
+	return _u
+}
+
+// AddSchemaVersion adds value to the "schema_version" field.
+func (_u *AppSettingUpdate) AddSchemaVersion(v int) *AppSettingUpdate {
+	_u.mutation.AddSchemaVersion(v)
+	return _u
+}
+
+// SetLazyStart sets the "lazy_start" field.
+func (_u *AppSettingUpdate) SetLazyStart(v bool) *AppSettingUpdate {
+	_u.mutation.SetLazyStart(v)
+	return _u
+}
+
+// SetNillableLazyStart sets the "lazy_start" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableLazyStart(v *bool) *AppSettingUpdate {
+	if v != nil {
+		_u.SetLazyStart(*v)
+	}
+	return _u
+}
+
+// SetRetryablePatterns sets the "retryable_patterns" field.
+func (_u *AppSettingUpdate) SetRetryablePatterns(v []string) *AppSettingUpdate {
+	_u.mutation.SetRetryablePatterns(v)
+	return _u
+}
+
+// AppendRetryablePatterns appends value to the "retryable_patterns" field.
+func (_u *AppSettingUpdate) AppendRetryablePatterns(v []string) *AppSettingUpdate {
+	_u.mutation.AppendRetryablePatterns(v)
+	return _u
+}
+
+// ClearRetryablePatterns clears the value of the "retryable_patterns" field.
+func (_u *AppSettingUpdate) ClearRetryablePatterns() *AppSettingUpdate {
+	_u.mutation.ClearRetryablePatterns()
+	return _u
+}
+
+// SetNonRetryablePatterns sets the "non_retryable_patterns" field.
+func (_u *AppSettingUpdate) SetNonRetryablePatterns(v []string) *AppSettingUpdate {
+	_u.mutation.SetNonRetryablePatterns(v)
+	return _u
+}
+
+// AppendNonRetryablePatterns appends value to the "non_retryable_patterns" field.
+func (_u *AppSettingUpdate) AppendNonRetryablePatterns(v []string) *AppSettingUpdate {
+	_u.mutation.AppendNonRetryablePatterns(v)
+	return _u
+}
+
+// ClearNonRetryablePatterns clears the value of the "non_retryable_patterns" field.
+func (_u *AppSettingUpdate) ClearNonRetryablePatterns() *AppSettingUpdate {
+	_u.mutation.ClearNonRetryablePatterns()
+	return _u
+}
+
+// SetProtocolOrder sets the "protocol_order" field.
+func (_u *AppSettingUpdate) SetProtocolOrder(v []string) *AppSettingUpdate {
+	_u.mutation.SetProtocolOrder(v)
+	return _u
+}
+
+// AppendProtocolOrder appends value to the "protocol_order" field.
+func (_u *AppSettingUpdate) AppendProtocolOrder(v []string) *AppSettingUpdate {
+	_u.mutation.AppendProtocolOrder(v)
+	return _u
+}
+
+// ClearProtocolOrder clears the value of the "protocol_order" field.
+func (_u *AppSettingUpdate) ClearProtocolOrder() *AppSettingUpdate {
+	_u.mutation.ClearProtocolOrder()
+	return _u
+}
+
+// SetListenPort sets the "listen_port" field.
+func (_u *AppSettingUpdate) SetListenPort(v int) *AppSettingUpdate {
+	_u.mutation.ResetListenPort()
+	_u.mutation.SetListenPort(v)
+	return _u
+}
+
+// SetNillableListenPort sets the "listen_port" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableListenPort(v *int) *AppSettingUpdate {
+	if v != nil {
+		_u.SetListenPort(*v)
+	}
+	return _u
+}
+
+// AddListenPort adds value to the "listen_port" field.
+func (_u *AppSettingUpdate) AddListenPort(v int) *AppSettingUpdate {
+	_u.mutation.AddListenPort(v)
+	return _u
+}
+
+// SetListenAddr sets the "listen_addr" field.
+func (_u *AppSettingUpdate) SetListenAddr(v string) *AppSettingUpdate {
+	_u.mutation.SetListenAddr(v)
+	return _u
+}
+
+// SetNillableListenAddr sets the "listen_addr" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableListenAddr(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetListenAddr(*v)
+	}
+	return _u
+}
+
+// SetRequireConnectedForReady sets the "require_connected_for_ready" field.
+func (_u *AppSettingUpdate) SetRequireConnectedForReady(v bool) *AppSettingUpdate {
+	_u.mutation.SetRequireConnectedForReady(v)
+	return _u
+}
+
+// SetNillableRequireConnectedForReady sets the "require_connected_for_ready" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableRequireConnectedForReady(v *bool) *AppSettingUpdate {
+	if v != nil {
+		_u.SetRequireConnectedForReady(*v)
+	}
+	return _u
+}
+
+// SetStrictExtraArgs sets the "strict_extra_args" field.
+func (_u *AppSettingUpdate) SetStrictExtraArgs(v bool) *AppSettingUpdate {
+	_u.mutation.SetStrictExtraArgs(v)
+	return _u
+}
+
+// SetNillableStrictExtraArgs sets the "strict_extra_args" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableStrictExtraArgs(v *bool) *AppSettingUpdate {
+	if v != nil {
+		_u.SetStrictExtraArgs(*v)
+	}
+	return _u
+}
+
+// SetMaxRestartsPerHour sets the "max_restarts_per_hour" field.
+func (_u *AppSettingUpdate) SetMaxRestartsPerHour(v int) *AppSettingUpdate {
+	_u.mutation.ResetMaxRestartsPerHour()
+	_u.mutation.SetMaxRestartsPerHour(v)
+	return _u
+}
+
+// SetNillableMaxRestartsPerHour sets the "max_restarts_per_hour" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableMaxRestartsPerHour(v *int) *AppSettingUpdate {
+	if v != nil {
+		_u.SetMaxRestartsPerHour(*v)
+	}
+	return _u
+}
+
+// AddMaxRestartsPerHour adds value to the "max_restarts_per_hour" field.
+func (_u *AppSettingUpdate) AddMaxRestartsPerHour(v int) *AppSettingUpdate {
+	_u.mutation.AddMaxRestartsPerHour(v)
+	return _u
+}
+
+// SetMaxRuntime sets the "max_runtime" field.
+func (_u *AppSettingUpdate) SetMaxRuntime(v string) *AppSettingUpdate {
+	_u.mutation.SetMaxRuntime(v)
+	return _u
+}
+
+// SetNillableMaxRuntime sets the "max_runtime" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableMaxRuntime(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetMaxRuntime(*v)
+	}
+	return _u
+}
+
+// SetRequireConfirm sets the "require_confirm" field.
+func (_u *AppSettingUpdate) SetRequireConfirm(v bool) *AppSettingUpdate {
+	_u.mutation.SetRequireConfirm(v)
+	return _u
+}
+
+// SetNillableRequireConfirm sets the "require_confirm" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableRequireConfirm(v *bool) *AppSettingUpdate {
+	if v != nil {
+		_u.SetRequireConfirm(*v)
+	}
+	return _u
+}
+
+// SetRestartJitter sets the "restart_jitter" field.
+func (_u *AppSettingUpdate) SetRestartJitter(v int) *AppSettingUpdate {
+	_u.mutation.ResetRestartJitter()
+	_u.mutation.SetRestartJitter(v)
+	return _u
+}
+
+// SetNillableRestartJitter sets the "restart_jitter" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableRestartJitter(v *int) *AppSettingUpdate {
+	if v != nil {
+		_u.SetRestartJitter(*v)
+	}
+	return _u
+}
+
+// AddRestartJitter adds value to the "restart_jitter" field.
+func (_u *AppSettingUpdate) AddRestartJitter(v int) *AppSettingUpdate {
+	_u.mutation.AddRestartJitter(v)
+	return _u
+}
+
+// SetSoleLogSink sets the "sole_log_sink" field.
+func (_u *AppSettingUpdate) SetSoleLogSink(v bool) *AppSettingUpdate {
+	_u.mutation.SetSoleLogSink(v)
+	return _u
+}
+
+// SetNillableSoleLogSink sets the "sole_log_sink" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableSoleLogSink(v *bool) *AppSettingUpdate {
+	if v != nil {
+		_u.SetSoleLogSink(*v)
+	}
+	return _u
+}
+
+// SetMaxProtocolSwitches sets the "max_protocol_switches" field.
+func (_u *AppSettingUpdate) SetMaxProtocolSwitches(v int) *AppSettingUpdate {
+	_u.mutation.ResetMaxProtocolSwitches()
+	_u.mutation.SetMaxProtocolSwitches(v)
+	return _u
+}
+
+// SetNillableMaxProtocolSwitches sets the "max_protocol_switches" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableMaxProtocolSwitches(v *int) *AppSettingUpdate {
+	if v != nil {
+		_u.SetMaxProtocolSwitches(*v)
+	}
+	return _u
+}
+
+// AddMaxProtocolSwitches adds value to the "max_protocol_switches" field.
+func (_u *AppSettingUpdate) AddMaxProtocolSwitches(v int) *AppSettingUpdate {
+	_u.mutation.AddMaxProtocolSwitches(v)
+	return _u
+}
+
+// SetStatsPersistInterval sets the "stats_persist_interval" field.
+func (_u *AppSettingUpdate) SetStatsPersistInterval(v string) *AppSettingUpdate {
+	_u.mutation.SetStatsPersistInterval(v)
+	return _u
+}
+
+// SetNillableStatsPersistInterval sets the "stats_persist_interval" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableStatsPersistInterval(v *string) *AppSettingUpdate {
+	if v != nil {
+		_u.SetStatsPersistInterval(*v)
+	}
+	return _u
+}
+
+// SetEdgeIPFallback sets the "edge_ip_fallback" field.
+func (_u *AppSettingUpdate) SetEdgeIPFallback(v bool) *AppSettingUpdate {
+	_u.mutation.SetEdgeIPFallback(v)
+	return _u
+}
+
+// SetNillableEdgeIPFallback sets the "edge_ip_fallback" field if the given value is not nil.
+func (_u *AppSettingUpdate) SetNillableEdgeIPFallback(v *bool) *AppSettingUpdate {
+	if v != nil {
+		_u.SetEdgeIPFallback(*v)
+	}
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *AppSettingUpdate) SetUpdatedAt(v time.Time) *AppSettingUpdate {
 	_u.mutation.SetUpdatedAt(v)
@@ -620,9 +988,15 @@ func (_u *AppSettingUpdate) sqlSave(ctx context.Context) (_node int, err error)
 	if value, ok := _u.mutation.EdgeBindAddress(); ok {
 		_spec.SetField(appsetting.FieldEdgeBindAddress, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.EdgeInterface(); ok {
+		_spec.SetField(appsetting.FieldEdgeInterface, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.PostQuantum(); ok {
 		_spec.SetField(appsetting.FieldPostQuantum, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.PostQuantumMode(); ok {
+		_spec.SetField(appsetting.FieldPostQuantumMode, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.NoTLSVerify(); ok {
 		_spec.SetField(appsetting.FieldNoTLSVerify, field.TypeBool, value)
 	}
@@ -671,6 +1045,111 @@ func (_u *AppSettingUpdate) sqlSave(ctx context.Context) (_node int, err error)
 	if value, ok := _u.mutation.S3WebdavDedicatedTunnelHostname(); ok {
 		_spec.SetField(appsetting.FieldS3WebdavDedicatedTunnelHostname, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.ConfigFile(); ok {
+		_spec.SetField(appsetting.FieldConfigFile, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.MetricsPollInterval(); ok {
+		_spec.SetField(appsetting.FieldMetricsPollInterval, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Tags(); ok {
+		_spec.SetField(appsetting.FieldTags, field.TypeJSON, value)
+	}
+	if _u.mutation.TagsCleared() {
+		_spec.ClearField(appsetting.FieldTags, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IdleTimeout(); ok {
+		_spec.SetField(appsetting.FieldIdleTimeout, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SchemaVersion(); ok {
+		_spec.SetField(appsetting.FieldSchemaVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSchemaVersion(); ok {
+		_spec.AddField(appsetting.FieldSchemaVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.LazyStart(); ok {
+		_spec.SetField(appsetting.FieldLazyStart, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RetryablePatterns(); ok {
+		_spec.SetField(appsetting.FieldRetryablePatterns, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRetryablePatterns(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, appsetting.FieldRetryablePatterns, value)
+		})
+	}
+	if _u.mutation.RetryablePatternsCleared() {
+		_spec.ClearField(appsetting.FieldRetryablePatterns, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.NonRetryablePatterns(); ok {
+		_spec.SetField(appsetting.FieldNonRetryablePatterns, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedNonRetryablePatterns(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, appsetting.FieldNonRetryablePatterns, value)
+		})
+	}
+	if _u.mutation.NonRetryablePatternsCleared() {
+		_spec.ClearField(appsetting.FieldNonRetryablePatterns, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.ProtocolOrder(); ok {
+		_spec.SetField(appsetting.FieldProtocolOrder, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedProtocolOrder(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, appsetting.FieldProtocolOrder, value)
+		})
+	}
+	if _u.mutation.ProtocolOrderCleared() {
+		_spec.ClearField(appsetting.FieldProtocolOrder, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.ListenPort(); ok {
+		_spec.SetField(appsetting.FieldListenPort, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedListenPort(); ok {
+		_spec.AddField(appsetting.FieldListenPort, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ListenAddr(); ok {
+		_spec.SetField(appsetting.FieldListenAddr, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RequireConnectedForReady(); ok {
+		_spec.SetField(appsetting.FieldRequireConnectedForReady, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.StrictExtraArgs(); ok {
+		_spec.SetField(appsetting.FieldStrictExtraArgs, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxRestartsPerHour(); ok {
+		_spec.SetField(appsetting.FieldMaxRestartsPerHour, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxRestartsPerHour(); ok {
+		_spec.AddField(appsetting.FieldMaxRestartsPerHour, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxRuntime(); ok {
+		_spec.SetField(appsetting.FieldMaxRuntime, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RequireConfirm(); ok {
+		_spec.SetField(appsetting.FieldRequireConfirm, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RestartJitter(); ok {
+		_spec.SetField(appsetting.FieldRestartJitter, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRestartJitter(); ok {
+		_spec.AddField(appsetting.FieldRestartJitter, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.SoleLogSink(); ok {
+		_spec.SetField(appsetting.FieldSoleLogSink, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxProtocolSwitches(); ok {
+		_spec.SetField(appsetting.FieldMaxProtocolSwitches, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxProtocolSwitches(); ok {
+		_spec.AddField(appsetting.FieldMaxProtocolSwitches, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.StatsPersistInterval(); ok {
+		_spec.SetField(appsetting.FieldStatsPersistInterval, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.EdgeIPFallback(); ok {
+		_spec.SetField(appsetting.FieldEdgeIPFallback, field.TypeBool, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(appsetting.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -932,6 +1411,20 @@ func (_u *AppSettingUpdateOne) SetNillableEdgeBindAddress(v *string) *AppSetting
 	return _u
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (_u *AppSettingUpdateOne) SetEdgeInterface(v string) *AppSettingUpdateOne {
+	_u.mutation.SetEdgeInterface(v)
+	return _u
+}
+
+// SetNillableEdgeInterface sets the "edge_interface" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableEdgeInterface(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetEdgeInterface(*v)
+	}
+	return _u
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (_u *AppSettingUpdateOne) SetPostQuantum(v bool) *AppSettingUpdateOne {
 	_u.mutation.SetPostQuantum(v)
@@ -946,6 +1439,20 @@ func (_u *AppSettingUpdateOne) SetNillablePostQuantum(v *bool) *AppSettingUpdate
 	return _u
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (_u *AppSettingUpdateOne) SetPostQuantumMode(v string) *AppSettingUpdateOne {
+	_u.mutation.SetPostQuantumMode(v)
+	return _u
+}
+
+// SetNillablePostQuantumMode sets the "post_quantum_mode" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillablePostQuantumMode(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetPostQuantumMode(*v)
+	}
+	return _u
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (_u *AppSettingUpdateOne) SetNoTLSVerify(v bool) *AppSettingUpdateOne {
 	_u.mutation.SetNoTLSVerify(v)
@@ -1163,6 +1670,345 @@ func (_u *AppSettingUpdateOne) SetNillableS3WebdavDedicatedTunnelHostname(v *str
 	return _u
 }
 
+// SetConfigFile sets the "config_file" field.
+func (_u *AppSettingUpdateOne) SetConfigFile(v string) *AppSettingUpdateOne {
+	_u.mutation.SetConfigFile(v)
+	return _u
+}
+
+// SetNillableConfigFile sets the "config_file" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableConfigFile(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetConfigFile(*v)
+	}
+	return _u
+}
+
+// SetMetricsPollInterval sets the "metrics_poll_interval" field.
+func (_u *AppSettingUpdateOne) SetMetricsPollInterval(v string) *AppSettingUpdateOne {
+	_u.mutation.SetMetricsPollInterval(v)
+	return _u
+}
+
+// SetNillableMetricsPollInterval sets the "metrics_poll_interval" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableMetricsPollInterval(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetMetricsPollInterval(*v)
+	}
+	return _u
+}
+
+// SetTags sets the "tags" field.
+func (_u *AppSettingUpdateOne) SetTags(v map[string]string) *AppSettingUpdateOne {
+	_u.mutation.SetTags(v)
+	return _u
+}
+
+// ClearTags clears the value of the "tags" field.
+func (_u *AppSettingUpdateOne) ClearTags() *AppSettingUpdateOne {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// SetIdleTimeout sets the "idle_timeout" field.
+func (_u *AppSettingUpdateOne) SetIdleTimeout(v string) *AppSettingUpdateOne {
+	_u.mutation.SetIdleTimeout(v)
+	return _u
+}
+
+// SetNillableIdleTimeout sets the "idle_timeout" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableIdleTimeout(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetIdleTimeout(*v)
+	}
+	return _u
+}
+
+// SetSchemaVersion sets the "schema_version" field.
+func (_u *AppSettingUpdateOne) SetSchemaVersion(v int) *AppSettingUpdateOne {
+	_u.mutation.ResetSchemaVersion()
+	_u.mutation.SetSchemaVersion(v)
+	return _u
+}
+
+// SetNillableSchemaVersion sets the "schema_version" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableSchemaVersion(v *int) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetSchemaVersion(*v)
+	}
+	return _u
+}
+
+// AddSchemaVersion adds value to the "schema_version" field.
+func (_u *AppSettingUpdateOne) AddSchemaVersion(v int) *AppSettingUpdateOne {
+	_u.mutation.AddSchemaVersion(v)
+	return _u
+}
+
+// SetLazyStart sets the "lazy_start" field.
+func (_u *AppSettingUpdateOne) SetLazyStart(v bool) *AppSettingUpdateOne {
+	_u.mutation.SetLazyStart(v)
+	return _u
+}
+
+// SetNillableLazyStart sets the "lazy_start" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableLazyStart(v *bool) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetLazyStart(*v)
+	}
+	return _u
+}
+
+// SetRetryablePatterns sets the "retryable_patterns" field.
+func (_u *AppSettingUpdateOne) SetRetryablePatterns(v []string) *AppSettingUpdateOne {
+	_u.mutation.SetRetryablePatterns(v)
+	return _u
+}
+
+// AppendRetryablePatterns appends value to the "retryable_patterns" field.
+func (_u *AppSettingUpdateOne) AppendRetryablePatterns(v []string) *AppSettingUpdateOne {
+	_u.mutation.AppendRetryablePatterns(v)
+	return _u
+}
+
+// ClearRetryablePatterns clears the value of the "retryable_patterns" field.
+func (_u *AppSettingUpdateOne) ClearRetryablePatterns() *AppSettingUpdateOne {
+	_u.mutation.ClearRetryablePatterns()
+	return _u
+}
+
+// SetNonRetryablePatterns sets the "non_retryable_patterns" field.
+func (_u *AppSettingUpdateOne) SetNonRetryablePatterns(v []string) *AppSettingUpdateOne {
+	_u.mutation.SetNonRetryablePatterns(v)
+	return _u
+}
+
+// AppendNonRetryablePatterns appends value to the "non_retryable_patterns" field.
+func (_u *AppSettingUpdateOne) AppendNonRetryablePatterns(v []string) *AppSettingUpdateOne {
+	_u.mutation.AppendNonRetryablePatterns(v)
+	return _u
+}
+
+// ClearNonRetryablePatterns clears the value of the "non_retryable_patterns" field.
+func (_u *AppSettingUpdateOne) ClearNonRetryablePatterns() *AppSettingUpdateOne {
+	_u.mutation.ClearNonRetryablePatterns()
+	return _u
+}
+
+// SetProtocolOrder sets the "protocol_order" field.
+func (_u *AppSettingUpdateOne) SetProtocolOrder(v []string) *AppSettingUpdateOne {
+	_u.mutation.SetProtocolOrder(v)
+	return _u
+}
+
+// AppendProtocolOrder appends value to the "protocol_order" field.
+func (_u *AppSettingUpdateOne) AppendProtocolOrder(v []string) *AppSettingUpdateOne {
+	_u.mutation.AppendProtocolOrder(v)
+	return _u
+}
+
+// ClearProtocolOrder clears the value of the "protocol_order" field.
+func (_u *AppSettingUpdateOne) ClearProtocolOrder() *AppSettingUpdateOne {
+	_u.mutation.ClearProtocolOrder()
+	return _u
+}
+
+// SetListenPort sets the "listen_port" field.
+func (_u *AppSettingUpdateOne) SetListenPort(v int) *AppSettingUpdateOne {
+	_u.mutation.ResetListenPort()
+	_u.mutation.SetListenPort(v)
+	return _u
+}
+
+// SetNillableListenPort sets the "listen_port" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableListenPort(v *int) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetListenPort(*v)
+	}
+	return _u
+}
+
+// AddListenPort adds value to the "listen_port" field.
+func (_u *AppSettingUpdateOne) AddListenPort(v int) *AppSettingUpdateOne {
+	_u.mutation.AddListenPort(v)
+	return _u
+}
+
+// SetListenAddr sets the "listen_addr" field.
+func (_u *AppSettingUpdateOne) SetListenAddr(v string) *AppSettingUpdateOne {
+	_u.mutation.SetListenAddr(v)
+	return _u
+}
+
+// SetNillableListenAddr sets the "listen_addr" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableListenAddr(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetListenAddr(*v)
+	}
+	return _u
+}
+
+// SetRequireConnectedForReady sets the "require_connected_for_ready" field.
+func (_u *AppSettingUpdateOne) SetRequireConnectedForReady(v bool) *AppSettingUpdateOne {
+	_u.mutation.SetRequireConnectedForReady(v)
+	return _u
+}
+
+// SetNillableRequireConnectedForReady sets the "require_connected_for_ready" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableRequireConnectedForReady(v *bool) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetRequireConnectedForReady(*v)
+	}
+	return _u
+}
+
+// SetStrictExtraArgs sets the "strict_extra_args" field.
+func (_u *AppSettingUpdateOne) SetStrictExtraArgs(v bool) *AppSettingUpdateOne {
+	_u.mutation.SetStrictExtraArgs(v)
+	return _u
+}
+
+// SetNillableStrictExtraArgs sets the "strict_extra_args" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableStrictExtraArgs(v *bool) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetStrictExtraArgs(*v)
+	}
+	return _u
+}
+
+// SetMaxRestartsPerHour sets the "max_restarts_per_hour" field.
+func (_u *AppSettingUpdateOne) SetMaxRestartsPerHour(v int) *AppSettingUpdateOne {
+	_u.mutation.ResetMaxRestartsPerHour()
+	_u.mutation.SetMaxRestartsPerHour(v)
+	return _u
+}
+
+// SetNillableMaxRestartsPerHour sets the "max_restarts_per_hour" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableMaxRestartsPerHour(v *int) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetMaxRestartsPerHour(*v)
+	}
+	return _u
+}
+
+// AddMaxRestartsPerHour adds value to the "max_restarts_per_hour" field.
+func (_u *AppSettingUpdateOne) AddMaxRestartsPerHour(v int) *AppSettingUpdateOne {
+	_u.mutation.AddMaxRestartsPerHour(v)
+	return _u
+}
+
+// SetMaxRuntime sets the "max_runtime" field.
+func (_u *AppSettingUpdateOne) SetMaxRuntime(v string) *AppSettingUpdateOne {
+	_u.mutation.SetMaxRuntime(v)
+	return _u
+}
+
+// SetNillableMaxRuntime sets the "max_runtime" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableMaxRuntime(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetMaxRuntime(*v)
+	}
+	return _u
+}
+
+// SetRequireConfirm sets the "require_confirm" field.
+func (_u *AppSettingUpdateOne) SetRequireConfirm(v bool) *AppSettingUpdateOne {
+	_u.mutation.SetRequireConfirm(v)
+	return _u
+}
+
+// SetNillableRequireConfirm sets the "require_confirm" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableRequireConfirm(v *bool) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetRequireConfirm(*v)
+	}
+	return _u
+}
+
+// SetRestartJitter sets the "restart_jitter" field.
+func (_u *AppSettingUpdateOne) SetRestartJitter(v int) *AppSettingUpdateOne {
+	_u.mutation.ResetRestartJitter()
+	_u.mutation.SetRestartJitter(v)
+	return _u
+}
+
+// SetNillableRestartJitter sets the "restart_jitter" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableRestartJitter(v *int) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetRestartJitter(*v)
+	}
+	return _u
+}
+
+// AddRestartJitter adds value to the "restart_jitter" field.
+func (_u *AppSettingUpdateOne) AddRestartJitter(v int) *AppSettingUpdateOne {
+	_u.mutation.AddRestartJitter(v)
+	return _u
+}
+
+// SetSoleLogSink sets the "sole_log_sink" field.
+func (_u *AppSettingUpdateOne) SetSoleLogSink(v bool) *AppSettingUpdateOne {
+	_u.mutation.SetSoleLogSink(v)
+	return _u
+}
+
+// SetNillableSoleLogSink sets the "sole_log_sink" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableSoleLogSink(v *bool) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetSoleLogSink(*v)
+	}
+	return _u
+}
+
+// SetMaxProtocolSwitches sets the "max_protocol_switches" field.
+func (_u *AppSettingUpdateOne) SetMaxProtocolSwitches(v int) *AppSettingUpdateOne {
+	_u.mutation.ResetMaxProtocolSwitches()
+	_u.mutation.SetMaxProtocolSwitches(v)
+	return _u
+}
+
+// SetNillableMaxProtocolSwitches sets the "max_protocol_switches" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableMaxProtocolSwitches(v *int) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetMaxProtocolSwitches(*v)
+	}
+	return _u
+}
+
+// AddMaxProtocolSwitches adds value to the "max_protocol_switches" field.
+func (_u *AppSettingUpdateOne) AddMaxProtocolSwitches(v int) *AppSettingUpdateOne {
+	_u.mutation.AddMaxProtocolSwitches(v)
+	return _u
+}
+
+// SetStatsPersistInterval sets the "stats_persist_interval" field.
+func (_u *AppSettingUpdateOne) SetStatsPersistInterval(v string) *AppSettingUpdateOne {
+	_u.mutation.SetStatsPersistInterval(v)
+	return _u
+}
+
+// SetNillableStatsPersistInterval sets the "stats_persist_interval" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableStatsPersistInterval(v *string) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetStatsPersistInterval(*v)
+	}
+	return _u
+}
+
+// SetEdgeIPFallback sets the "edge_ip_fallback" field.
+func (_u *AppSettingUpdateOne) SetEdgeIPFallback(v bool) *AppSettingUpdateOne {
+	_u.mutation.SetEdgeIPFallback(v)
+	return _u
+}
+
+// SetNillableEdgeIPFallback sets the "edge_ip_fallback" field if the given value is not nil.
+func (_u *AppSettingUpdateOne) SetNillableEdgeIPFallback(v *bool) *AppSettingUpdateOne {
+	if v != nil {
+		_u.SetEdgeIPFallback(*v)
+	}
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *AppSettingUpdateOne) SetUpdatedAt(v time.Time) *AppSettingUpdateOne {
 	_u.mutation.SetUpdatedAt(v)
@@ -1316,9 +2162,15 @@ func (_u *AppSettingUpdateOne) sqlSave(ctx context.Context) (_node *AppSetting,
 	if value, ok := _u.mutation.EdgeBindAddress(); ok {
 		_spec.SetField(appsetting.FieldEdgeBindAddress, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.EdgeInterface(); ok {
+		_spec.SetField(appsetting.FieldEdgeInterface, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.PostQuantum(); ok {
 		_spec.SetField(appsetting.FieldPostQuantum, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.PostQuantumMode(); ok {
+		_spec.SetField(appsetting.FieldPostQuantumMode, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.NoTLSVerify(); ok {
 		_spec.SetField(appsetting.FieldNoTLSVerify, field.TypeBool, value)
 	}
@@ -1367,6 +2219,111 @@ func (_u *AppSettingUpdateOne) sqlSave(ctx context.Context) (_node *AppSetting,
 	if value, ok := _u.mutation.S3WebdavDedicatedTunnelHostname(); ok {
 		_spec.SetField(appsetting.FieldS3WebdavDedicatedTunnelHostname, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.ConfigFile(); ok {
+		_spec.SetField(appsetting.FieldConfigFile, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.MetricsPollInterval(); ok {
+		_spec.SetField(appsetting.FieldMetricsPollInterval, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Tags(); ok {
+		_spec.SetField(appsetting.FieldTags, field.TypeJSON, value)
+	}
+	if _u.mutation.TagsCleared() {
+		_spec.ClearField(appsetting.FieldTags, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IdleTimeout(); ok {
+		_spec.SetField(appsetting.FieldIdleTimeout, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.SchemaVersion(); ok {
+		_spec.SetField(appsetting.FieldSchemaVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSchemaVersion(); ok {
+		_spec.AddField(appsetting.FieldSchemaVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.LazyStart(); ok {
+		_spec.SetField(appsetting.FieldLazyStart, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RetryablePatterns(); ok {
+		_spec.SetField(appsetting.FieldRetryablePatterns, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRetryablePatterns(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, appsetting.FieldRetryablePatterns, value)
+		})
+	}
+	if _u.mutation.RetryablePatternsCleared() {
+		_spec.ClearField(appsetting.FieldRetryablePatterns, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.NonRetryablePatterns(); ok {
+		_spec.SetField(appsetting.FieldNonRetryablePatterns, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedNonRetryablePatterns(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, appsetting.FieldNonRetryablePatterns, value)
+		})
+	}
+	if _u.mutation.NonRetryablePatternsCleared() {
+		_spec.ClearField(appsetting.FieldNonRetryablePatterns, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.ProtocolOrder(); ok {
+		_spec.SetField(appsetting.FieldProtocolOrder, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedProtocolOrder(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, appsetting.FieldProtocolOrder, value)
+		})
+	}
+	if _u.mutation.ProtocolOrderCleared() {
+		_spec.ClearField(appsetting.FieldProtocolOrder, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.ListenPort(); ok {
+		_spec.SetField(appsetting.FieldListenPort, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedListenPort(); ok {
+		_spec.AddField(appsetting.FieldListenPort, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ListenAddr(); ok {
+		_spec.SetField(appsetting.FieldListenAddr, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RequireConnectedForReady(); ok {
+		_spec.SetField(appsetting.FieldRequireConnectedForReady, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.StrictExtraArgs(); ok {
+		_spec.SetField(appsetting.FieldStrictExtraArgs, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxRestartsPerHour(); ok {
+		_spec.SetField(appsetting.FieldMaxRestartsPerHour, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxRestartsPerHour(); ok {
+		_spec.AddField(appsetting.FieldMaxRestartsPerHour, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxRuntime(); ok {
+		_spec.SetField(appsetting.FieldMaxRuntime, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RequireConfirm(); ok {
+		_spec.SetField(appsetting.FieldRequireConfirm, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RestartJitter(); ok {
+		_spec.SetField(appsetting.FieldRestartJitter, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRestartJitter(); ok {
+		_spec.AddField(appsetting.FieldRestartJitter, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.SoleLogSink(); ok {
+		_spec.SetField(appsetting.FieldSoleLogSink, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MaxProtocolSwitches(); ok {
+		_spec.SetField(appsetting.FieldMaxProtocolSwitches, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxProtocolSwitches(); ok {
+		_spec.AddField(appsetting.FieldMaxProtocolSwitches, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.StatsPersistInterval(); ok {
+		_spec.SetField(appsetting.FieldStatsPersistInterval, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.EdgeIPFallback(); ok {
+		_spec.SetField(appsetting.FieldEdgeIPFallback, field.TypeBool, value)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(appsetting.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -28,7 +28,9 @@ var (
 		{Name: "log_json", Type: field.TypeBool, Default: false},
 		{Name: "edge_ip_version", Type: field.TypeString, Default: "auto"},
 		{Name: "edge_bind_address", Type: field.TypeString, Default: ""},
+		{Name: "edge_interface", Type: field.TypeString, Default: ""},
 		{Name: "post_quantum", Type: field.TypeBool, Default: false},
+		{Name: "post_quantum_mode", Type: field.TypeString, Default: ""},
 		{Name: "no_tls_verify", Type: field.TypeBool, Default: false},
 		{Name: "extra_args", Type: field.TypeString, Default: ""},
 		{Name: "active_tunnel_key", Type: field.TypeString, Default: "default"},
@@ -44,6 +46,27 @@ var (
 		{Name: "s3_webdav_dedicated_domain_mode", Type: field.TypeString, Default: "none"},
 		{Name: "s3_webdav_dedicated_custom_domain", Type: field.TypeString, Default: ""},
 		{Name: "s3_webdav_dedicated_tunnel_hostname", Type: field.TypeString, Default: ""},
+		{Name: "config_file", Type: field.TypeString, Default: ""},
+		{Name: "metrics_poll_interval", Type: field.TypeString, Default: "15s"},
+		{Name: "tags", Type: field.TypeJSON, Nullable: true},
+		{Name: "idle_timeout", Type: field.TypeString, Default: ""},
+		{Name: "schema_version", Type: field.TypeInt, Default: 1},
+		{Name: "lazy_start", Type: field.TypeBool, Default: false},
+		{Name: "retryable_patterns", Type: field.TypeJSON, Nullable: true},
+		{Name: "non_retryable_patterns", Type: field.TypeJSON, Nullable: true},
+		{Name: "protocol_order", Type: field.TypeJSON, Nullable: true},
+		{Name: "listen_port", Type: field.TypeInt, Default: 0},
+		{Name: "listen_addr", Type: field.TypeString, Default: ""},
+		{Name: "require_connected_for_ready", Type: field.TypeBool, Default: false},
+		{Name: "strict_extra_args", Type: field.TypeBool, Default: false},
+		{Name: "max_restarts_per_hour", Type: field.TypeInt, Default: 0},
+		{Name: "max_runtime", Type: field.TypeString, Default: ""},
+		{Name: "require_confirm", Type: field.TypeBool, Default: false},
+		{Name: "restart_jitter", Type: field.TypeInt, Default: 0},
+		{Name: "sole_log_sink", Type: field.TypeBool, Default: false},
+		{Name: "max_protocol_switches", Type: field.TypeInt, Default: 0},
+		{Name: "stats_persist_interval", Type: field.TypeString, Default: "1m"},
+		{Name: "edge_ip_fallback", Type: field.TypeBool, Default: false},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 	}
@@ -254,11 +277,13 @@ var (
 		{Name: "account_id", Type: field.TypeString, Default: ""},
 		{Name: "tunnel_id", Type: field.TypeString, Default: ""},
 		{Name: "auto_start", Type: field.TypeBool, Default: false},
+		{Name: "lazy_start", Type: field.TypeBool, Default: false},
 		{Name: "auto_restart", Type: field.TypeBool, Default: true},
 		{Name: "custom_tag", Type: field.TypeString, Default: ""},
 		{Name: "software_name", Type: field.TypeString, Default: "cfui"},
 		{Name: "protocol", Type: field.TypeString, Default: "auto"},
 		{Name: "grace_period", Type: field.TypeString, Default: "30s"},
+		{Name: "idle_timeout", Type: field.TypeString, Default: ""},
 		{Name: "region", Type: field.TypeString, Default: ""},
 		{Name: "retries", Type: field.TypeInt, Default: 5},
 		{Name: "metrics_enable", Type: field.TypeBool, Default: false},
@@ -268,9 +293,12 @@ var (
 		{Name: "log_json", Type: field.TypeBool, Default: false},
 		{Name: "edge_ip_version", Type: field.TypeString, Default: "auto"},
 		{Name: "edge_bind_address", Type: field.TypeString, Default: ""},
+		{Name: "edge_interface", Type: field.TypeString, Default: ""},
 		{Name: "post_quantum", Type: field.TypeBool, Default: false},
+		{Name: "post_quantum_mode", Type: field.TypeString, Default: ""},
 		{Name: "no_tls_verify", Type: field.TypeBool, Default: false},
 		{Name: "extra_args", Type: field.TypeString, Default: ""},
+		{Name: "tags", Type: field.TypeJSON, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 	}
@@ -73,7 +73,9 @@ type AppSettingMutation struct {
 	log_json                            *bool
 	edge_ip_version                     *string
 	edge_bind_address                   *string
+	edge_interface                      *string
 	post_quantum                        *bool
+	post_quantum_mode                   *string
 	no_tls_verify                       *bool
 	extra_args                          *string
 	active_tunnel_key                   *string
@@ -90,6 +92,35 @@ type AppSettingMutation struct {
 	s3_webdav_dedicated_domain_mode     *string
 	s3_webdav_dedicated_custom_domain   *string
 	s3_webdav_dedicated_tunnel_hostname *string
+	config_file                         *string
+	metrics_poll_interval               *string
+	tags                                *map[string]string
+	idle_timeout                        *string
+	schema_version                      *int
+	addschema_version                   *int
+	lazy_start                          *bool
+	retryable_patterns                  *[]string
+	appendretryable_patterns            []string
+	non_retryable_patterns              *[]string
+	appendnon_retryable_patterns        []string
+	protocol_order                      *[]string
+	appendprotocol_order                []string
+	listen_port                         *int
+	addlisten_port                      *int
+	listen_addr                         *string
+	require_connected_for_ready         *bool
+	strict_extra_args                   *bool
+	max_restarts_per_hour               *int
+	addmax_restarts_per_hour            *int
+	max_runtime                         *string
+	require_confirm                     *bool
+	restart_jitter                      *int
+	addrestart_jitter                   *int
+	sole_log_sink                       *bool
+	max_protocol_switches               *int
+	addmax_protocol_switches            *int
+	stats_persist_interval              *string
+	edge_ip_fallback                    *bool
 	created_at                          *time.Time
 	updated_at                          *time.Time
 	clearedFields                       map[string]struct{}
@@ -812,6 +843,42 @@ func (m *AppSettingMutation) ResetEdgeBindAddress() {
 	m.edge_bind_address = nil
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (m *AppSettingMutation) SetEdgeInterface(s string) {
+	m.edge_interface = &s
+}
+
+// EdgeInterface returns the value of the "edge_interface" field in the mutation.
+func (m *AppSettingMutation) EdgeInterface() (r string, exists bool) {
+	v := m.edge_interface
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEdgeInterface returns the old "edge_interface" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldEdgeInterface(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEdgeInterface is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEdgeInterface requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEdgeInterface: %w", err)
+	}
+	return oldValue.EdgeInterface, nil
+}
+
+// ResetEdgeInterface resets all changes to the "edge_interface" field.
+func (m *AppSettingMutation) ResetEdgeInterface() {
+	m.edge_interface = nil
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (m *AppSettingMutation) SetPostQuantum(b bool) {
 	m.post_quantum = &b
@@ -848,6 +915,42 @@ func (m *AppSettingMutation) ResetPostQuantum() {
 	m.post_quantum = nil
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (m *AppSettingMutation) SetPostQuantumMode(s string) {
+	m.post_quantum_mode = &s
+}
+
+// PostQuantumMode returns the value of the "post_quantum_mode" field in the mutation.
+func (m *AppSettingMutation) PostQuantumMode() (r string, exists bool) {
+	v := m.post_quantum_mode
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPostQuantumMode returns the old "post_quantum_mode" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldPostQuantumMode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPostQuantumMode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPostQuantumMode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPostQuantumMode: %w", err)
+	}
+	return oldValue.PostQuantumMode, nil
+}
+
+// ResetPostQuantumMode resets all changes to the "post_quantum_mode" field.
+func (m *AppSettingMutation) ResetPostQuantumMode() {
+	m.post_quantum_mode = nil
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (m *AppSettingMutation) SetNoTLSVerify(b bool) {
 	m.no_tls_verify = &b
@@ -1408,184 +1511,1146 @@ func (m *AppSettingMutation) ResetS3WebdavDedicatedTunnelHostname() {
 	m.s3_webdav_dedicated_tunnel_hostname = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *AppSettingMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetConfigFile sets the "config_file" field.
+func (m *AppSettingMutation) SetConfigFile(s string) {
+	m.config_file = &s
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *AppSettingMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// ConfigFile returns the value of the "config_file" field in the mutation.
+func (m *AppSettingMutation) ConfigFile() (r string, exists bool) {
+	v := m.config_file
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the AppSetting entity.
+// OldConfigFile returns the old "config_file" field's value of the AppSetting entity.
 // If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AppSettingMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *AppSettingMutation) OldConfigFile(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldConfigFile is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldConfigFile requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldConfigFile: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.ConfigFile, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *AppSettingMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetConfigFile resets all changes to the "config_file" field.
+func (m *AppSettingMutation) ResetConfigFile() {
+	m.config_file = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *AppSettingMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetMetricsPollInterval sets the "metrics_poll_interval" field.
+func (m *AppSettingMutation) SetMetricsPollInterval(s string) {
+	m.metrics_poll_interval = &s
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *AppSettingMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// MetricsPollInterval returns the value of the "metrics_poll_interval" field in the mutation.
+func (m *AppSettingMutation) MetricsPollInterval() (r string, exists bool) {
+	v := m.metrics_poll_interval
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the AppSetting entity.
+// OldMetricsPollInterval returns the old "metrics_poll_interval" field's value of the AppSetting entity.
 // If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AppSettingMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *AppSettingMutation) OldMetricsPollInterval(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldMetricsPollInterval is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldMetricsPollInterval requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldMetricsPollInterval: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.MetricsPollInterval, nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *AppSettingMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// ResetMetricsPollInterval resets all changes to the "metrics_poll_interval" field.
+func (m *AppSettingMutation) ResetMetricsPollInterval() {
+	m.metrics_poll_interval = nil
 }
 
-// Where appends a list predicates to the AppSettingMutation builder.
-func (m *AppSettingMutation) Where(ps ...predicate.AppSetting) {
-	m.predicates = append(m.predicates, ps...)
+// SetTags sets the "tags" field.
+func (m *AppSettingMutation) SetTags(value map[string]string) {
+	m.tags = &value
 }
 
-// WhereP appends storage-level predicates to the AppSettingMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *AppSettingMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.AppSetting, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// Tags returns the value of the "tags" field in the mutation.
+func (m *AppSettingMutation) Tags() (r map[string]string, exists bool) {
+	v := m.tags
+	if v == nil {
+		return
 	}
-	m.Where(p...)
+	return *v, true
 }
 
-// Op returns the operation name.
-func (m *AppSettingMutation) Op() Op {
-	return m.op
+// OldTags returns the old "tags" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldTags(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTags is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTags requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTags: %w", err)
+	}
+	return oldValue.Tags, nil
 }
 
-// SetOp allows setting the mutation operation.
-func (m *AppSettingMutation) SetOp(op Op) {
-	m.op = op
+// ClearTags clears the value of the "tags" field.
+func (m *AppSettingMutation) ClearTags() {
+	m.tags = nil
+	m.clearedFields[appsetting.FieldTags] = struct{}{}
 }
 
-// Type returns the node type of this mutation (AppSetting).
-func (m *AppSettingMutation) Type() string {
-	return m.typ
+// TagsCleared returns if the "tags" field was cleared in this mutation.
+func (m *AppSettingMutation) TagsCleared() bool {
+	_, ok := m.clearedFields[appsetting.FieldTags]
+	return ok
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *AppSettingMutation) Fields() []string {
-	fields := make([]string, 0, 34)
-	if m.key != nil {
-		fields = append(fields, appsetting.FieldKey)
+// ResetTags resets all changes to the "tags" field.
+func (m *AppSettingMutation) ResetTags() {
+	m.tags = nil
+	delete(m.clearedFields, appsetting.FieldTags)
+}
+
+// SetIdleTimeout sets the "idle_timeout" field.
+func (m *AppSettingMutation) SetIdleTimeout(s string) {
+	m.idle_timeout = &s
+}
+
+// IdleTimeout returns the value of the "idle_timeout" field in the mutation.
+func (m *AppSettingMutation) IdleTimeout() (r string, exists bool) {
+	v := m.idle_timeout
+	if v == nil {
+		return
 	}
-	if m.auto_start != nil {
-		fields = append(fields, appsetting.FieldAutoStart)
+	return *v, true
+}
+
+// OldIdleTimeout returns the old "idle_timeout" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldIdleTimeout(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIdleTimeout is only allowed on UpdateOne operations")
 	}
-	if m.auto_restart != nil {
-		fields = append(fields, appsetting.FieldAutoRestart)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIdleTimeout requires an ID field in the mutation")
 	}
-	if m.custom_tag != nil {
-		fields = append(fields, appsetting.FieldCustomTag)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIdleTimeout: %w", err)
 	}
-	if m.software_name != nil {
-		fields = append(fields, appsetting.FieldSoftwareName)
+	return oldValue.IdleTimeout, nil
+}
+
+// ResetIdleTimeout resets all changes to the "idle_timeout" field.
+func (m *AppSettingMutation) ResetIdleTimeout() {
+	m.idle_timeout = nil
+}
+
+// SetSchemaVersion sets the "schema_version" field.
+func (m *AppSettingMutation) SetSchemaVersion(i int) {
+	m.schema_version = &i
+	m.addschema_version = nil
+}
+
+// SchemaVersion returns the value of the "schema_version" field in the mutation.
+func (m *AppSettingMutation) SchemaVersion() (r int, exists bool) {
+	v := m.schema_version
+	if v == nil {
+		return
 	}
-	if m.protocol != nil {
-		fields = append(fields, appsetting.FieldProtocol)
+	return *v, true
+}
+
+// OldSchemaVersion returns the old "schema_version" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldSchemaVersion(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSchemaVersion is only allowed on UpdateOne operations")
 	}
-	if m.grace_period != nil {
-		fields = append(fields, appsetting.FieldGracePeriod)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSchemaVersion requires an ID field in the mutation")
 	}
-	if m.region != nil {
-		fields = append(fields, appsetting.FieldRegion)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSchemaVersion: %w", err)
 	}
-	if m.retries != nil {
-		fields = append(fields, appsetting.FieldRetries)
+	return oldValue.SchemaVersion, nil
+}
+
+// AddSchemaVersion adds i to the "schema_version" field.
+func (m *AppSettingMutation) AddSchemaVersion(i int) {
+	if m.addschema_version != nil {
+		*m.addschema_version += i
+	} else {
+		m.addschema_version = &i
 	}
-	if m.metrics_enable != nil {
-		fields = append(fields, appsetting.FieldMetricsEnable)
+}
+
+// AddedSchemaVersion returns the value that was added to the "schema_version" field in this mutation.
+func (m *AppSettingMutation) AddedSchemaVersion() (r int, exists bool) {
+	v := m.addschema_version
+	if v == nil {
+		return
 	}
-	if m.metrics_port != nil {
-		fields = append(fields, appsetting.FieldMetricsPort)
+	return *v, true
+}
+
+// ResetSchemaVersion resets all changes to the "schema_version" field.
+func (m *AppSettingMutation) ResetSchemaVersion() {
+	m.schema_version = nil
+	m.addschema_version = nil
+}
+
+// SetLazyStart sets the "lazy_start" field.
+func (m *AppSettingMutation) SetLazyStart(b bool) {
+	m.lazy_start = &b
+}
+
+// LazyStart returns the value of the "lazy_start" field in the mutation.
+func (m *AppSettingMutation) LazyStart() (r bool, exists bool) {
+	v := m.lazy_start
+	if v == nil {
+		return
 	}
-	if m.log_level != nil {
-		fields = append(fields, appsetting.FieldLogLevel)
+	return *v, true
+}
+
+// OldLazyStart returns the old "lazy_start" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldLazyStart(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLazyStart is only allowed on UpdateOne operations")
 	}
-	if m.log_file != nil {
-		fields = append(fields, appsetting.FieldLogFile)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLazyStart requires an ID field in the mutation")
 	}
-	if m.log_json != nil {
-		fields = append(fields, appsetting.FieldLogJSON)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLazyStart: %w", err)
 	}
-	if m.edge_ip_version != nil {
-		fields = append(fields, appsetting.FieldEdgeIPVersion)
+	return oldValue.LazyStart, nil
+}
+
+// ResetLazyStart resets all changes to the "lazy_start" field.
+func (m *AppSettingMutation) ResetLazyStart() {
+	m.lazy_start = nil
+}
+
+// SetRetryablePatterns sets the "retryable_patterns" field.
+func (m *AppSettingMutation) SetRetryablePatterns(s []string) {
+	m.retryable_patterns = &s
+	m.appendretryable_patterns = nil
+}
+
+// RetryablePatterns returns the value of the "retryable_patterns" field in the mutation.
+func (m *AppSettingMutation) RetryablePatterns() (r []string, exists bool) {
+	v := m.retryable_patterns
+	if v == nil {
+		return
 	}
-	if m.edge_bind_address != nil {
-		fields = append(fields, appsetting.FieldEdgeBindAddress)
+	return *v, true
+}
+
+// OldRetryablePatterns returns the old "retryable_patterns" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldRetryablePatterns(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRetryablePatterns is only allowed on UpdateOne operations")
 	}
-	if m.post_quantum != nil {
-		fields = append(fields, appsetting.FieldPostQuantum)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRetryablePatterns requires an ID field in the mutation")
 	}
-	if m.no_tls_verify != nil {
-		fields = append(fields, appsetting.FieldNoTLSVerify)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRetryablePatterns: %w", err)
 	}
-	if m.extra_args != nil {
-		fields = append(fields, appsetting.FieldExtraArgs)
+	return oldValue.RetryablePatterns, nil
+}
+
+// AppendRetryablePatterns adds s to the "retryable_patterns" field.
+func (m *AppSettingMutation) AppendRetryablePatterns(s []string) {
+	m.appendretryable_patterns = append(m.appendretryable_patterns, s...)
+}
+
+// AppendedRetryablePatterns returns the list of values that were appended to the "retryable_patterns" field in this mutation.
+func (m *AppSettingMutation) AppendedRetryablePatterns() ([]string, bool) {
+	if len(m.appendretryable_patterns) == 0 {
+		return nil, false
 	}
-	if m.active_tunnel_key != nil {
-		fields = append(fields, appsetting.FieldActiveTunnelKey)
+	return m.appendretryable_patterns, true
+}
+
+// ClearRetryablePatterns clears the value of the "retryable_patterns" field.
+func (m *AppSettingMutation) ClearRetryablePatterns() {
+	m.retryable_patterns = nil
+	m.appendretryable_patterns = nil
+	m.clearedFields[appsetting.FieldRetryablePatterns] = struct{}{}
+}
+
+// RetryablePatternsCleared returns if the "retryable_patterns" field was cleared in this mutation.
+func (m *AppSettingMutation) RetryablePatternsCleared() bool {
+	_, ok := m.clearedFields[appsetting.FieldRetryablePatterns]
+	return ok
+}
+
+// ResetRetryablePatterns resets all changes to the "retryable_patterns" field.
+func (m *AppSettingMutation) ResetRetryablePatterns() {
+	m.retryable_patterns = nil
+	m.appendretryable_patterns = nil
+	delete(m.clearedFields, appsetting.FieldRetryablePatterns)
+}
+
+// SetNonRetryablePatterns sets the "non_retryable_patterns" field.
+func (m *AppSettingMutation) SetNonRetryablePatterns(s []string) {
+	m.non_retryable_patterns = &s
+	m.appendnon_retryable_patterns = nil
+}
+
+// NonRetryablePatterns returns the value of the "non_retryable_patterns" field in the mutation.
+func (m *AppSettingMutation) NonRetryablePatterns() (r []string, exists bool) {
+	v := m.non_retryable_patterns
+	if v == nil {
+		return
 	}
-	if m.mcp_enabled != nil {
-		fields = append(fields, appsetting.FieldMcpEnabled)
+	return *v, true
+}
+
+// OldNonRetryablePatterns returns the old "non_retryable_patterns" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldNonRetryablePatterns(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNonRetryablePatterns is only allowed on UpdateOne operations")
 	}
-	if m.oauth_client_id != nil {
-		fields = append(fields, appsetting.FieldOauthClientID)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNonRetryablePatterns requires an ID field in the mutation")
 	}
-	if m.oauth_relay_callback_url != nil {
-		fields = append(fields, appsetting.FieldOauthRelayCallbackURL)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNonRetryablePatterns: %w", err)
 	}
-	if m.s3_webdav_enabled != nil {
-		fields = append(fields, appsetting.FieldS3WebdavEnabled)
+	return oldValue.NonRetryablePatterns, nil
+}
+
+// AppendNonRetryablePatterns adds s to the "non_retryable_patterns" field.
+func (m *AppSettingMutation) AppendNonRetryablePatterns(s []string) {
+	m.appendnon_retryable_patterns = append(m.appendnon_retryable_patterns, s...)
+}
+
+// AppendedNonRetryablePatterns returns the list of values that were appended to the "non_retryable_patterns" field in this mutation.
+func (m *AppSettingMutation) AppendedNonRetryablePatterns() ([]string, bool) {
+	if len(m.appendnon_retryable_patterns) == 0 {
+		return nil, false
+	}
+	return m.appendnon_retryable_patterns, true
+}
+
+// ClearNonRetryablePatterns clears the value of the "non_retryable_patterns" field.
+func (m *AppSettingMutation) ClearNonRetryablePatterns() {
+	m.non_retryable_patterns = nil
+	m.appendnon_retryable_patterns = nil
+	m.clearedFields[appsetting.FieldNonRetryablePatterns] = struct{}{}
+}
+
+// NonRetryablePatternsCleared returns if the "non_retryable_patterns" field was cleared in this mutation.
+func (m *AppSettingMutation) NonRetryablePatternsCleared() bool {
+	_, ok := m.clearedFields[appsetting.FieldNonRetryablePatterns]
+	return ok
+}
+
+// ResetNonRetryablePatterns resets all changes to the "non_retryable_patterns" field.
+func (m *AppSettingMutation) ResetNonRetryablePatterns() {
+	m.non_retryable_patterns = nil
+	m.appendnon_retryable_patterns = nil
+	delete(m.clearedFields, appsetting.FieldNonRetryablePatterns)
+}
+
+// SetProtocolOrder sets the "protocol_order" field.
+func (m *AppSettingMutation) SetProtocolOrder(s []string) {
+	m.protocol_order = &s
+	m.appendprotocol_order = nil
+}
+
+// ProtocolOrder returns the value of the "protocol_order" field in the mutation.
+func (m *AppSettingMutation) ProtocolOrder() (r []string, exists bool) {
+	v := m.protocol_order
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProtocolOrder returns the old "protocol_order" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldProtocolOrder(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProtocolOrder is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProtocolOrder requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProtocolOrder: %w", err)
+	}
+	return oldValue.ProtocolOrder, nil
+}
+
+// AppendProtocolOrder adds s to the "protocol_order" field.
+func (m *AppSettingMutation) AppendProtocolOrder(s []string) {
+	m.appendprotocol_order = append(m.appendprotocol_order, s...)
+}
+
+// AppendedProtocolOrder returns the list of values that were appended to the "protocol_order" field in this mutation.
+func (m *AppSettingMutation) AppendedProtocolOrder() ([]string, bool) {
+	if len(m.appendprotocol_order) == 0 {
+		return nil, false
+	}
+	return m.appendprotocol_order, true
+}
+
+// ClearProtocolOrder clears the value of the "protocol_order" field.
+func (m *AppSettingMutation) ClearProtocolOrder() {
+	m.protocol_order = nil
+	m.appendprotocol_order = nil
+	m.clearedFields[appsetting.FieldProtocolOrder] = struct{}{}
+}
+
+// ProtocolOrderCleared returns if the "protocol_order" field was cleared in this mutation.
+func (m *AppSettingMutation) ProtocolOrderCleared() bool {
+	_, ok := m.clearedFields[appsetting.FieldProtocolOrder]
+	return ok
+}
+
+// ResetProtocolOrder resets all changes to the "protocol_order" field.
+func (m *AppSettingMutation) ResetProtocolOrder() {
+	m.protocol_order = nil
+	m.appendprotocol_order = nil
+	delete(m.clearedFields, appsetting.FieldProtocolOrder)
+}
+
+// SetListenPort sets the "listen_port" field.
+func (m *AppSettingMutation) SetListenPort(i int) {
+	m.listen_port = &i
+	m.addlisten_port = nil
+}
+
+// ListenPort returns the value of the "listen_port" field in the mutation.
+func (m *AppSettingMutation) ListenPort() (r int, exists bool) {
+	v := m.listen_port
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldListenPort returns the old "listen_port" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldListenPort(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldListenPort is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldListenPort requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldListenPort: %w", err)
+	}
+	return oldValue.ListenPort, nil
+}
+
+// AddListenPort adds i to the "listen_port" field.
+func (m *AppSettingMutation) AddListenPort(i int) {
+	if m.addlisten_port != nil {
+		*m.addlisten_port += i
+	} else {
+		m.addlisten_port = &i
+	}
+}
+
+// AddedListenPort returns the value that was added to the "listen_port" field in this mutation.
+func (m *AppSettingMutation) AddedListenPort() (r int, exists bool) {
+	v := m.addlisten_port
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetListenPort resets all changes to the "listen_port" field.
+func (m *AppSettingMutation) ResetListenPort() {
+	m.listen_port = nil
+	m.addlisten_port = nil
+}
+
+// SetListenAddr sets the "listen_addr" field.
+func (m *AppSettingMutation) SetListenAddr(s string) {
+	m.listen_addr = &s
+}
+
+// ListenAddr returns the value of the "listen_addr" field in the mutation.
+func (m *AppSettingMutation) ListenAddr() (r string, exists bool) {
+	v := m.listen_addr
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldListenAddr returns the old "listen_addr" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldListenAddr(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldListenAddr is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldListenAddr requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldListenAddr: %w", err)
+	}
+	return oldValue.ListenAddr, nil
+}
+
+// ResetListenAddr resets all changes to the "listen_addr" field.
+func (m *AppSettingMutation) ResetListenAddr() {
+	m.listen_addr = nil
+}
+
+// SetRequireConnectedForReady sets the "require_connected_for_ready" field.
+func (m *AppSettingMutation) SetRequireConnectedForReady(b bool) {
+	m.require_connected_for_ready = &b
+}
+
+// RequireConnectedForReady returns the value of the "require_connected_for_ready" field in the mutation.
+func (m *AppSettingMutation) RequireConnectedForReady() (r bool, exists bool) {
+	v := m.require_connected_for_ready
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequireConnectedForReady returns the old "require_connected_for_ready" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldRequireConnectedForReady(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequireConnectedForReady is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequireConnectedForReady requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequireConnectedForReady: %w", err)
+	}
+	return oldValue.RequireConnectedForReady, nil
+}
+
+// ResetRequireConnectedForReady resets all changes to the "require_connected_for_ready" field.
+func (m *AppSettingMutation) ResetRequireConnectedForReady() {
+	m.require_connected_for_ready = nil
+}
+
+// SetStrictExtraArgs sets the "strict_extra_args" field.
+func (m *AppSettingMutation) SetStrictExtraArgs(b bool) {
+	m.strict_extra_args = &b
+}
+
+// StrictExtraArgs returns the value of the "strict_extra_args" field in the mutation.
+func (m *AppSettingMutation) StrictExtraArgs() (r bool, exists bool) {
+	v := m.strict_extra_args
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStrictExtraArgs returns the old "strict_extra_args" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldStrictExtraArgs(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStrictExtraArgs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStrictExtraArgs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStrictExtraArgs: %w", err)
+	}
+	return oldValue.StrictExtraArgs, nil
+}
+
+// ResetStrictExtraArgs resets all changes to the "strict_extra_args" field.
+func (m *AppSettingMutation) ResetStrictExtraArgs() {
+	m.strict_extra_args = nil
+}
+
+// SetMaxRestartsPerHour sets the "max_restarts_per_hour" field.
+func (m *AppSettingMutation) SetMaxRestartsPerHour(i int) {
+	m.max_restarts_per_hour = &i
+	m.addmax_restarts_per_hour = nil
+}
+
+// MaxRestartsPerHour returns the value of the "max_restarts_per_hour" field in the mutation.
+func (m *AppSettingMutation) MaxRestartsPerHour() (r int, exists bool) {
+	v := m.max_restarts_per_hour
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxRestartsPerHour returns the old "max_restarts_per_hour" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldMaxRestartsPerHour(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxRestartsPerHour is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxRestartsPerHour requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxRestartsPerHour: %w", err)
+	}
+	return oldValue.MaxRestartsPerHour, nil
+}
+
+// AddMaxRestartsPerHour adds i to the "max_restarts_per_hour" field.
+func (m *AppSettingMutation) AddMaxRestartsPerHour(i int) {
+	if m.addmax_restarts_per_hour != nil {
+		*m.addmax_restarts_per_hour += i
+	} else {
+		m.addmax_restarts_per_hour = &i
+	}
+}
+
+// AddedMaxRestartsPerHour returns the value that was added to the "max_restarts_per_hour" field in this mutation.
+func (m *AppSettingMutation) AddedMaxRestartsPerHour() (r int, exists bool) {
+	v := m.addmax_restarts_per_hour
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxRestartsPerHour resets all changes to the "max_restarts_per_hour" field.
+func (m *AppSettingMutation) ResetMaxRestartsPerHour() {
+	m.max_restarts_per_hour = nil
+	m.addmax_restarts_per_hour = nil
+}
+
+// SetMaxRuntime sets the "max_runtime" field.
+func (m *AppSettingMutation) SetMaxRuntime(s string) {
+	m.max_runtime = &s
+}
+
+// MaxRuntime returns the value of the "max_runtime" field in the mutation.
+func (m *AppSettingMutation) MaxRuntime() (r string, exists bool) {
+	v := m.max_runtime
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxRuntime returns the old "max_runtime" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldMaxRuntime(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxRuntime is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxRuntime requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxRuntime: %w", err)
+	}
+	return oldValue.MaxRuntime, nil
+}
+
+// ResetMaxRuntime resets all changes to the "max_runtime" field.
+func (m *AppSettingMutation) ResetMaxRuntime() {
+	m.max_runtime = nil
+}
+
+// SetRequireConfirm sets the "require_confirm" field.
+func (m *AppSettingMutation) SetRequireConfirm(b bool) {
+	m.require_confirm = &b
+}
+
+// RequireConfirm returns the value of the "require_confirm" field in the mutation.
+func (m *AppSettingMutation) RequireConfirm() (r bool, exists bool) {
+	v := m.require_confirm
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequireConfirm returns the old "require_confirm" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldRequireConfirm(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequireConfirm is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequireConfirm requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequireConfirm: %w", err)
+	}
+	return oldValue.RequireConfirm, nil
+}
+
+// ResetRequireConfirm resets all changes to the "require_confirm" field.
+func (m *AppSettingMutation) ResetRequireConfirm() {
+	m.require_confirm = nil
+}
+
+// SetRestartJitter sets the "restart_jitter" field.
+func (m *AppSettingMutation) SetRestartJitter(i int) {
+	m.restart_jitter = &i
+	m.addrestart_jitter = nil
+}
+
+// RestartJitter returns the value of the "restart_jitter" field in the mutation.
+func (m *AppSettingMutation) RestartJitter() (r int, exists bool) {
+	v := m.restart_jitter
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRestartJitter returns the old "restart_jitter" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldRestartJitter(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRestartJitter is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRestartJitter requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRestartJitter: %w", err)
+	}
+	return oldValue.RestartJitter, nil
+}
+
+// AddRestartJitter adds i to the "restart_jitter" field.
+func (m *AppSettingMutation) AddRestartJitter(i int) {
+	if m.addrestart_jitter != nil {
+		*m.addrestart_jitter += i
+	} else {
+		m.addrestart_jitter = &i
+	}
+}
+
+// AddedRestartJitter returns the value that was added to the "restart_jitter" field in this mutation.
+func (m *AppSettingMutation) AddedRestartJitter() (r int, exists bool) {
+	v := m.addrestart_jitter
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRestartJitter resets all changes to the "restart_jitter" field.
+func (m *AppSettingMutation) ResetRestartJitter() {
+	m.restart_jitter = nil
+	m.addrestart_jitter = nil
+}
+
+// SetSoleLogSink sets the "sole_log_sink" field.
+func (m *AppSettingMutation) SetSoleLogSink(b bool) {
+	m.sole_log_sink = &b
+}
+
+// SoleLogSink returns the value of the "sole_log_sink" field in the mutation.
+func (m *AppSettingMutation) SoleLogSink() (r bool, exists bool) {
+	v := m.sole_log_sink
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSoleLogSink returns the old "sole_log_sink" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldSoleLogSink(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSoleLogSink is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSoleLogSink requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSoleLogSink: %w", err)
+	}
+	return oldValue.SoleLogSink, nil
+}
+
+// ResetSoleLogSink resets all changes to the "sole_log_sink" field.
+func (m *AppSettingMutation) ResetSoleLogSink() {
+	m.sole_log_sink = nil
+}
+
+// SetMaxProtocolSwitches sets the "max_protocol_switches" field.
+func (m *AppSettingMutation) SetMaxProtocolSwitches(i int) {
+	m.max_protocol_switches = &i
+	m.addmax_protocol_switches = nil
+}
+
+// MaxProtocolSwitches returns the value of the "max_protocol_switches" field in the mutation.
+func (m *AppSettingMutation) MaxProtocolSwitches() (r int, exists bool) {
+	v := m.max_protocol_switches
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxProtocolSwitches returns the old "max_protocol_switches" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldMaxProtocolSwitches(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxProtocolSwitches is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxProtocolSwitches requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxProtocolSwitches: %w", err)
+	}
+	return oldValue.MaxProtocolSwitches, nil
+}
+
+// AddMaxProtocolSwitches adds i to the "max_protocol_switches" field.
+func (m *AppSettingMutation) AddMaxProtocolSwitches(i int) {
+	if m.addmax_protocol_switches != nil {
+		*m.addmax_protocol_switches += i
+	} else {
+		m.addmax_protocol_switches = &i
+	}
+}
+
+// AddedMaxProtocolSwitches returns the value that was added to the "max_protocol_switches" field in this mutation.
+func (m *AppSettingMutation) AddedMaxProtocolSwitches() (r int, exists bool) {
+	v := m.addmax_protocol_switches
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxProtocolSwitches resets all changes to the "max_protocol_switches" field.
+func (m *AppSettingMutation) ResetMaxProtocolSwitches() {
+	m.max_protocol_switches = nil
+	m.addmax_protocol_switches = nil
+}
+
+// SetStatsPersistInterval sets the "stats_persist_interval" field.
+func (m *AppSettingMutation) SetStatsPersistInterval(s string) {
+	m.stats_persist_interval = &s
+}
+
+// StatsPersistInterval returns the value of the "stats_persist_interval" field in the mutation.
+func (m *AppSettingMutation) StatsPersistInterval() (r string, exists bool) {
+	v := m.stats_persist_interval
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatsPersistInterval returns the old "stats_persist_interval" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldStatsPersistInterval(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatsPersistInterval is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatsPersistInterval requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatsPersistInterval: %w", err)
+	}
+	return oldValue.StatsPersistInterval, nil
+}
+
+// ResetStatsPersistInterval resets all changes to the "stats_persist_interval" field.
+func (m *AppSettingMutation) ResetStatsPersistInterval() {
+	m.stats_persist_interval = nil
+}
+
+// SetEdgeIPFallback sets the "edge_ip_fallback" field.
+func (m *AppSettingMutation) SetEdgeIPFallback(b bool) {
+	m.edge_ip_fallback = &b
+}
+
+// EdgeIPFallback returns the value of the "edge_ip_fallback" field in the mutation.
+func (m *AppSettingMutation) EdgeIPFallback() (r bool, exists bool) {
+	v := m.edge_ip_fallback
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEdgeIPFallback returns the old "edge_ip_fallback" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldEdgeIPFallback(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEdgeIPFallback is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEdgeIPFallback requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEdgeIPFallback: %w", err)
+	}
+	return oldValue.EdgeIPFallback, nil
+}
+
+// ResetEdgeIPFallback resets all changes to the "edge_ip_fallback" field.
+func (m *AppSettingMutation) ResetEdgeIPFallback() {
+	m.edge_ip_fallback = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *AppSettingMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *AppSettingMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *AppSettingMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *AppSettingMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *AppSettingMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the AppSetting entity.
+// If the AppSetting object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AppSettingMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *AppSettingMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// Where appends a list predicates to the AppSettingMutation builder.
+func (m *AppSettingMutation) Where(ps ...predicate.AppSetting) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the AppSettingMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *AppSettingMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AppSetting, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *AppSettingMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *AppSettingMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (AppSetting).
+func (m *AppSettingMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *AppSettingMutation) Fields() []string {
+	fields := make([]string, 0, 57)
+	if m.key != nil {
+		fields = append(fields, appsetting.FieldKey)
+	}
+	if m.auto_start != nil {
+		fields = append(fields, appsetting.FieldAutoStart)
+	}
+	if m.auto_restart != nil {
+		fields = append(fields, appsetting.FieldAutoRestart)
+	}
+	if m.custom_tag != nil {
+		fields = append(fields, appsetting.FieldCustomTag)
+	}
+	if m.software_name != nil {
+		fields = append(fields, appsetting.FieldSoftwareName)
+	}
+	if m.protocol != nil {
+		fields = append(fields, appsetting.FieldProtocol)
+	}
+	if m.grace_period != nil {
+		fields = append(fields, appsetting.FieldGracePeriod)
+	}
+	if m.region != nil {
+		fields = append(fields, appsetting.FieldRegion)
+	}
+	if m.retries != nil {
+		fields = append(fields, appsetting.FieldRetries)
+	}
+	if m.metrics_enable != nil {
+		fields = append(fields, appsetting.FieldMetricsEnable)
+	}
+	if m.metrics_port != nil {
+		fields = append(fields, appsetting.FieldMetricsPort)
+	}
+	if m.log_level != nil {
+		fields = append(fields, appsetting.FieldLogLevel)
+	}
+	if m.log_file != nil {
+		fields = append(fields, appsetting.FieldLogFile)
+	}
+	if m.log_json != nil {
+		fields = append(fields, appsetting.FieldLogJSON)
+	}
+	if m.edge_ip_version != nil {
+		fields = append(fields, appsetting.FieldEdgeIPVersion)
+	}
+	if m.edge_bind_address != nil {
+		fields = append(fields, appsetting.FieldEdgeBindAddress)
+	}
+	if m.edge_interface != nil {
+		fields = append(fields, appsetting.FieldEdgeInterface)
+	}
+	if m.post_quantum != nil {
+		fields = append(fields, appsetting.FieldPostQuantum)
+	}
+	if m.post_quantum_mode != nil {
+		fields = append(fields, appsetting.FieldPostQuantumMode)
+	}
+	if m.no_tls_verify != nil {
+		fields = append(fields, appsetting.FieldNoTLSVerify)
+	}
+	if m.extra_args != nil {
+		fields = append(fields, appsetting.FieldExtraArgs)
+	}
+	if m.active_tunnel_key != nil {
+		fields = append(fields, appsetting.FieldActiveTunnelKey)
+	}
+	if m.mcp_enabled != nil {
+		fields = append(fields, appsetting.FieldMcpEnabled)
+	}
+	if m.oauth_client_id != nil {
+		fields = append(fields, appsetting.FieldOauthClientID)
+	}
+	if m.oauth_relay_callback_url != nil {
+		fields = append(fields, appsetting.FieldOauthRelayCallbackURL)
+	}
+	if m.s3_webdav_enabled != nil {
+		fields = append(fields, appsetting.FieldS3WebdavEnabled)
 	}
 	if m.s3_webdav_active_key != nil {
 		fields = append(fields, appsetting.FieldS3WebdavActiveKey)
@@ -1611,6 +2676,69 @@ func (m *AppSettingMutation) Fields() []string {
 	if m.s3_webdav_dedicated_tunnel_hostname != nil {
 		fields = append(fields, appsetting.FieldS3WebdavDedicatedTunnelHostname)
 	}
+	if m.config_file != nil {
+		fields = append(fields, appsetting.FieldConfigFile)
+	}
+	if m.metrics_poll_interval != nil {
+		fields = append(fields, appsetting.FieldMetricsPollInterval)
+	}
+	if m.tags != nil {
+		fields = append(fields, appsetting.FieldTags)
+	}
+	if m.idle_timeout != nil {
+		fields = append(fields, appsetting.FieldIdleTimeout)
+	}
+	if m.schema_version != nil {
+		fields = append(fields, appsetting.FieldSchemaVersion)
+	}
+	if m.lazy_start != nil {
+		fields = append(fields, appsetting.FieldLazyStart)
+	}
+	if m.retryable_patterns != nil {
+		fields = append(fields, appsetting.FieldRetryablePatterns)
+	}
+	if m.non_retryable_patterns != nil {
+		fields = append(fields, appsetting.FieldNonRetryablePatterns)
+	}
+	if m.protocol_order != nil {
+		fields = append(fields, appsetting.FieldProtocolOrder)
+	}
+	if m.listen_port != nil {
+		fields = append(fields, appsetting.FieldListenPort)
+	}
+	if m.listen_addr != nil {
+		fields = append(fields, appsetting.FieldListenAddr)
+	}
+	if m.require_connected_for_ready != nil {
+		fields = append(fields, appsetting.FieldRequireConnectedForReady)
+	}
+	if m.strict_extra_args != nil {
+		fields = append(fields, appsetting.FieldStrictExtraArgs)
+	}
+	if m.max_restarts_per_hour != nil {
+		fields = append(fields, appsetting.FieldMaxRestartsPerHour)
+	}
+	if m.max_runtime != nil {
+		fields = append(fields, appsetting.FieldMaxRuntime)
+	}
+	if m.require_confirm != nil {
+		fields = append(fields, appsetting.FieldRequireConfirm)
+	}
+	if m.restart_jitter != nil {
+		fields = append(fields, appsetting.FieldRestartJitter)
+	}
+	if m.sole_log_sink != nil {
+		fields = append(fields, appsetting.FieldSoleLogSink)
+	}
+	if m.max_protocol_switches != nil {
+		fields = append(fields, appsetting.FieldMaxProtocolSwitches)
+	}
+	if m.stats_persist_interval != nil {
+		fields = append(fields, appsetting.FieldStatsPersistInterval)
+	}
+	if m.edge_ip_fallback != nil {
+		fields = append(fields, appsetting.FieldEdgeIPFallback)
+	}
 	if m.created_at != nil {
 		fields = append(fields, appsetting.FieldCreatedAt)
 	}
@@ -1657,8 +2785,12 @@ func (m *AppSettingMutation) Field(name string) (ent.Value, bool) {
 		return m.EdgeIPVersion()
 	case appsetting.FieldEdgeBindAddress:
 		return m.EdgeBindAddress()
+	case appsetting.FieldEdgeInterface:
+		return m.EdgeInterface()
 	case appsetting.FieldPostQuantum:
 		return m.PostQuantum()
+	case appsetting.FieldPostQuantumMode:
+		return m.PostQuantumMode()
 	case appsetting.FieldNoTLSVerify:
 		return m.NoTLSVerify()
 	case appsetting.FieldExtraArgs:
@@ -1689,6 +2821,48 @@ func (m *AppSettingMutation) Field(name string) (ent.Value, bool) {
 		return m.S3WebdavDedicatedCustomDomain()
 	case appsetting.FieldS3WebdavDedicatedTunnelHostname:
 		return m.S3WebdavDedicatedTunnelHostname()
+	case appsetting.FieldConfigFile:
+		return m.ConfigFile()
+	case appsetting.FieldMetricsPollInterval:
+		return m.MetricsPollInterval()
+	case appsetting.FieldTags:
+		return m.Tags()
+	case appsetting.FieldIdleTimeout:
+		return m.IdleTimeout()
+	case appsetting.FieldSchemaVersion:
+		return m.SchemaVersion()
+	case appsetting.FieldLazyStart:
+		return m.LazyStart()
+	case appsetting.FieldRetryablePatterns:
+		return m.RetryablePatterns()
+	case appsetting.FieldNonRetryablePatterns:
+		return m.NonRetryablePatterns()
+	case appsetting.FieldProtocolOrder:
+		return m.ProtocolOrder()
+	case appsetting.FieldListenPort:
+		return m.ListenPort()
+	case appsetting.FieldListenAddr:
+		return m.ListenAddr()
+	case appsetting.FieldRequireConnectedForReady:
+		return m.RequireConnectedForReady()
+	case appsetting.FieldStrictExtraArgs:
+		return m.StrictExtraArgs()
+	case appsetting.FieldMaxRestartsPerHour:
+		return m.MaxRestartsPerHour()
+	case appsetting.FieldMaxRuntime:
+		return m.MaxRuntime()
+	case appsetting.FieldRequireConfirm:
+		return m.RequireConfirm()
+	case appsetting.FieldRestartJitter:
+		return m.RestartJitter()
+	case appsetting.FieldSoleLogSink:
+		return m.SoleLogSink()
+	case appsetting.FieldMaxProtocolSwitches:
+		return m.MaxProtocolSwitches()
+	case appsetting.FieldStatsPersistInterval:
+		return m.StatsPersistInterval()
+	case appsetting.FieldEdgeIPFallback:
+		return m.EdgeIPFallback()
 	case appsetting.FieldCreatedAt:
 		return m.CreatedAt()
 	case appsetting.FieldUpdatedAt:
@@ -1734,8 +2908,12 @@ func (m *AppSettingMutation) OldField(ctx context.Context, name string) (ent.Val
 		return m.OldEdgeIPVersion(ctx)
 	case appsetting.FieldEdgeBindAddress:
 		return m.OldEdgeBindAddress(ctx)
+	case appsetting.FieldEdgeInterface:
+		return m.OldEdgeInterface(ctx)
 	case appsetting.FieldPostQuantum:
 		return m.OldPostQuantum(ctx)
+	case appsetting.FieldPostQuantumMode:
+		return m.OldPostQuantumMode(ctx)
 	case appsetting.FieldNoTLSVerify:
 		return m.OldNoTLSVerify(ctx)
 	case appsetting.FieldExtraArgs:
@@ -1766,6 +2944,48 @@ func (m *AppSettingMutation) OldField(ctx context.Context, name string) (ent.Val
 		return m.OldS3WebdavDedicatedCustomDomain(ctx)
 	case appsetting.FieldS3WebdavDedicatedTunnelHostname:
 		return m.OldS3WebdavDedicatedTunnelHostname(ctx)
+	case appsetting.FieldConfigFile:
+		return m.OldConfigFile(ctx)
+	case appsetting.FieldMetricsPollInterval:
+		return m.OldMetricsPollInterval(ctx)
+	case appsetting.FieldTags:
+		return m.OldTags(ctx)
+	case appsetting.FieldIdleTimeout:
+		return m.OldIdleTimeout(ctx)
+	case appsetting.FieldSchemaVersion:
+		return m.OldSchemaVersion(ctx)
+	case appsetting.FieldLazyStart:
+		return m.OldLazyStart(ctx)
+	case appsetting.FieldRetryablePatterns:
+		return m.OldRetryablePatterns(ctx)
+	case appsetting.FieldNonRetryablePatterns:
+		return m.OldNonRetryablePatterns(ctx)
+	case appsetting.FieldProtocolOrder:
+		return m.OldProtocolOrder(ctx)
+	case appsetting.FieldListenPort:
+		return m.OldListenPort(ctx)
+	case appsetting.FieldListenAddr:
+		return m.OldListenAddr(ctx)
+	case appsetting.FieldRequireConnectedForReady:
+		return m.OldRequireConnectedForReady(ctx)
+	case appsetting.FieldStrictExtraArgs:
+		return m.OldStrictExtraArgs(ctx)
+	case appsetting.FieldMaxRestartsPerHour:
+		return m.OldMaxRestartsPerHour(ctx)
+	case appsetting.FieldMaxRuntime:
+		return m.OldMaxRuntime(ctx)
+	case appsetting.FieldRequireConfirm:
+		return m.OldRequireConfirm(ctx)
+	case appsetting.FieldRestartJitter:
+		return m.OldRestartJitter(ctx)
+	case appsetting.FieldSoleLogSink:
+		return m.OldSoleLogSink(ctx)
+	case appsetting.FieldMaxProtocolSwitches:
+		return m.OldMaxProtocolSwitches(ctx)
+	case appsetting.FieldStatsPersistInterval:
+		return m.OldStatsPersistInterval(ctx)
+	case appsetting.FieldEdgeIPFallback:
+		return m.OldEdgeIPFallback(ctx)
 	case appsetting.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	case appsetting.FieldUpdatedAt:
@@ -1891,6 +3111,13 @@ func (m *AppSettingMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetEdgeBindAddress(v)
 		return nil
+	case appsetting.FieldEdgeInterface:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEdgeInterface(v)
+		return nil
 	case appsetting.FieldPostQuantum:
 		v, ok := value.(bool)
 		if !ok {
@@ -1898,6 +3125,13 @@ func (m *AppSettingMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPostQuantum(v)
 		return nil
+	case appsetting.FieldPostQuantumMode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPostQuantumMode(v)
+		return nil
 	case appsetting.FieldNoTLSVerify:
 		v, ok := value.(bool)
 		if !ok {
@@ -1912,96 +3146,243 @@ func (m *AppSettingMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetExtraArgs(v)
 		return nil
-	case appsetting.FieldActiveTunnelKey:
-		v, ok := value.(string)
+	case appsetting.FieldActiveTunnelKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActiveTunnelKey(v)
+		return nil
+	case appsetting.FieldMcpEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMcpEnabled(v)
+		return nil
+	case appsetting.FieldOauthClientID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOauthClientID(v)
+		return nil
+	case appsetting.FieldOauthRelayCallbackURL:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOauthRelayCallbackURL(v)
+		return nil
+	case appsetting.FieldS3WebdavEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavEnabled(v)
+		return nil
+	case appsetting.FieldS3WebdavActiveKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavActiveKey(v)
+		return nil
+	case appsetting.FieldS3WebdavAccessMode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavAccessMode(v)
+		return nil
+	case appsetting.FieldS3WebdavDedicatedBindHost:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavDedicatedBindHost(v)
+		return nil
+	case appsetting.FieldS3WebdavDedicatedPort:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavDedicatedPort(v)
+		return nil
+	case appsetting.FieldS3WebdavDedicatedAutoStart:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavDedicatedAutoStart(v)
+		return nil
+	case appsetting.FieldS3WebdavDedicatedDomainMode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavDedicatedDomainMode(v)
+		return nil
+	case appsetting.FieldS3WebdavDedicatedCustomDomain:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavDedicatedCustomDomain(v)
+		return nil
+	case appsetting.FieldS3WebdavDedicatedTunnelHostname:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetS3WebdavDedicatedTunnelHostname(v)
+		return nil
+	case appsetting.FieldConfigFile:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetConfigFile(v)
+		return nil
+	case appsetting.FieldMetricsPollInterval:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMetricsPollInterval(v)
+		return nil
+	case appsetting.FieldTags:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTags(v)
+		return nil
+	case appsetting.FieldIdleTimeout:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIdleTimeout(v)
+		return nil
+	case appsetting.FieldSchemaVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSchemaVersion(v)
+		return nil
+	case appsetting.FieldLazyStart:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLazyStart(v)
+		return nil
+	case appsetting.FieldRetryablePatterns:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRetryablePatterns(v)
+		return nil
+	case appsetting.FieldNonRetryablePatterns:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNonRetryablePatterns(v)
+		return nil
+	case appsetting.FieldProtocolOrder:
+		v, ok := value.([]string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetActiveTunnelKey(v)
+		m.SetProtocolOrder(v)
 		return nil
-	case appsetting.FieldMcpEnabled:
-		v, ok := value.(bool)
+	case appsetting.FieldListenPort:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetMcpEnabled(v)
+		m.SetListenPort(v)
 		return nil
-	case appsetting.FieldOauthClientID:
+	case appsetting.FieldListenAddr:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetOauthClientID(v)
+		m.SetListenAddr(v)
 		return nil
-	case appsetting.FieldOauthRelayCallbackURL:
-		v, ok := value.(string)
+	case appsetting.FieldRequireConnectedForReady:
+		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetOauthRelayCallbackURL(v)
+		m.SetRequireConnectedForReady(v)
 		return nil
-	case appsetting.FieldS3WebdavEnabled:
+	case appsetting.FieldStrictExtraArgs:
 		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavEnabled(v)
+		m.SetStrictExtraArgs(v)
 		return nil
-	case appsetting.FieldS3WebdavActiveKey:
-		v, ok := value.(string)
+	case appsetting.FieldMaxRestartsPerHour:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavActiveKey(v)
+		m.SetMaxRestartsPerHour(v)
 		return nil
-	case appsetting.FieldS3WebdavAccessMode:
+	case appsetting.FieldMaxRuntime:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavAccessMode(v)
+		m.SetMaxRuntime(v)
 		return nil
-	case appsetting.FieldS3WebdavDedicatedBindHost:
-		v, ok := value.(string)
+	case appsetting.FieldRequireConfirm:
+		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavDedicatedBindHost(v)
+		m.SetRequireConfirm(v)
 		return nil
-	case appsetting.FieldS3WebdavDedicatedPort:
+	case appsetting.FieldRestartJitter:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavDedicatedPort(v)
+		m.SetRestartJitter(v)
 		return nil
-	case appsetting.FieldS3WebdavDedicatedAutoStart:
+	case appsetting.FieldSoleLogSink:
 		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavDedicatedAutoStart(v)
+		m.SetSoleLogSink(v)
 		return nil
-	case appsetting.FieldS3WebdavDedicatedDomainMode:
-		v, ok := value.(string)
+	case appsetting.FieldMaxProtocolSwitches:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavDedicatedDomainMode(v)
+		m.SetMaxProtocolSwitches(v)
 		return nil
-	case appsetting.FieldS3WebdavDedicatedCustomDomain:
+	case appsetting.FieldStatsPersistInterval:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavDedicatedCustomDomain(v)
+		m.SetStatsPersistInterval(v)
 		return nil
-	case appsetting.FieldS3WebdavDedicatedTunnelHostname:
-		v, ok := value.(string)
+	case appsetting.FieldEdgeIPFallback:
+		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetS3WebdavDedicatedTunnelHostname(v)
+		m.SetEdgeIPFallback(v)
 		return nil
 	case appsetting.FieldCreatedAt:
 		v, ok := value.(time.Time)
@@ -2034,6 +3415,21 @@ func (m *AppSettingMutation) AddedFields() []string {
 	if m.adds3_webdav_dedicated_port != nil {
 		fields = append(fields, appsetting.FieldS3WebdavDedicatedPort)
 	}
+	if m.addschema_version != nil {
+		fields = append(fields, appsetting.FieldSchemaVersion)
+	}
+	if m.addlisten_port != nil {
+		fields = append(fields, appsetting.FieldListenPort)
+	}
+	if m.addmax_restarts_per_hour != nil {
+		fields = append(fields, appsetting.FieldMaxRestartsPerHour)
+	}
+	if m.addrestart_jitter != nil {
+		fields = append(fields, appsetting.FieldRestartJitter)
+	}
+	if m.addmax_protocol_switches != nil {
+		fields = append(fields, appsetting.FieldMaxProtocolSwitches)
+	}
 	return fields
 }
 
@@ -2048,6 +3444,16 @@ func (m *AppSettingMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedMetricsPort()
 	case appsetting.FieldS3WebdavDedicatedPort:
 		return m.AddedS3WebdavDedicatedPort()
+	case appsetting.FieldSchemaVersion:
+		return m.AddedSchemaVersion()
+	case appsetting.FieldListenPort:
+		return m.AddedListenPort()
+	case appsetting.FieldMaxRestartsPerHour:
+		return m.AddedMaxRestartsPerHour()
+	case appsetting.FieldRestartJitter:
+		return m.AddedRestartJitter()
+	case appsetting.FieldMaxProtocolSwitches:
+		return m.AddedMaxProtocolSwitches()
 	}
 	return nil, false
 }
@@ -2078,6 +3484,41 @@ func (m *AppSettingMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddS3WebdavDedicatedPort(v)
 		return nil
+	case appsetting.FieldSchemaVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSchemaVersion(v)
+		return nil
+	case appsetting.FieldListenPort:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddListenPort(v)
+		return nil
+	case appsetting.FieldMaxRestartsPerHour:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxRestartsPerHour(v)
+		return nil
+	case appsetting.FieldRestartJitter:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRestartJitter(v)
+		return nil
+	case appsetting.FieldMaxProtocolSwitches:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxProtocolSwitches(v)
+		return nil
 	}
 	return fmt.Errorf("unknown AppSetting numeric field %s", name)
 }
@@ -2085,7 +3526,20 @@ func (m *AppSettingMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *AppSettingMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(appsetting.FieldTags) {
+		fields = append(fields, appsetting.FieldTags)
+	}
+	if m.FieldCleared(appsetting.FieldRetryablePatterns) {
+		fields = append(fields, appsetting.FieldRetryablePatterns)
+	}
+	if m.FieldCleared(appsetting.FieldNonRetryablePatterns) {
+		fields = append(fields, appsetting.FieldNonRetryablePatterns)
+	}
+	if m.FieldCleared(appsetting.FieldProtocolOrder) {
+		fields = append(fields, appsetting.FieldProtocolOrder)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -2098,6 +3552,20 @@ func (m *AppSettingMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *AppSettingMutation) ClearField(name string) error {
+	switch name {
+	case appsetting.FieldTags:
+		m.ClearTags()
+		return nil
+	case appsetting.FieldRetryablePatterns:
+		m.ClearRetryablePatterns()
+		return nil
+	case appsetting.FieldNonRetryablePatterns:
+		m.ClearNonRetryablePatterns()
+		return nil
+	case appsetting.FieldProtocolOrder:
+		m.ClearProtocolOrder()
+		return nil
+	}
 	return fmt.Errorf("unknown AppSetting nullable field %s", name)
 }
 
@@ -2153,9 +3621,15 @@ func (m *AppSettingMutation) ResetField(name string) error {
 	case appsetting.FieldEdgeBindAddress:
 		m.ResetEdgeBindAddress()
 		return nil
+	case appsetting.FieldEdgeInterface:
+		m.ResetEdgeInterface()
+		return nil
 	case appsetting.FieldPostQuantum:
 		m.ResetPostQuantum()
 		return nil
+	case appsetting.FieldPostQuantumMode:
+		m.ResetPostQuantumMode()
+		return nil
 	case appsetting.FieldNoTLSVerify:
 		m.ResetNoTLSVerify()
 		return nil
@@ -2201,6 +3675,69 @@ func (m *AppSettingMutation) ResetField(name string) error {
 	case appsetting.FieldS3WebdavDedicatedTunnelHostname:
 		m.ResetS3WebdavDedicatedTunnelHostname()
 		return nil
+	case appsetting.FieldConfigFile:
+		m.ResetConfigFile()
+		return nil
+	case appsetting.FieldMetricsPollInterval:
+		m.ResetMetricsPollInterval()
+		return nil
+	case appsetting.FieldTags:
+		m.ResetTags()
+		return nil
+	case appsetting.FieldIdleTimeout:
+		m.ResetIdleTimeout()
+		return nil
+	case appsetting.FieldSchemaVersion:
+		m.ResetSchemaVersion()
+		return nil
+	case appsetting.FieldLazyStart:
+		m.ResetLazyStart()
+		return nil
+	case appsetting.FieldRetryablePatterns:
+		m.ResetRetryablePatterns()
+		return nil
+	case appsetting.FieldNonRetryablePatterns:
+		m.ResetNonRetryablePatterns()
+		return nil
+	case appsetting.FieldProtocolOrder:
+		m.ResetProtocolOrder()
+		return nil
+	case appsetting.FieldListenPort:
+		m.ResetListenPort()
+		return nil
+	case appsetting.FieldListenAddr:
+		m.ResetListenAddr()
+		return nil
+	case appsetting.FieldRequireConnectedForReady:
+		m.ResetRequireConnectedForReady()
+		return nil
+	case appsetting.FieldStrictExtraArgs:
+		m.ResetStrictExtraArgs()
+		return nil
+	case appsetting.FieldMaxRestartsPerHour:
+		m.ResetMaxRestartsPerHour()
+		return nil
+	case appsetting.FieldMaxRuntime:
+		m.ResetMaxRuntime()
+		return nil
+	case appsetting.FieldRequireConfirm:
+		m.ResetRequireConfirm()
+		return nil
+	case appsetting.FieldRestartJitter:
+		m.ResetRestartJitter()
+		return nil
+	case appsetting.FieldSoleLogSink:
+		m.ResetSoleLogSink()
+		return nil
+	case appsetting.FieldMaxProtocolSwitches:
+		m.ResetMaxProtocolSwitches()
+		return nil
+	case appsetting.FieldStatsPersistInterval:
+		m.ResetStatsPersistInterval()
+		return nil
+	case appsetting.FieldEdgeIPFallback:
+		m.ResetEdgeIPFallback()
+		return nil
 	case appsetting.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
@@ -9658,11 +11195,13 @@ type TunnelProfileMutation struct {
 	account_id                *string
 	tunnel_id                 *string
 	auto_start                *bool
+	lazy_start                *bool
 	auto_restart              *bool
 	custom_tag                *string
 	software_name             *string
 	protocol                  *string
 	grace_period              *string
+	idle_timeout              *string
 	region                    *string
 	retries                   *int
 	addretries                *int
@@ -9674,9 +11213,12 @@ type TunnelProfileMutation struct {
 	log_json                  *bool
 	edge_ip_version           *string
 	edge_bind_address         *string
+	edge_interface            *string
 	post_quantum              *bool
+	post_quantum_mode         *string
 	no_tls_verify             *bool
 	extra_args                *string
+	tags                      *map[string]string
 	created_at                *time.Time
 	updated_at                *time.Time
 	clearedFields             map[string]struct{}
@@ -10127,6 +11669,42 @@ func (m *TunnelProfileMutation) ResetAutoStart() {
 	m.auto_start = nil
 }
 
+// SetLazyStart sets the "lazy_start" field.
+func (m *TunnelProfileMutation) SetLazyStart(b bool) {
+	m.lazy_start = &b
+}
+
+// LazyStart returns the value of the "lazy_start" field in the mutation.
+func (m *TunnelProfileMutation) LazyStart() (r bool, exists bool) {
+	v := m.lazy_start
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLazyStart returns the old "lazy_start" field's value of the TunnelProfile entity.
+// If the TunnelProfile object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TunnelProfileMutation) OldLazyStart(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLazyStart is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLazyStart requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLazyStart: %w", err)
+	}
+	return oldValue.LazyStart, nil
+}
+
+// ResetLazyStart resets all changes to the "lazy_start" field.
+func (m *TunnelProfileMutation) ResetLazyStart() {
+	m.lazy_start = nil
+}
+
 // SetAutoRestart sets the "auto_restart" field.
 func (m *TunnelProfileMutation) SetAutoRestart(b bool) {
 	m.auto_restart = &b
@@ -10307,6 +11885,42 @@ func (m *TunnelProfileMutation) ResetGracePeriod() {
 	m.grace_period = nil
 }
 
+// SetIdleTimeout sets the "idle_timeout" field.
+func (m *TunnelProfileMutation) SetIdleTimeout(s string) {
+	m.idle_timeout = &s
+}
+
+// IdleTimeout returns the value of the "idle_timeout" field in the mutation.
+func (m *TunnelProfileMutation) IdleTimeout() (r string, exists bool) {
+	v := m.idle_timeout
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIdleTimeout returns the old "idle_timeout" field's value of the TunnelProfile entity.
+// If the TunnelProfile object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TunnelProfileMutation) OldIdleTimeout(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIdleTimeout is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIdleTimeout requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIdleTimeout: %w", err)
+	}
+	return oldValue.IdleTimeout, nil
+}
+
+// ResetIdleTimeout resets all changes to the "idle_timeout" field.
+func (m *TunnelProfileMutation) ResetIdleTimeout() {
+	m.idle_timeout = nil
+}
+
 // SetRegion sets the "region" field.
 func (m *TunnelProfileMutation) SetRegion(s string) {
 	m.region = &s
@@ -10671,6 +12285,42 @@ func (m *TunnelProfileMutation) ResetEdgeBindAddress() {
 	m.edge_bind_address = nil
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (m *TunnelProfileMutation) SetEdgeInterface(s string) {
+	m.edge_interface = &s
+}
+
+// EdgeInterface returns the value of the "edge_interface" field in the mutation.
+func (m *TunnelProfileMutation) EdgeInterface() (r string, exists bool) {
+	v := m.edge_interface
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEdgeInterface returns the old "edge_interface" field's value of the TunnelProfile entity.
+// If the TunnelProfile object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TunnelProfileMutation) OldEdgeInterface(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEdgeInterface is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEdgeInterface requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEdgeInterface: %w", err)
+	}
+	return oldValue.EdgeInterface, nil
+}
+
+// ResetEdgeInterface resets all changes to the "edge_interface" field.
+func (m *TunnelProfileMutation) ResetEdgeInterface() {
+	m.edge_interface = nil
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (m *TunnelProfileMutation) SetPostQuantum(b bool) {
 	m.post_quantum = &b
@@ -10707,6 +12357,42 @@ func (m *TunnelProfileMutation) ResetPostQuantum() {
 	m.post_quantum = nil
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (m *TunnelProfileMutation) SetPostQuantumMode(s string) {
+	m.post_quantum_mode = &s
+}
+
+// PostQuantumMode returns the value of the "post_quantum_mode" field in the mutation.
+func (m *TunnelProfileMutation) PostQuantumMode() (r string, exists bool) {
+	v := m.post_quantum_mode
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPostQuantumMode returns the old "post_quantum_mode" field's value of the TunnelProfile entity.
+// If the TunnelProfile object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TunnelProfileMutation) OldPostQuantumMode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPostQuantumMode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPostQuantumMode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPostQuantumMode: %w", err)
+	}
+	return oldValue.PostQuantumMode, nil
+}
+
+// ResetPostQuantumMode resets all changes to the "post_quantum_mode" field.
+func (m *TunnelProfileMutation) ResetPostQuantumMode() {
+	m.post_quantum_mode = nil
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (m *TunnelProfileMutation) SetNoTLSVerify(b bool) {
 	m.no_tls_verify = &b
@@ -10779,6 +12465,55 @@ func (m *TunnelProfileMutation) ResetExtraArgs() {
 	m.extra_args = nil
 }
 
+// SetTags sets the "tags" field.
+func (m *TunnelProfileMutation) SetTags(value map[string]string) {
+	m.tags = &value
+}
+
+// Tags returns the value of the "tags" field in the mutation.
+func (m *TunnelProfileMutation) Tags() (r map[string]string, exists bool) {
+	v := m.tags
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTags returns the old "tags" field's value of the TunnelProfile entity.
+// If the TunnelProfile object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TunnelProfileMutation) OldTags(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTags is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTags requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTags: %w", err)
+	}
+	return oldValue.Tags, nil
+}
+
+// ClearTags clears the value of the "tags" field.
+func (m *TunnelProfileMutation) ClearTags() {
+	m.tags = nil
+	m.clearedFields[tunnelprofile.FieldTags] = struct{}{}
+}
+
+// TagsCleared returns if the "tags" field was cleared in this mutation.
+func (m *TunnelProfileMutation) TagsCleared() bool {
+	_, ok := m.clearedFields[tunnelprofile.FieldTags]
+	return ok
+}
+
+// ResetTags resets all changes to the "tags" field.
+func (m *TunnelProfileMutation) ResetTags() {
+	m.tags = nil
+	delete(m.clearedFields, tunnelprofile.FieldTags)
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (m *TunnelProfileMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
@@ -10885,7 +12620,7 @@ func (m *TunnelProfileMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *TunnelProfileMutation) Fields() []string {
-	fields := make([]string, 0, 28)
+	fields := make([]string, 0, 33)
 	if m.key != nil {
 		fields = append(fields, tunnelprofile.FieldKey)
 	}
@@ -10913,6 +12648,9 @@ func (m *TunnelProfileMutation) Fields() []string {
 	if m.auto_start != nil {
 		fields = append(fields, tunnelprofile.FieldAutoStart)
 	}
+	if m.lazy_start != nil {
+		fields = append(fields, tunnelprofile.FieldLazyStart)
+	}
 	if m.auto_restart != nil {
 		fields = append(fields, tunnelprofile.FieldAutoRestart)
 	}
@@ -10928,6 +12666,9 @@ func (m *TunnelProfileMutation) Fields() []string {
 	if m.grace_period != nil {
 		fields = append(fields, tunnelprofile.FieldGracePeriod)
 	}
+	if m.idle_timeout != nil {
+		fields = append(fields, tunnelprofile.FieldIdleTimeout)
+	}
 	if m.region != nil {
 		fields = append(fields, tunnelprofile.FieldRegion)
 	}
@@ -10955,15 +12696,24 @@ func (m *TunnelProfileMutation) Fields() []string {
 	if m.edge_bind_address != nil {
 		fields = append(fields, tunnelprofile.FieldEdgeBindAddress)
 	}
+	if m.edge_interface != nil {
+		fields = append(fields, tunnelprofile.FieldEdgeInterface)
+	}
 	if m.post_quantum != nil {
 		fields = append(fields, tunnelprofile.FieldPostQuantum)
 	}
+	if m.post_quantum_mode != nil {
+		fields = append(fields, tunnelprofile.FieldPostQuantumMode)
+	}
 	if m.no_tls_verify != nil {
 		fields = append(fields, tunnelprofile.FieldNoTLSVerify)
 	}
 	if m.extra_args != nil {
 		fields = append(fields, tunnelprofile.FieldExtraArgs)
 	}
+	if m.tags != nil {
+		fields = append(fields, tunnelprofile.FieldTags)
+	}
 	if m.created_at != nil {
 		fields = append(fields, tunnelprofile.FieldCreatedAt)
 	}
@@ -10996,6 +12746,8 @@ func (m *TunnelProfileMutation) Field(name string) (ent.Value, bool) {
 		return m.TunnelID()
 	case tunnelprofile.FieldAutoStart:
 		return m.AutoStart()
+	case tunnelprofile.FieldLazyStart:
+		return m.LazyStart()
 	case tunnelprofile.FieldAutoRestart:
 		return m.AutoRestart()
 	case tunnelprofile.FieldCustomTag:
@@ -11006,6 +12758,8 @@ func (m *TunnelProfileMutation) Field(name string) (ent.Value, bool) {
 		return m.Protocol()
 	case tunnelprofile.FieldGracePeriod:
 		return m.GracePeriod()
+	case tunnelprofile.FieldIdleTimeout:
+		return m.IdleTimeout()
 	case tunnelprofile.FieldRegion:
 		return m.Region()
 	case tunnelprofile.FieldRetries:
@@ -11024,12 +12778,18 @@ func (m *TunnelProfileMutation) Field(name string) (ent.Value, bool) {
 		return m.EdgeIPVersion()
 	case tunnelprofile.FieldEdgeBindAddress:
 		return m.EdgeBindAddress()
+	case tunnelprofile.FieldEdgeInterface:
+		return m.EdgeInterface()
 	case tunnelprofile.FieldPostQuantum:
 		return m.PostQuantum()
+	case tunnelprofile.FieldPostQuantumMode:
+		return m.PostQuantumMode()
 	case tunnelprofile.FieldNoTLSVerify:
 		return m.NoTLSVerify()
 	case tunnelprofile.FieldExtraArgs:
 		return m.ExtraArgs()
+	case tunnelprofile.FieldTags:
+		return m.Tags()
 	case tunnelprofile.FieldCreatedAt:
 		return m.CreatedAt()
 	case tunnelprofile.FieldUpdatedAt:
@@ -11061,6 +12821,8 @@ func (m *TunnelProfileMutation) OldField(ctx context.Context, name string) (ent.
 		return m.OldTunnelID(ctx)
 	case tunnelprofile.FieldAutoStart:
 		return m.OldAutoStart(ctx)
+	case tunnelprofile.FieldLazyStart:
+		return m.OldLazyStart(ctx)
 	case tunnelprofile.FieldAutoRestart:
 		return m.OldAutoRestart(ctx)
 	case tunnelprofile.FieldCustomTag:
@@ -11071,6 +12833,8 @@ func (m *TunnelProfileMutation) OldField(ctx context.Context, name string) (ent.
 		return m.OldProtocol(ctx)
 	case tunnelprofile.FieldGracePeriod:
 		return m.OldGracePeriod(ctx)
+	case tunnelprofile.FieldIdleTimeout:
+		return m.OldIdleTimeout(ctx)
 	case tunnelprofile.FieldRegion:
 		return m.OldRegion(ctx)
 	case tunnelprofile.FieldRetries:
@@ -11089,12 +12853,18 @@ func (m *TunnelProfileMutation) OldField(ctx context.Context, name string) (ent.
 		return m.OldEdgeIPVersion(ctx)
 	case tunnelprofile.FieldEdgeBindAddress:
 		return m.OldEdgeBindAddress(ctx)
+	case tunnelprofile.FieldEdgeInterface:
+		return m.OldEdgeInterface(ctx)
 	case tunnelprofile.FieldPostQuantum:
 		return m.OldPostQuantum(ctx)
+	case tunnelprofile.FieldPostQuantumMode:
+		return m.OldPostQuantumMode(ctx)
 	case tunnelprofile.FieldNoTLSVerify:
 		return m.OldNoTLSVerify(ctx)
 	case tunnelprofile.FieldExtraArgs:
 		return m.OldExtraArgs(ctx)
+	case tunnelprofile.FieldTags:
+		return m.OldTags(ctx)
 	case tunnelprofile.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	case tunnelprofile.FieldUpdatedAt:
@@ -11171,6 +12941,13 @@ func (m *TunnelProfileMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetAutoStart(v)
 		return nil
+	case tunnelprofile.FieldLazyStart:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLazyStart(v)
+		return nil
 	case tunnelprofile.FieldAutoRestart:
 		v, ok := value.(bool)
 		if !ok {
@@ -11206,6 +12983,13 @@ func (m *TunnelProfileMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetGracePeriod(v)
 		return nil
+	case tunnelprofile.FieldIdleTimeout:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIdleTimeout(v)
+		return nil
 	case tunnelprofile.FieldRegion:
 		v, ok := value.(string)
 		if !ok {
@@ -11269,6 +13053,13 @@ func (m *TunnelProfileMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetEdgeBindAddress(v)
 		return nil
+	case tunnelprofile.FieldEdgeInterface:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEdgeInterface(v)
+		return nil
 	case tunnelprofile.FieldPostQuantum:
 		v, ok := value.(bool)
 		if !ok {
@@ -11276,6 +13067,13 @@ func (m *TunnelProfileMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPostQuantum(v)
 		return nil
+	case tunnelprofile.FieldPostQuantumMode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPostQuantumMode(v)
+		return nil
 	case tunnelprofile.FieldNoTLSVerify:
 		v, ok := value.(bool)
 		if !ok {
@@ -11290,6 +13088,13 @@ func (m *TunnelProfileMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetExtraArgs(v)
 		return nil
+	case tunnelprofile.FieldTags:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTags(v)
+		return nil
 	case tunnelprofile.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -11372,7 +13177,11 @@ func (m *TunnelProfileMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *TunnelProfileMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(tunnelprofile.FieldTags) {
+		fields = append(fields, tunnelprofile.FieldTags)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -11385,6 +13194,11 @@ func (m *TunnelProfileMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *TunnelProfileMutation) ClearField(name string) error {
+	switch name {
+	case tunnelprofile.FieldTags:
+		m.ClearTags()
+		return nil
+	}
 	return fmt.Errorf("unknown TunnelProfile nullable field %s", name)
 }
 
@@ -11419,6 +13233,9 @@ func (m *TunnelProfileMutation) ResetField(name string) error {
 	case tunnelprofile.FieldAutoStart:
 		m.ResetAutoStart()
 		return nil
+	case tunnelprofile.FieldLazyStart:
+		m.ResetLazyStart()
+		return nil
 	case tunnelprofile.FieldAutoRestart:
 		m.ResetAutoRestart()
 		return nil
@@ -11434,6 +13251,9 @@ func (m *TunnelProfileMutation) ResetField(name string) error {
 	case tunnelprofile.FieldGracePeriod:
 		m.ResetGracePeriod()
 		return nil
+	case tunnelprofile.FieldIdleTimeout:
+		m.ResetIdleTimeout()
+		return nil
 	case tunnelprofile.FieldRegion:
 		m.ResetRegion()
 		return nil
@@ -11461,15 +13281,24 @@ func (m *TunnelProfileMutation) ResetField(name string) error {
 	case tunnelprofile.FieldEdgeBindAddress:
 		m.ResetEdgeBindAddress()
 		return nil
+	case tunnelprofile.FieldEdgeInterface:
+		m.ResetEdgeInterface()
+		return nil
 	case tunnelprofile.FieldPostQuantum:
 		m.ResetPostQuantum()
 		return nil
+	case tunnelprofile.FieldPostQuantumMode:
+		m.ResetPostQuantumMode()
+		return nil
 	case tunnelprofile.FieldNoTLSVerify:
 		m.ResetNoTLSVerify()
 		return nil
 	case tunnelprofile.FieldExtraArgs:
 		m.ResetExtraArgs()
 		return nil
+	case tunnelprofile.FieldTags:
+		m.ResetTags()
+		return nil
 	case tunnelprofile.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
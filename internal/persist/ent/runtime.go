@@ -89,76 +89,152 @@ func init() {
 	appsettingDescEdgeBindAddress := appsettingFields[15].Descriptor()
 	// appsetting.DefaultEdgeBindAddress holds the default value on creation for the edge_bind_address field.
 	appsetting.DefaultEdgeBindAddress = appsettingDescEdgeBindAddress.Default.(string)
+	// appsettingDescEdgeInterface is the schema descriptor for edge_interface field.
+	appsettingDescEdgeInterface := appsettingFields[16].Descriptor()
+	// appsetting.DefaultEdgeInterface holds the default value on creation for the edge_interface field.
+	appsetting.DefaultEdgeInterface = appsettingDescEdgeInterface.Default.(string)
 	// appsettingDescPostQuantum is the schema descriptor for post_quantum field.
-	appsettingDescPostQuantum := appsettingFields[16].Descriptor()
+	appsettingDescPostQuantum := appsettingFields[17].Descriptor()
 	// appsetting.DefaultPostQuantum holds the default value on creation for the post_quantum field.
 	appsetting.DefaultPostQuantum = appsettingDescPostQuantum.Default.(bool)
+	// appsettingDescPostQuantumMode is the schema descriptor for post_quantum_mode field.
+	appsettingDescPostQuantumMode := appsettingFields[18].Descriptor()
+	// appsetting.DefaultPostQuantumMode holds the default value on creation for the post_quantum_mode field.
+	appsetting.DefaultPostQuantumMode = appsettingDescPostQuantumMode.Default.(string)
 	// appsettingDescNoTLSVerify is the schema descriptor for no_tls_verify field.
-	appsettingDescNoTLSVerify := appsettingFields[17].Descriptor()
+	appsettingDescNoTLSVerify := appsettingFields[19].Descriptor()
 	// appsetting.DefaultNoTLSVerify holds the default value on creation for the no_tls_verify field.
 	appsetting.DefaultNoTLSVerify = appsettingDescNoTLSVerify.Default.(bool)
 	// appsettingDescExtraArgs is the schema descriptor for extra_args field.
-	appsettingDescExtraArgs := appsettingFields[18].Descriptor()
+	appsettingDescExtraArgs := appsettingFields[20].Descriptor()
 	// appsetting.DefaultExtraArgs holds the default value on creation for the extra_args field.
 	appsetting.DefaultExtraArgs = appsettingDescExtraArgs.Default.(string)
 	// appsettingDescActiveTunnelKey is the schema descriptor for active_tunnel_key field.
-	appsettingDescActiveTunnelKey := appsettingFields[19].Descriptor()
+	appsettingDescActiveTunnelKey := appsettingFields[21].Descriptor()
 	// appsetting.DefaultActiveTunnelKey holds the default value on creation for the active_tunnel_key field.
 	appsetting.DefaultActiveTunnelKey = appsettingDescActiveTunnelKey.Default.(string)
 	// appsettingDescMcpEnabled is the schema descriptor for mcp_enabled field.
-	appsettingDescMcpEnabled := appsettingFields[20].Descriptor()
+	appsettingDescMcpEnabled := appsettingFields[22].Descriptor()
 	// appsetting.DefaultMcpEnabled holds the default value on creation for the mcp_enabled field.
 	appsetting.DefaultMcpEnabled = appsettingDescMcpEnabled.Default.(bool)
 	// appsettingDescOauthClientID is the schema descriptor for oauth_client_id field.
-	appsettingDescOauthClientID := appsettingFields[21].Descriptor()
+	appsettingDescOauthClientID := appsettingFields[23].Descriptor()
 	// appsetting.DefaultOauthClientID holds the default value on creation for the oauth_client_id field.
 	appsetting.DefaultOauthClientID = appsettingDescOauthClientID.Default.(string)
 	// appsettingDescOauthRelayCallbackURL is the schema descriptor for oauth_relay_callback_url field.
-	appsettingDescOauthRelayCallbackURL := appsettingFields[22].Descriptor()
+	appsettingDescOauthRelayCallbackURL := appsettingFields[24].Descriptor()
 	// appsetting.DefaultOauthRelayCallbackURL holds the default value on creation for the oauth_relay_callback_url field.
 	appsetting.DefaultOauthRelayCallbackURL = appsettingDescOauthRelayCallbackURL.Default.(string)
 	// appsettingDescS3WebdavEnabled is the schema descriptor for s3_webdav_enabled field.
-	appsettingDescS3WebdavEnabled := appsettingFields[23].Descriptor()
+	appsettingDescS3WebdavEnabled := appsettingFields[25].Descriptor()
 	// appsetting.DefaultS3WebdavEnabled holds the default value on creation for the s3_webdav_enabled field.
 	appsetting.DefaultS3WebdavEnabled = appsettingDescS3WebdavEnabled.Default.(bool)
 	// appsettingDescS3WebdavActiveKey is the schema descriptor for s3_webdav_active_key field.
-	appsettingDescS3WebdavActiveKey := appsettingFields[24].Descriptor()
+	appsettingDescS3WebdavActiveKey := appsettingFields[26].Descriptor()
 	// appsetting.DefaultS3WebdavActiveKey holds the default value on creation for the s3_webdav_active_key field.
 	appsetting.DefaultS3WebdavActiveKey = appsettingDescS3WebdavActiveKey.Default.(string)
 	// appsettingDescS3WebdavAccessMode is the schema descriptor for s3_webdav_access_mode field.
-	appsettingDescS3WebdavAccessMode := appsettingFields[25].Descriptor()
+	appsettingDescS3WebdavAccessMode := appsettingFields[27].Descriptor()
 	// appsetting.DefaultS3WebdavAccessMode holds the default value on creation for the s3_webdav_access_mode field.
 	appsetting.DefaultS3WebdavAccessMode = appsettingDescS3WebdavAccessMode.Default.(string)
 	// appsettingDescS3WebdavDedicatedBindHost is the schema descriptor for s3_webdav_dedicated_bind_host field.
-	appsettingDescS3WebdavDedicatedBindHost := appsettingFields[26].Descriptor()
+	appsettingDescS3WebdavDedicatedBindHost := appsettingFields[28].Descriptor()
 	// appsetting.DefaultS3WebdavDedicatedBindHost holds the default value on creation for the s3_webdav_dedicated_bind_host field.
 	appsetting.DefaultS3WebdavDedicatedBindHost = appsettingDescS3WebdavDedicatedBindHost.Default.(string)
 	// appsettingDescS3WebdavDedicatedPort is the schema descriptor for s3_webdav_dedicated_port field.
-	appsettingDescS3WebdavDedicatedPort := appsettingFields[27].Descriptor()
+	appsettingDescS3WebdavDedicatedPort := appsettingFields[29].Descriptor()
 	// appsetting.DefaultS3WebdavDedicatedPort holds the default value on creation for the s3_webdav_dedicated_port field.
 	appsetting.DefaultS3WebdavDedicatedPort = appsettingDescS3WebdavDedicatedPort.Default.(int)
 	// appsettingDescS3WebdavDedicatedAutoStart is the schema descriptor for s3_webdav_dedicated_auto_start field.
-	appsettingDescS3WebdavDedicatedAutoStart := appsettingFields[28].Descriptor()
+	appsettingDescS3WebdavDedicatedAutoStart := appsettingFields[30].Descriptor()
 	// appsetting.DefaultS3WebdavDedicatedAutoStart holds the default value on creation for the s3_webdav_dedicated_auto_start field.
 	appsetting.DefaultS3WebdavDedicatedAutoStart = appsettingDescS3WebdavDedicatedAutoStart.Default.(bool)
 	// appsettingDescS3WebdavDedicatedDomainMode is the schema descriptor for s3_webdav_dedicated_domain_mode field.
-	appsettingDescS3WebdavDedicatedDomainMode := appsettingFields[29].Descriptor()
+	appsettingDescS3WebdavDedicatedDomainMode := appsettingFields[31].Descriptor()
 	// appsetting.DefaultS3WebdavDedicatedDomainMode holds the default value on creation for the s3_webdav_dedicated_domain_mode field.
 	appsetting.DefaultS3WebdavDedicatedDomainMode = appsettingDescS3WebdavDedicatedDomainMode.Default.(string)
 	// appsettingDescS3WebdavDedicatedCustomDomain is the schema descriptor for s3_webdav_dedicated_custom_domain field.
-	appsettingDescS3WebdavDedicatedCustomDomain := appsettingFields[30].Descriptor()
+	appsettingDescS3WebdavDedicatedCustomDomain := appsettingFields[32].Descriptor()
 	// appsetting.DefaultS3WebdavDedicatedCustomDomain holds the default value on creation for the s3_webdav_dedicated_custom_domain field.
 	appsetting.DefaultS3WebdavDedicatedCustomDomain = appsettingDescS3WebdavDedicatedCustomDomain.Default.(string)
 	// appsettingDescS3WebdavDedicatedTunnelHostname is the schema descriptor for s3_webdav_dedicated_tunnel_hostname field.
-	appsettingDescS3WebdavDedicatedTunnelHostname := appsettingFields[31].Descriptor()
+	appsettingDescS3WebdavDedicatedTunnelHostname := appsettingFields[33].Descriptor()
 	// appsetting.DefaultS3WebdavDedicatedTunnelHostname holds the default value on creation for the s3_webdav_dedicated_tunnel_hostname field.
 	appsetting.DefaultS3WebdavDedicatedTunnelHostname = appsettingDescS3WebdavDedicatedTunnelHostname.Default.(string)
+	// appsettingDescConfigFile is the schema descriptor for config_file field.
+	appsettingDescConfigFile := appsettingFields[34].Descriptor()
+	// appsetting.DefaultConfigFile holds the default value on creation for the config_file field.
+	appsetting.DefaultConfigFile = appsettingDescConfigFile.Default.(string)
+	// appsettingDescMetricsPollInterval is the schema descriptor for metrics_poll_interval field.
+	appsettingDescMetricsPollInterval := appsettingFields[35].Descriptor()
+	// appsetting.DefaultMetricsPollInterval holds the default value on creation for the metrics_poll_interval field.
+	appsetting.DefaultMetricsPollInterval = appsettingDescMetricsPollInterval.Default.(string)
+	// appsettingDescIdleTimeout is the schema descriptor for idle_timeout field.
+	appsettingDescIdleTimeout := appsettingFields[37].Descriptor()
+	// appsetting.DefaultIdleTimeout holds the default value on creation for the idle_timeout field.
+	appsetting.DefaultIdleTimeout = appsettingDescIdleTimeout.Default.(string)
+	// appsettingDescSchemaVersion is the schema descriptor for schema_version field.
+	appsettingDescSchemaVersion := appsettingFields[38].Descriptor()
+	// appsetting.DefaultSchemaVersion holds the default value on creation for the schema_version field.
+	appsetting.DefaultSchemaVersion = appsettingDescSchemaVersion.Default.(int)
+	// appsettingDescLazyStart is the schema descriptor for lazy_start field.
+	appsettingDescLazyStart := appsettingFields[39].Descriptor()
+	// appsetting.DefaultLazyStart holds the default value on creation for the lazy_start field.
+	appsetting.DefaultLazyStart = appsettingDescLazyStart.Default.(bool)
+	// appsettingDescListenPort is the schema descriptor for listen_port field.
+	appsettingDescListenPort := appsettingFields[43].Descriptor()
+	// appsetting.DefaultListenPort holds the default value on creation for the listen_port field.
+	appsetting.DefaultListenPort = appsettingDescListenPort.Default.(int)
+	// appsettingDescListenAddr is the schema descriptor for listen_addr field.
+	appsettingDescListenAddr := appsettingFields[44].Descriptor()
+	// appsetting.DefaultListenAddr holds the default value on creation for the listen_addr field.
+	appsetting.DefaultListenAddr = appsettingDescListenAddr.Default.(string)
+	// appsettingDescRequireConnectedForReady is the schema descriptor for require_connected_for_ready field.
+	appsettingDescRequireConnectedForReady := appsettingFields[45].Descriptor()
+	// appsetting.DefaultRequireConnectedForReady holds the default value on creation for the require_connected_for_ready field.
+	appsetting.DefaultRequireConnectedForReady = appsettingDescRequireConnectedForReady.Default.(bool)
+	// appsettingDescStrictExtraArgs is the schema descriptor for strict_extra_args field.
+	appsettingDescStrictExtraArgs := appsettingFields[46].Descriptor()
+	// appsetting.DefaultStrictExtraArgs holds the default value on creation for the strict_extra_args field.
+	appsetting.DefaultStrictExtraArgs = appsettingDescStrictExtraArgs.Default.(bool)
+	// appsettingDescMaxRestartsPerHour is the schema descriptor for max_restarts_per_hour field.
+	appsettingDescMaxRestartsPerHour := appsettingFields[47].Descriptor()
+	// appsetting.DefaultMaxRestartsPerHour holds the default value on creation for the max_restarts_per_hour field.
+	appsetting.DefaultMaxRestartsPerHour = appsettingDescMaxRestartsPerHour.Default.(int)
+	// appsettingDescMaxRuntime is the schema descriptor for max_runtime field.
+	appsettingDescMaxRuntime := appsettingFields[48].Descriptor()
+	// appsetting.DefaultMaxRuntime holds the default value on creation for the max_runtime field.
+	appsetting.DefaultMaxRuntime = appsettingDescMaxRuntime.Default.(string)
+	// appsettingDescRequireConfirm is the schema descriptor for require_confirm field.
+	appsettingDescRequireConfirm := appsettingFields[49].Descriptor()
+	// appsetting.DefaultRequireConfirm holds the default value on creation for the require_confirm field.
+	appsetting.DefaultRequireConfirm = appsettingDescRequireConfirm.Default.(bool)
+	// appsettingDescRestartJitter is the schema descriptor for restart_jitter field.
+	appsettingDescRestartJitter := appsettingFields[50].Descriptor()
+	// appsetting.DefaultRestartJitter holds the default value on creation for the restart_jitter field.
+	appsetting.DefaultRestartJitter = appsettingDescRestartJitter.Default.(int)
+	// appsettingDescSoleLogSink is the schema descriptor for sole_log_sink field.
+	appsettingDescSoleLogSink := appsettingFields[51].Descriptor()
+	// appsetting.DefaultSoleLogSink holds the default value on creation for the sole_log_sink field.
+	appsetting.DefaultSoleLogSink = appsettingDescSoleLogSink.Default.(bool)
+	// appsettingDescMaxProtocolSwitches is the schema descriptor for max_protocol_switches field.
+	appsettingDescMaxProtocolSwitches := appsettingFields[52].Descriptor()
+	// appsetting.DefaultMaxProtocolSwitches holds the default value on creation for the max_protocol_switches field.
+	appsetting.DefaultMaxProtocolSwitches = appsettingDescMaxProtocolSwitches.Default.(int)
+	// appsettingDescStatsPersistInterval is the schema descriptor for stats_persist_interval field.
+	appsettingDescStatsPersistInterval := appsettingFields[53].Descriptor()
+	// appsetting.DefaultStatsPersistInterval holds the default value on creation for the stats_persist_interval field.
+	appsetting.DefaultStatsPersistInterval = appsettingDescStatsPersistInterval.Default.(string)
+	// appsettingDescEdgeIPFallback is the schema descriptor for edge_ip_fallback field.
+	appsettingDescEdgeIPFallback := appsettingFields[54].Descriptor()
+	// appsetting.DefaultEdgeIPFallback holds the default value on creation for the edge_ip_fallback field.
+	appsetting.DefaultEdgeIPFallback = appsettingDescEdgeIPFallback.Default.(bool)
 	// appsettingDescCreatedAt is the schema descriptor for created_at field.
-	appsettingDescCreatedAt := appsettingFields[32].Descriptor()
+	appsettingDescCreatedAt := appsettingFields[55].Descriptor()
 	// appsetting.DefaultCreatedAt holds the default value on creation for the created_at field.
 	appsetting.DefaultCreatedAt = appsettingDescCreatedAt.Default.(func() time.Time)
 	// appsettingDescUpdatedAt is the schema descriptor for updated_at field.
-	appsettingDescUpdatedAt := appsettingFields[33].Descriptor()
+	appsettingDescUpdatedAt := appsettingFields[56].Descriptor()
 	// appsetting.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	appsetting.DefaultUpdatedAt = appsettingDescUpdatedAt.Default.(func() time.Time)
 	// appsetting.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -559,80 +635,96 @@ func init() {
 	tunnelprofileDescAutoStart := tunnelprofileFields[8].Descriptor()
 	// tunnelprofile.DefaultAutoStart holds the default value on creation for the auto_start field.
 	tunnelprofile.DefaultAutoStart = tunnelprofileDescAutoStart.Default.(bool)
+	// tunnelprofileDescLazyStart is the schema descriptor for lazy_start field.
+	tunnelprofileDescLazyStart := tunnelprofileFields[9].Descriptor()
+	// tunnelprofile.DefaultLazyStart holds the default value on creation for the lazy_start field.
+	tunnelprofile.DefaultLazyStart = tunnelprofileDescLazyStart.Default.(bool)
 	// tunnelprofileDescAutoRestart is the schema descriptor for auto_restart field.
-	tunnelprofileDescAutoRestart := tunnelprofileFields[9].Descriptor()
+	tunnelprofileDescAutoRestart := tunnelprofileFields[10].Descriptor()
 	// tunnelprofile.DefaultAutoRestart holds the default value on creation for the auto_restart field.
 	tunnelprofile.DefaultAutoRestart = tunnelprofileDescAutoRestart.Default.(bool)
 	// tunnelprofileDescCustomTag is the schema descriptor for custom_tag field.
-	tunnelprofileDescCustomTag := tunnelprofileFields[10].Descriptor()
+	tunnelprofileDescCustomTag := tunnelprofileFields[11].Descriptor()
 	// tunnelprofile.DefaultCustomTag holds the default value on creation for the custom_tag field.
 	tunnelprofile.DefaultCustomTag = tunnelprofileDescCustomTag.Default.(string)
 	// tunnelprofileDescSoftwareName is the schema descriptor for software_name field.
-	tunnelprofileDescSoftwareName := tunnelprofileFields[11].Descriptor()
+	tunnelprofileDescSoftwareName := tunnelprofileFields[12].Descriptor()
 	// tunnelprofile.DefaultSoftwareName holds the default value on creation for the software_name field.
 	tunnelprofile.DefaultSoftwareName = tunnelprofileDescSoftwareName.Default.(string)
 	// tunnelprofileDescProtocol is the schema descriptor for protocol field.
-	tunnelprofileDescProtocol := tunnelprofileFields[12].Descriptor()
+	tunnelprofileDescProtocol := tunnelprofileFields[13].Descriptor()
 	// tunnelprofile.DefaultProtocol holds the default value on creation for the protocol field.
 	tunnelprofile.DefaultProtocol = tunnelprofileDescProtocol.Default.(string)
 	// tunnelprofileDescGracePeriod is the schema descriptor for grace_period field.
-	tunnelprofileDescGracePeriod := tunnelprofileFields[13].Descriptor()
+	tunnelprofileDescGracePeriod := tunnelprofileFields[14].Descriptor()
 	// tunnelprofile.DefaultGracePeriod holds the default value on creation for the grace_period field.
 	tunnelprofile.DefaultGracePeriod = tunnelprofileDescGracePeriod.Default.(string)
+	// tunnelprofileDescIdleTimeout is the schema descriptor for idle_timeout field.
+	tunnelprofileDescIdleTimeout := tunnelprofileFields[15].Descriptor()
+	// tunnelprofile.DefaultIdleTimeout holds the default value on creation for the idle_timeout field.
+	tunnelprofile.DefaultIdleTimeout = tunnelprofileDescIdleTimeout.Default.(string)
 	// tunnelprofileDescRegion is the schema descriptor for region field.
-	tunnelprofileDescRegion := tunnelprofileFields[14].Descriptor()
+	tunnelprofileDescRegion := tunnelprofileFields[16].Descriptor()
 	// tunnelprofile.DefaultRegion holds the default value on creation for the region field.
 	tunnelprofile.DefaultRegion = tunnelprofileDescRegion.Default.(string)
 	// tunnelprofileDescRetries is the schema descriptor for retries field.
-	tunnelprofileDescRetries := tunnelprofileFields[15].Descriptor()
+	tunnelprofileDescRetries := tunnelprofileFields[17].Descriptor()
 	// tunnelprofile.DefaultRetries holds the default value on creation for the retries field.
 	tunnelprofile.DefaultRetries = tunnelprofileDescRetries.Default.(int)
 	// tunnelprofileDescMetricsEnable is the schema descriptor for metrics_enable field.
-	tunnelprofileDescMetricsEnable := tunnelprofileFields[16].Descriptor()
+	tunnelprofileDescMetricsEnable := tunnelprofileFields[18].Descriptor()
 	// tunnelprofile.DefaultMetricsEnable holds the default value on creation for the metrics_enable field.
 	tunnelprofile.DefaultMetricsEnable = tunnelprofileDescMetricsEnable.Default.(bool)
 	// tunnelprofileDescMetricsPort is the schema descriptor for metrics_port field.
-	tunnelprofileDescMetricsPort := tunnelprofileFields[17].Descriptor()
+	tunnelprofileDescMetricsPort := tunnelprofileFields[19].Descriptor()
 	// tunnelprofile.DefaultMetricsPort holds the default value on creation for the metrics_port field.
 	tunnelprofile.DefaultMetricsPort = tunnelprofileDescMetricsPort.Default.(int)
 	// tunnelprofileDescLogLevel is the schema descriptor for log_level field.
-	tunnelprofileDescLogLevel := tunnelprofileFields[18].Descriptor()
+	tunnelprofileDescLogLevel := tunnelprofileFields[20].Descriptor()
 	// tunnelprofile.DefaultLogLevel holds the default value on creation for the log_level field.
 	tunnelprofile.DefaultLogLevel = tunnelprofileDescLogLevel.Default.(string)
 	// tunnelprofileDescLogFile is the schema descriptor for log_file field.
-	tunnelprofileDescLogFile := tunnelprofileFields[19].Descriptor()
+	tunnelprofileDescLogFile := tunnelprofileFields[21].Descriptor()
 	// tunnelprofile.DefaultLogFile holds the default value on creation for the log_file field.
 	tunnelprofile.DefaultLogFile = tunnelprofileDescLogFile.Default.(string)
 	// tunnelprofileDescLogJSON is the schema descriptor for log_json field.
-	tunnelprofileDescLogJSON := tunnelprofileFields[20].Descriptor()
+	tunnelprofileDescLogJSON := tunnelprofileFields[22].Descriptor()
 	// tunnelprofile.DefaultLogJSON holds the default value on creation for the log_json field.
 	tunnelprofile.DefaultLogJSON = tunnelprofileDescLogJSON.Default.(bool)
 	// tunnelprofileDescEdgeIPVersion is the schema descriptor for edge_ip_version field.
-	tunnelprofileDescEdgeIPVersion := tunnelprofileFields[21].Descriptor()
+	tunnelprofileDescEdgeIPVersion := tunnelprofileFields[23].Descriptor()
 	// tunnelprofile.DefaultEdgeIPVersion holds the default value on creation for the edge_ip_version field.
 	tunnelprofile.DefaultEdgeIPVersion = tunnelprofileDescEdgeIPVersion.Default.(string)
 	// tunnelprofileDescEdgeBindAddress is the schema descriptor for edge_bind_address field.
-	tunnelprofileDescEdgeBindAddress := tunnelprofileFields[22].Descriptor()
+	tunnelprofileDescEdgeBindAddress := tunnelprofileFields[24].Descriptor()
 	// tunnelprofile.DefaultEdgeBindAddress holds the default value on creation for the edge_bind_address field.
 	tunnelprofile.DefaultEdgeBindAddress = tunnelprofileDescEdgeBindAddress.Default.(string)
+	// tunnelprofileDescEdgeInterface is the schema descriptor for edge_interface field.
+	tunnelprofileDescEdgeInterface := tunnelprofileFields[25].Descriptor()
+	// tunnelprofile.DefaultEdgeInterface holds the default value on creation for the edge_interface field.
+	tunnelprofile.DefaultEdgeInterface = tunnelprofileDescEdgeInterface.Default.(string)
 	// tunnelprofileDescPostQuantum is the schema descriptor for post_quantum field.
-	tunnelprofileDescPostQuantum := tunnelprofileFields[23].Descriptor()
+	tunnelprofileDescPostQuantum := tunnelprofileFields[26].Descriptor()
 	// tunnelprofile.DefaultPostQuantum holds the default value on creation for the post_quantum field.
 	tunnelprofile.DefaultPostQuantum = tunnelprofileDescPostQuantum.Default.(bool)
+	// tunnelprofileDescPostQuantumMode is the schema descriptor for post_quantum_mode field.
+	tunnelprofileDescPostQuantumMode := tunnelprofileFields[27].Descriptor()
+	// tunnelprofile.DefaultPostQuantumMode holds the default value on creation for the post_quantum_mode field.
+	tunnelprofile.DefaultPostQuantumMode = tunnelprofileDescPostQuantumMode.Default.(string)
 	// tunnelprofileDescNoTLSVerify is the schema descriptor for no_tls_verify field.
-	tunnelprofileDescNoTLSVerify := tunnelprofileFields[24].Descriptor()
+	tunnelprofileDescNoTLSVerify := tunnelprofileFields[28].Descriptor()
 	// tunnelprofile.DefaultNoTLSVerify holds the default value on creation for the no_tls_verify field.
 	tunnelprofile.DefaultNoTLSVerify = tunnelprofileDescNoTLSVerify.Default.(bool)
 	// tunnelprofileDescExtraArgs is the schema descriptor for extra_args field.
-	tunnelprofileDescExtraArgs := tunnelprofileFields[25].Descriptor()
+	tunnelprofileDescExtraArgs := tunnelprofileFields[29].Descriptor()
 	// tunnelprofile.DefaultExtraArgs holds the default value on creation for the extra_args field.
 	tunnelprofile.DefaultExtraArgs = tunnelprofileDescExtraArgs.Default.(string)
 	// tunnelprofileDescCreatedAt is the schema descriptor for created_at field.
-	tunnelprofileDescCreatedAt := tunnelprofileFields[26].Descriptor()
+	tunnelprofileDescCreatedAt := tunnelprofileFields[31].Descriptor()
 	// tunnelprofile.DefaultCreatedAt holds the default value on creation for the created_at field.
 	tunnelprofile.DefaultCreatedAt = tunnelprofileDescCreatedAt.Default.(func() time.Time)
 	// tunnelprofileDescUpdatedAt is the schema descriptor for updated_at field.
-	tunnelprofileDescUpdatedAt := tunnelprofileFields[27].Descriptor()
+	tunnelprofileDescUpdatedAt := tunnelprofileFields[32].Descriptor()
 	// tunnelprofile.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	tunnelprofile.DefaultUpdatedAt = tunnelprofileDescUpdatedAt.Default.(func() time.Time)
 	// tunnelprofile.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -31,7 +31,9 @@ func (AppSetting) Fields() []ent.Field {
 		field.Bool("log_json").Default(false),
 		field.String("edge_ip_version").Default("auto"),
 		field.String("edge_bind_address").Default(""),
+		field.String("edge_interface").Default(""),
 		field.Bool("post_quantum").Default(false),
+		field.String("post_quantum_mode").Default(""),
 		field.Bool("no_tls_verify").Default(false),
 		field.String("extra_args").Default(""),
 		field.String("active_tunnel_key").Default("default"),
@@ -47,6 +49,27 @@ func (AppSetting) Fields() []ent.Field {
 		field.String("s3_webdav_dedicated_domain_mode").Default("none"),
 		field.String("s3_webdav_dedicated_custom_domain").Default(""),
 		field.String("s3_webdav_dedicated_tunnel_hostname").Default(""),
+		field.String("config_file").Default(""),
+		field.String("metrics_poll_interval").Default("15s"),
+		field.JSON("tags", map[string]string{}).Optional(),
+		field.String("idle_timeout").Default(""),
+		field.Int("schema_version").Default(1),
+		field.Bool("lazy_start").Default(false),
+		field.JSON("retryable_patterns", []string{}).Optional(),
+		field.JSON("non_retryable_patterns", []string{}).Optional(),
+		field.JSON("protocol_order", []string{}).Optional(),
+		field.Int("listen_port").Default(0),
+		field.String("listen_addr").Default(""),
+		field.Bool("require_connected_for_ready").Default(false),
+		field.Bool("strict_extra_args").Default(false),
+		field.Int("max_restarts_per_hour").Default(0),
+		field.String("max_runtime").Default(""),
+		field.Bool("require_confirm").Default(false),
+		field.Int("restart_jitter").Default(0),
+		field.Bool("sole_log_sink").Default(false),
+		field.Int("max_protocol_switches").Default(0),
+		field.String("stats_persist_interval").Default("1m"),
+		field.Bool("edge_ip_fallback").Default(false),
 		field.Time("created_at").Default(time.Now).Immutable(),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
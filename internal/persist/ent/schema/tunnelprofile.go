@@ -33,11 +33,13 @@ func (TunnelProfile) Fields() []ent.Field {
 		field.String("account_id").Default(""),
 		field.String("tunnel_id").Default(""),
 		field.Bool("auto_start").Default(false),
+		field.Bool("lazy_start").Default(false),
 		field.Bool("auto_restart").Default(true),
 		field.String("custom_tag").Default(""),
 		field.String("software_name").Default("cfui"),
 		field.String("protocol").Default("auto"),
 		field.String("grace_period").Default("30s"),
+		field.String("idle_timeout").Default(""),
 		field.String("region").Default(""),
 		field.Int("retries").Default(5),
 		field.Bool("metrics_enable").Default(false),
@@ -47,9 +49,12 @@ func (TunnelProfile) Fields() []ent.Field {
 		field.Bool("log_json").Default(false),
 		field.String("edge_ip_version").Default("auto"),
 		field.String("edge_bind_address").Default(""),
+		field.String("edge_interface").Default(""),
 		field.Bool("post_quantum").Default(false),
+		field.String("post_quantum_mode").Default(""),
 		field.Bool("no_tls_verify").Default(false),
 		field.String("extra_args").Default(""),
+		field.JSON("tags", map[string]string{}).Optional(),
 		field.Time("created_at").Default(time.Now).Immutable(),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"cfui/internal/persist/ent/tunnelprofile"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -35,6 +36,8 @@ type TunnelProfile struct {
 	TunnelID string `json:"tunnel_id,omitempty"`
 	// AutoStart holds the value of the "auto_start" field.
 	AutoStart bool `json:"auto_start,omitempty"`
+	// LazyStart holds the value of the "lazy_start" field.
+	LazyStart bool `json:"lazy_start,omitempty"`
 	// AutoRestart holds the value of the "auto_restart" field.
 	AutoRestart bool `json:"auto_restart,omitempty"`
 	// CustomTag holds the value of the "custom_tag" field.
@@ -45,6 +48,8 @@ type TunnelProfile struct {
 	Protocol string `json:"protocol,omitempty"`
 	// GracePeriod holds the value of the "grace_period" field.
 	GracePeriod string `json:"grace_period,omitempty"`
+	// IdleTimeout holds the value of the "idle_timeout" field.
+	IdleTimeout string `json:"idle_timeout,omitempty"`
 	// Region holds the value of the "region" field.
 	Region string `json:"region,omitempty"`
 	// Retries holds the value of the "retries" field.
@@ -63,12 +68,18 @@ type TunnelProfile struct {
 	EdgeIPVersion string `json:"edge_ip_version,omitempty"`
 	// EdgeBindAddress holds the value of the "edge_bind_address" field.
 	EdgeBindAddress string `json:"edge_bind_address,omitempty"`
+	// EdgeInterface holds the value of the "edge_interface" field.
+	EdgeInterface string `json:"edge_interface,omitempty"`
 	// PostQuantum holds the value of the "post_quantum" field.
 	PostQuantum bool `json:"post_quantum,omitempty"`
+	// PostQuantumMode holds the value of the "post_quantum_mode" field.
+	PostQuantumMode string `json:"post_quantum_mode,omitempty"`
 	// NoTLSVerify holds the value of the "no_tls_verify" field.
 	NoTLSVerify bool `json:"no_tls_verify,omitempty"`
 	// ExtraArgs holds the value of the "extra_args" field.
 	ExtraArgs string `json:"extra_args,omitempty"`
+	// Tags holds the value of the "tags" field.
+	Tags map[string]string `json:"tags,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -81,11 +92,13 @@ func (*TunnelProfile) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case tunnelprofile.FieldLocalEnabled, tunnelprofile.FieldRemoteManagementEnabled, tunnelprofile.FieldAutoStart, tunnelprofile.FieldAutoRestart, tunnelprofile.FieldMetricsEnable, tunnelprofile.FieldLogJSON, tunnelprofile.FieldPostQuantum, tunnelprofile.FieldNoTLSVerify:
+		case tunnelprofile.FieldTags:
+			values[i] = new([]byte)
+		case tunnelprofile.FieldLocalEnabled, tunnelprofile.FieldRemoteManagementEnabled, tunnelprofile.FieldAutoStart, tunnelprofile.FieldLazyStart, tunnelprofile.FieldAutoRestart, tunnelprofile.FieldMetricsEnable, tunnelprofile.FieldLogJSON, tunnelprofile.FieldPostQuantum, tunnelprofile.FieldNoTLSVerify:
 			values[i] = new(sql.NullBool)
 		case tunnelprofile.FieldID, tunnelprofile.FieldSortOrder, tunnelprofile.FieldRetries, tunnelprofile.FieldMetricsPort:
 			values[i] = new(sql.NullInt64)
-		case tunnelprofile.FieldKey, tunnelprofile.FieldName, tunnelprofile.FieldToken, tunnelprofile.FieldAccountID, tunnelprofile.FieldTunnelID, tunnelprofile.FieldCustomTag, tunnelprofile.FieldSoftwareName, tunnelprofile.FieldProtocol, tunnelprofile.FieldGracePeriod, tunnelprofile.FieldRegion, tunnelprofile.FieldLogLevel, tunnelprofile.FieldLogFile, tunnelprofile.FieldEdgeIPVersion, tunnelprofile.FieldEdgeBindAddress, tunnelprofile.FieldExtraArgs:
+		case tunnelprofile.FieldKey, tunnelprofile.FieldName, tunnelprofile.FieldToken, tunnelprofile.FieldAccountID, tunnelprofile.FieldTunnelID, tunnelprofile.FieldCustomTag, tunnelprofile.FieldSoftwareName, tunnelprofile.FieldProtocol, tunnelprofile.FieldGracePeriod, tunnelprofile.FieldIdleTimeout, tunnelprofile.FieldRegion, tunnelprofile.FieldLogLevel, tunnelprofile.FieldLogFile, tunnelprofile.FieldEdgeIPVersion, tunnelprofile.FieldEdgeBindAddress, tunnelprofile.FieldEdgeInterface, tunnelprofile.FieldPostQuantumMode, tunnelprofile.FieldExtraArgs:
 			values[i] = new(sql.NullString)
 		case tunnelprofile.FieldCreatedAt, tunnelprofile.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -164,6 +177,12 @@ func (_m *TunnelProfile) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.AutoStart = value.Bool
 			}
+		case tunnelprofile.FieldLazyStart:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field lazy_start", values[i])
+			} else if value.Valid {
+				_m.LazyStart = value.Bool
+			}
 		case tunnelprofile.FieldAutoRestart:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field auto_restart", values[i])
@@ -194,6 +213,12 @@ func (_m *TunnelProfile) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.GracePeriod = value.String
 			}
+		case tunnelprofile.FieldIdleTimeout:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field idle_timeout", values[i])
+			} else if value.Valid {
+				_m.IdleTimeout = value.String
+			}
 		case tunnelprofile.FieldRegion:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field region", values[i])
@@ -248,12 +273,24 @@ func (_m *TunnelProfile) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.EdgeBindAddress = value.String
 			}
+		case tunnelprofile.FieldEdgeInterface:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field edge_interface", values[i])
+			} else if value.Valid {
+				_m.EdgeInterface = value.String
+			}
 		case tunnelprofile.FieldPostQuantum:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field post_quantum", values[i])
 			} else if value.Valid {
 				_m.PostQuantum = value.Bool
 			}
+		case tunnelprofile.FieldPostQuantumMode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field post_quantum_mode", values[i])
+			} else if value.Valid {
+				_m.PostQuantumMode = value.String
+			}
 		case tunnelprofile.FieldNoTLSVerify:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field no_tls_verify", values[i])
@@ -266,6 +303,14 @@ func (_m *TunnelProfile) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.ExtraArgs = value.String
 			}
+		case tunnelprofile.FieldTags:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field tags", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Tags); err != nil {
+					return fmt.Errorf("unmarshal field tags: %w", err)
+				}
+			}
 		case tunnelprofile.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -340,6 +385,9 @@ func (_m *TunnelProfile) String() string {
 	builder.WriteString("auto_start=")
 	builder.WriteString(fmt.Sprintf("%v", _m.AutoStart))
 	builder.WriteString(", ")
+	builder.WriteString("lazy_start=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LazyStart))
+	builder.WriteString(", ")
 	builder.WriteString("auto_restart=")
 	builder.WriteString(fmt.Sprintf("%v", _m.AutoRestart))
 	builder.WriteString(", ")
@@ -355,6 +403,9 @@ func (_m *TunnelProfile) String() string {
 	builder.WriteString("grace_period=")
 	builder.WriteString(_m.GracePeriod)
 	builder.WriteString(", ")
+	builder.WriteString("idle_timeout=")
+	builder.WriteString(_m.IdleTimeout)
+	builder.WriteString(", ")
 	builder.WriteString("region=")
 	builder.WriteString(_m.Region)
 	builder.WriteString(", ")
@@ -382,15 +433,24 @@ func (_m *TunnelProfile) String() string {
 	builder.WriteString("edge_bind_address=")
 	builder.WriteString(_m.EdgeBindAddress)
 	builder.WriteString(", ")
+	builder.WriteString("edge_interface=")
+	builder.WriteString(_m.EdgeInterface)
+	builder.WriteString(", ")
 	builder.WriteString("post_quantum=")
 	builder.WriteString(fmt.Sprintf("%v", _m.PostQuantum))
 	builder.WriteString(", ")
+	builder.WriteString("post_quantum_mode=")
+	builder.WriteString(_m.PostQuantumMode)
+	builder.WriteString(", ")
 	builder.WriteString("no_tls_verify=")
 	builder.WriteString(fmt.Sprintf("%v", _m.NoTLSVerify))
 	builder.WriteString(", ")
 	builder.WriteString("extra_args=")
 	builder.WriteString(_m.ExtraArgs)
 	builder.WriteString(", ")
+	builder.WriteString("tags=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Tags))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
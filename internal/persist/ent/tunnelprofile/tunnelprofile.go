@@ -31,6 +31,8 @@ const (
 	FieldTunnelID = "tunnel_id"
 	// FieldAutoStart holds the string denoting the auto_start field in the database.
 	FieldAutoStart = "auto_start"
+	// FieldLazyStart holds the string denoting the lazy_start field in the database.
+	FieldLazyStart = "lazy_start"
 	// FieldAutoRestart holds the string denoting the auto_restart field in the database.
 	FieldAutoRestart = "auto_restart"
 	// FieldCustomTag holds the string denoting the custom_tag field in the database.
@@ -41,6 +43,8 @@ const (
 	FieldProtocol = "protocol"
 	// FieldGracePeriod holds the string denoting the grace_period field in the database.
 	FieldGracePeriod = "grace_period"
+	// FieldIdleTimeout holds the string denoting the idle_timeout field in the database.
+	FieldIdleTimeout = "idle_timeout"
 	// FieldRegion holds the string denoting the region field in the database.
 	FieldRegion = "region"
 	// FieldRetries holds the string denoting the retries field in the database.
@@ -59,12 +63,18 @@ const (
 	FieldEdgeIPVersion = "edge_ip_version"
 	// FieldEdgeBindAddress holds the string denoting the edge_bind_address field in the database.
 	FieldEdgeBindAddress = "edge_bind_address"
+	// FieldEdgeInterface holds the string denoting the edge_interface field in the database.
+	FieldEdgeInterface = "edge_interface"
 	// FieldPostQuantum holds the string denoting the post_quantum field in the database.
 	FieldPostQuantum = "post_quantum"
+	// FieldPostQuantumMode holds the string denoting the post_quantum_mode field in the database.
+	FieldPostQuantumMode = "post_quantum_mode"
 	// FieldNoTLSVerify holds the string denoting the no_tls_verify field in the database.
 	FieldNoTLSVerify = "no_tls_verify"
 	// FieldExtraArgs holds the string denoting the extra_args field in the database.
 	FieldExtraArgs = "extra_args"
+	// FieldTags holds the string denoting the tags field in the database.
+	FieldTags = "tags"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
@@ -85,11 +95,13 @@ var Columns = []string{
 	FieldAccountID,
 	FieldTunnelID,
 	FieldAutoStart,
+	FieldLazyStart,
 	FieldAutoRestart,
 	FieldCustomTag,
 	FieldSoftwareName,
 	FieldProtocol,
 	FieldGracePeriod,
+	FieldIdleTimeout,
 	FieldRegion,
 	FieldRetries,
 	FieldMetricsEnable,
@@ -99,9 +111,12 @@ var Columns = []string{
 	FieldLogJSON,
 	FieldEdgeIPVersion,
 	FieldEdgeBindAddress,
+	FieldEdgeInterface,
 	FieldPostQuantum,
+	FieldPostQuantumMode,
 	FieldNoTLSVerify,
 	FieldExtraArgs,
+	FieldTags,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -135,6 +150,8 @@ var (
 	DefaultTunnelID string
 	// DefaultAutoStart holds the default value on creation for the "auto_start" field.
 	DefaultAutoStart bool
+	// DefaultLazyStart holds the default value on creation for the "lazy_start" field.
+	DefaultLazyStart bool
 	// DefaultAutoRestart holds the default value on creation for the "auto_restart" field.
 	DefaultAutoRestart bool
 	// DefaultCustomTag holds the default value on creation for the "custom_tag" field.
@@ -145,6 +162,8 @@ var (
 	DefaultProtocol string
 	// DefaultGracePeriod holds the default value on creation for the "grace_period" field.
 	DefaultGracePeriod string
+	// DefaultIdleTimeout holds the default value on creation for the "idle_timeout" field.
+	DefaultIdleTimeout string
 	// DefaultRegion holds the default value on creation for the "region" field.
 	DefaultRegion string
 	// DefaultRetries holds the default value on creation for the "retries" field.
@@ -163,8 +182,12 @@ var (
 	DefaultEdgeIPVersion string
 	// DefaultEdgeBindAddress holds the default value on creation for the "edge_bind_address" field.
 	DefaultEdgeBindAddress string
+	// DefaultEdgeInterface holds the default value on creation for the "edge_interface" field.
+	DefaultEdgeInterface string
 	// DefaultPostQuantum holds the default value on creation for the "post_quantum" field.
 	DefaultPostQuantum bool
+	// DefaultPostQuantumMode holds the default value on creation for the "post_quantum_mode" field.
+	DefaultPostQuantumMode string
 	// DefaultNoTLSVerify holds the default value on creation for the "no_tls_verify" field.
 	DefaultNoTLSVerify bool
 	// DefaultExtraArgs holds the default value on creation for the "extra_args" field.
@@ -230,6 +253,11 @@ func ByAutoStart(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAutoStart, opts...).ToFunc()
 }
 
+// ByLazyStart orders the results by the lazy_start field.
+func ByLazyStart(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLazyStart, opts...).ToFunc()
+}
+
 // ByAutoRestart orders the results by the auto_restart field.
 func ByAutoRestart(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAutoRestart, opts...).ToFunc()
@@ -255,6 +283,11 @@ func ByGracePeriod(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldGracePeriod, opts...).ToFunc()
 }
 
+// ByIdleTimeout orders the results by the idle_timeout field.
+func ByIdleTimeout(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIdleTimeout, opts...).ToFunc()
+}
+
 // ByRegion orders the results by the region field.
 func ByRegion(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldRegion, opts...).ToFunc()
@@ -300,11 +333,21 @@ func ByEdgeBindAddress(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldEdgeBindAddress, opts...).ToFunc()
 }
 
+// ByEdgeInterface orders the results by the edge_interface field.
+func ByEdgeInterface(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEdgeInterface, opts...).ToFunc()
+}
+
 // ByPostQuantum orders the results by the post_quantum field.
 func ByPostQuantum(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPostQuantum, opts...).ToFunc()
 }
 
+// ByPostQuantumMode orders the results by the post_quantum_mode field.
+func ByPostQuantumMode(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPostQuantumMode, opts...).ToFunc()
+}
+
 // ByNoTLSVerify orders the results by the no_tls_verify field.
 func ByNoTLSVerify(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldNoTLSVerify, opts...).ToFunc()
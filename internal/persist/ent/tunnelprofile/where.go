@@ -99,6 +99,11 @@ func AutoStart(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldAutoStart, v))
 }
 
+// LazyStart applies equality check predicate on the "lazy_start" field. It's identical to LazyStartEQ.
+func LazyStart(v bool) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldLazyStart, v))
+}
+
 // AutoRestart applies equality check predicate on the "auto_restart" field. It's identical to AutoRestartEQ.
 func AutoRestart(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldAutoRestart, v))
@@ -124,6 +129,11 @@ func GracePeriod(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldGracePeriod, v))
 }
 
+// IdleTimeout applies equality check predicate on the "idle_timeout" field. It's identical to IdleTimeoutEQ.
+func IdleTimeout(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldIdleTimeout, v))
+}
+
 // Region applies equality check predicate on the "region" field. It's identical to RegionEQ.
 func Region(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldRegion, v))
@@ -169,11 +179,21 @@ func EdgeBindAddress(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldEdgeBindAddress, v))
 }
 
+// EdgeInterface applies equality check predicate on the "edge_interface" field. It's identical to EdgeInterfaceEQ.
+func EdgeInterface(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldEdgeInterface, v))
+}
+
 // PostQuantum applies equality check predicate on the "post_quantum" field. It's identical to PostQuantumEQ.
 func PostQuantum(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldPostQuantum, v))
 }
 
+// PostQuantumMode applies equality check predicate on the "post_quantum_mode" field. It's identical to PostQuantumModeEQ.
+func PostQuantumMode(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldPostQuantumMode, v))
+}
+
 // NoTLSVerify applies equality check predicate on the "no_tls_verify" field. It's identical to NoTLSVerifyEQ.
 func NoTLSVerify(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldNoTLSVerify, v))
@@ -589,6 +609,16 @@ func AutoStartNEQ(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldNEQ(FieldAutoStart, v))
 }
 
+// LazyStartEQ applies the EQ predicate on the "lazy_start" field.
+func LazyStartEQ(v bool) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldLazyStart, v))
+}
+
+// LazyStartNEQ applies the NEQ predicate on the "lazy_start" field.
+func LazyStartNEQ(v bool) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNEQ(FieldLazyStart, v))
+}
+
 // AutoRestartEQ applies the EQ predicate on the "auto_restart" field.
 func AutoRestartEQ(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldAutoRestart, v))
@@ -859,6 +889,71 @@ func GracePeriodContainsFold(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldContainsFold(FieldGracePeriod, v))
 }
 
+// IdleTimeoutEQ applies the EQ predicate on the "idle_timeout" field.
+func IdleTimeoutEQ(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutNEQ applies the NEQ predicate on the "idle_timeout" field.
+func IdleTimeoutNEQ(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNEQ(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutIn applies the In predicate on the "idle_timeout" field.
+func IdleTimeoutIn(vs ...string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldIn(FieldIdleTimeout, vs...))
+}
+
+// IdleTimeoutNotIn applies the NotIn predicate on the "idle_timeout" field.
+func IdleTimeoutNotIn(vs ...string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNotIn(FieldIdleTimeout, vs...))
+}
+
+// IdleTimeoutGT applies the GT predicate on the "idle_timeout" field.
+func IdleTimeoutGT(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldGT(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutGTE applies the GTE predicate on the "idle_timeout" field.
+func IdleTimeoutGTE(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldGTE(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutLT applies the LT predicate on the "idle_timeout" field.
+func IdleTimeoutLT(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldLT(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutLTE applies the LTE predicate on the "idle_timeout" field.
+func IdleTimeoutLTE(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldLTE(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutContains applies the Contains predicate on the "idle_timeout" field.
+func IdleTimeoutContains(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldContains(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutHasPrefix applies the HasPrefix predicate on the "idle_timeout" field.
+func IdleTimeoutHasPrefix(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldHasPrefix(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutHasSuffix applies the HasSuffix predicate on the "idle_timeout" field.
+func IdleTimeoutHasSuffix(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldHasSuffix(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutEqualFold applies the EqualFold predicate on the "idle_timeout" field.
+func IdleTimeoutEqualFold(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEqualFold(FieldIdleTimeout, v))
+}
+
+// IdleTimeoutContainsFold applies the ContainsFold predicate on the "idle_timeout" field.
+func IdleTimeoutContainsFold(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldContainsFold(FieldIdleTimeout, v))
+}
+
 // RegionEQ applies the EQ predicate on the "region" field.
 func RegionEQ(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldRegion, v))
@@ -1284,6 +1379,71 @@ func EdgeBindAddressContainsFold(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldContainsFold(FieldEdgeBindAddress, v))
 }
 
+// EdgeInterfaceEQ applies the EQ predicate on the "edge_interface" field.
+func EdgeInterfaceEQ(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceNEQ applies the NEQ predicate on the "edge_interface" field.
+func EdgeInterfaceNEQ(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNEQ(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceIn applies the In predicate on the "edge_interface" field.
+func EdgeInterfaceIn(vs ...string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldIn(FieldEdgeInterface, vs...))
+}
+
+// EdgeInterfaceNotIn applies the NotIn predicate on the "edge_interface" field.
+func EdgeInterfaceNotIn(vs ...string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNotIn(FieldEdgeInterface, vs...))
+}
+
+// EdgeInterfaceGT applies the GT predicate on the "edge_interface" field.
+func EdgeInterfaceGT(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldGT(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceGTE applies the GTE predicate on the "edge_interface" field.
+func EdgeInterfaceGTE(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldGTE(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceLT applies the LT predicate on the "edge_interface" field.
+func EdgeInterfaceLT(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldLT(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceLTE applies the LTE predicate on the "edge_interface" field.
+func EdgeInterfaceLTE(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldLTE(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceContains applies the Contains predicate on the "edge_interface" field.
+func EdgeInterfaceContains(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldContains(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceHasPrefix applies the HasPrefix predicate on the "edge_interface" field.
+func EdgeInterfaceHasPrefix(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldHasPrefix(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceHasSuffix applies the HasSuffix predicate on the "edge_interface" field.
+func EdgeInterfaceHasSuffix(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldHasSuffix(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceEqualFold applies the EqualFold predicate on the "edge_interface" field.
+func EdgeInterfaceEqualFold(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEqualFold(FieldEdgeInterface, v))
+}
+
+// EdgeInterfaceContainsFold applies the ContainsFold predicate on the "edge_interface" field.
+func EdgeInterfaceContainsFold(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldContainsFold(FieldEdgeInterface, v))
+}
+
 // PostQuantumEQ applies the EQ predicate on the "post_quantum" field.
 func PostQuantumEQ(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldPostQuantum, v))
@@ -1294,6 +1454,71 @@ func PostQuantumNEQ(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldNEQ(FieldPostQuantum, v))
 }
 
+// PostQuantumModeEQ applies the EQ predicate on the "post_quantum_mode" field.
+func PostQuantumModeEQ(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEQ(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeNEQ applies the NEQ predicate on the "post_quantum_mode" field.
+func PostQuantumModeNEQ(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNEQ(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeIn applies the In predicate on the "post_quantum_mode" field.
+func PostQuantumModeIn(vs ...string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldIn(FieldPostQuantumMode, vs...))
+}
+
+// PostQuantumModeNotIn applies the NotIn predicate on the "post_quantum_mode" field.
+func PostQuantumModeNotIn(vs ...string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNotIn(FieldPostQuantumMode, vs...))
+}
+
+// PostQuantumModeGT applies the GT predicate on the "post_quantum_mode" field.
+func PostQuantumModeGT(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldGT(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeGTE applies the GTE predicate on the "post_quantum_mode" field.
+func PostQuantumModeGTE(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldGTE(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeLT applies the LT predicate on the "post_quantum_mode" field.
+func PostQuantumModeLT(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldLT(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeLTE applies the LTE predicate on the "post_quantum_mode" field.
+func PostQuantumModeLTE(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldLTE(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeContains applies the Contains predicate on the "post_quantum_mode" field.
+func PostQuantumModeContains(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldContains(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeHasPrefix applies the HasPrefix predicate on the "post_quantum_mode" field.
+func PostQuantumModeHasPrefix(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldHasPrefix(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeHasSuffix applies the HasSuffix predicate on the "post_quantum_mode" field.
+func PostQuantumModeHasSuffix(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldHasSuffix(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeEqualFold applies the EqualFold predicate on the "post_quantum_mode" field.
+func PostQuantumModeEqualFold(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldEqualFold(FieldPostQuantumMode, v))
+}
+
+// PostQuantumModeContainsFold applies the ContainsFold predicate on the "post_quantum_mode" field.
+func PostQuantumModeContainsFold(v string) predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldContainsFold(FieldPostQuantumMode, v))
+}
+
 // NoTLSVerifyEQ applies the EQ predicate on the "no_tls_verify" field.
 func NoTLSVerifyEQ(v bool) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldNoTLSVerify, v))
@@ -1369,6 +1594,16 @@ func ExtraArgsContainsFold(v string) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldContainsFold(FieldExtraArgs, v))
 }
 
+// TagsIsNil applies the IsNil predicate on the "tags" field.
+func TagsIsNil() predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldIsNull(FieldTags))
+}
+
+// TagsNotNil applies the NotNil predicate on the "tags" field.
+func TagsNotNil() predicate.TunnelProfile {
+	return predicate.TunnelProfile(sql.FieldNotNull(FieldTags))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.TunnelProfile {
 	return predicate.TunnelProfile(sql.FieldEQ(FieldCreatedAt, v))
@@ -138,6 +138,20 @@ func (_c *TunnelProfileCreate) SetNillableAutoStart(v *bool) *TunnelProfileCreat
 	return _c
 }
 
+// SetLazyStart sets the "lazy_start" field.
+func (_c *TunnelProfileCreate) SetLazyStart(v bool) *TunnelProfileCreate {
+	_c.mutation.SetLazyStart(v)
+	return _c
+}
+
+// SetNillableLazyStart sets the "lazy_start" field if the given value is not nil.
+func (_c *TunnelProfileCreate) SetNillableLazyStart(v *bool) *TunnelProfileCreate {
+	if v != nil {
+		_c.SetLazyStart(*v)
+	}
+	return _c
+}
+
 // SetAutoRestart sets the "auto_restart" field.
 func (_c *TunnelProfileCreate) SetAutoRestart(v bool) *TunnelProfileCreate {
 	_c.mutation.SetAutoRestart(v)
@@ -208,6 +222,20 @@ func (_c *TunnelProfileCreate) SetNillableGracePeriod(v *string) *TunnelProfileC
 	return _c
 }
 
+// SetIdleTimeout sets the "idle_timeout" field.
+func (_c *TunnelProfileCreate) SetIdleTimeout(v string) *TunnelProfileCreate {
+	_c.mutation.SetIdleTimeout(v)
+	return _c
+}
+
+// SetNillableIdleTimeout sets the "idle_timeout" field if the given value is not nil.
+func (_c *TunnelProfileCreate) SetNillableIdleTimeout(v *string) *TunnelProfileCreate {
+	if v != nil {
+		_c.SetIdleTimeout(*v)
+	}
+	return _c
+}
+
 // SetRegion sets the "region" field.
 func (_c *TunnelProfileCreate) SetRegion(v string) *TunnelProfileCreate {
 	_c.mutation.SetRegion(v)
@@ -334,6 +362,20 @@ func (_c *TunnelProfileCreate) SetNillableEdgeBindAddress(v *string) *TunnelProf
 	return _c
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (_c *TunnelProfileCreate) SetEdgeInterface(v string) *TunnelProfileCreate {
+	_c.mutation.SetEdgeInterface(v)
+	return _c
+}
+
+// SetNillableEdgeInterface sets the "edge_interface" field if the given value is not nil.
+func (_c *TunnelProfileCreate) SetNillableEdgeInterface(v *string) *TunnelProfileCreate {
+	if v != nil {
+		_c.SetEdgeInterface(*v)
+	}
+	return _c
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (_c *TunnelProfileCreate) SetPostQuantum(v bool) *TunnelProfileCreate {
 	_c.mutation.SetPostQuantum(v)
@@ -348,6 +390,20 @@ func (_c *TunnelProfileCreate) SetNillablePostQuantum(v *bool) *TunnelProfileCre
 	return _c
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (_c *TunnelProfileCreate) SetPostQuantumMode(v string) *TunnelProfileCreate {
+	_c.mutation.SetPostQuantumMode(v)
+	return _c
+}
+
+// SetNillablePostQuantumMode sets the "post_quantum_mode" field if the given value is not nil.
+func (_c *TunnelProfileCreate) SetNillablePostQuantumMode(v *string) *TunnelProfileCreate {
+	if v != nil {
+		_c.SetPostQuantumMode(*v)
+	}
+	return _c
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (_c *TunnelProfileCreate) SetNoTLSVerify(v bool) *TunnelProfileCreate {
 	_c.mutation.SetNoTLSVerify(v)
@@ -376,6 +432,12 @@ func (_c *TunnelProfileCreate) SetNillableExtraArgs(v *string) *TunnelProfileCre
 	return _c
 }
 
+// SetTags sets the "tags" field.
+func (_c *TunnelProfileCreate) SetTags(v map[string]string) *TunnelProfileCreate {
+	_c.mutation.SetTags(v)
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *TunnelProfileCreate) SetCreatedAt(v time.Time) *TunnelProfileCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -471,6 +533,10 @@ func (_c *TunnelProfileCreate) defaults() {
 		v := tunnelprofile.DefaultAutoStart
 		_c.mutation.SetAutoStart(v)
 	}
+	if _, ok := _c.mutation.LazyStart(); !ok {
+		v := tunnelprofile.DefaultLazyStart
+		_c.mutation.SetLazyStart(v)
+	}
 	if _, ok := _c.mutation.AutoRestart(); !ok {
 		v := tunnelprofile.DefaultAutoRestart
 		_c.mutation.SetAutoRestart(v)
@@ -491,6 +557,10 @@ func (_c *TunnelProfileCreate) defaults() {
 		v := tunnelprofile.DefaultGracePeriod
 		_c.mutation.SetGracePeriod(v)
 	}
+	if _, ok := _c.mutation.IdleTimeout(); !ok {
+		v := tunnelprofile.DefaultIdleTimeout
+		_c.mutation.SetIdleTimeout(v)
+	}
 	if _, ok := _c.mutation.Region(); !ok {
 		v := tunnelprofile.DefaultRegion
 		_c.mutation.SetRegion(v)
@@ -527,10 +597,18 @@ func (_c *TunnelProfileCreate) defaults() {
 		v := tunnelprofile.DefaultEdgeBindAddress
 		_c.mutation.SetEdgeBindAddress(v)
 	}
+	if _, ok := _c.mutation.EdgeInterface(); !ok {
+		v := tunnelprofile.DefaultEdgeInterface
+		_c.mutation.SetEdgeInterface(v)
+	}
 	if _, ok := _c.mutation.PostQuantum(); !ok {
 		v := tunnelprofile.DefaultPostQuantum
 		_c.mutation.SetPostQuantum(v)
 	}
+	if _, ok := _c.mutation.PostQuantumMode(); !ok {
+		v := tunnelprofile.DefaultPostQuantumMode
+		_c.mutation.SetPostQuantumMode(v)
+	}
 	if _, ok := _c.mutation.NoTLSVerify(); !ok {
 		v := tunnelprofile.DefaultNoTLSVerify
 		_c.mutation.SetNoTLSVerify(v)
@@ -583,6 +661,9 @@ func (_c *TunnelProfileCreate) check() error {
 	if _, ok := _c.mutation.AutoStart(); !ok {
 		return &ValidationError{Name: "auto_start", err: errors.New(`ent: missing required field "TunnelProfile.auto_start"`)}
 	}
+	if _, ok := _c.mutation.LazyStart(); !ok {
+		return &ValidationError{Name: "lazy_start", err: errors.New(`ent: missing required field "TunnelProfile.lazy_start"`)}
+	}
 	if _, ok := _c.mutation.AutoRestart(); !ok {
 		return &ValidationError{Name: "auto_restart", err: errors.New(`ent: missing required field "TunnelProfile.auto_restart"`)}
 	}
@@ -598,6 +679,9 @@ func (_c *TunnelProfileCreate) check() error {
 	if _, ok := _c.mutation.GracePeriod(); !ok {
 		return &ValidationError{Name: "grace_period", err: errors.New(`ent: missing required field "TunnelProfile.grace_period"`)}
 	}
+	if _, ok := _c.mutation.IdleTimeout(); !ok {
+		return &ValidationError{Name: "idle_timeout", err: errors.New(`ent: missing required field "TunnelProfile.idle_timeout"`)}
+	}
 	if _, ok := _c.mutation.Region(); !ok {
 		return &ValidationError{Name: "region", err: errors.New(`ent: missing required field "TunnelProfile.region"`)}
 	}
@@ -625,9 +709,15 @@ func (_c *TunnelProfileCreate) check() error {
 	if _, ok := _c.mutation.EdgeBindAddress(); !ok {
 		return &ValidationError{Name: "edge_bind_address", err: errors.New(`ent: missing required field "TunnelProfile.edge_bind_address"`)}
 	}
+	if _, ok := _c.mutation.EdgeInterface(); !ok {
+		return &ValidationError{Name: "edge_interface", err: errors.New(`ent: missing required field "TunnelProfile.edge_interface"`)}
+	}
 	if _, ok := _c.mutation.PostQuantum(); !ok {
 		return &ValidationError{Name: "post_quantum", err: errors.New(`ent: missing required field "TunnelProfile.post_quantum"`)}
 	}
+	if _, ok := _c.mutation.PostQuantumMode(); !ok {
+		return &ValidationError{Name: "post_quantum_mode", err: errors.New(`ent: missing required field "TunnelProfile.post_quantum_mode"`)}
+	}
 	if _, ok := _c.mutation.NoTLSVerify(); !ok {
 		return &ValidationError{Name: "no_tls_verify", err: errors.New(`ent: missing required field "TunnelProfile.no_tls_verify"`)}
 	}
@@ -702,6 +792,10 @@ func (_c *TunnelProfileCreate) createSpec() (*TunnelProfile, *sqlgraph.CreateSpe
 		_spec.SetField(tunnelprofile.FieldAutoStart, field.TypeBool, value)
 		_node.AutoStart = value
 	}
+	if value, ok := _c.mutation.LazyStart(); ok {
+		_spec.SetField(tunnelprofile.FieldLazyStart, field.TypeBool, value)
+		_node.LazyStart = value
+	}
 	if value, ok := _c.mutation.AutoRestart(); ok {
 		_spec.SetField(tunnelprofile.FieldAutoRestart, field.TypeBool, value)
 		_node.AutoRestart = value
@@ -722,6 +816,10 @@ func (_c *TunnelProfileCreate) createSpec() (*TunnelProfile, *sqlgraph.CreateSpe
 		_spec.SetField(tunnelprofile.FieldGracePeriod, field.TypeString, value)
 		_node.GracePeriod = value
 	}
+	if value, ok := _c.mutation.IdleTimeout(); ok {
+		_spec.SetField(tunnelprofile.FieldIdleTimeout, field.TypeString, value)
+		_node.IdleTimeout = value
+	}
 	if value, ok := _c.mutation.Region(); ok {
 		_spec.SetField(tunnelprofile.FieldRegion, field.TypeString, value)
 		_node.Region = value
@@ -758,10 +856,18 @@ func (_c *TunnelProfileCreate) createSpec() (*TunnelProfile, *sqlgraph.CreateSpe
 		_spec.SetField(tunnelprofile.FieldEdgeBindAddress, field.TypeString, value)
 		_node.EdgeBindAddress = value
 	}
+	if value, ok := _c.mutation.EdgeInterface(); ok {
+		_spec.SetField(tunnelprofile.FieldEdgeInterface, field.TypeString, value)
+		_node.EdgeInterface = value
+	}
 	if value, ok := _c.mutation.PostQuantum(); ok {
 		_spec.SetField(tunnelprofile.FieldPostQuantum, field.TypeBool, value)
 		_node.PostQuantum = value
 	}
+	if value, ok := _c.mutation.PostQuantumMode(); ok {
+		_spec.SetField(tunnelprofile.FieldPostQuantumMode, field.TypeString, value)
+		_node.PostQuantumMode = value
+	}
 	if value, ok := _c.mutation.NoTLSVerify(); ok {
 		_spec.SetField(tunnelprofile.FieldNoTLSVerify, field.TypeBool, value)
 		_node.NoTLSVerify = value
@@ -770,6 +876,10 @@ func (_c *TunnelProfileCreate) createSpec() (*TunnelProfile, *sqlgraph.CreateSpe
 		_spec.SetField(tunnelprofile.FieldExtraArgs, field.TypeString, value)
 		_node.ExtraArgs = value
 	}
+	if value, ok := _c.mutation.Tags(); ok {
+		_spec.SetField(tunnelprofile.FieldTags, field.TypeJSON, value)
+		_node.Tags = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(tunnelprofile.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -161,6 +161,20 @@ func (_u *TunnelProfileUpdate) SetNillableAutoStart(v *bool) *TunnelProfileUpdat
 	return _u
 }
 
+// SetLazyStart sets the "lazy_start" field.
+func (_u *TunnelProfileUpdate) SetLazyStart(v bool) *TunnelProfileUpdate {
+	_u.mutation.SetLazyStart(v)
+	return _u
+}
+
+// SetNillableLazyStart sets the "lazy_start" field if the given value is not nil.
+func (_u *TunnelProfileUpdate) SetNillableLazyStart(v *bool) *TunnelProfileUpdate {
+	if v != nil {
+		_u.SetLazyStart(*v)
+	}
+	return _u
+}
+
 // SetAutoRestart sets the "auto_restart" field.
 func (_u *TunnelProfileUpdate) SetAutoRestart(v bool) *TunnelProfileUpdate {
 	_u.mutation.SetAutoRestart(v)
@@ -231,6 +245,20 @@ func (_u *TunnelProfileUpdate) SetNillableGracePeriod(v *string) *TunnelProfileU
 	return _u
 }
 
+// SetIdleTimeout sets the "idle_timeout" field.
+func (_u *TunnelProfileUpdate) SetIdleTimeout(v string) *TunnelProfileUpdate {
+	_u.mutation.SetIdleTimeout(v)
+	return _u
+}
+
+// SetNillableIdleTimeout sets the "idle_timeout" field if the given value is not nil.
+func (_u *TunnelProfileUpdate) SetNillableIdleTimeout(v *string) *TunnelProfileUpdate {
+	if v != nil {
+		_u.SetIdleTimeout(*v)
+	}
+	return _u
+}
+
 // SetRegion sets the "region" field.
 func (_u *TunnelProfileUpdate) SetRegion(v string) *TunnelProfileUpdate {
 	_u.mutation.SetRegion(v)
@@ -371,6 +399,20 @@ func (_u *TunnelProfileUpdate) SetNillableEdgeBindAddress(v *string) *TunnelProf
 	return _u
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (_u *TunnelProfileUpdate) SetEdgeInterface(v string) *TunnelProfileUpdate {
+	_u.mutation.SetEdgeInterface(v)
+	return _u
+}
+
+// SetNillableEdgeInterface sets the "edge_interface" field if the given value is not nil.
+func (_u *TunnelProfileUpdate) SetNillableEdgeInterface(v *string) *TunnelProfileUpdate {
+	if v != nil {
+		_u.SetEdgeInterface(*v)
+	}
+	return _u
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (_u *TunnelProfileUpdate) SetPostQuantum(v bool) *TunnelProfileUpdate {
 	_u.mutation.SetPostQuantum(v)
@@ -385,6 +427,20 @@ func (_u *TunnelProfileUpdate) SetNillablePostQuantum(v *bool) *TunnelProfileUpd
 	return _u
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (_u *TunnelProfileUpdate) SetPostQuantumMode(v string) *TunnelProfileUpdate {
+	_u.mutation.SetPostQuantumMode(v)
+	return _u
+}
+
+// SetNillablePostQuantumMode sets the "post_quantum_mode" field if the given value is not nil.
+func (_u *TunnelProfileUpdate) SetNillablePostQuantumMode(v *string) *TunnelProfileUpdate {
+	if v != nil {
+		_u.SetPostQuantumMode(*v)
+	}
+	return _u
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (_u *TunnelProfileUpdate) SetNoTLSVerify(v bool) *TunnelProfileUpdate {
 	_u.mutation.SetNoTLSVerify(v)
@@ -413,6 +469,18 @@ func (_u *TunnelProfileUpdate) SetNillableExtraArgs(v *string) *TunnelProfileUpd
 	return _u
 }
 
+// SetTags sets the "tags" field.
+func (_u *TunnelProfileUpdate) SetTags(v map[string]string) *TunnelProfileUpdate {
+	_u.mutation.SetTags(v)
+	return _u
+}
+
+// ClearTags clears the value of the "tags" field.
+func (_u *TunnelProfileUpdate) ClearTags() *TunnelProfileUpdate {
+	_u.mutation.ClearTags()
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *TunnelProfileUpdate) SetUpdatedAt(v time.Time) *TunnelProfileUpdate {
 	_u.mutation.SetUpdatedAt(v)
@@ -512,6 +580,9 @@ func (_u *TunnelProfileUpdate) sqlSave(ctx context.Context) (_node int, err erro
 	if value, ok := _u.mutation.AutoStart(); ok {
 		_spec.SetField(tunnelprofile.FieldAutoStart, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.LazyStart(); ok {
+		_spec.SetField(tunnelprofile.FieldLazyStart, field.TypeBool, value)
+	}
 	if value, ok := _u.mutation.AutoRestart(); ok {
 		_spec.SetField(tunnelprofile.FieldAutoRestart, field.TypeBool, value)
 	}
@@ -527,6 +598,9 @@ func (_u *TunnelProfileUpdate) sqlSave(ctx context.Context) (_node int, err erro
 	if value, ok := _u.mutation.GracePeriod(); ok {
 		_spec.SetField(tunnelprofile.FieldGracePeriod, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.IdleTimeout(); ok {
+		_spec.SetField(tunnelprofile.FieldIdleTimeout, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Region(); ok {
 		_spec.SetField(tunnelprofile.FieldRegion, field.TypeString, value)
 	}
@@ -560,15 +634,27 @@ func (_u *TunnelProfileUpdate) sqlSave(ctx context.Context) (_node int, err erro
 	if value, ok := _u.mutation.EdgeBindAddress(); ok {
 		_spec.SetField(tunnelprofile.FieldEdgeBindAddress, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.EdgeInterface(); ok {
+		_spec.SetField(tunnelprofile.FieldEdgeInterface, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.PostQuantum(); ok {
 		_spec.SetField(tunnelprofile.FieldPostQuantum, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.PostQuantumMode(); ok {
+		_spec.SetField(tunnelprofile.FieldPostQuantumMode, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.NoTLSVerify(); ok {
 		_spec.SetField(tunnelprofile.FieldNoTLSVerify, field.TypeBool, value)
 	}
 	if value, ok := _u.mutation.ExtraArgs(); ok {
 		_spec.SetField(tunnelprofile.FieldExtraArgs, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Tags(); ok {
+		_spec.SetField(tunnelprofile.FieldTags, field.TypeJSON, value)
+	}
+	if _u.mutation.TagsCleared() {
+		_spec.ClearField(tunnelprofile.FieldTags, field.TypeJSON)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(tunnelprofile.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -725,6 +811,20 @@ func (_u *TunnelProfileUpdateOne) SetNillableAutoStart(v *bool) *TunnelProfileUp
 	return _u
 }
 
+// SetLazyStart sets the "lazy_start" field.
+func (_u *TunnelProfileUpdateOne) SetLazyStart(v bool) *TunnelProfileUpdateOne {
+	_u.mutation.SetLazyStart(v)
+	return _u
+}
+
+// SetNillableLazyStart sets the "lazy_start" field if the given value is not nil.
+func (_u *TunnelProfileUpdateOne) SetNillableLazyStart(v *bool) *TunnelProfileUpdateOne {
+	if v != nil {
+		_u.SetLazyStart(*v)
+	}
+	return _u
+}
+
 // SetAutoRestart sets the "auto_restart" field.
 func (_u *TunnelProfileUpdateOne) SetAutoRestart(v bool) *TunnelProfileUpdateOne {
 	_u.mutation.SetAutoRestart(v)
@@ -795,6 +895,20 @@ func (_u *TunnelProfileUpdateOne) SetNillableGracePeriod(v *string) *TunnelProfi
 	return _u
 }
 
+// SetIdleTimeout sets the "idle_timeout" field.
+func (_u *TunnelProfileUpdateOne) SetIdleTimeout(v string) *TunnelProfileUpdateOne {
+	_u.mutation.SetIdleTimeout(v)
+	return _u
+}
+
+// SetNillableIdleTimeout sets the "idle_timeout" field if the given value is not nil.
+func (_u *TunnelProfileUpdateOne) SetNillableIdleTimeout(v *string) *TunnelProfileUpdateOne {
+	if v != nil {
+		_u.SetIdleTimeout(*v)
+	}
+	return _u
+}
+
 // SetRegion sets the "region" field.
 func (_u *TunnelProfileUpdateOne) SetRegion(v string) *TunnelProfileUpdateOne {
 	_u.mutation.SetRegion(v)
@@ -935,6 +1049,20 @@ func (_u *TunnelProfileUpdateOne) SetNillableEdgeBindAddress(v *string) *TunnelP
 	return _u
 }
 
+// SetEdgeInterface sets the "edge_interface" field.
+func (_u *TunnelProfileUpdateOne) SetEdgeInterface(v string) *TunnelProfileUpdateOne {
+	_u.mutation.SetEdgeInterface(v)
+	return _u
+}
+
+// SetNillableEdgeInterface sets the "edge_interface" field if the given value is not nil.
+func (_u *TunnelProfileUpdateOne) SetNillableEdgeInterface(v *string) *TunnelProfileUpdateOne {
+	if v != nil {
+		_u.SetEdgeInterface(*v)
+	}
+	return _u
+}
+
 // SetPostQuantum sets the "post_quantum" field.
 func (_u *TunnelProfileUpdateOne) SetPostQuantum(v bool) *TunnelProfileUpdateOne {
 	_u.mutation.SetPostQuantum(v)
@@ -949,6 +1077,20 @@ func (_u *TunnelProfileUpdateOne) SetNillablePostQuantum(v *bool) *TunnelProfile
 	return _u
 }
 
+// SetPostQuantumMode sets the "post_quantum_mode" field.
+func (_u *TunnelProfileUpdateOne) SetPostQuantumMode(v string) *TunnelProfileUpdateOne {
+	_u.mutation.SetPostQuantumMode(v)
+	return _u
+}
+
+// SetNillablePostQuantumMode sets the "post_quantum_mode" field if the given value is not nil.
+func (_u *TunnelProfileUpdateOne) SetNillablePostQuantumMode(v *string) *TunnelProfileUpdateOne {
+	if v != nil {
+		_u.SetPostQuantumMode(*v)
+	}
+	return _u
+}
+
 // SetNoTLSVerify sets the "no_tls_verify" field.
 func (_u *TunnelProfileUpdateOne) SetNoTLSVerify(v bool) *TunnelProfileUpdateOne {
 	_u.mutation.SetNoTLSVerify(v)
@@ -977,6 +1119,18 @@ func (_u *TunnelProfileUpdateOne) SetNillableExtraArgs(v *string) *TunnelProfile
 	return _u
 }
 
+// SetTags sets the "tags" field.
+func (_u *TunnelProfileUpdateOne) SetTags(v map[string]string) *TunnelProfileUpdateOne {
+	_u.mutation.SetTags(v)
+	return _u
+}
+
+// ClearTags clears the value of the "tags" field.
+func (_u *TunnelProfileUpdateOne) ClearTags() *TunnelProfileUpdateOne {
+	_u.mutation.ClearTags()
+	return _u
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (_u *TunnelProfileUpdateOne) SetUpdatedAt(v time.Time) *TunnelProfileUpdateOne {
 	_u.mutation.SetUpdatedAt(v)
@@ -1106,6 +1260,9 @@ func (_u *TunnelProfileUpdateOne) sqlSave(ctx context.Context) (_node *TunnelPro
 	if value, ok := _u.mutation.AutoStart(); ok {
 		_spec.SetField(tunnelprofile.FieldAutoStart, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.LazyStart(); ok {
+		_spec.SetField(tunnelprofile.FieldLazyStart, field.TypeBool, value)
+	}
 	if value, ok := _u.mutation.AutoRestart(); ok {
 		_spec.SetField(tunnelprofile.FieldAutoRestart, field.TypeBool, value)
 	}
@@ -1121,6 +1278,9 @@ func (_u *TunnelProfileUpdateOne) sqlSave(ctx context.Context) (_node *TunnelPro
 	if value, ok := _u.mutation.GracePeriod(); ok {
 		_spec.SetField(tunnelprofile.FieldGracePeriod, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.IdleTimeout(); ok {
+		_spec.SetField(tunnelprofile.FieldIdleTimeout, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.Region(); ok {
 		_spec.SetField(tunnelprofile.FieldRegion, field.TypeString, value)
 	}
@@ -1154,15 +1314,27 @@ func (_u *TunnelProfileUpdateOne) sqlSave(ctx context.Context) (_node *TunnelPro
 	if value, ok := _u.mutation.EdgeBindAddress(); ok {
 		_spec.SetField(tunnelprofile.FieldEdgeBindAddress, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.EdgeInterface(); ok {
+		_spec.SetField(tunnelprofile.FieldEdgeInterface, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.PostQuantum(); ok {
 		_spec.SetField(tunnelprofile.FieldPostQuantum, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.PostQuantumMode(); ok {
+		_spec.SetField(tunnelprofile.FieldPostQuantumMode, field.TypeString, value)
+	}
 	if value, ok := _u.mutation.NoTLSVerify(); ok {
 		_spec.SetField(tunnelprofile.FieldNoTLSVerify, field.TypeBool, value)
 	}
 	if value, ok := _u.mutation.ExtraArgs(); ok {
 		_spec.SetField(tunnelprofile.FieldExtraArgs, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Tags(); ok {
+		_spec.SetField(tunnelprofile.FieldTags, field.TypeJSON, value)
+	}
+	if _u.mutation.TagsCleared() {
+		_spec.ClearField(tunnelprofile.FieldTags, field.TypeJSON)
+	}
 	if value, ok := _u.mutation.UpdatedAt(); ok {
 		_spec.SetField(tunnelprofile.FieldUpdatedAt, field.TypeTime, value)
 	}
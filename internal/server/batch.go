@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// maxBatchRequests caps how many sub-requests one POST /api/batch may carry.
+const maxBatchRequests = 20
+
+// batchUnsupportedPaths never complete on their own (streams), would
+// recurse, or send whole files (downloads and exports), which a batch would
+// buffer in memory and re-encode, so they cannot run inside a batch.
+var batchUnsupportedPaths = map[string]bool{
+	"/api/batch":                 true,
+	"/api/logs/stream":           true,
+	"/api/metrics/stream":        true,
+	"/api/ws":                    true,
+	"/api/logs/download":         true,
+	"/api/logs/export":           true,
+	"/api/cf/r2/object/download": true,
+	"/api/cf/kv/value/download":  true,
+	"/api/s3/files/download":     true,
+}
+
+// BatchRequest is one sub-request of POST /api/batch. Body is sent as the
+// sub-request's JSON body.
+type BatchRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResult is the response to one sub-request. Body holds the handler's
+// JSON output, or a JSON string for non-JSON output.
+type BatchResult struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// batchWritesAllowed reports whether CFUI_BATCH_ALLOW_WRITES lets batches
+// carry mutating methods. Batches are read-only by default.
+func batchWritesAllowed() bool {
+	allowed, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("CFUI_BATCH_ALLOW_WRITES")))
+	return allowed
+}
+
+// handleBatch runs sub-requests sequentially against api and returns their
+// results in order. A rejected sub-request fails the whole batch before any
+// of them runs.
+func (s *Server) handleBatch(api http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var reqs []BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid batch: %w", err))
+			return
+		}
+		if len(reqs) == 0 || len(reqs) > maxBatchRequests {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("batch must contain 1 to %d requests", maxBatchRequests))
+			return
+		}
+		allowWrites := batchWritesAllowed()
+		for i := range reqs {
+			if err := validateBatchRequest(&reqs[i], allowWrites); err != nil {
+				writeAPIError(w, http.StatusBadRequest, fmt.Errorf("request %d: %w", i, err))
+				return
+			}
+		}
+
+		results := make([]BatchResult, 0, len(reqs))
+		for _, req := range reqs {
+			sub, err := http.NewRequestWithContext(r.Context(), req.Method, req.Path, bytes.NewReader(req.Body))
+			if err != nil {
+				writeAPIError(w, http.StatusBadRequest, err)
+				return
+			}
+			sub.RemoteAddr = r.RemoteAddr
+			if len(req.Body) > 0 {
+				sub.Header.Set("Content-Type", "application/json")
+			}
+			rec := newBatchRecorder()
+			api.ServeHTTP(rec, sub)
+			results = append(results, rec.result())
+		}
+		writeJSON(w, results)
+	}
+}
+
+func validateBatchRequest(req *BatchRequest, allowWrites bool) error {
+	req.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+	u, err := url.Parse(req.Path)
+	if err != nil || u.IsAbs() || !strings.HasPrefix(u.Path, "/api/") {
+		return fmt.Errorf("path %q must be a local /api/ path", req.Path)
+	}
+	if batchUnsupportedPaths[u.Path] {
+		return fmt.Errorf("path %q cannot be batched", u.Path)
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		if !allowWrites {
+			return errors.New("mutating methods are disabled in batches (set CFUI_BATCH_ALLOW_WRITES=true)")
+		}
+	default:
+		return fmt.Errorf("unsupported method %q", req.Method)
+	}
+	return nil
+}
+
+// batchRecorder buffers one sub-request's response.
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{header: make(http.Header)}
+}
+
+func (b *batchRecorder) Header() http.Header { return b.header }
+
+func (b *batchRecorder) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *batchRecorder) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(p)
+}
+
+func (b *batchRecorder) result() BatchResult {
+	res := BatchResult{Status: b.status}
+	if res.Status == 0 {
+		res.Status = http.StatusOK
+	}
+	body := bytes.TrimSpace(b.body.Bytes())
+	switch {
+	case len(body) == 0:
+	case json.Valid(body):
+		res.Body = json.RawMessage(body)
+	default:
+		res.Body, _ = json.Marshal(string(body))
+	}
+	return res
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postBatch(t *testing.T, s *Server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/status", s.handleStatus)
+	mux.HandleFunc("/api/version", s.handleVersion)
+	mux.HandleFunc("/api/config", s.handleConfig)
+	rec := httptest.NewRecorder()
+	s.handleBatch(mux)(rec, httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(body)))
+	return rec
+}
+
+func TestBatchStatusAndVersion(t *testing.T) {
+	s := newServerTestServer(t)
+	rec := postBatch(t, s, `[{"method":"GET","path":"/api/status"},{"path":"/api/version"}]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("batch status %d: %s", rec.Code, rec.Body.String())
+	}
+	var results []BatchResult
+	if err := json.NewDecoder(rec.Body).Decode(&results); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(results) != 2 || results[0].Status != http.StatusOK || results[1].Status != http.StatusOK {
+		t.Fatalf("results = %+v", results)
+	}
+	var status StatusResponse
+	if err := json.Unmarshal(results[0].Body, &status); err != nil || status.Status == "" {
+		t.Fatalf("status body %s: %v", results[0].Body, err)
+	}
+	var version map[string]any
+	if err := json.Unmarshal(results[1].Body, &version); err != nil || version["version"] == nil {
+		t.Fatalf("version body %s: %v", results[1].Body, err)
+	}
+}
+
+func TestBatchRejectsUnsafeRequests(t *testing.T) {
+	s := newServerTestServer(t)
+	tooMany := "[" + strings.TrimSuffix(strings.Repeat(`{"path":"/api/status"},`, maxBatchRequests+1), ",") + "]"
+	for name, body := range map[string]string{
+		"write":    `[{"method":"POST","path":"/api/config","body":{"auto_restart":false}}]`,
+		"stream":   `[{"path":"/api/logs/stream"}]`,
+		"download": `[{"path":"/api/logs/download"}]`,
+		"external": `[{"path":"http://example.com/api/status"}]`,
+		"too many": tooMany,
+	} {
+		if rec := postBatch(t, s, body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, rec.Code)
+		}
+	}
+
+	t.Setenv("CFUI_BATCH_ALLOW_WRITES", "true")
+	rec := postBatch(t, s, `[{"method":"POST","path":"/api/config","body":{"auto_restart":false}}]`)
+	if rec.Code != http.StatusOK || s.cfgMgr.Get().AutoRestart {
+		t.Fatalf("enabled write: status %d, auto_restart %v", rec.Code, s.cfgMgr.Get().AutoRestart)
+	}
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"bytes"
+	"cfui/internal/config"
+	"cfui/internal/mcpbridge"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ConfigFieldResponse carries a single top-level config value.
+type ConfigFieldResponse struct {
+	Field string `json:"field"`
+	Value any    `json:"value"`
+}
+
+// secretConfigKeys are masked wherever they appear in a field value.
+var secretConfigKeys = map[string]bool{
+	"token":     true,
+	"api_token": true,
+	"api_key":   true,
+}
+
+// handleConfigField serves GET /api/config/{field}, where field is a json tag
+// of config.Config, so pollers can read one value without the whole config.
+func (s *Server) handleConfigField(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/config/"), "/")
+	if name == "" {
+		s.handleConfig(w, r)
+		return
+	}
+
+	value, ok := configFieldValue(s.cfgMgr.Get(), name)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("unknown config field %q", name))
+		return
+	}
+	masked, err := maskConfigValue(name, value)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, ConfigFieldResponse{Field: name, Value: masked})
+}
+
+// configFieldValue looks up a top-level field of cfg by its json tag.
+func configFieldValue(cfg config.Config, name string) (any, bool) {
+	v := reflect.ValueOf(cfg)
+	i, ok := configFieldIndex(v.Type(), name)
+	if !ok {
+		return nil, false
+	}
+	return v.Field(i).Interface(), true
+}
+
+// configFieldIndex returns the index of the config.Config field whose json
+// tag is name.
+func configFieldIndex(t reflect.Type, name string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" || tag != name {
+			continue
+		}
+		return i, true
+	}
+	return 0, false
+}
+
+// applyConfigPatch applies a PATCH /api/config body, an object of top-level
+// fields, to cfg. Each value is decoded straight into its field's type with
+// UseNumber, never through a float64, so large integers round-trip exactly.
+// Unknown fields are rejected instead of silently dropped.
+func applyConfigPatch(cfg *config.Config, body io.Reader) error {
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+	var fields map[string]json.RawMessage
+	if err := dec.Decode(&fields); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(cfg).Elem()
+	for name, raw := range fields {
+		i, ok := configFieldIndex(v.Type(), name)
+		if !ok {
+			return fmt.Errorf("unknown config field %q", name)
+		}
+		value := reflect.New(v.Field(i).Type())
+		fieldDec := json.NewDecoder(bytes.NewReader(raw))
+		fieldDec.UseNumber()
+		if err := fieldDec.Decode(value.Interface()); err != nil {
+			return fmt.Errorf("config field %q: %w", name, err)
+		}
+		v.Field(i).Set(value.Elem())
+	}
+	return nil
+}
+
+// maskConfigValue masks secrets in value: the value itself when name is a
+// secret key, and any secret keys nested inside structs, maps, or slices.
+func maskConfigValue(name string, value any) (any, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return maskSecretTree(name, tree), nil
+}
+
+func maskSecretTree(key string, v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = maskSecretTree(k, item)
+		}
+		return v
+	case []any:
+		for i := range v {
+			v[i] = maskSecretTree("", v[i])
+		}
+		return v
+	case string:
+		if secretConfigKeys[key] {
+			return mcpbridge.MaskToken(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// ConfigChange is one top-level config field changed by a save. Old and New
+// are masked like GET /api/config/{field} values.
+type ConfigChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// configChanges lists the top-level fields that differ between before and
+// after, in struct order. The tunnels list is skipped: the top-level fields
+// already mirror the active profile, so its edits show up there.
+func configChanges(before, after config.Config) ([]ConfigChange, error) {
+	return fieldChanges(reflect.ValueOf(before), reflect.ValueOf(after), "tunnels")
+}
+
+// fieldChanges lists the json-tagged fields that differ between two values
+// of the same struct type, in struct order, skipping the named fields.
+func fieldChanges(bv, av reflect.Value, skip ...string) ([]ConfigChange, error) {
+	var changes []ConfigChange
+	t := bv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || slices.Contains(skip, name) {
+			continue
+		}
+		// Compare the encoded values: masks can hide a changed secret,
+		// and nil and empty slices encode the same.
+		oldJSON, err := json.Marshal(bv.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		newJSON, err := json.Marshal(av.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(oldJSON, newJSON) {
+			continue
+		}
+		oldValue, err := maskConfigValue(name, bv.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		newValue, err := maskConfigValue(name, av.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, ConfigChange{Field: name, Old: oldValue, New: newValue})
+	}
+	return changes, nil
+}
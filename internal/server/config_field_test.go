@@ -0,0 +1,146 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func getConfigField(t *testing.T, s *Server, field string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/config/"+field, nil)
+	rec := httptest.NewRecorder()
+	s.handleConfigField(rec, req)
+	return rec
+}
+
+func TestConfigFieldReturnsKnownField(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.Protocol = "http2"
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	rec := getConfigField(t, s, "protocol")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp ConfigFieldResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Field != "protocol" || resp.Value != "http2" {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+}
+
+func TestConfigFieldMasksToken(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.Token = "eyJhIjoic2VjcmV0LXR1bm5lbC10b2tlbiJ9"
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	for _, field := range []string{"token", "tunnels"} {
+		rec := getConfigField(t, s, field)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s status %d: %s", field, rec.Code, rec.Body.String())
+		}
+		body := rec.Body.String()
+		if strings.Contains(body, cfg.Token) {
+			t.Fatalf("%s response leaked raw token: %s", field, body)
+		}
+		if !strings.Contains(body, "eyJh...biJ9") {
+			t.Fatalf("%s response did not include masked token: %s", field, body)
+		}
+	}
+}
+
+func TestConfigFieldMasksGlobalAPIKey(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.TunnelManagement.APIEmail = "ops@example.com"
+	cfg.TunnelManagement.APIKey = "0123456789abcdef0123456789abcdef01234"
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	rec := getConfigField(t, s, "tunnel_management")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); strings.Contains(body, cfg.TunnelManagement.APIKey) {
+		t.Fatalf("response leaked raw API key: %s", body)
+	}
+}
+
+func TestConfigFieldUnknownFieldReturns404(t *testing.T) {
+	s := newServerTestServer(t)
+	rec := getConfigField(t, s, "does_not_exist")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status %d, want 404: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestConfigSaveListsOnlyChangedFields(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.Protocol = "auto"
+	cfg.Token = "eyJhIjoib2xkLXR1bm5lbC10b2tlbiJ9"
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	body := `{"protocol":"http2","token":"eyJhIjoibmV3LXR1bm5lbC10b2tlbiJ9"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/config", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.handleConfig(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp ConfigSaveResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Changes) != 2 {
+		t.Fatalf("changes = %+v, want protocol and token only", resp.Changes)
+	}
+	byField := map[string]ConfigChange{}
+	for _, change := range resp.Changes {
+		byField[change.Field] = change
+	}
+	if got := byField["protocol"]; got.Old != "auto" || got.New != "http2" {
+		t.Fatalf("protocol change = %+v, want auto -> http2", got)
+	}
+	token, ok := byField["token"]
+	if !ok || token.New == "eyJhIjoibmV3LXR1bm5lbC10b2tlbiJ9" || token.Old == "eyJhIjoib2xkLXR1bm5lbC10b2tlbiJ9" {
+		t.Fatalf("token change = %+v, want a masked value", token)
+	}
+}
+
+func TestConfigPatchPreservesLargeIntegers(t *testing.T) {
+	s := newServerTestServer(t)
+	// 2^53+1 is the first integer a float64 cannot represent.
+	const large = 9007199254740993
+	body := `{"max_restarts_per_hour":9007199254740993}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/config", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.handleConfig(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := s.cfgMgr.Get().MaxRestartsPerHour; got != large {
+		t.Fatalf("max_restarts_per_hour = %d, want %d", got, large)
+	}
+
+	req = httptest.NewRequest(http.MethodPatch, "/api/config", strings.NewReader(`{"no_such_field":1}`))
+	rec = httptest.NewRecorder()
+	s.handleConfig(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown field status %d, want 400: %s", rec.Code, rec.Body.String())
+	}
+}
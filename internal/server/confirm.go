@@ -0,0 +1,31 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errConfirmRequired rejects a destructive control action that lacks the
+// confirmation RequireConfirm asks for.
+var errConfirmRequired = errors.New("confirmation required")
+
+// destructiveActions are the control actions that take a tunnel down.
+var destructiveActions = map[string]bool{"stop": true, "restart": true}
+
+// checkConfirm returns errConfirmRequired when RequireConfirm is on and a
+// destructive action does not carry the tunnel key (""= active) as its
+// confirmation. Other actions always pass.
+func (s *Server) checkConfirm(key, action, confirm string) error {
+	cfg := s.cfgMgr.Get()
+	if !cfg.RequireConfirm || !destructiveActions[action] {
+		return nil
+	}
+	want := key
+	if profile, ok := cfg.TunnelProfile(key); ok {
+		want = profile.Key
+	}
+	if confirm != want {
+		return fmt.Errorf("%w: set \"confirm\" to the tunnel key %q to %s it", errConfirmRequired, want, action)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/config"
+	"cfui/internal/service"
+)
+
+func TestControlErrorStatus(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{cloudflared.ErrAlreadyRunning, http.StatusConflict, "already_running"},
+		{cloudflared.ErrNotRunning, http.StatusConflict, "not_running"},
+		{cloudflared.ErrNoPendingRestart, http.StatusConflict, "no_pending_restart"},
+		{cloudflared.ErrTokenMissing, http.StatusUnprocessableEntity, "token_missing"},
+		{fmt.Errorf("%w: panic in tunnel.Init", cloudflared.ErrInitFailed), http.StatusServiceUnavailable, "init_failed"},
+		{fmt.Errorf("%w: it has schema v9", config.ErrConfigReadOnly), http.StatusConflict, "config_read_only"},
+		{errors.New("tunnel profile \"x\" not found"), http.StatusInternalServerError, "control_failed"},
+	}
+	for _, tt := range tests {
+		status, code := controlErrorStatus(tt.err)
+		if status != tt.wantStatus || code != tt.wantCode {
+			t.Errorf("controlErrorStatus(%v) = %d %q, want %d %q", tt.err, status, code, tt.wantStatus, tt.wantCode)
+		}
+	}
+}
+
+func TestHandleControlReportsTypedErrors(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+
+	control := func(action string) (int, ControlErrorResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handleControl(rec, httptest.NewRequest(http.MethodPost, "/api/control", strings.NewReader(`{"action":"`+action+`"}`)))
+		var body ControlErrorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: decode response: %v", action, err)
+		}
+		return rec.Code, body
+	}
+
+	if status, body := control("stop"); status != http.StatusConflict || body.Code != "not_running" {
+		t.Fatalf("stop while stopped = %d %+v, want 409 not_running", status, body)
+	}
+	if status, body := control("cancel_restart"); status != http.StatusConflict || body.Code != "no_pending_restart" {
+		t.Fatalf("cancel_restart without backoff = %d %+v, want 409 no_pending_restart", status, body)
+	}
+	if status, body := control("start"); status != http.StatusUnprocessableEntity || body.Code != "token_missing" {
+		t.Fatalf("start without token = %d %+v, want 422 token_missing", status, body)
+	}
+	// Restarting a stopped tunnel just starts it.
+	if status, body := control("restart"); status != http.StatusUnprocessableEntity || body.Code != "token_missing" {
+		t.Fatalf("restart without token = %d %+v, want 422 token_missing", status, body)
+	}
+}
+
+func TestHandleControlRestartWaitsForNewRun(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+	var restarted []string
+	var restartErr error
+	s.restartProfile = func(key string) error {
+		restarted = append(restarted, key)
+		return restartErr
+	}
+	control := func() *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handleControl(rec, httptest.NewRequest(http.MethodPost, "/api/control", strings.NewReader(`{"action":"restart"}`)))
+		return rec
+	}
+
+	rec := control()
+	var resp ControlResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if rec.Code != http.StatusOK || !resp.Success || resp.Message != "Tunnel restarted successfully" {
+		t.Fatalf("restart = %d %+v, want 200 once the new run is launched", rec.Code, resp)
+	}
+	if len(restarted) != 1 {
+		t.Fatalf("restarted %v, want one synchronous restart", restarted)
+	}
+
+	restartErr = fmt.Errorf("restart: %w", errors.New(`timeout waiting for tunnel "default" to stop`))
+	rec = control()
+	var body ControlErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(body.Error, "timeout waiting") {
+		t.Fatalf("restart with stuck stop = %d %+v, want the stop timeout as an error", rec.Code, body)
+	}
+}
+
+func TestHandleControlRequiresConfirmationForStop(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.RequireConfirm = true
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	var ran []string
+	s.control = func(key, action, _ string) (string, error) {
+		ran = append(ran, action)
+		return "ok", nil
+	}
+
+	control := func(body string) (int, ControlErrorResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handleControl(rec, httptest.NewRequest(http.MethodPost, "/api/control", strings.NewReader(body)))
+		var resp ControlErrorResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec.Code, resp
+	}
+
+	for _, body := range []string{`{"action":"stop"}`, `{"action":"stop","confirm":"office"}`} {
+		if status, resp := control(body); status != http.StatusPreconditionRequired || resp.Code != "confirmation_required" {
+			t.Fatalf("%s = %d %+v, want 428 confirmation_required", body, status, resp)
+		}
+	}
+	if len(ran) != 0 {
+		t.Fatalf("unconfirmed stop reached the runner: %v", ran)
+	}
+	key := s.cfgMgr.Get().ActiveTunnelProfile().Key
+	if status, resp := control(`{"action":"stop","confirm":"` + key + `"}`); status != http.StatusOK {
+		t.Fatalf("confirmed stop = %d %+v, want 200", status, resp)
+	}
+	if status, resp := control(`{"action":"start"}`); status != http.StatusOK {
+		t.Fatalf("start = %d %+v, want 200 without confirmation", status, resp)
+	}
+	if want := []string{"stop", "start"}; !slices.Equal(ran, want) {
+		t.Fatalf("actions run = %v, want %v", ran, want)
+	}
+}
+
+func TestTunnelProtocolRequiresConfirmation(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+	cfg := s.cfgMgr.Get()
+	cfg.RequireConfirm = true
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	key := s.cfgMgr.Get().ActiveTunnelProfile().Key
+
+	switchProtocol := func(body string) int {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handleTunnelProtocol(rec, httptest.NewRequest(http.MethodPost, "/api/tunnels/"+key+"/protocol", strings.NewReader(body)), key)
+		return rec.Code
+	}
+	if status := switchProtocol(`{"protocol":"http2"}`); status != http.StatusPreconditionRequired {
+		t.Fatalf("unconfirmed switch = %d, want 428", status)
+	}
+	if profile, _ := s.cfgMgr.Get().TunnelProfile(key); profile.Protocol == "http2" {
+		t.Fatal("unconfirmed switch saved the protocol")
+	}
+	if status := switchProtocol(`{"protocol":"http2","confirm":"` + key + `"}`); status != http.StatusOK {
+		t.Fatalf("confirmed switch = %d, want 200", status)
+	}
+}
+
+func TestHandleEventsLimit(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+	for i := 0; i < 3; i++ {
+		s.runner.ObserveLogLine(fmt.Sprintf("2026-03-01T10:00:0%dZ INF Registered tunnel connection connIndex=%d connection=c%d event=0 ip=198.41.192.7 location=sjc08 protocol=quic", i, i, i))
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleEvents(rec, httptest.NewRequest(http.MethodGet, "/api/events?limit=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp EventsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Count != 2 || !strings.Contains(resp.Events[1].Detail, "connIndex=2") || resp.Events[1].Time.IsZero() {
+		t.Fatalf("events = %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleEvents(rec, httptest.NewRequest(http.MethodGet, "/api/events?limit=abc", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid limit status = %d, want 400", rec.Code)
+	}
+}
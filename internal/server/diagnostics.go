@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/logger"
+)
+
+// diagnosticsErrorLines is how many of the latest error log lines a
+// diagnostics dump repeats.
+const diagnosticsErrorLines = 5
+
+// DumpDiagnostics logs a snapshot of the process: goroutine count, the
+// status of every tunnel, recent errors and log stream subscribers. main
+// calls it on SIGUSR1 so the state can be inspected when the HTTP API does
+// not respond; it only reads state and changes nothing.
+func (s *Server) DumpDiagnostics() {
+	in := s.healthInputs(time.Now())
+	health := summarizeHealth(in)
+	log().Infof("Diagnostics: %d goroutines, health %s, %d running tunnels, %d connections, %d errors in the last hour, %d log subscribers",
+		runtime.NumGoroutine(), health.Status, health.RunningTunnels, in.connections, in.recentErrors, in.subscribers)
+	for _, key := range slices.Sorted(maps.Keys(in.statuses)) {
+		log().Infof("Diagnostics: %s", diagnosticsTunnelLine(key, in.statuses[key]))
+	}
+	if b := logger.GetBroadcaster(); b != nil {
+		entries := b.ErrorEntries()
+		for _, entry := range entries[max(0, len(entries)-diagnosticsErrorLines):] {
+			log().Infof("Diagnostics: recent error: %s", entry.Line)
+		}
+	}
+}
+
+// diagnosticsTunnelLine describes one tunnel's status on a single line.
+func diagnosticsTunnelLine(key string, st cloudflared.Status) string {
+	state := "stopped"
+	if st.Running {
+		state = "running"
+	}
+	line := fmt.Sprintf("tunnel %q %s, protocol %s", key, state, st.Protocol)
+	if st.StopReason != "" {
+		line += ", stop reason " + string(st.StopReason)
+	}
+	if !st.NextRestartAt.IsZero() {
+		line += ", restart at " + st.NextRestartAt.UTC().Format(time.RFC3339)
+	}
+	if st.LastError != nil {
+		line += ", last error: " + st.LastError.Error()
+	}
+	return line
+}
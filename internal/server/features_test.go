@@ -299,3 +299,31 @@ func TestTunnelProfileCanBeEditedWithoutActivatingLocalRunner(t *testing.T) {
 		t.Fatalf("office profile was not updated: %#v", got.Tunnels)
 	}
 }
+
+func TestFeaturesResponseReportsUITogglesFromEnv(t *testing.T) {
+	for _, tt := range []struct {
+		env  string
+		want bool
+	}{{"", false}, {"true", true}, {"no", false}} {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("CFUI_HIDE_ADVANCED", tt.env)
+			s := newServerTestServer(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/features", nil)
+			rec := httptest.NewRecorder()
+			s.handleFeatures(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("features status %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var resp FeaturesResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode features response: %v", err)
+			}
+			got, ok := resp.UI["hide_advanced"]
+			if !ok || got != tt.want {
+				t.Fatalf("ui = %#v, want hide_advanced=%v", resp.UI, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/logger"
+)
+
+// Health verdicts, from best to worst.
+const (
+	healthHealthy   = "healthy"
+	healthDegraded  = "degraded"
+	healthUnhealthy = "unhealthy"
+)
+
+// healthErrorWindow and healthErrorThreshold define a high log error rate:
+// this many error lines within the window degrade the "logs" check.
+const (
+	healthErrorWindow    = time.Hour
+	healthErrorThreshold = 10
+)
+
+// HealthCheck is the verdict of one subsystem with a human-readable detail.
+type HealthCheck struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// HealthSummaryResponse is the /api/health/summary body. Status is the worst
+// of the checks: tunnels, connections, restarts, logs, subscribers, config.
+type HealthSummaryResponse struct {
+	Status          string                 `json:"status"`
+	CheckedAt       time.Time              `json:"checked_at"`
+	RunningTunnels  int                    `json:"running_tunnels"`
+	Connections     int                    `json:"connections"`
+	LastConnectedAt *time.Time             `json:"last_connected_at,omitempty"`
+	RecentErrors    int                    `json:"recent_errors"`
+	Subscribers     int                    `json:"subscribers"`
+	Checks          map[string]HealthCheck `json:"checks"`
+}
+
+// healthInputs is the state a health summary is judged from, gathered apart
+// from the judging so tests can supply it directly.
+type healthInputs struct {
+	now time.Time
+	// statuses holds the profiles that have an instance, by key.
+	statuses        map[string]cloudflared.Status
+	connections     int
+	lastConnectedAt time.Time
+	// recentErrors is -1 when no log broadcaster is available.
+	recentErrors int
+	subscribers  int
+	configErr    error
+}
+
+func (s *Server) handleHealthSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, summarizeHealth(s.healthInputs(time.Now())))
+}
+
+// healthInputs collects the current state of every subsystem.
+func (s *Server) healthInputs(now time.Time) healthInputs {
+	cfg := s.cfgMgr.Get()
+	in := healthInputs{now: now, statuses: make(map[string]cloudflared.Status), recentErrors: -1, configErr: cfg.Validate()}
+	if s.runner != nil {
+		for _, profile := range cfg.Tunnels {
+			if st, ok := s.runner.ProfileStatus(profile.Key); ok {
+				in.statuses[profile.Key] = st
+			}
+		}
+		in.connections = len(s.runner.Connections())
+		for _, ev := range slices.Backward(s.runner.Events(0)) {
+			if ev.Type == cloudflared.EventConnected {
+				in.lastConnectedAt = ev.Time
+				break
+			}
+		}
+	}
+	if b := logger.GetBroadcaster(); b != nil {
+		in.recentErrors = b.ErrorsSince(now.Add(-healthErrorWindow))
+		in.subscribers = b.SubscriberCount()
+	}
+	return in
+}
+
+// summarizeHealth judges each subsystem and rolls the checks up into one
+// verdict. A stopped tunnel is healthy; one that stopped on an error is not.
+func summarizeHealth(in healthInputs) HealthSummaryResponse {
+	resp := HealthSummaryResponse{
+		CheckedAt:   in.now.UTC(),
+		Connections: in.connections,
+		Subscribers: in.subscribers,
+		Checks:      make(map[string]HealthCheck),
+	}
+	if !in.lastConnectedAt.IsZero() {
+		at := in.lastConnectedAt
+		resp.LastConnectedAt = &at
+	}
+
+	var errored, tripped, pending []string
+	for _, key := range slices.Sorted(maps.Keys(in.statuses)) {
+		st := in.statuses[key]
+		switch {
+		case st.Running:
+			resp.RunningTunnels++
+		case st.StopReason == cloudflared.StopCrashLoop || st.StopReason == cloudflared.StopFlapping:
+			tripped = append(tripped, key)
+		case !st.NextRestartAt.IsZero():
+			pending = append(pending, key)
+		case st.StopReason == cloudflared.StopError:
+			errored = append(errored, key)
+		}
+	}
+
+	switch {
+	case len(errored) > 0 && resp.RunningTunnels == 0:
+		resp.Checks["tunnels"] = HealthCheck{healthUnhealthy, "stopped on an error: " + strings.Join(errored, ", ")}
+	case len(errored) > 0:
+		resp.Checks["tunnels"] = HealthCheck{healthDegraded, "stopped on an error: " + strings.Join(errored, ", ")}
+	default:
+		resp.Checks["tunnels"] = HealthCheck{healthHealthy, fmt.Sprintf("%d running", resp.RunningTunnels)}
+	}
+
+	switch {
+	case resp.RunningTunnels > 0 && in.connections == 0:
+		resp.Checks["connections"] = HealthCheck{healthDegraded, "no edge connection registered"}
+	default:
+		resp.Checks["connections"] = HealthCheck{healthHealthy, fmt.Sprintf("%d registered", in.connections)}
+	}
+
+	switch {
+	case len(tripped) > 0:
+		resp.Checks["restarts"] = HealthCheck{healthUnhealthy, "auto-restart gave up: " + strings.Join(tripped, ", ")}
+	case len(pending) > 0:
+		resp.Checks["restarts"] = HealthCheck{healthDegraded, "waiting to restart: " + strings.Join(pending, ", ")}
+	default:
+		resp.Checks["restarts"] = HealthCheck{Status: healthHealthy}
+	}
+
+	switch {
+	case in.recentErrors < 0:
+		resp.Checks["logs"] = HealthCheck{healthDegraded, "log broadcaster unavailable"}
+		resp.Checks["subscribers"] = HealthCheck{healthDegraded, "log broadcaster unavailable"}
+	default:
+		resp.RecentErrors = in.recentErrors
+		logs := HealthCheck{healthHealthy, fmt.Sprintf("%d errors in the last hour", in.recentErrors)}
+		if in.recentErrors >= healthErrorThreshold {
+			logs.Status = healthDegraded
+		}
+		resp.Checks["logs"] = logs
+		resp.Checks["subscribers"] = HealthCheck{healthHealthy, fmt.Sprintf("%d log streams", in.subscribers)}
+	}
+
+	if in.configErr != nil {
+		resp.Checks["config"] = HealthCheck{healthDegraded, in.configErr.Error()}
+	} else {
+		resp.Checks["config"] = HealthCheck{Status: healthHealthy}
+	}
+
+	resp.Status = healthHealthy
+	for _, check := range resp.Checks {
+		if healthRank(check.Status) > healthRank(resp.Status) {
+			resp.Status = check.Status
+		}
+	}
+	return resp
+}
+
+func healthRank(status string) int {
+	switch status {
+	case healthUnhealthy:
+		return 2
+	case healthDegraded:
+		return 1
+	}
+	return 0
+}
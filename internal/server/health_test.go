@@ -0,0 +1,42 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cfui/internal/cloudflared"
+)
+
+func TestSummarizeHealthDowngradesErroredTunnel(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	in := healthInputs{
+		now:             now,
+		statuses:        map[string]cloudflared.Status{"home": {Running: true}},
+		connections:     4,
+		lastConnectedAt: now.Add(-time.Minute),
+	}
+	if got := summarizeHealth(in); got.Status != healthHealthy || got.RunningTunnels != 1 || got.LastConnectedAt == nil {
+		t.Fatalf("running tunnel: %+v, want healthy", got)
+	}
+
+	// A second tunnel stopped on an error while the first still serves.
+	in.statuses["office"] = cloudflared.Status{LastError: errors.New("bad token"), StopReason: cloudflared.StopError}
+	got := summarizeHealth(in)
+	if got.Status != healthDegraded || got.Checks["tunnels"].Status != healthDegraded {
+		t.Fatalf("one errored tunnel: %+v, want degraded", got)
+	}
+
+	// With nothing left running the verdict is unhealthy.
+	in.statuses["home"] = cloudflared.Status{StopReason: cloudflared.StopUser}
+	in.connections = 0
+	if got := summarizeHealth(in); got.Status != healthUnhealthy || got.Checks["tunnels"].Detail != "stopped on an error: office" {
+		t.Fatalf("only an errored tunnel: %+v, want unhealthy naming office", got)
+	}
+
+	// A tripped restart breaker is unhealthy on its own.
+	in.statuses = map[string]cloudflared.Status{"home": {StopReason: cloudflared.StopFlapping}}
+	if got := summarizeHealth(in); got.Status != healthUnhealthy || got.Checks["restarts"].Status != healthUnhealthy {
+		t.Fatalf("flapping tunnel: %+v, want unhealthy restarts", got)
+	}
+}
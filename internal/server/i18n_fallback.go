@@ -0,0 +1,48 @@
+package server
+
+// builtinEnglish is the last-resort translation table, compiled into the
+// binary so the UI keeps its core labels when the embedded locales are
+// missing or unparsable. Values mirror locales/en.toml.
+var builtinEnglish = map[string]string{
+	"status_checking":      "Checking...",
+	"status_running":       "Running",
+	"status_stopped":       "Stopped",
+	"status_error":         "Error",
+	"app_title":            "CloudFlared UI",
+	"skip_to_content":      "Skip to content",
+	"tunnel_config":        "Tunnel Configuration",
+	"tunnel_token":         "Tunnel Token",
+	"token_show":           "Show token",
+	"token_hide":           "Hide token",
+	"advanced_config":      "Advanced Configuration",
+	"protocol":             "Protocol",
+	"protocol_auto":        "Auto",
+	"autostart":            "Auto-start on launch",
+	"autorestart":          "Auto-restart on failure",
+	"start_this_tunnel":    "Start This Tunnel",
+	"stop_this_tunnel":     "Stop This Tunnel",
+	"system_logs":          "System Logs",
+	"clear":                "Clear",
+	"system_ready":         "System ready. Waiting for configuration...",
+	"error_token_required": "Token is required to start the tunnel",
+	"error_generic":        "An error occurred",
+	"command_sent":         "Command sent",
+	"config_saved":         "Configuration saved",
+	"status_changed":       "Status changed",
+	"save":                 "Save",
+	"cancel":               "Cancel",
+	"confirm":              "Confirm",
+	"confirm_title":        "Confirm",
+	"close":                "Close",
+	"delete":               "Delete",
+	"language_choose":      "Choose language",
+}
+
+// builtinTranslations returns a copy of builtinEnglish for a response.
+func builtinTranslations() map[string]string {
+	translations := make(map[string]string, len(builtinEnglish))
+	for key, value := range builtinEnglish {
+		translations[key] = value
+	}
+	return translations
+}
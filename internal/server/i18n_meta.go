@@ -0,0 +1,40 @@
+package server
+
+import "strings"
+
+// localeMetaKey names the optional TOML table holding per-language metadata,
+// and the key it is returned under by /api/i18n/{lang}:
+//
+//	[_meta]
+//	direction = "rtl"
+//	date_format = "DD/MM/YYYY"
+const localeMetaKey = "_meta"
+
+// Defaults for languages that declare no metadata: left-to-right text and
+// ISO 8601 dates.
+const (
+	defaultLocaleDirection  = "ltr"
+	defaultLocaleDateFormat = "YYYY-MM-DD"
+)
+
+// LocaleMeta carries the formatting hints the UI needs beyond strings.
+type LocaleMeta struct {
+	Direction  string `json:"direction"`   // ltr or rtl
+	DateFormat string `json:"date_format"` // e.g. YYYY-MM-DD
+}
+
+func defaultLocaleMeta() LocaleMeta {
+	return LocaleMeta{Direction: defaultLocaleDirection, DateFormat: defaultLocaleDateFormat}
+}
+
+// apply overlays the fields a locale file's _meta table sets. Unknown
+// directions are ignored rather than breaking the layout.
+func (m *LocaleMeta) apply(table map[string]string) {
+	switch direction := strings.ToLower(strings.TrimSpace(table["direction"])); direction {
+	case "ltr", "rtl":
+		m.Direction = direction
+	}
+	if dateFormat := strings.TrimSpace(table["date_format"]); dateFormat != "" {
+		m.DateFormat = dateFormat
+	}
+}
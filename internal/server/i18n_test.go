@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -36,10 +37,7 @@ other = "Split OAuth"
 		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
 	}
 
-	var got map[string]string
-	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
-		t.Fatalf("decode response: %v", err)
-	}
+	got, _ := decodeI18nResponse(t, rec.Body)
 	if got["hello"] != "Hello" {
 		t.Fatalf("legacy key not loaded: %#v", got)
 	}
@@ -47,3 +45,115 @@ other = "Split OAuth"
 		t.Fatalf("split key did not override legacy key: %#v", got)
 	}
 }
+
+func TestHandleI18nFallsBackToBuiltinEnglish(t *testing.T) {
+	cases := []struct {
+		name    string
+		locales fstest.MapFS
+		lang    string
+	}{
+		{name: "empty embed", locales: fstest.MapFS{}, lang: "en"},
+		{
+			name: "corrupt locale",
+			locales: fstest.MapFS{
+				"locales/ja.toml": {Data: []byte("[status_running\nother = ")},
+			},
+			lang: "ja",
+		},
+		{
+			name: "corrupt split file",
+			locales: fstest.MapFS{
+				"locales/en.toml":       {Data: []byte("[hello]\nother = \"Hello\"\n")},
+				"locales/en/oauth.toml": {Data: []byte("not toml = [")},
+			},
+			lang: "en",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServerTestServer(t)
+			s.locales = tc.locales
+			rec := httptest.NewRecorder()
+			s.handleI18n(rec, httptest.NewRequest(http.MethodGet, "/api/i18n/"+tc.lang, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+			}
+			got, meta := decodeI18nResponse(t, rec.Body)
+			if meta != defaultLocaleMeta() {
+				t.Fatalf("built-in fallback meta = %+v, want defaults", meta)
+			}
+			if got["status_running"] != builtinEnglish["status_running"] || len(got) != len(builtinEnglish) {
+				t.Fatalf("expected built-in strings, got %#v", got)
+			}
+		})
+	}
+
+	// Missing non-English locales still 404 so the UI can retry with "en".
+	s := newServerTestServer(t)
+	s.locales = fstest.MapFS{}
+	rec := httptest.NewRecorder()
+	s.handleI18n(rec, httptest.NewRequest(http.MethodGet, "/api/i18n/ja", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("missing ja locale: status %d, want 404", rec.Code)
+	}
+}
+
+func TestHandleI18nSurfacesLocaleMeta(t *testing.T) {
+	s := &Server{
+		locales: fstest.MapFS{
+			"locales/ar.toml": {
+				Data: []byte(`
+[_meta]
+direction = "RTL"
+date_format = "DD/MM/YYYY"
+
+[hello]
+other = "مرحبا"
+`),
+			},
+			"locales/en.toml": {Data: []byte("[hello]\nother = \"Hello\"\n")},
+		},
+	}
+
+	for lang, want := range map[string]LocaleMeta{
+		"ar": {Direction: "rtl", DateFormat: "DD/MM/YYYY"},
+		"en": defaultLocaleMeta(),
+	} {
+		rec := httptest.NewRecorder()
+		s.handleI18n(rec, httptest.NewRequest(http.MethodGet, "/api/i18n/"+lang, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", lang, rec.Code, rec.Body.String())
+		}
+		got, meta := decodeI18nResponse(t, rec.Body)
+		if meta != want {
+			t.Fatalf("%s: meta = %+v, want %+v", lang, meta, want)
+		}
+		if got["hello"] == "" {
+			t.Fatalf("%s: translations missing alongside meta: %#v", lang, got)
+		}
+	}
+}
+
+// decodeI18nResponse splits an /api/i18n response into its strings and
+// the _meta object.
+func decodeI18nResponse(t *testing.T, body io.Reader) (map[string]string, LocaleMeta) {
+	t.Helper()
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&raw); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	var meta LocaleMeta
+	if err := json.Unmarshal(raw[localeMetaKey], &meta); err != nil {
+		t.Fatalf("decode _meta: %v", err)
+	}
+	delete(raw, localeMetaKey)
+	translations := make(map[string]string, len(raw))
+	for key, value := range raw {
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			t.Fatalf("decode %s: %v", key, err)
+		}
+		translations[key] = text
+	}
+	return translations, meta
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/version"
+)
+
+// LastErrorResponse is the /api/tunnel/last-error body. Chain lists the
+// message of every error in the wrapped chain, outermost first, so context
+// that Error alone flattens stays visible. Stack is only reported by
+// development builds, and only for errors recovered from a panic.
+type LastErrorResponse struct {
+	Tunnel  string    `json:"tunnel"`
+	Message string    `json:"message"`
+	Kind    string    `json:"kind"`
+	Chain   []string  `json:"chain"`
+	At      time.Time `json:"at"`
+	Stack   string    `json:"stack,omitempty"`
+}
+
+// handleLastError reports the last error of the profile named by ?tunnel=
+// (default: active) in full, for precise bug reports.
+func (s *Server) handleLastError(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.runner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, fmt.Errorf("tunnel runner is not available"))
+		return
+	}
+	profile, ok := s.cfgMgr.Get().TunnelProfile(r.URL.Query().Get("tunnel"))
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", r.URL.Query().Get("tunnel")))
+		return
+	}
+	st, _ := s.runner.ProfileStatus(profile.Key)
+	if st.LastError == nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel %q has no recorded error", profile.Key))
+		return
+	}
+	writeJSON(w, lastErrorResponse(profile.Key, st, version.Version == "dev"))
+}
+
+func lastErrorResponse(key string, st cloudflared.Status, withStack bool) LastErrorResponse {
+	resp := LastErrorResponse{
+		Tunnel:  key,
+		Message: st.LastError.Error(),
+		Kind:    errorKind(st.LastError),
+		Chain:   errorChain(st.LastError),
+		At:      st.LastErrorAt.UTC(),
+	}
+	if withStack {
+		resp.Stack = string(cloudflared.ErrorStack(st.LastError))
+	}
+	return resp
+}
+
+// errorKind classifies err the way the restart policy does: init_failed,
+// non_retryable, protocol, or retryable.
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, cloudflared.ErrInitFailed):
+		return "init_failed"
+	case !cloudflared.IsRetryableError(err):
+		return "non_retryable"
+	case cloudflared.IsProtocolRelatedError(err):
+		return "protocol"
+	}
+	return "retryable"
+}
+
+// errorChain walks err depth-first through Unwrap, including joined errors,
+// and returns each message, skipping one that merely repeats its wrapper's.
+func errorChain(err error) []string {
+	var chain []string
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if msg := err.Error(); len(chain) == 0 || chain[len(chain)-1] != msg {
+			chain = append(chain, msg)
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
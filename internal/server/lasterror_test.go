@@ -0,0 +1,30 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"cfui/internal/cloudflared"
+)
+
+func TestLastErrorExposesWrappedStartError(t *testing.T) {
+	cause := errors.New("open /etc/ssl/certs: permission denied")
+	st := cloudflared.Status{
+		LastError:   fmt.Errorf("%w: %w", cloudflared.ErrInitFailed, cause),
+		LastErrorAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	resp := lastErrorResponse("home", st, false)
+	if resp.Kind != "init_failed" || !resp.At.Equal(st.LastErrorAt) {
+		t.Fatalf("response = %+v, want kind init_failed at %v", resp, st.LastErrorAt)
+	}
+	want := []string{st.LastError.Error(), cloudflared.ErrInitFailed.Error(), cause.Error()}
+	if fmt.Sprint(resp.Chain) != fmt.Sprint(want) {
+		t.Fatalf("chain = %q, want %q", resp.Chain, want)
+	}
+	if resp.Stack != "" {
+		t.Fatalf("release build exposed a stack: %q", resp.Stack)
+	}
+}
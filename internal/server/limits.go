@@ -0,0 +1,46 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxHeaderBytes caps request headers at 64 KiB: far below net/http's
+// 1 MiB default, yet roomy enough for large cookies and bearer tokens.
+const DefaultMaxHeaderBytes = 64 << 10
+
+// minMaxHeaderBytes keeps an override from breaking ordinary requests.
+const minMaxHeaderBytes = 4 << 10
+
+// DefaultMaxInFlight caps concurrent API requests. The UI needs a handful;
+// the cap only bites on a misbehaving client or a scrape storm.
+const DefaultMaxInFlight = 256
+
+// MaxInFlightFromEnv reads CFUI_MAX_INFLIGHT, falling back to
+// DefaultMaxInFlight when it is unset or malformed. Zero disables the limit.
+func MaxInFlightFromEnv() int {
+	raw := strings.TrimSpace(os.Getenv("CFUI_MAX_INFLIGHT"))
+	if raw == "" {
+		return DefaultMaxInFlight
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return DefaultMaxInFlight
+	}
+	return n
+}
+
+// MaxHeaderBytesFromEnv reads CFUI_MAX_HEADER_BYTES, falling back to
+// DefaultMaxHeaderBytes when it is unset, malformed or below 4 KiB.
+func MaxHeaderBytesFromEnv() int {
+	raw := strings.TrimSpace(os.Getenv("CFUI_MAX_HEADER_BYTES"))
+	if raw == "" {
+		return DefaultMaxHeaderBytes
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < minMaxHeaderBytes {
+		return DefaultMaxHeaderBytes
+	}
+	return n
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxHeaderBytesFromEnv(t *testing.T) {
+	cases := map[string]int{
+		"":       DefaultMaxHeaderBytes,
+		"131072": 131072,
+		" 8192 ": 8192,
+		"1024":   DefaultMaxHeaderBytes,
+		"-1":     DefaultMaxHeaderBytes,
+		"lots":   DefaultMaxHeaderBytes,
+	}
+	for raw, want := range cases {
+		t.Setenv("CFUI_MAX_HEADER_BYTES", raw)
+		if got := MaxHeaderBytesFromEnv(); got != want {
+			t.Errorf("CFUI_MAX_HEADER_BYTES=%q: got %d, want %d", raw, got, want)
+		}
+	}
+}
+
+func TestOversizedHeadersAreRejected(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.Config.MaxHeaderBytes = DefaultMaxHeaderBytes
+	srv.Start()
+	defer srv.Close()
+
+	send := func(cookieSize int) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatalf("new request: %v", err)
+		}
+		req.Header.Set("Cookie", "session="+strings.Repeat("a", cookieSize))
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("do request: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := send(8 << 10); got != http.StatusNoContent {
+		t.Fatalf("8 KiB cookie: status %d, want %d", got, http.StatusNoContent)
+	}
+	if got := send(2 * DefaultMaxHeaderBytes); got != http.StatusRequestHeaderFieldsTooLarge {
+		t.Fatalf("oversized cookie: status %d, want %d", got, http.StatusRequestHeaderFieldsTooLarge)
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"strings"
+
+	"cfui/internal/logger"
+)
+
+// logComponentFilter parses a ?component= value, a comma-separated list such
+// as "cfui.runner,cloudflared", into a predicate on log entries. A component
+// also matches its children, so "cfui" selects every cfui logger. Named
+// events always pass. An empty value passes everything.
+func logComponentFilter(raw string) func(logger.LogEntry) bool {
+	var wants []string
+	for _, want := range strings.Split(raw, ",") {
+		if want = strings.TrimSpace(want); want != "" {
+			wants = append(wants, want)
+		}
+	}
+	return func(entry logger.LogEntry) bool {
+		if len(wants) == 0 || entry.Event != "" {
+			return true
+		}
+		for _, want := range wants {
+			if logger.ComponentMatches(entry.Component, want) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"cfui/internal/logger"
+)
+
+// LogConfigResponse is the /api/logs/config body: the logger settings in
+// effect. LogDirSource says where LogDir came from: "LOG_DIR", "DATA_DIR"
+// (its logs subdirectory), "default" (./data/logs), or "fallback" when
+// the configured directory was unwritable and a temporary one is used.
+type LogConfigResponse struct {
+	LogDir       string `json:"log_dir"`
+	LogDirSource string `json:"log_dir_source"`
+	LogFile      string `json:"log_file"`
+	MaxSizeMB    int    `json:"max_size_mb"`
+	MaxBackups   int    `json:"max_backups"`
+	MaxAgeDays   int    `json:"max_age_days"`
+	Compress     bool   `json:"compress"`
+	Level        string `json:"level"`
+	MaxFileAge   string `json:"max_file_age,omitempty"`
+	DedupWindow  string `json:"dedup_window,omitempty"`
+	BufferSize   int    `json:"buffer_size"`
+}
+
+// handleLogConfig serves GET /api/logs/config, the effective logger
+// configuration, to help explain where logs go and when they rotate.
+func (s *Server) handleLogConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cfg, ok := logger.ActiveConfig()
+	if !ok {
+		writeAPIError(w, http.StatusServiceUnavailable, errors.New("logger is not initialized"))
+		return
+	}
+	writeJSON(w, logConfigResponse(cfg, os.Getenv))
+}
+
+// logConfigResponse describes cfg, attributing its LogDir with getenv.
+func logConfigResponse(cfg logger.Config, getenv func(string) string) LogConfigResponse {
+	resp := LogConfigResponse{
+		LogDir:       cfg.LogDir,
+		LogDirSource: logDirSource(cfg.LogDir, getenv),
+		LogFile:      filepath.Join(cfg.LogDir, cfg.LogFileName),
+		MaxSizeMB:    cfg.MaxSize,
+		MaxBackups:   cfg.MaxBackups,
+		MaxAgeDays:   cfg.MaxAge,
+		Compress:     cfg.Compress,
+		Level:        cfg.LogLevel,
+		BufferSize:   logger.RecentBufferSize,
+	}
+	if cfg.MaxFileAge > 0 {
+		resp.MaxFileAge = cfg.MaxFileAge.String()
+	}
+	if cfg.DedupWindow > 0 {
+		resp.DedupWindow = cfg.DedupWindow.String()
+	}
+	return resp
+}
+
+// logDirSource mirrors main's precedence: LOG_DIR, then DATA_DIR/logs, then
+// ./data/logs. A directory matching none of them is a temporary fallback.
+func logDirSource(logDir string, getenv func(string) string) string {
+	sameDir := func(dir string) bool { return dir != "" && filepath.Clean(dir) == filepath.Clean(logDir) }
+	switch {
+	case getenv("LOG_DIR") != "":
+		if sameDir(getenv("LOG_DIR")) {
+			return "LOG_DIR"
+		}
+	case getenv("DATA_DIR") != "":
+		if sameDir(filepath.Join(getenv("DATA_DIR"), "logs")) {
+			return "DATA_DIR"
+		}
+	case sameDir(filepath.Join("data", "logs")):
+		return "default"
+	}
+	return "fallback"
+}
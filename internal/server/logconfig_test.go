@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cfui/internal/logger"
+)
+
+func TestLogConfigReportsInitializedConfig(t *testing.T) {
+	s := newServerTestServer(t)
+	shared, _ := logger.ActiveConfig()
+	t.Cleanup(func() {
+		// Hand the other tests their logger back.
+		if err := logger.Initialize(&shared); err != nil {
+			t.Errorf("restore logger: %v", err)
+		}
+	})
+
+	dir := t.TempDir()
+	t.Setenv("LOG_DIR", dir)
+	cfg := &logger.Config{
+		LogDir:      dir,
+		LogFileName: "cfui-home",
+		MaxSize:     5,
+		MaxBackups:  2,
+		MaxAge:      3,
+		Compress:    true,
+		LogLevel:    "WARN",
+		MaxFileAge:  24 * time.Hour,
+	}
+	if err := logger.Initialize(cfg); err != nil {
+		t.Fatalf("Initialize logger: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleLogConfig(rec, httptest.NewRequest(http.MethodGet, "/api/logs/config", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var got LogConfigResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := LogConfigResponse{
+		LogDir:       dir,
+		LogDirSource: "LOG_DIR",
+		LogFile:      logger.FilePath(),
+		MaxSizeMB:    5,
+		MaxBackups:   2,
+		MaxAgeDays:   3,
+		Compress:     true,
+		Level:        "warn",
+		MaxFileAge:   "24h0m0s",
+		BufferSize:   logger.RecentBufferSize,
+	}
+	if got != want {
+		t.Fatalf("log config = %+v, want %+v", got, want)
+	}
+	if got.LogFile != filepath.Join(dir, "cfui-home.log") {
+		t.Fatalf("log file = %q, want cfui-home.log in %s", got.LogFile, dir)
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cfui/internal/logger"
+)
+
+// Window sizes for /api/logs/context. Larger requests are clamped.
+const (
+	defaultLogContextLines = 5
+	maxLogContextLines     = 100
+)
+
+// LogContextResponse is a window of recent log lines around one line.
+// Start is the /api/logs/recent index of Logs[0]; Index is the requested
+// line's index in the same numbering.
+type LogContextResponse struct {
+	Logs  []string `json:"logs"`
+	Start int      `json:"start"`
+	Index int      `json:"index"`
+	Count int      `json:"count"`
+}
+
+// handleLogContext returns the lines before and after the recent-logs line at
+// index. Windows that reach past either end of the buffer are cut short.
+func (s *Server) handleLogContext(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("index") == "" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("index is required"))
+		return
+	}
+	var index, before, after int
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"index", &index}, {"before", &before}, {"after", &after}} {
+		n, err := logContextParam(query.Get(p.name), defaultLogContextLines)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("%s: %w", p.name, err))
+			return
+		}
+		*p.dst = n
+	}
+
+	broadcaster := logger.GetBroadcaster()
+	if broadcaster == nil {
+		log().Error("Log broadcaster not initialized")
+		http.Error(w, "Log broadcaster not available", http.StatusInternalServerError)
+		return
+	}
+	lines := broadcaster.GetRecentLogs()
+	start, end, ok := logContextWindow(len(lines), index, before, after)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("index %d is outside the %d buffered lines", index, len(lines)))
+		return
+	}
+	writeJSON(w, LogContextResponse{Logs: lines[start:end], Start: start, Index: index, Count: end - start})
+}
+
+// logContextParam parses a non-negative integer query value. Empty values use
+// fallback.
+func logContextParam(raw string, fallback int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative integer", raw)
+	}
+	return n, nil
+}
+
+// logContextWindow returns the [start, end) slice bounds of the window around
+// index in a buffer of n lines. ok is false when index is not in the buffer.
+func logContextWindow(n, index, before, after int) (start, end int, ok bool) {
+	if index < 0 || index >= n {
+		return 0, 0, false
+	}
+	before = min(before, maxLogContextLines)
+	after = min(after, maxLogContextLines)
+	return max(index-before, 0), min(index+after+1, n), true
+}
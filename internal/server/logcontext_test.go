@@ -0,0 +1,79 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cfui/internal/logger"
+)
+
+func TestLogContextWindow(t *testing.T) {
+	tests := []struct {
+		name               string
+		n, index           int
+		before, after      int
+		wantStart, wantEnd int
+		wantOK             bool
+	}{
+		{name: "mid buffer", n: 20, index: 10, before: 3, after: 2, wantStart: 7, wantEnd: 13, wantOK: true},
+		{name: "first line", n: 20, index: 0, before: 5, after: 1, wantStart: 0, wantEnd: 2, wantOK: true},
+		{name: "last line", n: 20, index: 19, before: 1, after: 5, wantStart: 18, wantEnd: 20, wantOK: true},
+		{name: "clamped size", n: 500, index: 250, before: 1000, after: 0, wantStart: 150, wantEnd: 251, wantOK: true},
+		{name: "past end", n: 20, index: 20, before: 1, after: 1},
+		{name: "empty buffer", n: 0, index: 0},
+	}
+	for _, tt := range tests {
+		start, end, ok := logContextWindow(tt.n, tt.index, tt.before, tt.after)
+		if start != tt.wantStart || end != tt.wantEnd || ok != tt.wantOK {
+			t.Errorf("%s: window = [%d, %d) %v, want [%d, %d) %v", tt.name, start, end, ok, tt.wantStart, tt.wantEnd, tt.wantOK)
+		}
+	}
+}
+
+func TestHandleLogContextReturnsSurroundingLines(t *testing.T) {
+	s := newServerTestServer(t)
+	b := logger.GetBroadcaster()
+	for i := 0; i < 5; i++ {
+		b.Broadcast(fmt.Sprintf("context-test line %d", i))
+	}
+	lines := b.GetRecentLogs()
+	target := len(lines) - 3 // "context-test line 2"
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.handleLogContext(rec, httptest.NewRequest(http.MethodGet, "/api/logs/context?"+query, nil))
+		return rec
+	}
+
+	rec := get(fmt.Sprintf("index=%d&before=1&after=1", target))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var resp LogContextResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []string{"context-test line 1", "context-test line 2", "context-test line 3"}
+	if resp.Count != 3 || resp.Start != target-1 || fmt.Sprint(resp.Logs) != fmt.Sprint(want) {
+		t.Fatalf("context = %+v, want %v from %d", resp, want, target-1)
+	}
+
+	rec = get(fmt.Sprintf("index=%d&after=10", len(lines)-1))
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Logs[resp.Count-1] != "context-test line 4" {
+		t.Fatalf("boundary context = %+v (err %v)", resp, err)
+	}
+
+	for query, wantStatus := range map[string]int{
+		"":                                  http.StatusBadRequest,
+		"index=-1":                          http.StatusBadRequest,
+		"index=1&before=x":                  http.StatusBadRequest,
+		fmt.Sprintf("index=%d", len(lines)): http.StatusNotFound,
+	} {
+		if rec := get(query); rec.Code != wantStatus {
+			t.Errorf("query %q status = %d, want %d", query, rec.Code, wantStatus)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"cfui/internal/logger"
+)
+
+// handleLogDownload serves GET /api/logs/download?from=&to=, the log file
+// lines stamped within the RFC 3339 range, as an attachment. from is
+// required; to defaults to now.
+func (s *Server) handleLogDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("from") == "" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("from is required"))
+		return
+	}
+	from, to, err := logTimeRange(query)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	name := fmt.Sprintf("cfui-logs-%s-%s.log", from.UTC().Format("20060102T150405Z"), to.UTC().Format("20060102T150405Z"))
+	serveLogFile(w, r, name, func(out io.Writer, path string) error {
+		return logger.WriteRange(out, path, from, to)
+	})
+}
+
+// handleLogExport serves GET /api/logs/export?format=ndjson&from=&to=, the
+// log history as newline-delimited JSON for log-analysis tools. ndjson is
+// the only format and the default. Without from the whole history is
+// exported; to defaults to now.
+func (s *Server) handleLogExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	if format := query.Get("format"); format != "" && format != "ndjson" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("format must be ndjson, got %q", format))
+		return
+	}
+	from, to, err := logTimeRange(query)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	name := fmt.Sprintf("cfui-logs-%s.ndjson", to.UTC().Format("20060102T150405Z"))
+	serveLogFile(w, r, name, func(out io.Writer, path string) error {
+		return logger.WriteNDJSON(out, path, from, to)
+	})
+}
+
+// logTimeRange parses the optional RFC 3339 from and to query values. A
+// missing from is the zero time and a missing to is now.
+func logTimeRange(query url.Values) (from, to time.Time, err error) {
+	if raw := query.Get("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, fmt.Errorf("from: %q is not an RFC 3339 time", raw)
+		}
+	}
+	to = time.Now()
+	if raw := query.Get("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, fmt.Errorf("to: %q is not an RFC 3339 time", raw)
+		}
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("to must not be before from")
+	}
+	return from, to, nil
+}
+
+// serveLogFile sends what write produces from the active log file as an
+// NDJSON attachment named name.
+func serveLogFile(w http.ResponseWriter, r *http.Request, name string, write func(io.Writer, string) error) {
+	path := logger.FilePath()
+	if path == "" {
+		http.Error(w, "Log file not available", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	out := bufio.NewWriter(w)
+	// The status is already sent once lines flow, so a failure part way
+	// can only cut the download short.
+	if err := write(out, path); err != nil {
+		log().Warnf("Log download for %s stopped early: %v", r.RemoteAddr, err)
+	}
+	if err := out.Flush(); err != nil {
+		log().Debugf("Log download for %s not delivered: %v", r.RemoteAddr, err)
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net/http"
+
+	"cfui/internal/logger"
+)
+
+// LogRotateResponse names the active log file after a rotation.
+type LogRotateResponse struct {
+	Success bool   `json:"success"`
+	File    string `json:"file"`
+}
+
+// handleLogRotate serves POST /api/logs/rotate, which starts a fresh log
+// file so a capture has a clean boundary. The previous file becomes a
+// timestamped backup next to it.
+func (s *Server) handleLogRotate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	file, err := logger.Rotate()
+	if err != nil {
+		log().Errorf("Failed to rotate log file on request from %s: %v", r.RemoteAddr, err)
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log().Infof("Log file rotated by %s", r.RemoteAddr)
+	writeJSON(w, LogRotateResponse{Success: true, File: file})
+}
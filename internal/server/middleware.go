@@ -3,8 +3,12 @@ package server
 import (
 	"cfui/internal/logger"
 	"net/http"
+	"os"
 	"runtime/debug"
 	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
 )
 
 // PanicRecoveryMiddleware recovers from panics in HTTP handlers
@@ -13,8 +17,8 @@ func PanicRecoveryMiddleware(next http.Handler) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				// Log the panic with stack trace
-				logger.Sugar.Errorf("HTTP handler panic: %v", err)
-				logger.Sugar.Errorf("Stack trace:\n%s", debug.Stack())
+				log().Errorf("HTTP handler panic: %v", err)
+				log().Errorf("Stack trace:\n%s", debug.Stack())
 
 				// Return 500 Internal Server Error to client
 				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -25,28 +29,126 @@ func PanicRecoveryMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// LoggingMiddleware logs all HTTP requests. High-frequency polling endpoints
-// are logged at debug level so they don't flood the log file (and the UI's
-// live log panel, which would otherwise echo its own polling forever).
+// ConcurrencyLimitMiddleware caps in-flight requests at CFUI_MAX_INFLIGHT.
+func ConcurrencyLimitMiddleware(next http.Handler) http.Handler {
+	return NewConcurrencyLimitMiddleware(MaxInFlightFromEnv())(next)
+}
+
+// NewConcurrencyLimitMiddleware rejects /api/ requests beyond max in flight
+// with 503 and Retry-After instead of queueing them. Long-lived requests are
+// not counted: API streams, and everything outside /api/ such as MCP
+// sessions and WebDAV transfers, would otherwise hold slots for hours.
+// max <= 0 disables the limit.
+func NewConcurrencyLimitMiddleware(max int) func(http.Handler) http.Handler {
+	if max <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	slots := make(chan struct{}, max)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") || isStreamingPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				next.ServeHTTP(w, r)
+			default:
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "Server busy, retry shortly", http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+// AccessLogMode controls how much of the read-only request traffic is logged.
+// Mutating requests are always logged at info level.
+type AccessLogMode string
+
+const (
+	// AccessLogOff drops polling reads and logs other reads at debug level.
+	AccessLogOff AccessLogMode = "off"
+	// AccessLogSampled logs one in accessLogSampleEvery polling reads at
+	// debug level. This is the default.
+	AccessLogSampled AccessLogMode = "sampled"
+	// AccessLogFull logs every request at info level.
+	AccessLogFull AccessLogMode = "full"
+)
+
+const accessLogSampleEvery = 50
+
+// AccessLogModeFromEnv reads CFUI_ACCESS_LOG, defaulting to sampled.
+func AccessLogModeFromEnv() AccessLogMode {
+	switch mode := AccessLogMode(strings.ToLower(strings.TrimSpace(os.Getenv("CFUI_ACCESS_LOG")))); mode {
+	case AccessLogOff, AccessLogFull:
+		return mode
+	default:
+		return AccessLogSampled
+	}
+}
+
+// LoggingMiddleware logs HTTP requests using the CFUI_ACCESS_LOG mode.
+// High-frequency polling endpoints are sampled or dropped so they don't flood
+// the log file (and the UI's live log panel, which would otherwise echo its
+// own polling forever).
 func LoggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet && isPollingPath(r.URL.Path) {
-			logger.Sugar.Debugf("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
-		} else {
-			logger.Sugar.Infof("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+	return NewLoggingMiddleware(AccessLogModeFromEnv())(next)
+}
+
+// NewLoggingMiddleware returns a request logger for the given mode.
+func NewLoggingMiddleware(mode AccessLogMode) func(http.Handler) http.Handler {
+	var polls atomic.Uint64
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logRequest(mode, &polls, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// accessLog returns the logger for access log lines, named http.
+func accessLog() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentHTTP)
+}
+
+func logRequest(mode AccessLogMode, polls *atomic.Uint64, r *http.Request) {
+	read := r.Method == http.MethodGet || r.Method == http.MethodHead
+	switch {
+	case !read || mode == AccessLogFull:
+		accessLog().Infof("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+	case isPollingPath(r.URL.Path):
+		if mode == AccessLogOff {
+			return
 		}
-		next.ServeHTTP(w, r)
-	})
+		if polls.Add(1)%accessLogSampleEvery == 1 {
+			accessLog().Debugf("%s %s from %s (sampled 1/%d)", r.Method, r.URL.Path, r.RemoteAddr, accessLogSampleEvery)
+		}
+	case mode == AccessLogOff:
+		accessLog().Debugf("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+	default:
+		accessLog().Infof("%s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+	}
 }
 
 func isPollingPath(path string) bool {
 	switch path {
-	case "/api/status", "/api/ddns/status", "/api/logs/recent", "/api/s3/files/sync":
+	case "/api/status", "/api/ddns/status", "/api/logs/recent", "/api/s3/files/sync", "/readyz", "/api/ping", "/metrics":
 		return true
 	}
 	return strings.HasPrefix(path, "/api/tunnels/") && strings.HasSuffix(path, "/status")
 }
 
+// isStreamingPath reports routes that hold their response open: SSE streams
+// and WebSockets.
+func isStreamingPath(path string) bool {
+	switch path {
+	case "/api/logs/stream", "/api/metrics/stream", "/api/ws":
+		return true
+	}
+	return strings.HasPrefix(path, "/api/cf/workers/") && strings.HasSuffix(path, "/tail")
+}
+
 // ChainMiddleware chains multiple middleware together
 func ChainMiddleware(handler http.Handler, middlewares ...func(http.Handler) http.Handler) http.Handler {
 	for i := len(middlewares) - 1; i >= 0; i-- {
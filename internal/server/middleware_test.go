@@ -0,0 +1,110 @@
+package server
+
+import (
+	"cfui/internal/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observeLogs routes logger.Sugar into an in-memory observer for one test.
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	prev := logger.Sugar
+	logger.Sugar = zap.New(core).Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+	return logs
+}
+
+func TestLoggingMiddlewareAccessLogModes(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	tests := []struct {
+		mode       AccessLogMode
+		wantStatus int // expected log entries for 10 status polls
+	}{
+		{mode: AccessLogOff, wantStatus: 0},
+		{mode: AccessLogSampled, wantStatus: 1},
+		{mode: AccessLogFull, wantStatus: 10},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			logs := observeLogs(t)
+			handler := NewLoggingMiddleware(tt.mode)(ok)
+
+			for i := 0; i < 10; i++ {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/status", nil))
+			}
+			if got := logs.FilterMessageSnippet("/api/status").Len(); got != tt.wantStatus {
+				t.Fatalf("status poll log entries = %d, want %d", got, tt.wantStatus)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/control", strings.NewReader(`{}`)))
+			control := logs.FilterMessageSnippet("POST /api/control").All()
+			if len(control) != 1 || control[0].Level != zapcore.InfoLevel {
+				t.Fatalf("control request must always log at info, got %#v", control)
+			}
+		})
+	}
+}
+
+func TestAccessLogModeFromEnv(t *testing.T) {
+	for raw, want := range map[string]AccessLogMode{"": AccessLogSampled, "OFF": AccessLogOff, "full": AccessLogFull, "bogus": AccessLogSampled} {
+		t.Setenv("CFUI_ACCESS_LOG", raw)
+		if got := AccessLogModeFromEnv(); got != want {
+			t.Errorf("AccessLogModeFromEnv(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestConcurrencyLimitRejectsBeyondMaxButIgnoresLongLivedRequests(t *testing.T) {
+	const max = 2
+	entered := make(chan string)
+	release := make(chan struct{})
+	handler := NewConcurrencyLimitMiddleware(max)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- r.URL.Path
+		<-release
+	}))
+
+	// Open streams, MCP sessions and WebDAV transfers do not take slots.
+	held := []string{"/api/logs/stream", "/api/ws", "/mcp", "/webdav/s3/backup.tar"}
+	done := make(chan int, len(held)+max)
+	serve := func(path string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		done <- rec.Code
+	}
+	for _, path := range held {
+		go serve(path)
+		<-entered
+	}
+	for i := 0; i < max; i++ {
+		go serve("/api/status")
+		<-entered
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("request %d: status %d, Retry-After %q; want 503 with Retry-After", max+1, rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	close(release)
+	for i := 0; i < len(held)+max; i++ {
+		if code := <-done; code != http.StatusOK {
+			t.Fatalf("admitted request status = %d, want 200", code)
+		}
+	}
+	// Freed slots admit new requests.
+	go func() { <-entered }()
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("request after release: status %d, want 200", rec.Code)
+	}
+}
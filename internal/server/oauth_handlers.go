@@ -80,7 +80,7 @@ func (s *Server) handleOAuthConfig(w http.ResponseWriter, r *http.Request) {
 		cfg.OAuthRelayCallbackURL = relayURL
 	}
 	if err := s.cfgMgr.Save(cfg); err != nil {
-		writeAPIError(w, http.StatusInternalServerError, err)
+		writeAPIError(w, saveErrorStatus(err, http.StatusInternalServerError), err)
 		return
 	}
 	status, err := s.resetOAuthService().Status(r.Context())
@@ -511,7 +511,7 @@ func (s *Server) handleCFTunnels(w http.ResponseWriter, r *http.Request) {
 		if req.SaveLocalProfile {
 			localProfile, err = s.saveOAuthTunnelLocalProfile(result.Tunnel, result.Token, accountID, req.ActivateLocal)
 			if err != nil {
-				writeAPIError(w, http.StatusBadRequest, err)
+				writeAPIError(w, saveErrorStatus(err, http.StatusBadRequest), err)
 				return
 			}
 		}
@@ -549,7 +549,7 @@ func (s *Server) handleCFTunnel(w http.ResponseWriter, r *http.Request) {
 		if parseBoolQuery(r.URL.Query().Get("delete_local_profile")) {
 			localProfile, localProfileRemoved, err = s.cleanupOAuthTunnelLocalProfile(target.TunnelID)
 			if err != nil {
-				writeAPIError(w, http.StatusBadRequest, err)
+				writeAPIError(w, saveErrorStatus(err, http.StatusBadRequest), err)
 				return
 			}
 		}
@@ -828,7 +828,7 @@ func (s *Server) summarizeLocalTunnelProfile(profile config.TunnelProfileConfig,
 		return summary
 	}
 	st, _ := s.runner.ProfileStatus(profile.Key)
-	status := statusResponseFrom(st)
+	status := statusResponseFrom(st, profile.Name)
 	summary.Running = status.Running
 	summary.Status = status.Status
 	summary.Protocol = status.Protocol
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"cfui/internal/config"
+	"net/http"
+	"strings"
+)
+
+// Onboarding step identifiers, in the order the setup wizard walks them.
+const (
+	onboardingStepToken     = "token"
+	onboardingStepLocal     = "local_profile"
+	onboardingStepStarted   = "tunnel_started"
+	onboardingStepAutoStart = "auto_start"
+)
+
+// OnboardingStep reports whether one setup step is complete.
+type OnboardingStep struct {
+	ID       string `json:"id"`
+	Done     bool   `json:"done"`
+	Required bool   `json:"required"`
+}
+
+// OnboardingResponse summarizes first-run setup progress. Ready is true once
+// every required step is done; NextStep is the first incomplete step, or ""
+// when setup is finished.
+type OnboardingResponse struct {
+	Ready    bool             `json:"ready"`
+	Complete bool             `json:"complete"`
+	NextStep string           `json:"next_step"`
+	Steps    []OnboardingStep `json:"steps"`
+}
+
+func (s *Server) handleOnboarding(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	running := 0
+	if s.runner != nil {
+		running = s.runner.RunningCount()
+	}
+	writeJSON(w, onboardingResponse(s.cfgMgr.Get(), running))
+}
+
+func onboardingResponse(cfg config.Config, running int) OnboardingResponse {
+	hasToken, hasLocal, autoStart := false, false, false
+	for _, profile := range cfg.Tunnels {
+		if strings.TrimSpace(profile.Token) == "" {
+			continue
+		}
+		hasToken = true
+		if profile.LocalEnabled {
+			hasLocal = true
+			autoStart = autoStart || profile.AutoStart
+		}
+	}
+
+	resp := OnboardingResponse{
+		Steps: []OnboardingStep{
+			{ID: onboardingStepToken, Done: hasToken, Required: true},
+			{ID: onboardingStepLocal, Done: hasLocal, Required: true},
+			{ID: onboardingStepStarted, Done: running > 0, Required: false},
+			{ID: onboardingStepAutoStart, Done: autoStart, Required: false},
+		},
+	}
+	resp.Ready, resp.Complete = true, true
+	for _, step := range resp.Steps {
+		if step.Done {
+			continue
+		}
+		if resp.NextStep == "" {
+			resp.NextStep = step.ID
+		}
+		resp.Complete = false
+		if step.Required {
+			resp.Ready = false
+		}
+	}
+	return resp
+}
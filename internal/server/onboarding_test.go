@@ -0,0 +1,55 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func getOnboarding(t *testing.T, s *Server) OnboardingResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.handleOnboarding(rec, httptest.NewRequest(http.MethodGet, "/api/onboarding", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("onboarding status %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp OnboardingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode onboarding: %v", err)
+	}
+	return resp
+}
+
+func TestOnboardingFreshInstall(t *testing.T) {
+	s := newServerTestServer(t)
+	resp := getOnboarding(t, s)
+	if resp.Ready || resp.Complete {
+		t.Fatalf("fresh install must not be ready: %#v", resp)
+	}
+	if resp.NextStep != onboardingStepToken {
+		t.Fatalf("next step = %q, want %q", resp.NextStep, onboardingStepToken)
+	}
+}
+
+func TestOnboardingConfigured(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.Token = "configured-token"
+	cfg.AutoStart = true
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	resp := getOnboarding(t, s)
+	if !resp.Ready {
+		t.Fatalf("configured install should be ready: %#v", resp)
+	}
+	// No runner in tests, so the tunnel is not running yet.
+	if resp.Complete || resp.NextStep != onboardingStepStarted {
+		t.Fatalf("next step = %q (complete=%v), want %q", resp.NextStep, resp.Complete, onboardingStepStarted)
+	}
+	if got := onboardingResponse(s.cfgMgr.Get(), 1); !got.Complete || got.NextStep != "" {
+		t.Fatalf("running configured install should be complete: %#v", got)
+	}
+}
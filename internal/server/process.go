@@ -0,0 +1,47 @@
+package server
+
+import (
+	"cfui/internal/cloudflared"
+	"net/http"
+	"os"
+	"runtime"
+
+	"cfui/version"
+)
+
+// tunnelProcessModeEmbedded means cloudflared runs as a library inside the
+// cfui process rather than as a child process with its own PID.
+const tunnelProcessModeEmbedded = "embedded"
+
+// TunnelProcessResponse describes how cloudflared is hosted. PID is the cfui
+// process itself; there is no separate cloudflared process to look for.
+type TunnelProcessResponse struct {
+	Mode               string `json:"mode"`
+	PID                int    `json:"pid"`
+	Version            string `json:"version"`
+	CloudflaredVersion string `json:"cloudflared_version"`
+	GoVersion          string `json:"go_version"`
+	Goroutines         int    `json:"goroutines"`
+	RunningTunnels     int    `json:"running_tunnels"`
+	Connections        int    `json:"connections"`
+}
+
+func (s *Server) handleTunnelProcess(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	resp := TunnelProcessResponse{
+		Mode:               tunnelProcessModeEmbedded,
+		PID:                os.Getpid(),
+		Version:            version.GetVersion(),
+		CloudflaredVersion: cloudflared.LibraryVersion(),
+		GoVersion:          runtime.Version(),
+		Goroutines:         runtime.NumGoroutine(),
+	}
+	if s.runner != nil {
+		resp.RunningTunnels = s.runner.RunningCount()
+		resp.Connections = len(s.runner.Connections())
+	}
+	writeJSON(w, resp)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"cfui/internal/cloudflared"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"cfui/version"
+)
+
+func TestTunnelProcessReportsEmbeddedModel(t *testing.T) {
+	s := newServerTestServer(t)
+	rec := httptest.NewRecorder()
+	s.handleTunnelProcess(rec, httptest.NewRequest(http.MethodGet, "/api/tunnel/process", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp TunnelProcessResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Mode != tunnelProcessModeEmbedded || resp.PID != os.Getpid() {
+		t.Fatalf("mode/pid = %q/%d, want embedded/%d", resp.Mode, resp.PID, os.Getpid())
+	}
+	if resp.Version != version.GetVersion() || resp.CloudflaredVersion != cloudflared.LibraryVersion() {
+		t.Fatalf("versions = %q/%q", resp.Version, resp.CloudflaredVersion)
+	}
+	if resp.CloudflaredVersion == "" || resp.CloudflaredVersion == "unknown" {
+		t.Fatalf("embedded cloudflared version not resolved: %q", resp.CloudflaredVersion)
+	}
+}
+
+func TestSupportedFlagsReportsCurrentValues(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.Tunnels[0].Region = "us"
+	cfg.Tunnels[0].NoTLSVerify = true
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleSupportedFlags(rec, httptest.NewRequest(http.MethodGet, "/api/tunnel/supported-flags", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp SupportedFlagsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Flags) != len(cloudflared.ManagedFlags) {
+		t.Fatalf("got %d flags, want %d", len(resp.Flags), len(cloudflared.ManagedFlags))
+	}
+	byFlag := make(map[string]SupportedFlag)
+	for _, flag := range resp.Flags {
+		byFlag[flag.Flag] = flag
+	}
+	if f := byFlag["--region"]; !f.Passed || f.Value != "us" || f.Fields[0] != "region" {
+		t.Fatalf("--region = %+v, want passed with us", f)
+	}
+	if f := byFlag["--no-tls-verify"]; !f.Passed || f.Value != "" {
+		t.Fatalf("--no-tls-verify = %+v, want passed without a value", f)
+	}
+	if f := byFlag["--logfile"]; f.Passed {
+		t.Fatalf("--logfile = %+v, want not passed", f)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleSupportedFlags(rec, httptest.NewRequest(http.MethodGet, "/api/tunnel/supported-flags?tunnel=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown tunnel status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// ProfileDiffField is one setting that differs between two tunnel profiles.
+// Values are masked like GET /api/config/{field} values.
+type ProfileDiffField struct {
+	Field string `json:"field"`
+	A     any    `json:"a"`
+	B     any    `json:"b"`
+}
+
+// ProfileDiffResponse lists the differing settings of profiles A and B, in
+// struct order. An empty Fields means the profiles are configured alike.
+type ProfileDiffResponse struct {
+	A      string             `json:"a"`
+	B      string             `json:"b"`
+	Fields []ProfileDiffField `json:"fields"`
+}
+
+// handleProfileDiff serves GET /api/profiles/diff?a=home&b=work, comparing
+// two saved tunnel profiles field by field. The keys themselves are left
+// out since they always differ.
+func (s *Server) handleProfileDiff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cfg := s.cfgMgr.Get()
+	keyA, keyB := r.URL.Query().Get("a"), r.URL.Query().Get("b")
+	if keyA == "" || keyB == "" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("both a and b profile keys are required"))
+		return
+	}
+	a, ok := cfg.TunnelProfile(keyA)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", keyA))
+		return
+	}
+	b, ok := cfg.TunnelProfile(keyB)
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", keyB))
+		return
+	}
+
+	changes, err := fieldChanges(reflect.ValueOf(a), reflect.ValueOf(b), "key")
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	resp := ProfileDiffResponse{A: a.Key, B: b.Key, Fields: make([]ProfileDiffField, 0, len(changes))}
+	for _, change := range changes {
+		resp.Fields = append(resp.Fields, ProfileDiffField{Field: change.Field, A: change.Old, B: change.New})
+	}
+	writeJSON(w, resp)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cfui/internal/config"
+)
+
+func TestProfileDiffListsOnlyDifferingFields(t *testing.T) {
+	s := newServerTestServer(t)
+	for _, profile := range []config.TunnelProfileConfig{
+		{Key: "home", Name: "Tunnel", Token: "eyJhIjoiaG9tZS10dW5uZWwtdG9rZW4ifQ", Protocol: "quic", Region: "us"},
+		{Key: "work", Name: "Tunnel", Token: "eyJhIjoid29yay10dW5uZWwtdG9rZW4ifQ", Protocol: "http2", Region: "us"},
+	} {
+		if _, err := s.cfgMgr.SaveTunnelProfile(profile.Key, profile); err != nil {
+			t.Fatalf("SaveTunnelProfile %s: %v", profile.Key, err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleProfileDiff(rec, httptest.NewRequest(http.MethodGet, "/api/profiles/diff?a=home&b=work", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "eyJhIjoiaG9tZS10dW5uZWwtdG9rZW4ifQ") {
+		t.Fatalf("diff leaked a raw token: %s", rec.Body.String())
+	}
+	var resp ProfileDiffResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	var fields []string
+	for _, f := range resp.Fields {
+		fields = append(fields, f.Field)
+	}
+	if strings.Join(fields, ",") != "token,protocol" {
+		t.Fatalf("diff fields = %v, want [token protocol]", fields)
+	}
+	if got := resp.Fields[1]; got.A != "quic" || got.B != "http2" {
+		t.Fatalf("protocol diff = %+v, want quic vs http2", got)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleProfileDiff(rec, httptest.NewRequest(http.MethodGet, "/api/profiles/diff?a=home&b=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("missing profile status %d, want 404", rec.Code)
+	}
+}
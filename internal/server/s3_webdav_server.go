@@ -79,20 +79,20 @@ func (s *Server) reconcileS3WebDAVDedicated(ctx context.Context, keepRunning boo
 	cfg := s.cfgMgr.Get().S3WebDAV
 	if !cfg.Enabled || normalizeS3WebDAVAccessMode(cfg.WebDAVAccessMode) != config.S3WebDAVAccessModeDedicated {
 		if err := s.s3WebDAV.stop(ctx, ""); err != nil && logger.Sugar != nil {
-			logger.Sugar.Warnf("Failed to stop dedicated S3 WebDAV server: %v", err)
+			log().Warnf("Failed to stop dedicated S3 WebDAV server: %v", err)
 		}
 		return
 	}
 	shouldStart := (!keepRunning && cfg.DedicatedAutoStart) || (keepRunning && s.s3WebDAV.isRunning())
 	if !shouldStart {
 		if err := s.s3WebDAV.stop(ctx, ""); err != nil && logger.Sugar != nil {
-			logger.Sugar.Warnf("Failed to stop dedicated S3 WebDAV server: %v", err)
+			log().Warnf("Failed to stop dedicated S3 WebDAV server: %v", err)
 		}
 		return
 	}
 	if err := s.startS3WebDAVDedicated(ctx); err != nil && logger.Sugar != nil {
 		addr, _ := s3DedicatedAddr(cfg)
-		logger.Sugar.Warnf("Failed to start dedicated S3 WebDAV server on %s: %v", addr, err)
+		log().Warnf("Failed to start dedicated S3 WebDAV server on %s: %v", addr, err)
 	}
 }
 
@@ -192,12 +192,12 @@ func (s *s3DedicatedServer) ensure(ctx context.Context, addr string, handler htt
 			}
 			s.mu.Unlock()
 			if logger.Sugar != nil {
-				logger.Sugar.Errorf("Dedicated S3 WebDAV server stopped unexpectedly: %v", err)
+				log().Errorf("Dedicated S3 WebDAV server stopped unexpectedly: %v", err)
 			}
 		}
 	}()
 	if logger.Sugar != nil {
-		logger.Sugar.Infof("Dedicated S3 WebDAV server listening on %s", s.addr)
+		log().Infof("Dedicated S3 WebDAV server listening on %s", s.addr)
 	}
 	return nil
 }
@@ -18,27 +18,58 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"mime"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"cfui/version"
 
 	"github.com/BurntSushi/toml"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
 )
 
+// log returns the package logger, named cfui.server so broadcast lines can
+// be filtered by component.
+func log() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentServer)
+}
+
 // API Response structures for type safety
 
 // StatusResponse represents the tunnel status response
 type StatusResponse struct {
-	Running  bool   `json:"running"`
-	Status   string `json:"status"`
-	Protocol string `json:"protocol"`
-	Error    string `json:"error,omitempty"`
+	Running    bool   `json:"running"`
+	Status     string `json:"status"`
+	Protocol   string `json:"protocol"`
+	TunnelName string `json:"tunnel_name,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// NextRestartAt is set while the tunnel waits out an auto-restart
+	// backoff.
+	NextRestartAt *time.Time `json:"next_restart_at,omitempty"`
+	// StopReason says why a stopped tunnel is not running: user, error,
+	// exited, crash_loop, flapping, idle, shutdown, or never_started.
+	StopReason string `json:"stop_reason,omitempty"`
+	// ProtocolThrashing is set when auto mode hit max_protocol_switches
+	// and stuck to Protocol.
+	ProtocolThrashing bool `json:"protocol_thrashing,omitempty"`
+	// EdgeIPVersion is the edge IP version of the latest run, which
+	// edge_ip_fallback may have moved from "6" to "4".
+	EdgeIPVersion string `json:"edge_ip_version,omitempty"`
+	// RestartCount is the number of auto-restarts since the tunnel last
+	// ran stably, and LastRestart when the latest one was scheduled.
+	RestartCount int        `json:"restart_count"`
+	LastRestart  *time.Time `json:"last_restart,omitempty"`
+	// UptimeSeconds is the time since the current run started; 0 while
+	// the tunnel is stopped.
+	UptimeSeconds int64 `json:"uptime_seconds"`
 }
 
 // Reset resets the StatusResponse to its zero state
@@ -46,7 +77,15 @@ func (r *StatusResponse) Reset() {
 	r.Running = false
 	r.Status = ""
 	r.Protocol = ""
+	r.TunnelName = ""
 	r.Error = ""
+	r.NextRestartAt = nil
+	r.StopReason = ""
+	r.ProtocolThrashing = false
+	r.EdgeIPVersion = ""
+	r.RestartCount = 0
+	r.LastRestart = nil
+	r.UptimeSeconds = 0
 }
 
 // ControlResponse represents the control action response
@@ -118,6 +157,16 @@ type Server struct {
 	// (SSE log streams) exit promptly instead of stalling http.Server.Shutdown
 	// until its timeout.
 	shutdownC chan struct{}
+	// logStreams counts open log streams so PrepareShutdown can wait for
+	// them to deliver the shutdown event.
+	logStreams atomic.Int64
+	// logStreamLimit caps concurrent log streams; nil means no cap.
+	logStreamLimit *streamLimiter
+	// control overrides runControl for control requests; nil uses it.
+	control func(key, action, requester string) (string, error)
+	// restartProfile overrides runner.RestartProfile for restart requests;
+	// nil uses it.
+	restartProfile func(key string) error
 }
 
 func NewServer(cfgMgr *config.Manager, runner *service.Runner, assets embed.FS, locales embed.FS) *Server {
@@ -145,12 +194,51 @@ func NewServerWithMode(cfgMgr *config.Manager, runner *service.Runner, assets em
 		assets:    assets,
 		locales:   locales,
 		shutdownC: make(chan struct{}),
+
+		logStreamLimit: streamLimiterFromEnv(),
+	}
+}
+
+// shutdownEvent tells log stream clients the server is going away, so they
+// can show it and reconnect after retry_after_ms instead of hammering a dead
+// server.
+const shutdownEvent = "shutdown"
+
+// DefaultShutdownDrain bounds how long PrepareShutdown waits for log streams
+// to deliver the shutdown event.
+const DefaultShutdownDrain = 2 * time.Second
+
+// ShutdownDrainFromEnv reads CFUI_SHUTDOWN_DRAIN (a Go duration such as
+// "500ms"; "0" skips the wait), defaulting to DefaultShutdownDrain.
+func ShutdownDrainFromEnv() time.Duration {
+	raw := strings.TrimSpace(os.Getenv("CFUI_SHUTDOWN_DRAIN"))
+	if raw == "" {
+		return DefaultShutdownDrain
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return DefaultShutdownDrain
 	}
+	return d
+}
+
+// shutdownEventData is the payload of the shutdown event.
+func shutdownEventData() string {
+	return fmt.Sprintf(`{"retry_after_ms":%d}`, sseRetryMillis)
 }
 
 // PrepareShutdown asks long-lived connections (log streams) to close so the
-// HTTP server can shut down promptly. Call before http.Server.Shutdown.
-func (s *Server) PrepareShutdown() {
+// HTTP server can shut down promptly. Log stream clients first get a
+// shutdown event; up to drain is spent waiting for them to receive it and
+// hang up. Call before http.Server.Shutdown.
+func (s *Server) PrepareShutdown(drain time.Duration) {
+	if broadcaster := logger.GetBroadcaster(); broadcaster != nil {
+		broadcaster.BroadcastEvent(shutdownEvent, shutdownEventData())
+	}
+	deadline := time.Now().Add(drain)
+	for s.logStreams.Load() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
 	select {
 	case <-s.shutdownC:
 		// already closed
@@ -213,14 +301,36 @@ func (s *Server) GetHandler() http.Handler {
 
 	// API Endpoints
 	mux.HandleFunc("/api/config", s.handleConfig)
+	mux.HandleFunc("/api/config/", s.handleConfigField)
 	mux.HandleFunc("/api/status", s.handleStatus)
 	mux.HandleFunc("/api/control", s.handleControl)
 	mux.HandleFunc("/api/tunnels", s.handleTunnels)
 	mux.HandleFunc("/api/tunnels/", s.handleTunnel)
+	mux.HandleFunc("/api/profiles/diff", s.handleProfileDiff)
 	mux.HandleFunc("/api/version", s.handleVersion)
+	mux.HandleFunc("/readyz", s.handleReady)
+	mux.HandleFunc("/api/ping", s.handlePing)
+	mux.HandleFunc("/api/health/summary", s.handleHealthSummary)
+	mux.HandleFunc("/metrics", s.handlePrometheusMetrics)
+	mux.HandleFunc("/api/metrics", s.handleMetrics)
+	mux.HandleFunc("/api/metrics/stream", s.handleMetricsStream)
+	mux.HandleFunc("/api/tunnel/connections", s.handleTunnelConnections)
+	mux.HandleFunc("/api/tunnel/process", s.handleTunnelProcess)
+	mux.HandleFunc("/api/tunnel/supported-flags", s.handleSupportedFlags)
+	mux.HandleFunc("/api/tunnel/last-error", s.handleLastError)
+	mux.HandleFunc("/api/events", s.handleEvents)
+	mux.HandleFunc("/api/restarts", s.handleRestarts)
+	mux.HandleFunc("/api/stats", s.handleStats)
 	mux.HandleFunc("/api/i18n/", s.handleI18n)
 	mux.HandleFunc("/api/logs/stream", s.handleLogStream)
 	mux.HandleFunc("/api/logs/recent", s.handleRecentLogs)
+	mux.HandleFunc("/api/logs/errors", s.handleErrorLogs)
+	mux.HandleFunc("/api/logs/context", s.handleLogContext)
+	mux.HandleFunc("/api/logs/download", s.handleLogDownload)
+	mux.HandleFunc("/api/logs/export", s.handleLogExport)
+	mux.HandleFunc("/api/logs/rotate", s.handleLogRotate)
+	mux.HandleFunc("/api/logs/config", s.handleLogConfig)
+	mux.HandleFunc("/api/ws", s.handleWS)
 	mux.HandleFunc("/api/tunnel-manager/settings", s.handleTunnelManagerSettings)
 	mux.HandleFunc("/api/tunnel-manager/tunnel", s.handleTunnelManagerTunnel)
 	mux.HandleFunc("/api/tunnel-manager/config", s.handleTunnelManagerConfig)
@@ -233,6 +343,7 @@ func (s *Server) GetHandler() http.Handler {
 	mux.HandleFunc("/api/mcp/tokens", s.handleMCPTokens)
 	mux.HandleFunc("/api/mcp/tokens/", s.handleMCPToken)
 	mux.HandleFunc("/api/features", s.handleFeatures)
+	mux.HandleFunc("/api/onboarding", s.handleOnboarding)
 	mux.HandleFunc("/api/oauth/status", s.handleOAuthStatus)
 	mux.HandleFunc("/api/oauth/relay-check", s.handleOAuthRelayCheck)
 	mux.HandleFunc("/api/oauth/config", s.handleOAuthConfig)
@@ -329,7 +440,7 @@ func (s *Server) GetHandler() http.Handler {
 	// The assets are in "web/dist", so we need to strip that prefix
 	fsys, err := fs.Sub(s.assets, "web/dist")
 	if err != nil {
-		logger.Sugar.Errorf("Failed to create sub filesystem: %v", err)
+		log().Errorf("Failed to create sub filesystem: %v", err)
 		panic(err)
 	}
 	indexHandler := serveEmbeddedIndex(fsys)
@@ -338,9 +449,12 @@ func (s *Server) GetHandler() http.Handler {
 	mux.HandleFunc("/local", indexHandler)
 	mux.HandleFunc("/local/", indexHandler)
 	mux.Handle("/", s.staticHandler(fsys))
+	// Sub-requests skip the middleware; the batch request itself is
+	// logged and panic-protected.
+	mux.HandleFunc("/api/batch", s.handleBatch(mux))
 
-	// Apply middleware chain: logging -> panic recovery -> handler
-	return ChainMiddleware(mux, LoggingMiddleware, PanicRecoveryMiddleware)
+	// Apply middleware chain: logging -> concurrency limit -> panic recovery -> handler
+	return ChainMiddleware(mux, LoggingMiddleware, ConcurrencyLimitMiddleware, PanicRecoveryMiddleware)
 }
 
 func serveEmbeddedIndex(fsys fs.FS) http.HandlerFunc {
@@ -351,7 +465,7 @@ func serveEmbeddedIndex(fsys fs.FS) http.HandlerFunc {
 		}
 		index, err := fs.ReadFile(fsys, "index.html")
 		if err != nil {
-			logger.Sugar.Errorf("Failed to read embedded index.html: %v", err)
+			log().Errorf("Failed to read embedded index.html: %v", err)
 			http.NotFound(w, r)
 			return
 		}
@@ -395,6 +509,9 @@ type FeaturesResponse struct {
 	MCP            bool                          `json:"mcp"`
 	S3WebDAV       bool                          `json:"s3_webdav"`
 	Availability   map[string]s3dav.Availability `json:"availability,omitempty"`
+	// UI holds deployment display toggles such as hide_advanced, set by
+	// environment variables; see uiToggleEnv.
+	UI map[string]bool `json:"ui"`
 }
 
 type LocalFeaturesResponse struct {
@@ -463,7 +580,7 @@ func (s *Server) handleFeatures(w http.ResponseWriter, r *http.Request) {
 		cfg.S3WebDAV.Enabled = *req.S3WebDAV
 	}
 	if err := s.cfgMgr.Save(cfg); err != nil {
-		writeAPIError(w, http.StatusInternalServerError, err)
+		writeAPIError(w, saveErrorStatus(err, http.StatusInternalServerError), err)
 		return
 	}
 	if req.S3WebDAV != nil {
@@ -505,6 +622,7 @@ func (s *Server) featuresResponse(ctx context.Context, cfg config.Config) Featur
 		Availability: map[string]s3dav.Availability{
 			"s3_webdav": s.s3Svc.FeatureAvailability(ctx, cfg.S3WebDAV),
 		},
+		UI: uiFeatures(),
 	}
 }
 
@@ -1056,18 +1174,70 @@ func writeAPIError(w http.ResponseWriter, status int, err error) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	if encodeErr := json.NewEncoder(w).Encode(map[string]string{"error": err.Error()}); encodeErr != nil {
-		logger.Sugar.Errorf("Failed to encode error response: %v", encodeErr)
+		log().Errorf("Failed to encode error response: %v", encodeErr)
+	}
+}
+
+// saveErrorStatus maps a config save failure onto an HTTP status: invalid
+// input is 400, a config a newer cfui wrote (read-only) is 409, and anything
+// else is fallback.
+func saveErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, config.ErrInvalidConfig):
+		return http.StatusBadRequest
+	case errors.Is(err, config.ErrConfigReadOnly):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+// writeJSONSized marshals v into memory and writes it with an explicit
+// Content-Length, so small fixed responses are never sent chunked. Use
+// writeJSON (streaming) for large or unbounded payloads.
+func writeJSONSized(w http.ResponseWriter, status int, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return err
 	}
+	payload = append(payload, '\n')
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+	w.WriteHeader(status)
+	_, err = w.Write(payload)
+	return err
 }
 
 func writeJSON(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(v); err != nil {
-		logger.Sugar.Errorf("Failed to encode JSON response: %v", err)
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	encodeJSONResponse(w, v, "JSON response")
+}
+
+// encodeJSONResponse streams v to w and logs a failure as "Failed to encode
+// <what>". It answers 500 only when nothing was written yet: once the body
+// has started (typically the client went away mid-write) the status is
+// already sent, and http.Error would just log a superfluous WriteHeader.
+func encodeJSONResponse(w http.ResponseWriter, v any, what string) {
+	tw := &writeTracker{w: w}
+	if err := json.NewEncoder(tw).Encode(v); err != nil {
+		log().Errorf("Failed to encode %s: %v", what, err)
+		if !tw.started {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
 	}
 }
 
+// writeTracker records whether any write reached w, successful or not.
+type writeTracker struct {
+	w       io.Writer
+	started bool
+}
+
+func (t *writeTracker) Write(p []byte) (int, error) {
+	t.started = true
+	return t.w.Write(p)
+}
+
 // StartDDNS starts the DDNS background service if configured.
 func (s *Server) StartDDNS() {
 	s.ddnsSvc.Start()
@@ -1078,32 +1248,70 @@ func (s *Server) StopDDNS() {
 	s.ddnsSvc.Stop()
 }
 
+// ConfigSaveResponse is the saved config plus, for each running tunnel, the
+// saved fields that only take effect once that tunnel restarts.
+type ConfigSaveResponse struct {
+	config.Config
+	PendingRestart map[string][]string `json:"pending_restart,omitempty"`
+	// Changes lists the fields this save changed, with secrets masked.
+	Changes []ConfigChange `json:"changes"`
+	// Warnings lists saved settings that are valid but likely unintended.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
 		cfg := s.cfgMgr.Get()
-		if err := json.NewEncoder(w).Encode(cfg); err != nil {
-			logger.Sugar.Errorf("Failed to encode config: %v", err)
-			http.Error(w, "Failed to encode config", http.StatusInternalServerError)
-		}
+		encodeJSONResponse(w, cfg, "config")
 		return
 	}
 
-	if r.Method == http.MethodPost {
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		before := s.cfgMgr.Get()
 		cfg := s.cfgMgr.Get()
-		if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
-			logger.Sugar.Warnf("Invalid config request from %s: %v", r.RemoteAddr, err)
+		var err error
+		if r.Method == http.MethodPatch {
+			err = applyConfigPatch(&cfg, r.Body)
+		} else {
+			err = json.NewDecoder(r.Body).Decode(&cfg)
+		}
+		if err != nil {
+			log().Warnf("Invalid config request from %s: %v", r.RemoteAddr, err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
 		if err := s.cfgMgr.Save(cfg); err != nil {
-			logger.Sugar.Errorf("Failed to save config: %v", err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			status := saveErrorStatus(err, http.StatusInternalServerError)
+			if status == http.StatusInternalServerError {
+				log().Errorf("Failed to save config: %v", err)
+			}
+			http.Error(w, err.Error(), status)
 			return
 		}
 
-		logger.Sugar.Infof("Configuration updated by %s", r.RemoteAddr)
-		writeJSON(w, s.cfgMgr.Get())
+		saved := s.cfgMgr.Get()
+		changes, err := configChanges(before, saved)
+		if err != nil {
+			log().Warnf("Failed to diff saved config: %v", err)
+		}
+		fields := make([]string, 0, len(changes))
+		for _, change := range changes {
+			fields = append(fields, change.Field)
+		}
+		log().Infof("Configuration updated by %s: %s", r.RemoteAddr, strings.Join(fields, ", "))
+		broadcastConfigChanged(saved.ActiveTunnelKey)
+		resp := ConfigSaveResponse{Config: saved, Changes: changes, Warnings: saved.Warnings()}
+		for _, warning := range resp.Warnings {
+			log().Warnf("Config warning: %s", warning)
+		}
+		if resp.Changes == nil {
+			resp.Changes = []ConfigChange{}
+		}
+		if s.runner != nil {
+			resp.PendingRestart = s.runner.PendingRestart()
+		}
+		writeJSON(w, resp)
 		return
 	}
 
@@ -1126,14 +1334,14 @@ func (s *Server) tunnelsResponse(cfg config.Config) TunnelsResponse {
 	statuses := make(map[string]StatusResponse, len(cfg.Tunnels))
 	for _, profile := range cfg.Tunnels {
 		st, _ := s.runner.ProfileStatus(profile.Key)
-		statuses[profile.Key] = statusResponseFrom(st)
+		statuses[profile.Key] = statusResponseFrom(st, profile.Name)
 	}
 	resp.Statuses = statuses
 	return resp
 }
 
-func statusResponseFrom(st cloudflared.Status) StatusResponse {
-	resp := StatusResponse{Running: st.Running, Protocol: st.Protocol}
+func statusResponseFrom(st cloudflared.Status, tunnelName string) StatusResponse {
+	resp := StatusResponse{Running: st.Running, Protocol: st.Protocol, TunnelName: tunnelName}
 	if st.Running {
 		resp.Status = "running"
 	} else {
@@ -1141,11 +1349,50 @@ func statusResponseFrom(st cloudflared.Status) StatusResponse {
 	}
 	if st.LastError != nil {
 		resp.Error = st.LastError.Error()
-		resp.Status = "error"
+		resp.Status = errorStatus(st.LastError)
 	}
+	resp.NextRestartAt = nextRestartAt(st)
+	resp.StopReason = string(st.StopReason)
+	resp.ProtocolThrashing = st.ProtocolThrashing
+	resp.EdgeIPVersion = st.EdgeIPVersion
+	resp.RestartCount, resp.LastRestart, resp.UptimeSeconds = restartFigures(st, time.Now())
 	return resp
 }
 
+// restartFigures returns the restart count, last restart time (nil when
+// there was none) and uptime in whole seconds reported for st.
+func restartFigures(st cloudflared.Status, now time.Time) (int, *time.Time, int64) {
+	var last *time.Time
+	if !st.LastRestartAt.IsZero() {
+		at := st.LastRestartAt
+		last = &at
+	}
+	var uptime int64
+	if st.Running && !st.StartedAt.IsZero() {
+		uptime = int64(max(0, now.Sub(st.StartedAt)) / time.Second)
+	}
+	return st.RestartCount, last, uptime
+}
+
+// nextRestartAt returns the pending auto-restart time, or nil when none is
+// pending.
+func nextRestartAt(st cloudflared.Status) *time.Time {
+	if st.NextRestartAt.IsZero() {
+		return nil
+	}
+	at := st.NextRestartAt
+	return &at
+}
+
+// errorStatus distinguishes a broken cloudflared runtime, which no restart
+// of the tunnel can fix, from an ordinary tunnel error.
+func errorStatus(err error) string {
+	if errors.Is(err, cloudflared.ErrInitFailed) {
+		return "init_failed"
+	}
+	return "error"
+}
+
 func (s *Server) handleTunnels(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -1158,7 +1405,7 @@ func (s *Server) handleTunnels(w http.ResponseWriter, r *http.Request) {
 		}
 		cfg, err := s.cfgMgr.SaveTunnelProfile("", req)
 		if err != nil {
-			writeAPIError(w, http.StatusBadRequest, err)
+			writeAPIError(w, saveErrorStatus(err, http.StatusBadRequest), err)
 			return
 		}
 		writeJSON(w, s.tunnelsResponse(cfg))
@@ -1189,6 +1436,10 @@ func (s *Server) handleTunnel(w http.ResponseWriter, r *http.Request) {
 		s.handleTunnelStatus(w, r, key)
 	case "control":
 		s.handleTunnelControl(w, r, key)
+	case "wake":
+		s.handleTunnelWake(w, r, key)
+	case "protocol":
+		s.handleTunnelProtocol(w, r, key)
 	default:
 		writeAPIError(w, http.StatusNotFound, fmt.Errorf("unknown tunnel action %q", action))
 	}
@@ -1211,14 +1462,14 @@ func (s *Server) handleTunnelProfile(w http.ResponseWriter, r *http.Request, key
 		}
 		cfg, err := s.cfgMgr.SaveTunnelProfile(key, req)
 		if err != nil {
-			writeAPIError(w, http.StatusBadRequest, err)
+			writeAPIError(w, saveErrorStatus(err, http.StatusBadRequest), err)
 			return
 		}
 		writeJSON(w, s.tunnelsResponse(cfg))
 	case http.MethodDelete:
 		cfg, err := s.cfgMgr.DeleteTunnelProfile(key)
 		if err != nil {
-			writeAPIError(w, http.StatusBadRequest, err)
+			writeAPIError(w, saveErrorStatus(err, http.StatusBadRequest), err)
 			return
 		}
 		// Stop the deleted profile's tunnel asynchronously: the instance may
@@ -1226,7 +1477,7 @@ func (s *Server) handleTunnelProfile(w http.ResponseWriter, r *http.Request, key
 		if s.runner != nil {
 			go func() {
 				if err := s.runner.RemoveProfile(key); err != nil {
-					logger.Sugar.Warnf("Error stopping tunnel for deleted profile %q: %v", key, err)
+					log().Warnf("Error stopping tunnel for deleted profile %q: %v", key, err)
 				}
 			}()
 		}
@@ -1246,7 +1497,7 @@ func (s *Server) handleTunnelActivateLocal(w http.ResponseWriter, r *http.Reques
 	// requires stopping anything.
 	cfg, err := s.cfgMgr.ActivateTunnelProfile(key)
 	if err != nil {
-		writeAPIError(w, http.StatusBadRequest, err)
+		writeAPIError(w, saveErrorStatus(err, http.StatusBadRequest), err)
 		return
 	}
 	writeJSON(w, s.tunnelsResponse(cfg))
@@ -1258,16 +1509,17 @@ func (s *Server) handleTunnelStatus(w http.ResponseWriter, r *http.Request, key
 		return
 	}
 	cfg := s.cfgMgr.Get()
-	if _, ok := cfg.TunnelProfile(key); !ok {
+	profile, ok := cfg.TunnelProfile(key)
+	if !ok {
 		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", key))
 		return
 	}
 	if s.runner == nil {
-		writeJSON(w, StatusResponse{Running: false, Status: "unavailable"})
+		writeJSON(w, StatusResponse{Running: false, Status: "unavailable", TunnelName: profile.Name})
 		return
 	}
 	st, _ := s.runner.ProfileStatus(key)
-	writeJSON(w, statusResponseFrom(st))
+	writeJSON(w, statusResponseFrom(st, profile.Name))
 }
 
 func (s *Server) handleTunnelControl(w http.ResponseWriter, r *http.Request, key string) {
@@ -1292,31 +1544,39 @@ func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) writeRunnerStatus(w http.ResponseWriter) {
+	tunnelName := s.cfgMgr.Get().TunnelName
 	if s.runner == nil {
-		writeJSON(w, StatusResponse{Running: false, Status: "unavailable"})
+		if err := writeJSONSized(w, http.StatusOK, StatusResponse{Running: false, Status: "unavailable", TunnelName: tunnelName}); err != nil {
+			log().Errorf("Failed to write status response: %v", err)
+		}
 		return
 	}
-	running, err, protocol := s.runner.Status()
+	st, _ := s.runner.ProfileStatus("")
 	status := "stopped"
-	if running {
+	if st.Running {
 		status = "running"
 	}
 
 	resp := statusResponsePool.Get()
 	defer statusResponsePool.Put(resp)
 
-	resp.Running = running
+	resp.Running = st.Running
 	resp.Status = status
-	resp.Protocol = protocol
-	if err != nil {
-		resp.Error = err.Error()
-		resp.Status = "error"
-		logger.Sugar.Warnf("Tunnel status error: %v", err)
+	resp.Protocol = st.Protocol
+	resp.TunnelName = tunnelName
+	if st.LastError != nil {
+		resp.Error = st.LastError.Error()
+		resp.Status = errorStatus(st.LastError)
+		log().Warnf("Tunnel status error: %v", st.LastError)
 	}
+	resp.NextRestartAt = nextRestartAt(st)
+	resp.StopReason = string(st.StopReason)
+	resp.ProtocolThrashing = st.ProtocolThrashing
+	resp.EdgeIPVersion = st.EdgeIPVersion
+	resp.RestartCount, resp.LastRestart, resp.UptimeSeconds = restartFigures(st, time.Now())
 
-	if encodeErr := json.NewEncoder(w).Encode(resp); encodeErr != nil {
-		logger.Sugar.Errorf("Failed to encode status response: %v", encodeErr)
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	if writeErr := writeJSONSized(w, http.StatusOK, resp); writeErr != nil {
+		log().Errorf("Failed to write status response: %v", writeErr)
 	}
 }
 
@@ -1329,73 +1589,243 @@ func (s *Server) handleControl(w http.ResponseWriter, r *http.Request) {
 	s.handleControlFor(w, r, "")
 }
 
-// handleControlFor starts or stops the tunnel of one profile (""= active).
+// handleControlFor starts, stops or restarts the tunnel of one profile (""=
+// active), or cancels its pending auto-restart.
 func (s *Server) handleControlFor(w http.ResponseWriter, r *http.Request, key string) {
 	var req struct {
 		Action string `json:"action"`
+		// Confirm repeats the tunnel key when RequireConfirm is on.
+		Confirm string `json:"confirm"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Sugar.Warnf("Invalid control request from %s: %v", r.RemoteAddr, err)
+		log().Warnf("Invalid control request from %s: %v", r.RemoteAddr, err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := s.checkConfirm(key, req.Action, req.Confirm); err != nil {
+		log().Infof("Unconfirmed %s of tunnel %q from %s rejected", req.Action, key, r.RemoteAddr)
+		writeControlError(w, err)
+		return
+	}
+
+	message, err := s.controlTunnel(key, req.Action, r.RemoteAddr)
+	if errors.Is(err, errInvalidControlAction) {
+		http.Error(w, "Invalid action", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		writeControlError(w, err)
+		return
+	}
+
+	resp := controlResponsePool.Get()
+	defer controlResponsePool.Put(resp)
 
+	resp.Success = true
+	resp.Action = req.Action
+	resp.Message = message
+
+	if writeErr := writeJSONSized(w, http.StatusOK, resp); writeErr != nil {
+		log().Errorf("Failed to write control response: %v", writeErr)
+	}
+}
+
+// errInvalidControlAction rejects an unknown control action.
+var errInvalidControlAction = errors.New("invalid action")
+
+// controlTunnel runs a control action through s.control, or runControl when
+// no override is installed.
+func (s *Server) controlTunnel(key, action, requester string) (string, error) {
+	if s.control != nil {
+		return s.control(key, action, requester)
+	}
+	return s.runControl(key, action, requester)
+}
+
+// runControl applies a control action to the tunnel of one profile (""=
+// active) and returns a message for the client. A stop is only initiated:
+// it completes in the background so the reply is not lost when the client
+// reaches cfui through the tunnel being stopped.
+func (s *Server) runControl(key, action, requester string) (string, error) {
+	if s.runner == nil {
+		return "", errors.New("tunnel runner is not available")
+	}
 	label := key
 	if label == "" {
 		label = "active"
 	}
 
-	switch req.Action {
+	switch action {
 	case "start":
-		logger.Sugar.Infof("Starting tunnel %q (requested by %s)", label, r.RemoteAddr)
+		log().Infof("Starting tunnel %q (requested by %s)", label, requester)
 		if err := s.runner.StartProfile(key); err != nil {
-			logger.Sugar.Errorf("Failed to start tunnel %q: %v", label, err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			log().Errorf("Failed to start tunnel %q: %v", label, err)
+			return "", err
 		}
-		logger.Sugar.Infof("Tunnel %q started successfully", label)
+		log().Infof("Tunnel %q started successfully", label)
+		return "Tunnel started successfully", nil
 	case "stop":
-		logger.Sugar.Infof("Stopping tunnel %q (requested by %s)", label, r.RemoteAddr)
-		// For stop action, respond immediately and stop asynchronously
-		// This prevents the client from getting "Failed to fetch" when the tunnel shuts down
-		resp := controlResponsePool.Get()
-		resp.Success = true
-		resp.Action = "stop"
-		resp.Message = "Tunnel stop initiated"
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		encodeErr := json.NewEncoder(w).Encode(resp)
-		controlResponsePool.Put(resp)
-
-		if encodeErr != nil {
-			logger.Sugar.Errorf("Failed to encode stop response: %v", encodeErr)
+		log().Infof("Stopping tunnel %q (requested by %s)", label, requester)
+		if err := s.runner.CheckStop(key); err != nil {
+			log().Infof("Tunnel %q not stopped: %v", label, err)
+			return "", err
 		}
 		go func() {
 			if stopErr := s.runner.StopProfile(key); stopErr != nil {
-				logger.Sugar.Errorf("Error stopping tunnel %q: %v", label, stopErr)
+				log().Errorf("Error stopping tunnel %q: %v", label, stopErr)
 			} else {
-				logger.Sugar.Infof("Tunnel %q stopped successfully", label)
+				log().Infof("Tunnel %q stopped successfully", label)
 			}
 		}()
-		return
+		return "Tunnel stop initiated", nil
+	case "restart":
+		// Unlike a stop this waits, so the reply says the new run is up.
+		log().Infof("Restarting tunnel %q (requested by %s)", label, requester)
+		restart := s.runner.RestartProfile
+		if s.restartProfile != nil {
+			restart = s.restartProfile
+		}
+		if err := restart(key); err != nil {
+			log().Errorf("Failed to restart tunnel %q: %v", label, err)
+			return "", err
+		}
+		log().Infof("Tunnel %q restarted successfully", label)
+		return "Tunnel restarted successfully", nil
+	case "cancel_restart":
+		log().Infof("Canceling pending restart of tunnel %q (requested by %s)", label, requester)
+		if err := s.runner.CancelRestart(key); err != nil {
+			log().Infof("Tunnel %q restart not canceled: %v", label, err)
+			return "", err
+		}
+		return "Pending restart canceled", nil
 	default:
-		logger.Sugar.Warnf("Invalid action '%s' from %s", req.Action, r.RemoteAddr)
-		http.Error(w, "Invalid action", http.StatusBadRequest)
+		log().Warnf("Invalid action '%s' from %s", action, requester)
+		return "", errInvalidControlAction
+	}
+}
+
+// WakeResponse reports the outcome of a wake request. Started is true only
+// for the request that fired the profile's lazy starter.
+type WakeResponse struct {
+	Started bool `json:"started"`
+	Running bool `json:"running"`
+}
+
+// handleTunnelWake is the trigger of a lazy-start profile: the first wake
+// after launch (or after an idle stop) starts the tunnel. Status polling
+// deliberately does not wake tunnels, or an open dashboard would keep them
+// up.
+func (s *Server) handleTunnelWake(w http.ResponseWriter, r *http.Request, key string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
+	if s.runner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, fmt.Errorf("tunnel runner is not available"))
+		return
+	}
+	if _, ok := s.cfgMgr.Get().TunnelProfile(key); !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", key))
+		return
+	}
+	started, err := s.runner.Wake(key)
+	if err != nil {
+		log().Errorf("Failed to wake tunnel %q: %v", key, err)
+		writeControlError(w, err)
+		return
+	}
+	st, _ := s.runner.ProfileStatus(key)
+	writeJSON(w, WakeResponse{Started: started, Running: st.Running})
+}
 
-	resp := controlResponsePool.Get()
-	defer controlResponsePool.Put(resp)
+// ProtocolResponse reports the protocol a profile now uses and whether its
+// tunnel is running after the switch.
+type ProtocolResponse struct {
+	Protocol string `json:"protocol"`
+	Running  bool   `json:"running"`
+}
 
-	resp.Success = true
-	resp.Action = req.Action
-	resp.Message = "Tunnel started successfully"
+// handleTunnelProtocol switches a profile's transport protocol, restarting a
+// running tunnel without waiting for a full graceful drain.
+func (s *Server) handleTunnelProtocol(w http.ResponseWriter, r *http.Request, key string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.runner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, fmt.Errorf("tunnel runner is not available"))
+		return
+	}
+	if _, ok := s.cfgMgr.Get().TunnelProfile(key); !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", key))
+		return
+	}
+	var req struct {
+		Protocol string `json:"protocol"`
+		// Confirm repeats the tunnel key when RequireConfirm is on; the
+		// switch restarts a running tunnel.
+		Confirm string `json:"confirm"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.checkConfirm(key, "restart", req.Confirm); err != nil {
+		writeControlError(w, err)
+		return
+	}
+	log().Infof("Switching tunnel %q to protocol %q (requested by %s)", key, req.Protocol, r.RemoteAddr)
+	if err := s.runner.RestartForProtocol(key, req.Protocol); err != nil {
+		if errors.Is(err, config.ErrInvalidConfig) {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		log().Errorf("Failed to switch tunnel %q to protocol %q: %v", key, req.Protocol, err)
+		writeControlError(w, err)
+		return
+	}
+	st, _ := s.runner.ProfileStatus(key)
+	writeJSON(w, ProtocolResponse{Protocol: req.Protocol, Running: st.Running})
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if encodeErr := json.NewEncoder(w).Encode(resp); encodeErr != nil {
-		logger.Sugar.Errorf("Failed to encode control response: %v", encodeErr)
+// ControlErrorResponse is the body of a failed control request. Code is a
+// stable identifier the UI can branch on; Error is for humans.
+type ControlErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// controlErrors maps typed runner errors onto HTTP statuses and codes.
+var controlErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{cloudflared.ErrAlreadyRunning, http.StatusConflict, "already_running"},
+	{cloudflared.ErrNotRunning, http.StatusConflict, "not_running"},
+	{cloudflared.ErrNoPendingRestart, http.StatusConflict, "no_pending_restart"},
+	{cloudflared.ErrTokenMissing, http.StatusUnprocessableEntity, "token_missing"},
+	{cloudflared.ErrInitFailed, http.StatusServiceUnavailable, "init_failed"},
+	{errInvalidControlAction, http.StatusBadRequest, "invalid_action"},
+	{errConfirmRequired, http.StatusPreconditionRequired, "confirmation_required"},
+	{config.ErrConfigReadOnly, http.StatusConflict, "config_read_only"},
+}
+
+// controlErrorStatus classifies a start/stop failure. Untyped errors are
+// reported as internal errors, as before.
+func controlErrorStatus(err error) (int, string) {
+	for _, known := range controlErrors {
+		if errors.Is(err, known.err) {
+			return known.status, known.code
+		}
+	}
+	return http.StatusInternalServerError, "control_failed"
+}
+
+func writeControlError(w http.ResponseWriter, err error) {
+	status, code := controlErrorStatus(err)
+	if writeErr := writeJSONSized(w, status, ControlErrorResponse{Error: err.Error(), Code: code}); writeErr != nil {
+		log().Errorf("Failed to write control error: %v", writeErr)
 	}
 }
 
@@ -1411,6 +1841,7 @@ func (s *Server) handleI18n(w http.ResponseWriter, r *http.Request) {
 	}
 
 	simple := make(map[string]string)
+	meta := defaultLocaleMeta()
 	loaded := false
 	loadFile := func(filePath string) error {
 		data, err := fs.ReadFile(s.locales, filePath)
@@ -1422,6 +1853,10 @@ func (s *Server) handleI18n(w http.ResponseWriter, r *http.Request) {
 			return fmt.Errorf("failed to parse %s: %w", filePath, err)
 		}
 		for key, value := range translations {
+			if key == localeMetaKey {
+				meta.apply(value)
+				continue
+			}
 			if other, ok := value["other"]; ok {
 				simple[key] = other
 			}
@@ -1431,41 +1866,52 @@ func (s *Server) handleI18n(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Keep the legacy single-file locale, then overlay split files.
+	var loadErr error
 	legacyPath := "locales/" + lang + ".toml"
 	if err := loadFile(legacyPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
-		logger.Sugar.Errorf("Failed to parse translations for %s: %v", lang, err)
-		http.Error(w, "Failed to parse translations", http.StatusInternalServerError)
-		return
+		loadErr = err
 	}
 	dirPath := "locales/" + lang
-	if entries, err := fs.ReadDir(s.locales, dirPath); err == nil {
-		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".toml") {
-				continue
-			}
-			if err := loadFile(dirPath + "/" + entry.Name()); err != nil {
-				logger.Sugar.Errorf("Failed to parse translations for %s: %v", lang, err)
-				http.Error(w, "Failed to parse translations", http.StatusInternalServerError)
-				return
+	if loadErr == nil {
+		if entries, err := fs.ReadDir(s.locales, dirPath); err == nil {
+			for _, entry := range entries {
+				if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".toml") {
+					continue
+				}
+				if err := loadFile(dirPath + "/" + entry.Name()); err != nil {
+					loadErr = err
+					break
+				}
 			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			loadErr = fmt.Errorf("failed to read %s: %w", dirPath, err)
 		}
-	} else if !errors.Is(err, fs.ErrNotExist) {
-		logger.Sugar.Errorf("Failed to read translation directory for %s: %v", lang, err)
-		http.Error(w, "Failed to parse translations", http.StatusInternalServerError)
-		return
 	}
 
-	if !loaded {
-		logger.Sugar.Warnf("Language file not found: %s (requested by %s)", lang, r.RemoteAddr)
+	// A corrupt locale, or a missing English one, falls back to the built-in
+	// strings. Other missing languages stay 404 so the UI retries with "en".
+	switch {
+	case loadErr != nil:
+		log().Errorf("Failed to load translations for %s, serving built-in English: %v", lang, loadErr)
+		simple, meta = builtinTranslations(), defaultLocaleMeta()
+	case !loaded && lang == "en":
+		log().Warnf("English locale missing from the build, serving built-in strings")
+		simple, meta = builtinTranslations(), defaultLocaleMeta()
+	case !loaded:
+		log().Warnf("Language file not found: %s (requested by %s)", lang, r.RemoteAddr)
 		http.Error(w, "Language not found", http.StatusNotFound)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if encodeErr := json.NewEncoder(w).Encode(simple); encodeErr != nil {
-		logger.Sugar.Errorf("Failed to encode i18n response for %s: %v", lang, encodeErr)
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	// Metadata rides along under "_meta"; no translation key starts with "_".
+	resp := make(map[string]any, len(simple)+1)
+	for key, value := range simple {
+		resp[key] = value
 	}
+	resp[localeMetaKey] = meta
+
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSONResponse(w, resp, "i18n response for "+lang)
 }
 
 // isValidLangCode accepts short locale codes like "en", "zh", "zh-cn".
@@ -1483,43 +1929,120 @@ func isValidLangCode(lang string) bool {
 	return true
 }
 
+// sseRetryMillis is the reconnect delay suggested to EventSource clients.
+const sseRetryMillis = 3000
+
+// sseStreamPreamble opens every SSE stream: a retry hint followed by a 2 KiB
+// comment so buffering intermediaries flush the first chunk immediately.
+var sseStreamPreamble = fmt.Sprintf("retry: %d\n\n:%s\n\n", sseRetryMillis, strings.Repeat(" ", 2048))
+
+// Bounds for the ?batch= coalescing window on the log stream.
+const (
+	minLogBatchWindow = 10 * time.Millisecond
+	maxLogBatchWindow = 5 * time.Second
+)
+
+// logReplaySource is the part of the log broadcaster a stream replays from.
+type logReplaySource interface {
+	RecentEntries() []logger.LogEntry
+	RecentEntriesAfter(seq uint64) []logger.LogEntry
+}
+
+// logReplay returns the lines a new stream opens with. A reconnecting client
+// only gets the lines after its Last-Event-ID, even on a live-only stream;
+// otherwise the recent buffer is fetched only when replay is on.
+func logReplay(src logReplaySource, lastEventID string, replay bool) []logger.LogEntry {
+	if lastID, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+		return src.RecentEntriesAfter(lastID)
+	}
+	if !replay {
+		return nil
+	}
+	return src.RecentEntries()
+}
+
 // handleLogStream streams logs to client using Server-Sent Events (SSE)
 func (s *Server) handleLogStream(w http.ResponseWriter, r *http.Request) {
+	// ?batch=100ms coalesces live lines into one event per window so log
+	// bursts do not cost one flush per line.
+	var batchWindow time.Duration
+	if raw := r.URL.Query().Get("batch"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < minLogBatchWindow || d > maxLogBatchWindow {
+			http.Error(w, fmt.Sprintf("batch must be a duration between %s and %s", minLogBatchWindow, maxLogBatchWindow), http.StatusBadRequest)
+			return
+		}
+		batchWindow = d
+	}
+	// ?replay=false starts a live-only view at the current position.
+	replay := true
+	if raw := r.URL.Query().Get("replay"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "replay must be true or false", http.StatusBadRequest)
+			return
+		}
+		replay = v
+	}
+	// ?component=cfui.runner,cloudflared keeps only lines from those
+	// components.
+	wanted := logComponentFilter(r.URL.Query().Get("component"))
+
 	// Set headers for SSE
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
+	// Stop nginx and similar proxies from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
 
 	broadcaster := logger.GetBroadcaster()
 	if broadcaster == nil {
-		logger.Sugar.Error("Log broadcaster not initialized")
+		log().Error("Log broadcaster not initialized")
 		http.Error(w, "Log streaming not available", http.StatusInternalServerError)
 		return
 	}
 
+	release, ok := s.admitLogStream(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
 	// Subscribe to log broadcasts with client address for tracking
 	logChan := broadcaster.Subscribe(r.RemoteAddr)
 	defer broadcaster.Unsubscribe(logChan)
+	s.logStreams.Add(1)
+	defer s.logStreams.Add(-1)
 
 	// Get flusher for SSE
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		logger.Sugar.Error("Streaming not supported")
+		log().Error("Streaming not supported")
 		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
 		return
 	}
 
-	logger.Sugar.Infof("Log stream client connected: %s", r.RemoteAddr)
+	log().Infof("Log stream client connected: %s", r.RemoteAddr)
 
-	// Send initial recent logs
-	recentLogs := broadcaster.GetRecentLogs()
-	for _, line := range recentLogs {
-		_, err := w.Write([]byte("data: " + line + "\n\n"))
-		if err != nil {
-			logger.Sugar.Warnf("Failed to send recent logs to %s: %v", r.RemoteAddr, err)
+	// The retry hint must be the first field; the padding comment pushes the
+	// opening chunk past proxies that hold small responses back.
+	if _, err := w.Write([]byte(sseStreamPreamble)); err != nil {
+		log().Warnf("Failed to start log stream for %s: %v", r.RemoteAddr, err)
+		return
+	}
+	flusher.Flush()
+
+	var lastSent uint64
+	for _, entry := range logReplay(broadcaster, r.Header.Get("Last-Event-ID"), replay) {
+		if !wanted(entry) {
+			continue
+		}
+		if err := writeLogEvent(w, entry); err != nil {
+			log().Warnf("Failed to send recent logs to %s: %v", r.RemoteAddr, err)
 			return
 		}
+		lastSent = entry.Seq
 	}
 	flusher.Flush()
 
@@ -1528,43 +2051,155 @@ func (s *Server) handleLogStream(w http.ResponseWriter, r *http.Request) {
 	heartbeatTicker := time.NewTicker(30 * time.Second)
 	defer heartbeatTicker.Stop()
 
+	var (
+		pending []logger.LogEntry
+		batchC  <-chan time.Time
+	)
+	flushBatch := func() error {
+		batchC = nil
+		if len(pending) == 0 {
+			return nil
+		}
+		err := writeLogBatch(w, pending)
+		pending = pending[:0]
+		flusher.Flush()
+		return err
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Sugar.Infof("Log stream client disconnected: %s", r.RemoteAddr)
+			log().Infof("Log stream client disconnected: %s", r.RemoteAddr)
 			return
+		case <-batchC:
+			if err := flushBatch(); err != nil {
+				log().Warnf("Failed to send log batch to %s: %v", r.RemoteAddr, err)
+				return
+			}
 		case <-s.shutdownC:
 			// Server is shutting down; close the stream so http.Server.Shutdown
-			// does not wait for its full timeout.
-			logger.Sugar.Infof("Log stream closed for shutdown: %s", r.RemoteAddr)
+			// does not wait for its full timeout. The broadcast shutdown event
+			// may have been dropped on a full channel, so send it here too.
+			_ = flushBatch()
+			_ = writeLogEvent(w, logger.LogEntry{Event: shutdownEvent, Line: shutdownEventData()})
+			flusher.Flush()
+			log().Infof("Log stream closed for shutdown: %s", r.RemoteAddr)
 			return
 		case <-heartbeatTicker.C:
 			// Send SSE comment as heartbeat to detect dead connections
 			_, err := w.Write([]byte(": heartbeat\n\n"))
 			if err != nil {
-				logger.Sugar.Warnf("Heartbeat failed for %s, closing connection: %v", r.RemoteAddr, err)
+				log().Warnf("Heartbeat failed for %s, closing connection: %v", r.RemoteAddr, err)
 				return
 			}
 			flusher.Flush()
 			// Mark subscriber as active
 			broadcaster.MarkActive(logChan)
-		case logLine, ok := <-logChan:
+		case entry, ok := <-logChan:
 			if !ok {
-				logger.Sugar.Infof("Log channel closed for %s", r.RemoteAddr)
+				log().Infof("Log channel closed for %s", r.RemoteAddr)
 				return
 			}
+			if (entry.Event == "" && entry.Seq <= lastSent) || !wanted(entry) {
+				// Already sent during the replay above, or filtered out.
+				continue
+			}
+			if batchWindow > 0 {
+				if entry.Event == "" {
+					pending = append(pending, entry)
+					if batchC == nil {
+						batchC = time.After(batchWindow)
+					}
+					continue
+				}
+				// Keep named events ordered after the lines before them.
+				if err := flushBatch(); err != nil {
+					log().Warnf("Failed to send log batch to %s: %v", r.RemoteAddr, err)
+					return
+				}
+			}
 			// Send log line as SSE event
-			_, err := w.Write([]byte("data: " + logLine + "\n\n"))
-			if err != nil {
-				logger.Sugar.Warnf("Failed to send log to %s: %v", r.RemoteAddr, err)
+			if err := writeLogEvent(w, entry); err != nil {
+				log().Warnf("Failed to send log to %s: %v", r.RemoteAddr, err)
 				return
 			}
 			flusher.Flush()
+			if entry.Event == shutdownEvent {
+				log().Infof("Log stream closed for shutdown: %s", r.RemoteAddr)
+				return
+			}
 			// Activity is already updated in Broadcast() on successful send
 		}
 	}
 }
 
+// handleErrorLogs returns the most recent error-level log lines.
+func (s *Server) handleErrorLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	broadcaster := logger.GetBroadcaster()
+	if broadcaster == nil {
+		log().Error("Log broadcaster not initialized")
+		http.Error(w, "Log broadcaster not available", http.StatusInternalServerError)
+		return
+	}
+	entries := broadcaster.ErrorEntries()
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		lines = append(lines, entry.Line)
+	}
+	writeJSON(w, RecentLogsResponse{Logs: lines, Count: len(lines)})
+}
+
+// writeLogEvent writes entry as an SSE event whose id is the broadcaster
+// sequence, so EventSource reconnects send it back as Last-Event-ID. Named
+// events carry no id so they do not move the client's resume point.
+func writeLogEvent(w io.Writer, entry logger.LogEntry) error {
+	if entry.Event != "" {
+		_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", entry.Event, entry.Line)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", entry.Seq, entry.Line)
+	return err
+}
+
+// writeLogBatch writes several entries as one SSE event, one data field per
+// line, so the client receives them newline-joined. The id is the last
+// entry's sequence.
+func writeLogBatch(w io.Writer, entries []logger.LogEntry) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "id: %d\n", entries[len(entries)-1].Seq)
+	for _, entry := range entries {
+		b.WriteString("data: ")
+		b.WriteString(strings.TrimRight(entry.Line, "\r\n"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// configChangedEvent is pushed on the log stream after a successful config
+// save so other open dashboards reload. It never includes config values.
+const configChangedEvent = "config-changed"
+
+func broadcastConfigChanged(activeTunnelKey string) {
+	broadcaster := logger.GetBroadcaster()
+	if broadcaster == nil {
+		return
+	}
+	data, err := json.Marshal(map[string]any{
+		"active_tunnel_key": activeTunnelKey,
+		"changed_at":        time.Now().UTC(),
+	})
+	if err != nil {
+		return
+	}
+	broadcaster.BroadcastEvent(configChangedEvent, string(data))
+}
+
 // handleRecentLogs returns recent logs from the circular buffer
 func (s *Server) handleRecentLogs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -1573,7 +2208,7 @@ func (s *Server) handleRecentLogs(w http.ResponseWriter, r *http.Request) {
 	}
 	broadcaster := logger.GetBroadcaster()
 	if broadcaster == nil {
-		logger.Sugar.Error("Log broadcaster not initialized")
+		log().Error("Log broadcaster not initialized")
 		http.Error(w, "Log broadcaster not available", http.StatusInternalServerError)
 		return
 	}
@@ -1587,10 +2222,225 @@ func (s *Server) handleRecentLogs(w http.ResponseWriter, r *http.Request) {
 	resp.Count = len(recentLogs)
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		logger.Sugar.Errorf("Failed to encode recent logs response: %v", err)
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	encodeJSONResponse(w, resp, "recent logs response")
+}
+
+// handleMetrics returns the cached tunnel metrics summary.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.runner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, errors.New("tunnel runner is unavailable"))
+		return
+	}
+	writeJSON(w, s.runner.MetricsSnapshot())
+}
+
+// handlePrometheusMetrics serves the tunnel metrics in the Prometheus
+// exposition format, so cfui can be scraped directly instead of through
+// cloudflared's own --metrics listener. Samples carry the "tunnel" label of
+// Runner.MetricsGatherer.
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.runner == nil || s.runner.GetMetricsRegistry() == nil {
+		http.Error(w, "tunnel metrics are unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	promhttp.HandlerFor(s.runner.MetricsGatherer(), promhttp.HandlerOpts{ErrorLog: promErrorLog{}}).ServeHTTP(w, r)
+}
+
+// promErrorLog routes promhttp's gather and encode errors to the server log.
+type promErrorLog struct{}
+
+func (promErrorLog) Println(v ...any) {
+	log().Warn(append([]any{"Prometheus metrics: "}, v...)...)
+}
+
+// MetricsStreamSnapshot is the data of a /api/metrics/stream "snapshot"
+// event. Byte counters cover QUIC connections only; Protocol is the
+// transport of the active tunnel.
+type MetricsStreamSnapshot struct {
+	Tunnel        string    `json:"tunnel"`
+	Connections   int       `json:"connections"`
+	SentBytes     float64   `json:"sent_bytes"`
+	ReceivedBytes float64   `json:"received_bytes"`
+	Protocol      string    `json:"protocol"`
+	CollectedAt   time.Time `json:"collected_at"`
+}
+
+// MetricsStreamNoData is the data of a "no_data" event, sent instead of a
+// snapshot while no tunnel runs.
+type MetricsStreamNoData struct {
+	Reason      string    `json:"reason"`
+	CollectedAt time.Time `json:"collected_at"`
+}
+
+// handleMetricsStream pushes a metrics snapshot over SSE each time the
+// metrics poller collects one, starting with the latest on connect.
+func (s *Server) handleMetricsStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.runner == nil {
+		writeAPIError(w, http.StatusServiceUnavailable, errors.New("tunnel runner is unavailable"))
+		return
+	}
+	if !s.runner.MetricsPolling() {
+		writeAPIError(w, http.StatusConflict, errors.New("metrics polling is disabled; set metrics_poll_interval to stream metrics"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	snapshots, unsubscribe := s.runner.SubscribeMetrics()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	if _, err := w.Write([]byte(sseStreamPreamble)); err != nil {
+		return
+	}
+	if err := s.writeMetricsEvent(w, s.runner.MetricsSnapshot()); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	heartbeatTicker := time.NewTicker(30 * time.Second)
+	defer heartbeatTicker.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.shutdownC:
+			return
+		case <-heartbeatTicker.C:
+			_, err = w.Write([]byte(": heartbeat\n\n"))
+		case snapshot := <-snapshots:
+			err = s.writeMetricsEvent(w, snapshot)
+		}
+		if err != nil {
+			log().Debugf("Metrics stream client %s closed: %v", r.RemoteAddr, err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeMetricsEvent writes snapshot as a "snapshot" event, or a "no_data"
+// event when no tunnel runs and no edge connection is registered.
+func (s *Server) writeMetricsEvent(w io.Writer, snapshot service.MetricsSnapshot) error {
+	event, data := "no_data", any(MetricsStreamNoData{Reason: "no tunnel is running", CollectedAt: snapshot.CollectedAt})
+	if s.runner.RunningCount() > 0 || snapshot.HAConnections > 0 {
+		st, _ := s.runner.ProfileStatus("")
+		event, data = "snapshot", MetricsStreamSnapshot{
+			Tunnel:        snapshot.Tunnel,
+			Connections:   snapshot.HAConnections,
+			SentBytes:     snapshot.SentBytes,
+			ReceivedBytes: snapshot.ReceivedBytes,
+			Protocol:      st.Protocol,
+			CollectedAt:   snapshot.CollectedAt,
+		}
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+// TunnelConnectionsResponse lists the registered edge connections.
+type TunnelConnectionsResponse struct {
+	Connections []cloudflared.Connection `json:"connections"`
+	Count       int                      `json:"count"`
+}
+
+func (s *Server) handleTunnelConnections(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	conns := []cloudflared.Connection{}
+	if s.runner != nil {
+		conns = s.runner.Connections()
+	}
+	writeJSON(w, TunnelConnectionsResponse{Connections: conns, Count: len(conns)})
+}
+
+// defaultEventsLimit is how many lifecycle events /api/events returns when
+// the request sets no limit.
+const defaultEventsLimit = 50
+
+// EventsResponse lists recent tunnel lifecycle events, oldest first.
+type EventsResponse struct {
+	Events []service.Event `json:"events"`
+	Count  int             `json:"count"`
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	limit := defaultEventsLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	events := []service.Event{}
+	if s.runner != nil {
+		events = s.runner.Events(limit)
+	}
+	writeJSON(w, EventsResponse{Events: events, Count: len(events)})
+}
+
+// RestartsResponse lists recorded auto-restart attempts, oldest first.
+type RestartsResponse struct {
+	Restarts []service.RestartRecord `json:"restarts"`
+	Count    int                     `json:"count"`
+}
+
+// handleRestarts returns the auto-restart history, optionally only for the
+// profile named by ?tunnel=.
+func (s *Server) handleRestarts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	restarts := []service.RestartRecord{}
+	if s.runner != nil {
+		restarts = s.runner.RestartHistory(r.URL.Query().Get("tunnel"))
+	}
+	writeJSON(w, RestartsResponse{Restarts: restarts, Count: len(restarts)})
+}
+
+// handleStats returns the lifetime tunnel stats, which carry over from
+// earlier runs of cfui through stats.json.
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var stats service.LifetimeStats
+	if s.runner != nil {
+		stats = s.runner.LifetimeStats()
 	}
+	writeJSON(w, stats)
 }
 
 // handleVersion returns version information
@@ -1607,10 +2457,59 @@ func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
 	resp.GitCommit = version.GitCommit
 	resp.FullInfo = version.GetFullVersion()
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		logger.Sugar.Errorf("Failed to encode version response: %v", err)
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	if err := writeJSONSized(w, http.StatusOK, resp); err != nil {
+		log().Errorf("Failed to write version response: %v", err)
+	}
+}
+
+// PingResponse is the /api/ping body.
+type PingResponse struct {
+	Pong bool      `json:"pong"`
+	Time time.Time `json:"time"`
+}
+
+// handlePing answers the SPA's reachability check. It touches no state, so
+// it stays cheap at any polling rate.
+func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, PingResponse{Pong: true, Time: time.Now().UTC().Truncate(time.Second)})
+}
+
+// ReadyResponse is the /readyz body.
+type ReadyResponse struct {
+	Ready  bool   `json:"ready"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// handleReady reports whether this instance should receive traffic: a tunnel
+// is running and, with RequireConnectedForReady, has registered an edge
+// connection. Not ready answers 503 so load balancers and probes need no
+// body parsing.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	resp := ReadyResponse{Ready: true}
+	switch {
+	case s.runner == nil:
+		resp = ReadyResponse{Reason: "tunnel runner is unavailable"}
+	case s.cfgMgr.Get().RequireConnectedForReady:
+		if len(s.runner.Connections()) == 0 {
+			resp = ReadyResponse{Reason: "no edge connection registered"}
+		}
+	case s.runner.RunningCount() == 0:
+		resp = ReadyResponse{Reason: "no tunnel is running"}
+	}
+	status := http.StatusOK
+	if !resp.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	if err := writeJSONSized(w, status, resp); err != nil {
+		log().Errorf("Failed to write readiness response: %v", err)
 	}
 }
 
@@ -1737,7 +2636,7 @@ func (s *Server) handleDDNSRecords(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 	if err := s.cfgMgr.Save(cfg); err != nil {
-		writeAPIError(w, http.StatusInternalServerError, err)
+		writeAPIError(w, saveErrorStatus(err, http.StatusInternalServerError), err)
 		return
 	}
 	s.ddnsSvc.Restart()
@@ -1799,7 +2698,7 @@ func (s *Server) handleDDNSRecord(w http.ResponseWriter, r *http.Request) {
 			rec.TTL = 1
 		}
 		if err := s.cfgMgr.Save(cfg); err != nil {
-			writeAPIError(w, http.StatusInternalServerError, err)
+			writeAPIError(w, saveErrorStatus(err, http.StatusInternalServerError), err)
 			return
 		}
 		s.ddnsSvc.Restart()
@@ -1807,7 +2706,7 @@ func (s *Server) handleDDNSRecord(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		cfg.DDNS.Records = append(cfg.DDNS.Records[:index], cfg.DDNS.Records[index+1:]...)
 		if err := s.cfgMgr.Save(cfg); err != nil {
-			writeAPIError(w, http.StatusInternalServerError, err)
+			writeAPIError(w, saveErrorStatus(err, http.StatusInternalServerError), err)
 			return
 		}
 		s.ddnsSvc.Restart()
@@ -0,0 +1,476 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/logger"
+	"cfui/internal/service"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestStatusAndVersionSetContentLength(t *testing.T) {
+	s := newServerTestServer(t)
+	for path, handler := range map[string]http.HandlerFunc{
+		"/api/status":  s.handleStatus,
+		"/api/version": s.handleVersion,
+	} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s status %d: %s", path, rec.Code, rec.Body.String())
+		}
+		got := rec.Header().Get("Content-Length")
+		if got == "" {
+			t.Fatalf("%s: Content-Length not set", path)
+		}
+		if n, err := strconv.Atoi(got); err != nil || n != rec.Body.Len() {
+			t.Fatalf("%s: Content-Length = %q, body is %d bytes", path, got, rec.Body.Len())
+		}
+	}
+}
+
+// brokenWriter is a ResponseWriter whose client went away: every body write
+// fails. It records each status written.
+type brokenWriter struct {
+	header   http.Header
+	statuses []int
+}
+
+func (w *brokenWriter) Header() http.Header { return w.header }
+
+func (w *brokenWriter) WriteHeader(status int) { w.statuses = append(w.statuses, status) }
+
+func (w *brokenWriter) Write([]byte) (int, error) {
+	if len(w.statuses) == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return 0, io.ErrClosedPipe
+}
+
+func TestWriteJSONSkipsErrorResponseAfterBodyStarted(t *testing.T) {
+	w := &brokenWriter{header: http.Header{}}
+	writeJSON(w, map[string]string{"status": "ok"})
+	if len(w.statuses) != 1 || w.statuses[0] != http.StatusOK {
+		t.Fatalf("statuses = %v, want only the implicit 200", w.statuses)
+	}
+
+	rec := httptest.NewRecorder()
+	writeJSON(rec, map[string]any{"bad": make(chan int)})
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("unencodable value status = %d, want 500", rec.Code)
+	}
+}
+
+func TestLogStreamDisablesProxyBufferingAndSendsRetryFirst(t *testing.T) {
+	s := newServerTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // the handler writes its preamble, then returns on the closed context
+
+	rec := httptest.NewRecorder()
+	s.handleLogStream(rec, httptest.NewRequest(http.MethodGet, "/api/logs/stream", nil).WithContext(ctx))
+
+	if got := rec.Header().Get("X-Accel-Buffering"); got != "no" {
+		t.Fatalf("X-Accel-Buffering = %q, want no", got)
+	}
+	if want := fmt.Sprintf("retry: %d\n\n", sseRetryMillis); !strings.HasPrefix(rec.Body.String(), want) {
+		t.Fatalf("stream must start with %q, got %q", want, rec.Body.String()[:min(rec.Body.Len(), 40)])
+	}
+	if !rec.Flushed {
+		t.Fatal("preamble was not flushed")
+	}
+}
+
+func TestLogStreamReplaysOnlyAfterLastEventID(t *testing.T) {
+	s := newServerTestServer(t)
+	broadcaster := logger.GetBroadcaster()
+	broadcaster.Broadcast("before reconnect\n")
+	last := broadcaster.RecentEntries()
+	lastID := last[len(last)-1].Seq
+	broadcaster.Broadcast("missed line\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/logs/stream", nil).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(lastID, 10))
+	rec := httptest.NewRecorder()
+	s.handleLogStream(rec, req)
+
+	body := rec.Body.String()
+	if strings.Contains(body, "before reconnect") {
+		t.Fatalf("replayed a line the client already had:\n%s", body)
+	}
+	if want := fmt.Sprintf("id: %d\ndata: missed line\n", lastID+1); !strings.Contains(body, want) {
+		t.Fatalf("stream missing %q:\n%s", want, body)
+	}
+}
+
+type countingReplaySource struct {
+	recentCalls int
+}
+
+func (c *countingReplaySource) RecentEntries() []logger.LogEntry {
+	c.recentCalls++
+	return []logger.LogEntry{{Seq: 1, Line: "old line"}}
+}
+
+func (c *countingReplaySource) RecentEntriesAfter(uint64) []logger.LogEntry { return nil }
+
+func TestLogStreamWithoutReplaySendsNoHistory(t *testing.T) {
+	src := &countingReplaySource{}
+	if got := logReplay(src, "", false); len(got) != 0 || src.recentCalls != 0 {
+		t.Fatalf("logReplay without replay = %v after %d fetches, want nothing and no fetch", got, src.recentCalls)
+	}
+
+	s := newServerTestServer(t)
+	logger.GetBroadcaster().Broadcast("historical line\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/logs/stream?replay=false", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	s.handleLogStream(rec, req)
+	if body := rec.Body.String(); strings.Contains(body, "historical line") || strings.Contains(body, "data:") {
+		t.Fatalf("live-only stream replayed history:\n%s", body)
+	}
+}
+
+func TestConfigSaveBroadcastsConfigChanged(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.Token = "secret-token"
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+	broadcaster := logger.GetBroadcaster()
+	ch := broadcaster.Subscribe("test")
+	defer broadcaster.Unsubscribe(ch)
+
+	rec := httptest.NewRecorder()
+	s.handleConfig(rec, httptest.NewRequest(http.MethodPost, "/api/config", strings.NewReader(`{"auto_restart":true}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("save status %d: %s", rec.Code, rec.Body.String())
+	}
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case entry := <-ch:
+			if entry.Event != configChangedEvent {
+				continue
+			}
+			if strings.Contains(entry.Line, "secret-token") {
+				t.Fatalf("config-changed event leaked the token: %s", entry.Line)
+			}
+			return
+		case <-timeout:
+			t.Fatal("no config-changed event after saving config")
+		}
+	}
+}
+
+func TestLogStreamBatchesRapidLines(t *testing.T) {
+	s := newServerTestServer(t)
+	broadcaster := logger.GetBroadcaster()
+	broadcaster.Broadcast("before batch\n")
+	recent := broadcaster.RecentEntries()
+	lastID := recent[len(recent)-1].Seq
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handleLogStream))
+	defer srv.Close()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/logs/stream?batch=200ms", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(lastID, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() []string {
+		t.Helper()
+		var fields []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read stream: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			if line == "" {
+				if len(fields) > 0 {
+					return fields
+				}
+				continue
+			}
+			if !strings.HasPrefix(line, ":") { // skip comments
+				fields = append(fields, line)
+			}
+		}
+	}
+	if got := readEvent(); got[0] != fmt.Sprintf("retry: %d", sseRetryMillis) {
+		t.Fatalf("first event = %q, want retry hint", got)
+	}
+
+	for _, line := range []string{"burst one\n", "burst two\n", "burst three\n"} {
+		broadcaster.Broadcast(line)
+	}
+	want := []string{
+		fmt.Sprintf("id: %d", lastID+3),
+		"data: burst one",
+		"data: burst two",
+		"data: burst three",
+	}
+	if got := readEvent(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("batched event = %q, want %q", got, want)
+	}
+}
+
+func TestLogStreamRejectsInvalidBatch(t *testing.T) {
+	s := newServerTestServer(t)
+	rec := httptest.NewRecorder()
+	s.handleLogStream(rec, httptest.NewRequest(http.MethodGet, "/api/logs/stream?batch=1ms", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rec.Code)
+	}
+}
+
+func TestStatusIncludesTunnelName(t *testing.T) {
+	s := newServerTestServer(t)
+	cfg := s.cfgMgr.Get()
+	cfg.TunnelName = "home-nas"
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+	if got := s.cfgMgr.Get().ActiveTunnelProfile().Name; got != "home-nas" {
+		t.Fatalf("active profile name = %q, want home-nas", got)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handleStatus(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+	var resp StatusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.TunnelName != "home-nas" {
+		t.Fatalf("tunnel_name = %q, want home-nas", resp.TunnelName)
+	}
+}
+
+func TestRestartFiguresResetUptimeWhenStopped(t *testing.T) {
+	now := time.Now()
+	restarted := now.Add(-time.Minute)
+	st := cloudflared.Status{Running: true, RestartCount: 2, LastRestartAt: restarted, StartedAt: now.Add(-90 * time.Second)}
+	count, last, uptime := restartFigures(st, now)
+	if count != 2 || last == nil || !last.Equal(restarted) || uptime != 90 {
+		t.Fatalf("running figures = %d, %v, %d; want 2, %v, 90", count, last, uptime, restarted)
+	}
+
+	st.Running, st.StartedAt = false, time.Time{}
+	if _, _, uptime := restartFigures(st, now); uptime != 0 {
+		t.Fatalf("stopped uptime = %d, want 0", uptime)
+	}
+	if _, last, _ := restartFigures(cloudflared.Status{}, now); last != nil {
+		t.Fatalf("last restart without restarts = %v, want nil", last)
+	}
+}
+
+func TestPrepareShutdownSendsShutdownEventToLogStreams(t *testing.T) {
+	s := newServerTestServer(t)
+	s.shutdownC = make(chan struct{})
+	broadcaster := logger.GetBroadcaster()
+	broadcaster.Broadcast("before shutdown\n")
+	recent := broadcaster.RecentEntries()
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handleLogStream))
+	defer srv.Close()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/logs/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(recent[len(recent)-1].Seq, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	if _, err := reader.ReadString('\n'); err != nil { // retry hint: the stream is live
+		t.Fatalf("read preamble: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.PrepareShutdown(5 * time.Second)
+		close(done)
+	}()
+
+	rest, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	want := "event: shutdown\ndata: " + shutdownEventData() + "\n\n"
+	if !strings.HasSuffix(string(rest), want) {
+		t.Fatalf("stream ended with %q, want shutdown event %q", rest, want)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("PrepareShutdown kept waiting after the stream closed")
+	}
+}
+
+func TestShutdownDrainFromEnv(t *testing.T) {
+	for raw, want := range map[string]time.Duration{
+		"":      DefaultShutdownDrain,
+		"500ms": 500 * time.Millisecond,
+		"0":     0,
+		"-1s":   DefaultShutdownDrain,
+		"soon":  DefaultShutdownDrain,
+	} {
+		t.Setenv("CFUI_SHUTDOWN_DRAIN", raw)
+		if got := ShutdownDrainFromEnv(); got != want {
+			t.Errorf("CFUI_SHUTDOWN_DRAIN=%q: got %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestMetricsStreamPushesSnapshotWhenRegistryHasData(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+	s.runner.Initialize()
+	t.Cleanup(func() { _ = s.runner.Shutdown() })
+
+	ha := prometheus.NewGauge(prometheus.GaugeOpts{Name: "cloudflared_tunnel_ha_connections", Help: "test"})
+	if err := cloudflared.MetricsRegistry().Register(ha); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	t.Cleanup(func() { cloudflared.MetricsRegistry().Unregister(ha) })
+	ha.Set(4)
+
+	srv := httptest.NewServer(http.HandlerFunc(s.handleMetricsStream))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	var event, data string
+	for event == "" || data == "" {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		if v, ok := strings.CutPrefix(line, "event: "); ok {
+			event = strings.TrimSpace(v)
+		}
+		if v, ok := strings.CutPrefix(line, "data: "); ok {
+			data = strings.TrimSpace(v)
+		}
+	}
+	if event != "snapshot" {
+		t.Fatalf("event = %q, want snapshot", event)
+	}
+	var snap MetricsStreamSnapshot
+	if err := json.Unmarshal([]byte(data), &snap); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	if snap.Connections != 4 || snap.CollectedAt.IsZero() {
+		t.Fatalf("snapshot = %+v, want 4 connections", snap)
+	}
+}
+
+func TestPrometheusMetricsServesLabeledRegistry(t *testing.T) {
+	s := newServerTestServer(t)
+	rec := httptest.NewRecorder()
+	s.handlePrometheusMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status without runner = %d, want 503", rec.Code)
+	}
+
+	s.runner = service.NewRunner(s.cfgMgr)
+	s.runner.Initialize()
+	t.Cleanup(func() { _ = s.runner.Shutdown() })
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "cfui_test_scrape_gauge", Help: "test"})
+	if err := cloudflared.MetricsRegistry().Register(gauge); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	t.Cleanup(func() { cloudflared.MetricsRegistry().Unregister(gauge) })
+	gauge.Set(2)
+
+	rec = httptest.NewRecorder()
+	s.handlePrometheusMetrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	want := fmt.Sprintf(`cfui_test_scrape_gauge{%s=%q} 2`, service.TunnelMetricsLabel, s.cfgMgr.Get().ActiveTunnelKey)
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Fatalf("body lacks %s:\n%s", want, rec.Body)
+	}
+}
+
+func TestPingReportsPongAndTime(t *testing.T) {
+	s := newServerTestServer(t)
+	rec := httptest.NewRecorder()
+	s.handlePing(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body.String(), err)
+	}
+	if len(body) != 2 || body["pong"] != true {
+		t.Fatalf("body = %v, want pong and time only", body)
+	}
+	stamp, _ := body["time"].(string)
+	if _, err := time.Parse(time.RFC3339, stamp); err != nil {
+		t.Fatalf("time %q is not RFC 3339: %v", stamp, err)
+	}
+	if !isPollingPath("/api/ping") {
+		t.Fatal("/api/ping should be sampled in the access log like other polling paths")
+	}
+}
+
+func TestReadyzWaitsForConnectionWhenRequired(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+	cfg := s.cfgMgr.Get()
+	cfg.RequireConnectedForReady = true
+	if err := s.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	ready := func() (int, ReadyResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handleReady(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var resp ReadyResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode %s: %v", rec.Body.String(), err)
+		}
+		return rec.Code, resp
+	}
+	if code, resp := ready(); code != http.StatusServiceUnavailable || resp.Ready {
+		t.Fatalf("before a connection: %d %+v, want 503 not ready", code, resp)
+	}
+
+	s.runner.ObserveLogLine("2026-03-01T10:00:00Z INF Registered tunnel connection connIndex=0 connection=aaaa event=0 ip=198.41.192.7 location=sjc08 protocol=quic")
+	if code, resp := ready(); code != http.StatusOK || !resp.Ready {
+		t.Fatalf("after a connection: %d %+v, want 200 ready", code, resp)
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLogStreamQueueWait bounds how long a queued log stream waits for a
+// slot before it gets 503.
+const DefaultLogStreamQueueWait = 10 * time.Second
+
+// streamLimiter caps concurrent log streams (SSE and WebSocket). A client
+// over the cap waits in a bounded queue, so a burst of dashboard reconnects
+// gets in as old connections drop instead of being turned away at once.
+// A nil limiter admits everyone.
+type streamLimiter struct {
+	slots chan struct{}
+	queue chan struct{}
+	wait  time.Duration
+}
+
+// newStreamLimiter allows limit concurrent streams with up to queue more
+// waiting for at most wait each. limit <= 0 disables the limit.
+func newStreamLimiter(limit, queue int, wait time.Duration) *streamLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &streamLimiter{
+		slots: make(chan struct{}, limit),
+		queue: make(chan struct{}, max(queue, 0)),
+		wait:  wait,
+	}
+}
+
+// streamLimiterFromEnv reads CFUI_MAX_LOG_STREAMS (0 or unset: unlimited),
+// CFUI_LOG_STREAM_QUEUE (default 0: no queue), and
+// CFUI_LOG_STREAM_QUEUE_WAIT (a Go duration, default
+// DefaultLogStreamQueueWait). Malformed values fall back to the defaults.
+func streamLimiterFromEnv() *streamLimiter {
+	envInt := func(name string) int {
+		n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name)))
+		if err != nil || n < 0 {
+			return 0
+		}
+		return n
+	}
+	wait := DefaultLogStreamQueueWait
+	if d, err := time.ParseDuration(strings.TrimSpace(os.Getenv("CFUI_LOG_STREAM_QUEUE_WAIT"))); err == nil && d >= 0 {
+		wait = d
+	}
+	return newStreamLimiter(envInt("CFUI_MAX_LOG_STREAMS"), envInt("CFUI_LOG_STREAM_QUEUE"), wait)
+}
+
+// acquire takes a stream slot, queueing for one when all are taken. ok is
+// false when the queue is full, the wait runs out, or ctx ends first; the
+// caller then answers 503. release must be called once the stream ends.
+func (l *streamLimiter) acquire(ctx context.Context) (release func(), ok bool) {
+	if l == nil {
+		return func() {}, true
+	}
+	release = func() { <-l.slots }
+	select {
+	case l.slots <- struct{}{}:
+		return release, true
+	default:
+	}
+	select {
+	case l.queue <- struct{}{}:
+		defer func() { <-l.queue }()
+	default:
+		return nil, false
+	}
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return release, true
+	case <-timer.C:
+		return nil, false
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// admitLogStream takes a log stream slot for r, answering 503 with
+// Retry-After when none frees up in time.
+func (s *Server) admitLogStream(w http.ResponseWriter, r *http.Request) (release func(), ok bool) {
+	release, ok = s.logStreamLimit.acquire(r.Context())
+	if !ok {
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "Too many log streams, retry shortly", http.StatusServiceUnavailable)
+	}
+	return release, ok
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cfui/internal/logger"
+)
+
+func TestQueuedLogStreamIsAdmittedWhenASlotFrees(t *testing.T) {
+	s := newServerTestServer(t)
+	s.shutdownC = make(chan struct{})
+	s.logStreamLimit = newStreamLimiter(1, 1, 5*time.Second)
+	srv := httptest.NewServer(http.HandlerFunc(s.handleLogStream))
+	defer srv.Close()
+
+	open := func(ctx context.Context) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/logs/stream", nil)
+		if err != nil {
+			return nil, err
+		}
+		return http.DefaultClient.Do(req)
+	}
+
+	firstCtx, closeFirst := context.WithCancel(context.Background())
+	defer closeFirst()
+	first, err := open(firstCtx)
+	if err != nil || first.StatusCode != http.StatusOK {
+		t.Fatalf("first stream: %v, %v", first, err)
+	}
+	defer first.Body.Close()
+
+	queued := make(chan *http.Response, 1)
+	go func() {
+		resp, err := open(context.Background())
+		if err != nil {
+			t.Errorf("queued stream: %v", err)
+			close(queued)
+			return
+		}
+		queued <- resp
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(s.logStreamLimit.queue) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("second stream never queued")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The queue holds one, so a third stream is turned away at once.
+	third, err := open(context.Background())
+	if err != nil {
+		t.Fatalf("third stream: %v", err)
+	}
+	third.Body.Close()
+	if third.StatusCode != http.StatusServiceUnavailable || third.Header.Get("Retry-After") == "" {
+		t.Fatalf("third stream status = %d, want 503 with Retry-After", third.StatusCode)
+	}
+
+	closeFirst()
+	var second *http.Response
+	select {
+	case second = <-queued:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued stream was not admitted after the slot freed")
+	}
+	if second == nil {
+		t.FailNow()
+	}
+	defer second.Body.Close()
+	if second.StatusCode != http.StatusOK {
+		t.Fatalf("queued stream status = %d, want 200", second.StatusCode)
+	}
+
+	logger.GetBroadcaster().Broadcast("queued-stream line\n")
+	reader := bufio.NewReader(second.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read queued stream: %v", err)
+		}
+		if strings.Contains(line, "queued-stream line") {
+			return
+		}
+	}
+}
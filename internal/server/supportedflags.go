@@ -0,0 +1,56 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/service"
+)
+
+// SupportedFlag is one cloudflared flag cfui sets from tunnel settings.
+// Passed says whether the profile's current settings pass the flag, and
+// Value is its argument then; boolean flags have none.
+type SupportedFlag struct {
+	Flag   string   `json:"flag"`
+	Fields []string `json:"fields"`
+	Passed bool     `json:"passed"`
+	Value  string   `json:"value,omitempty"`
+}
+
+// SupportedFlagsResponse lists the managed flags for one tunnel profile.
+type SupportedFlagsResponse struct {
+	Tunnel string          `json:"tunnel"`
+	Flags  []SupportedFlag `json:"flags"`
+}
+
+// handleSupportedFlags reports which cloudflared flags cfui manages and what
+// the profile named by ?tunnel= (default: active) currently passes. Values
+// come from the saved settings; auto mode's protocol fallback may pass a
+// concrete --protocol at run time.
+func (s *Server) handleSupportedFlags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	cfg := s.cfgMgr.Get()
+	profile, ok := cfg.TunnelProfile(r.URL.Query().Get("tunnel"))
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("tunnel profile %q not found", r.URL.Query().Get("tunnel")))
+		return
+	}
+	opts := service.OptionsFromProfile(profile)
+	opts.SoleLogSink = cfg.SoleLogSink
+	if resolved, err := opts.ResolveEdgeInterface(); err == nil {
+		opts = resolved
+	}
+	resp := SupportedFlagsResponse{Tunnel: profile.Key, Flags: make([]SupportedFlag, 0, len(cloudflared.ManagedFlags))}
+	for _, flag := range cloudflared.ManagedFlags {
+		value, passed := flag.Arg(opts, opts.Protocol)
+		if !passed {
+			value = ""
+		}
+		resp.Flags = append(resp.Flags, SupportedFlag{Flag: "--" + flag.Name, Fields: flag.Fields, Passed: passed, Value: value})
+	}
+	writeJSON(w, resp)
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// uiToggleEnv maps each frontend display toggle to the environment variable
+// that sets it. Toggles only change what the web UI renders; the API still
+// accepts every field, so they are a convenience for shared deployments,
+// not an access control.
+var uiToggleEnv = map[string]string{
+	// hide_advanced hides options that are easy to misuse: extra
+	// cloudflared arguments, TLS verification bypass, and process restart.
+	"hide_advanced": "CFUI_HIDE_ADVANCED",
+}
+
+// uiFeatures reads the display toggles for GET /api/features. Every known
+// toggle is present; unset or malformed values are false.
+func uiFeatures() map[string]bool {
+	toggles := make(map[string]bool, len(uiToggleEnv))
+	for name, env := range uiToggleEnv {
+		toggles[name], _ = strconv.ParseBool(strings.TrimSpace(os.Getenv(env)))
+	}
+	return toggles
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"cfui/internal/logger"
+
+	"nhooyr.io/websocket"
+)
+
+const (
+	// wsStatusInterval is how often the status is re-checked; it is only
+	// pushed when it changed.
+	wsStatusInterval = 2 * time.Second
+	wsPingInterval   = 30 * time.Second
+	wsWriteTimeout   = 10 * time.Second
+	// wsReadLimit bounds one client message; control messages are tiny.
+	wsReadLimit = 4096
+)
+
+// WSClientMessage is a message from a /api/ws client. The only type is
+// "control", whose Action is start, stop, restart, or cancel_restart.
+// Tunnel is a profile key; empty means the active profile. Confirm repeats
+// the tunnel key when RequireConfirm is on. ID is echoed in the result.
+type WSClientMessage struct {
+	Type    string `json:"type"`
+	Action  string `json:"action,omitempty"`
+	Tunnel  string `json:"tunnel,omitempty"`
+	Confirm string `json:"confirm,omitempty"`
+	ID      string `json:"id,omitempty"`
+}
+
+// WSServerMessage is a message pushed to a /api/ws client:
+//   - "status": Status of the active tunnel, sent on connect and on change.
+//   - "log": one log line with its sequence number and component.
+//   - "event": a named log stream event such as "shutdown", with JSON Data.
+//   - "result": the outcome of a control message.
+//   - "error": a message the server could not handle.
+type WSServerMessage struct {
+	Type   string          `json:"type"`
+	ID     string          `json:"id,omitempty"`
+	Status *StatusResponse `json:"status,omitempty"`
+	Seq    uint64          `json:"seq,omitempty"`
+	Line   string          `json:"line,omitempty"`
+	// Component is a log line's origin, such as cfui.runner or cloudflared.
+	Component string          `json:"component,omitempty"`
+	Event     string          `json:"event,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Action    string          `json:"action,omitempty"`
+	Success   bool            `json:"success,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Code      string          `json:"code,omitempty"`
+}
+
+// handleWS serves a WebSocket that streams logs and status and accepts
+// control commands, so one connection replaces log streaming plus status
+// polling.
+func (s *Server) handleWS(w http.ResponseWriter, r *http.Request) {
+	broadcaster := logger.GetBroadcaster()
+	if broadcaster == nil {
+		http.Error(w, "Log streaming not available", http.StatusInternalServerError)
+		return
+	}
+	release, ok := s.admitLogStream(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		log().Warnf("WebSocket upgrade failed for %s: %v", r.RemoteAddr, err)
+		return
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(wsReadLimit)
+
+	wanted := logComponentFilter(r.URL.Query().Get("component"))
+	logChan := broadcaster.Subscribe(r.RemoteAddr)
+	defer broadcaster.Unsubscribe(logChan)
+	s.logStreams.Add(1)
+	defer s.logStreams.Add(-1)
+	log().Infof("WebSocket client connected: %s", r.RemoteAddr)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	send := func(msg WSServerMessage) error {
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		writeCtx, cancelWrite := context.WithTimeout(ctx, wsWriteTimeout)
+		defer cancelWrite()
+		return conn.Write(writeCtx, websocket.MessageText, payload)
+	}
+
+	commands := make(chan WSClientMessage)
+	go s.readWSCommands(ctx, cancel, conn, commands, send)
+
+	var lastStatus []byte
+	pushStatus := func() error {
+		status := s.activeStatus()
+		encoded, _ := json.Marshal(status)
+		if string(encoded) == string(lastStatus) {
+			return nil
+		}
+		lastStatus = encoded
+		return send(WSServerMessage{Type: "status", Status: &status})
+	}
+	if err := pushStatus(); err != nil {
+		return
+	}
+	var lastSent uint64
+	for _, entry := range broadcaster.RecentEntries() {
+		if !wanted(entry) {
+			continue
+		}
+		if err := send(WSServerMessage{Type: "log", Seq: entry.Seq, Line: entry.Line, Component: entry.Component}); err != nil {
+			return
+		}
+		lastSent = entry.Seq
+	}
+
+	results := make(chan WSServerMessage)
+	statusTicker := time.NewTicker(wsStatusInterval)
+	defer statusTicker.Stop()
+	pingTicker := time.NewTicker(wsPingInterval)
+	defer pingTicker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			log().Infof("WebSocket client disconnected: %s", r.RemoteAddr)
+			return
+		case <-s.shutdownC:
+			_ = send(WSServerMessage{Type: "event", Event: shutdownEvent, Data: json.RawMessage(shutdownEventData())})
+			conn.Close(websocket.StatusGoingAway, "server shutting down")
+			return
+		case cmd := <-commands:
+			// Run the action off the loop so a slow start does not stall
+			// the log stream.
+			go func() {
+				result := s.wsControl(cmd, r.RemoteAddr)
+				select {
+				case results <- result:
+				case <-ctx.Done():
+				}
+			}()
+		case result := <-results:
+			if err = send(result); err == nil {
+				err = pushStatus()
+			}
+		case <-statusTicker.C:
+			err = pushStatus()
+		case <-pingTicker.C:
+			pingCtx, cancelPing := context.WithTimeout(ctx, wsWriteTimeout)
+			err = conn.Ping(pingCtx)
+			cancelPing()
+			broadcaster.MarkActive(logChan)
+		case entry, ok := <-logChan:
+			if !ok {
+				return
+			}
+			switch {
+			case entry.Event == shutdownEvent:
+				// Close right away, as the SSE stream does, so
+				// PrepareShutdown does not wait out its drain on us.
+				_ = send(WSServerMessage{Type: "event", Event: entry.Event, Data: json.RawMessage(entry.Line)})
+				conn.Close(websocket.StatusGoingAway, "server shutting down")
+				return
+			case entry.Event != "":
+				err = send(WSServerMessage{Type: "event", Event: entry.Event, Data: json.RawMessage(entry.Line)})
+			case entry.Seq > lastSent && wanted(entry):
+				err = send(WSServerMessage{Type: "log", Seq: entry.Seq, Line: entry.Line, Component: entry.Component})
+			}
+		}
+		if err != nil {
+			log().Debugf("WebSocket client %s closed: %v", r.RemoteAddr, err)
+			return
+		}
+	}
+}
+
+// readWSCommands forwards control messages until the connection fails, then
+// cancels the connection context. Malformed messages get an error reply.
+func (s *Server) readWSCommands(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, commands chan<- WSClientMessage, send func(WSServerMessage) error) {
+	defer cancel()
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+		var msg WSClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type != "control" {
+			if sendErr := send(WSServerMessage{Type: "error", ID: msg.ID, Error: "expected a control message", Code: "invalid_message"}); sendErr != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case commands <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// wsControl runs one control message and describes its outcome.
+func (s *Server) wsControl(cmd WSClientMessage, requester string) WSServerMessage {
+	result := WSServerMessage{Type: "result", ID: cmd.ID, Action: cmd.Action}
+	err := s.checkConfirm(cmd.Tunnel, cmd.Action, cmd.Confirm)
+	var message string
+	if err == nil {
+		message, err = s.controlTunnel(cmd.Tunnel, cmd.Action, requester)
+	}
+	if err != nil {
+		_, result.Code = controlErrorStatus(err)
+		result.Error = err.Error()
+		return result
+	}
+	result.Success, result.Message = true, message
+	return result
+}
+
+// activeStatus is the /api/status view of the active tunnel.
+func (s *Server) activeStatus() StatusResponse {
+	tunnelName := s.cfgMgr.Get().TunnelName
+	if s.runner == nil {
+		return StatusResponse{Status: "unavailable", TunnelName: tunnelName}
+	}
+	st, _ := s.runner.ProfileStatus("")
+	return statusResponseFrom(st, tunnelName)
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cfui/internal/logger"
+	"cfui/internal/service"
+
+	"nhooyr.io/websocket"
+)
+
+func TestWSControlStartsTunnelAndPushesStatus(t *testing.T) {
+	s := newServerTestServer(t)
+	s.runner = service.NewRunner(s.cfgMgr)
+	started := make(chan string, 1)
+	s.control = func(key, action, _ string) (string, error) {
+		if action == "start" {
+			started <- key
+		}
+		return "Tunnel started successfully", nil
+	}
+	srv := httptest.NewServer(http.HandlerFunc(s.handleWS))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.CloseNow()
+
+	read := func() WSServerMessage {
+		t.Helper()
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		var msg WSServerMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("decode %s: %v", data, err)
+		}
+		return msg
+	}
+	readUntil := func(typ string) WSServerMessage {
+		t.Helper()
+		for {
+			if msg := read(); msg.Type == typ {
+				return msg
+			}
+		}
+	}
+
+	if msg := read(); msg.Type != "status" || msg.Status == nil || msg.Status.Status != "stopped" {
+		t.Fatalf("first message = %+v, want the stopped status", msg)
+	}
+
+	if err := conn.Write(ctx, websocket.MessageText, []byte(`{"type":"control","action":"start","id":"1"}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	select {
+	case key := <-started:
+		if key != "" {
+			t.Fatalf("started key = %q, want the active profile", key)
+		}
+	case <-ctx.Done():
+		t.Fatal("control message did not start the tunnel")
+	}
+	if msg := readUntil("result"); !msg.Success || msg.ID != "1" || msg.Action != "start" {
+		t.Fatalf("result = %+v, want a successful start for id 1", msg)
+	}
+
+	logger.GetBroadcaster().Broadcast("ws-test live line")
+	for {
+		if msg := readUntil("log"); msg.Line == "ws-test live line" {
+			break
+		}
+	}
+
+	if err := conn.Write(ctx, websocket.MessageText, []byte(`{"type":"ping"}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if msg := readUntil("error"); msg.Code != "invalid_message" {
+		t.Fatalf("error = %+v, want invalid_message", msg)
+	}
+}
+
+func TestWSClosesOnBroadcastShutdownEvent(t *testing.T) {
+	s := newServerTestServer(t)
+	s.shutdownC = make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(s.handleWS))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.CloseNow()
+	if _, _, err := conn.Read(ctx); err != nil { // initial status: the stream is live
+		t.Fatalf("Read: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.PrepareShutdown(5 * time.Second)
+		close(done)
+	}()
+	var sawShutdown bool
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			if websocket.CloseStatus(err) != websocket.StatusGoingAway {
+				t.Fatalf("Read error = %v, want a going-away close", err)
+			}
+			break
+		}
+		var msg WSServerMessage
+		if err := json.Unmarshal(data, &msg); err == nil && msg.Event == shutdownEvent {
+			sawShutdown = true
+		}
+	}
+	if !sawShutdown {
+		t.Fatal("connection closed without a shutdown event")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("PrepareShutdown kept waiting after the WebSocket closed")
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"cfui/internal/cloudflared"
+)
+
+// eventBufferSize bounds the lifecycle event history kept in memory.
+const eventBufferSize = 200
+
+// Event is one entry of the tunnel lifecycle timeline.
+type Event struct {
+	Time time.Time             `json:"time"`
+	Type cloudflared.EventType `json:"type"`
+	// Tunnel is the profile key. It is empty for connection events, whose
+	// log lines do not name a tunnel.
+	Tunnel string `json:"tunnel,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// eventLog is a bounded, oldest-first history of lifecycle events. It has
+// its own lock because instances report events while holding theirs.
+type eventLog struct {
+	mu        sync.Mutex
+	entries   []Event
+	listeners []func(Event)
+	now       func() time.Time
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{now: time.Now}
+}
+
+func (l *eventLog) record(tunnel string, typ cloudflared.EventType, detail string) {
+	l.mu.Lock()
+	if len(l.entries) == eventBufferSize {
+		copy(l.entries, l.entries[1:])
+		l.entries = l.entries[:eventBufferSize-1]
+	}
+	ev := Event{Time: l.now(), Type: typ, Tunnel: tunnel, Detail: detail}
+	l.entries = append(l.entries, ev)
+	listeners := l.listeners
+	l.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(ev)
+	}
+}
+
+// recent returns up to limit of the newest events, oldest first. A limit of
+// zero or less returns everything kept.
+func (l *eventLog) recent(limit int) []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	start := 0
+	if limit > 0 && limit < len(l.entries) {
+		start = len(l.entries) - limit
+	}
+	return append([]Event(nil), l.entries[start:]...)
+}
+
+// Events returns up to limit of the most recent lifecycle events, oldest
+// first; limit <= 0 returns all retained events.
+func (r *Runner) Events(limit int) []Event {
+	return r.events.recent(limit)
+}
+
+// OnEvent registers fn to receive every lifecycle event as it is recorded.
+// fn runs on the reporting goroutine, possibly under an instance lock, so it
+// must not block.
+func (r *Runner) OnEvent(fn func(Event)) {
+	r.events.mu.Lock()
+	defer r.events.mu.Unlock()
+	r.events.listeners = append(r.events.listeners, fn)
+}
+
+// recordConnected adds a connected event for a registered edge connection.
+func (r *Runner) recordConnected(conn cloudflared.Connection) {
+	r.events.record("", cloudflared.EventConnected, fmt.Sprintf("connIndex=%d location=%s protocol=%s", conn.Index, conn.Location, conn.Protocol))
+}
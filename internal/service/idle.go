@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// idleCheckInterval is how often running tunnels are checked for traffic.
+const idleCheckInterval = 15 * time.Second
+
+// idleState remembers the request counter last seen for a running profile
+// and when it last changed.
+type idleState struct {
+	total float64
+	since time.Time
+}
+
+// startIdleWatch launches the background idle check. It is a no-op when
+// already running or after Shutdown.
+func (r *Runner) startIdleWatch() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.idleStopC != nil || r.bgCtx.Err() != nil {
+		return
+	}
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	r.idleStopC, r.idleDoneC = stopC, doneC
+	tickC, stopTicker := realTicker(idleCheckInterval)
+	started := r.goBackground(func(ctx context.Context) {
+		defer close(doneC)
+		defer stopTicker()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopC:
+				return
+			case now := <-tickC:
+				r.checkIdle(now)
+			}
+		}
+	})
+	if !started {
+		// Shutdown began after the check above.
+		stopTicker()
+		r.idleStopC, r.idleDoneC = nil, nil
+	}
+}
+
+// stopIdleWatch halts the background idle check and waits for it to exit.
+func (r *Runner) stopIdleWatch() {
+	r.mu.Lock()
+	stopC, doneC := r.idleStopC, r.idleDoneC
+	r.idleStopC, r.idleDoneC = nil, nil
+	r.mu.Unlock()
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+// resetIdle restarts the inactivity clock of a profile, e.g. on start.
+func (r *Runner) resetIdle(key string) {
+	r.mu.Lock()
+	delete(r.idle, key)
+	r.mu.Unlock()
+}
+
+// checkIdle stops every running profile with an IdleTimeout that has seen no
+// traffic for at least that long. cloudflared's request metrics are
+// process-wide, so traffic through any tunnel keeps all of them alive. A
+// stopped tunnel starts again on the next start request, or on the next wake
+// when it uses LazyStart.
+func (r *Runner) checkIdle(now time.Time) {
+	total, concurrent := r.requestCounters()
+	cfg := r.cfgMgr.Get()
+
+	var expired []string
+	r.mu.Lock()
+	for key, inst := range r.insts {
+		profile, ok := cfg.TunnelProfile(key)
+		timeout := profile.IdleTimeoutDuration()
+		if _, running := r.runningOptions(inst); !ok || timeout <= 0 || !running {
+			delete(r.idle, key)
+			continue
+		}
+		state, seen := r.idle[key]
+		if !seen || state.total != total || concurrent > 0 {
+			r.idle[key] = idleState{total: total, since: now}
+			continue
+		}
+		if now.Sub(state.since) >= timeout {
+			delete(r.idle, key)
+			expired = append(expired, key)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, key := range expired {
+		log().Infof("Stopping tunnel %q after no traffic for its idle timeout", key)
+		if err := r.stopIdle(key); err != nil {
+			log().Warnf("Failed to stop idle tunnel %q: %v", key, err)
+		}
+		if profile, ok := cfg.TunnelProfile(key); ok && profile.LazyStart {
+			r.armLazy(key)
+		}
+	}
+}
+
+// requestCounters returns the total and in-flight request counts from the
+// unlabeled metrics source.
+func (r *Runner) requestCounters() (total, concurrent float64) {
+	families, err := r.gatherer.Gather()
+	if err != nil {
+		log().Debugf("Metrics gather reported errors: %v", err)
+	}
+	for _, family := range families {
+		switch family.GetName() {
+		case metricTotalRequests:
+			total = sumMetricFamily(family)
+		case metricConcurrentRequests:
+			concurrent = sumMetricFamily(family)
+		}
+	}
+	return total, concurrent
+}
@@ -0,0 +1,40 @@
+package service
+
+// armLazy makes the next Wake of a profile start it.
+func (r *Runner) armLazy(key string) {
+	r.mu.Lock()
+	r.lazy[key] = true
+	r.mu.Unlock()
+}
+
+// disarmLazy cancels a pending lazy start, e.g. because the profile was
+// started or stopped explicitly.
+func (r *Runner) disarmLazy(key string) {
+	r.mu.Lock()
+	delete(r.lazy, key)
+	r.mu.Unlock()
+}
+
+// Wake fires the one-shot starter of a lazy-start profile ("" = active). It
+// reports whether this call started the tunnel; profiles that are not armed
+// are left alone. A failed start stays armed so the next wake retries.
+//
+// Profiles are armed at launch and again after an idle-timeout stop, so
+// LazyStart combined with IdleTimeout runs a tunnel only while it is used.
+func (r *Runner) Wake(key string) (bool, error) {
+	canonical := r.resolveKey(key)
+	r.mu.Lock()
+	armed := r.lazy[canonical]
+	delete(r.lazy, canonical)
+	r.mu.Unlock()
+	if !armed {
+		return false, nil
+	}
+
+	log().Infof("Waking lazy-start tunnel %q", canonical)
+	if err := r.startLazy(canonical); err != nil {
+		r.armLazy(canonical)
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"cfui/internal/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Metric families summarized by the poller.
+const (
+	metricHAConnections      = "cloudflared_tunnel_ha_connections"
+	metricTotalRequests      = "cloudflared_tunnel_total_requests"
+	metricRequestErrors      = "cloudflared_tunnel_request_errors"
+	metricConcurrentRequests = "cloudflared_tunnel_concurrent_requests_per_tunnel"
+	// Byte counters exist for QUIC connections only.
+	metricSentBytes     = "quic_client_sent_bytes"
+	metricReceivedBytes = "quic_client_receive_bytes"
+)
+
+// TunnelMetricsLabel is the label naming the tunnel profile(s) a metric
+// sample belongs to.
+const TunnelMetricsLabel = "tunnel"
+
+// MetricsSnapshot is a cached summary of the tunnel metrics.
+type MetricsSnapshot struct {
+	Tunnel             string    `json:"tunnel"`
+	HAConnections      int       `json:"ha_connections"`
+	TotalRequests      float64   `json:"total_requests"`
+	RequestErrors      float64   `json:"request_errors"`
+	ConcurrentRequests float64   `json:"concurrent_requests"`
+	SentBytes          float64   `json:"sent_bytes"`
+	ReceivedBytes      float64   `json:"received_bytes"`
+	CollectedAt        time.Time `json:"collected_at"`
+}
+
+// newTickerFunc starts a ticker and returns its channel and stop function.
+// Tests replace it to drive the poller without real time passing.
+type newTickerFunc func(time.Duration) (<-chan time.Time, func())
+
+func realTicker(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(d)
+	return t.C, t.Stop
+}
+
+// MetricsPoller periodically gathers tunnel metrics and caches a summary so
+// status reads do not pay for a full registry scrape.
+type MetricsPoller struct {
+	gatherer  prometheus.Gatherer
+	interval  func() time.Duration
+	newTicker newTickerFunc
+	// active reports whether there is anything to poll. When set, the
+	// poller stops itself on a tick that finds it false, so it does not
+	// gather an empty registry while every tunnel is down.
+	active func() bool
+	// spawn runs the poll loop on a new goroutine, or reports false when it
+	// may no longer start one. The runner ties it to its own lifetime.
+	spawn func(fn func(ctx context.Context)) bool
+
+	mu       sync.Mutex
+	snapshot MetricsSnapshot
+	stopC    chan struct{}
+	doneC    chan struct{}
+	// started is set by a Start that found the poller running, so a
+	// concurrent idle tick does not stop it right after.
+	started bool
+	// subs receive every snapshot the background poller collects.
+	subs map[chan MetricsSnapshot]struct{}
+}
+
+// NewMetricsPoller creates a poller. interval is read on every Start; zero
+// disables background polling.
+func NewMetricsPoller(gatherer prometheus.Gatherer, interval func() time.Duration) *MetricsPoller {
+	return &MetricsPoller{
+		gatherer:  gatherer,
+		interval:  interval,
+		newTicker: realTicker,
+		spawn: func(fn func(context.Context)) bool {
+			go fn(context.Background())
+			return true
+		},
+	}
+}
+
+// Start launches the background poller. It is a no-op when already running
+// or when the interval is zero.
+func (p *MetricsPoller) Start() {
+	interval := p.interval()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopC != nil {
+		p.started = true
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	p.stopC, p.doneC = stopC, doneC
+	tickC, stopTicker := p.newTicker(interval)
+	if !p.spawn(func(ctx context.Context) { p.loop(ctx, tickC, stopTicker, stopC, doneC) }) {
+		stopTicker()
+		p.stopC, p.doneC = nil, nil
+	}
+}
+
+// Stop halts the background poller and waits for it to exit.
+func (p *MetricsPoller) Stop() {
+	p.mu.Lock()
+	stopC, doneC := p.stopC, p.doneC
+	p.stopC, p.doneC = nil, nil
+	p.mu.Unlock()
+	if stopC == nil {
+		return
+	}
+	close(stopC)
+	<-doneC
+}
+
+func (p *MetricsPoller) loop(ctx context.Context, tickC <-chan time.Time, stopTicker func(), stopC, doneC chan struct{}) {
+	defer close(doneC)
+	defer stopTicker()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopC:
+			return
+		case now := <-tickC:
+			if p.idle(stopC) {
+				return
+			}
+			snapshot := p.collect(now)
+			p.mu.Lock()
+			p.publish(snapshot)
+			p.mu.Unlock()
+		}
+	}
+}
+
+// idle reports whether the poller has nothing to poll and, if so, marks it
+// stopped; the loop then exits. A Start since the previous tick keeps it
+// running.
+func (p *MetricsPoller) idle(stopC chan struct{}) bool {
+	if p.active == nil {
+		return false
+	}
+	p.mu.Lock()
+	p.started = false
+	p.mu.Unlock()
+	if p.active() {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started || p.stopC != stopC {
+		return false
+	}
+	p.stopC, p.doneC = nil, nil
+	return true
+}
+
+// Enabled reports whether background polling is configured. The poller
+// itself may still be stopped while no tunnel runs.
+func (p *MetricsPoller) Enabled() bool {
+	return p.interval() > 0
+}
+
+// Polling reports whether the background poller is running.
+func (p *MetricsPoller) Polling() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stopC != nil
+}
+
+// Subscribe returns a channel that receives each snapshot the background
+// poller collects, and a function that ends the subscription. A slow reader
+// only misses intermediate snapshots; the channel keeps the latest.
+func (p *MetricsPoller) Subscribe() (<-chan MetricsSnapshot, func()) {
+	ch := make(chan MetricsSnapshot, 1)
+	p.mu.Lock()
+	if p.subs == nil {
+		p.subs = make(map[chan MetricsSnapshot]struct{})
+	}
+	p.subs[ch] = struct{}{}
+	p.mu.Unlock()
+	return ch, func() {
+		p.mu.Lock()
+		delete(p.subs, ch)
+		p.mu.Unlock()
+	}
+}
+
+// publish hands a snapshot to every subscriber, replacing one it has not
+// read yet. Callers hold p.mu.
+func (p *MetricsPoller) publish(snapshot MetricsSnapshot) {
+	for ch := range p.subs {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- snapshot
+	}
+}
+
+// Snapshot returns the cached summary. When background polling is disabled,
+// or has not collected yet, the metrics are gathered on demand instead.
+func (p *MetricsPoller) Snapshot() MetricsSnapshot {
+	p.mu.Lock()
+	polling := p.stopC != nil
+	snapshot := p.snapshot
+	p.mu.Unlock()
+	if polling && !snapshot.CollectedAt.IsZero() {
+		return snapshot
+	}
+	return p.collect(time.Now())
+}
+
+func (p *MetricsPoller) collect(now time.Time) MetricsSnapshot {
+	snapshot := MetricsSnapshot{CollectedAt: now}
+	families, err := p.gatherer.Gather()
+	if err != nil && logger.Sugar != nil {
+		// Gatherers returns partial results alongside the error.
+		log().Debugf("Metrics gather reported errors: %v", err)
+	}
+	for _, family := range families {
+		if snapshot.Tunnel == "" {
+			snapshot.Tunnel = metricLabel(family, TunnelMetricsLabel)
+		}
+		switch family.GetName() {
+		case metricHAConnections:
+			snapshot.HAConnections = int(sumMetricFamily(family))
+		case metricTotalRequests:
+			snapshot.TotalRequests = sumMetricFamily(family)
+		case metricRequestErrors:
+			snapshot.RequestErrors = sumMetricFamily(family)
+		case metricConcurrentRequests:
+			snapshot.ConcurrentRequests = sumMetricFamily(family)
+		case metricSentBytes:
+			snapshot.SentBytes = sumMetricFamily(family)
+		case metricReceivedBytes:
+			snapshot.ReceivedBytes = sumMetricFamily(family)
+		}
+	}
+
+	p.mu.Lock()
+	p.snapshot = snapshot
+	p.mu.Unlock()
+	return snapshot
+}
+
+func sumMetricFamily(family *dto.MetricFamily) float64 {
+	var total float64
+	for _, m := range family.GetMetric() {
+		switch {
+		case m.GetGauge() != nil:
+			total += m.GetGauge().GetValue()
+		case m.GetCounter() != nil:
+			total += m.GetCounter().GetValue()
+		case m.GetUntyped() != nil:
+			total += m.GetUntyped().GetValue()
+		}
+	}
+	return total
+}
+
+// metricLabel returns the value of the named label on the family's first
+// sample that carries it.
+func metricLabel(family *dto.MetricFamily, name string) string {
+	for _, m := range family.GetMetric() {
+		if value, ok := labelValue(m, name); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func labelValue(m *dto.Metric, name string) (string, bool) {
+	for _, pair := range m.GetLabel() {
+		if pair.GetName() == name {
+			return pair.GetValue(), true
+		}
+	}
+	return "", false
+}
+
+// labelGatherer adds a label to every sample gathered from an underlying
+// gatherer. Samples that already carry the label are left unchanged.
+type labelGatherer struct {
+	gatherer prometheus.Gatherer
+	name     string
+	value    func() string
+}
+
+func (g labelGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	name, value := g.name, g.value()
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			if _, ok := labelValue(m, name); ok {
+				continue
+			}
+			m.Label = append(m.Label, &dto.LabelPair{Name: &name, Value: &value})
+			sort.Slice(m.Label, func(i, j int) bool { return m.Label[i].GetName() < m.Label[j].GetName() })
+		}
+	}
+	return families, err
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+type countingGatherer struct {
+	calls  atomic.Int32
+	gather func() ([]*dto.MetricFamily, error)
+}
+
+func (g *countingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.calls.Add(1)
+	return g.gather()
+}
+
+func TestMetricsPollerFiresAtConfiguredCadence(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	ha := prometheus.NewGauge(prometheus.GaugeOpts{Name: metricHAConnections})
+	reg.MustRegister(ha)
+	ha.Set(4)
+	gatherer := &countingGatherer{gather: reg.Gather}
+
+	tickC := make(chan time.Time)
+	var requested time.Duration
+	stopped := make(chan struct{})
+	p := NewMetricsPoller(gatherer, func() time.Duration { return 20 * time.Second })
+	p.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		requested = d
+		return tickC, func() { close(stopped) }
+	}
+
+	p.Start()
+	if requested != 20*time.Second {
+		t.Fatalf("ticker interval = %v, want 20s", requested)
+	}
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 3; i++ {
+		tickC <- base.Add(time.Duration(i) * requested)
+	}
+	// The unbuffered sends above only prove the loop received the ticks;
+	// Stop waits for the last collection to finish.
+	p.Stop()
+	<-stopped
+
+	if got := gatherer.calls.Load(); got != 3 {
+		t.Fatalf("gather calls = %d, want 3 (one per tick)", got)
+	}
+	snap := p.Snapshot()
+	if snap.HAConnections != 4 {
+		t.Fatalf("HAConnections = %d, want 4", snap.HAConnections)
+	}
+}
+
+func TestMetricsPollerPublishesToSubscribers(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	sent := prometheus.NewCounter(prometheus.CounterOpts{Name: metricSentBytes})
+	reg.MustRegister(sent)
+	sent.Add(1500)
+
+	tickC := make(chan time.Time)
+	p := NewMetricsPoller(reg, func() time.Duration { return 20 * time.Second })
+	p.newTicker = func(time.Duration) (<-chan time.Time, func()) { return tickC, func() {} }
+	snapshots, unsubscribe := p.Subscribe()
+	p.Start()
+	defer p.Stop()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tickC <- base
+	if got := <-snapshots; !got.CollectedAt.Equal(base) || got.SentBytes != 1500 {
+		t.Fatalf("published snapshot = %+v, want the tick's with 1500 sent bytes", got)
+	}
+
+	unsubscribe()
+	tickC <- base.Add(20 * time.Second)
+	// The loop takes the next tick only after publishing the previous one.
+	tickC <- base.Add(40 * time.Second)
+	select {
+	case got := <-snapshots:
+		t.Fatalf("snapshot %+v published after unsubscribe", got)
+	default:
+	}
+}
+
+func TestMetricsPollerZeroIntervalGathersOnDemand(t *testing.T) {
+	gatherer := &countingGatherer{gather: prometheus.NewRegistry().Gather}
+	p := NewMetricsPoller(gatherer, func() time.Duration { return 0 })
+	p.newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		t.Fatal("ticker must not start when polling is disabled")
+		return nil, nil
+	}
+
+	p.Start()
+	p.Snapshot()
+	p.Snapshot()
+	p.Stop()
+
+	if got := gatherer.calls.Load(); got != 2 {
+		t.Fatalf("gather calls = %d, want 2 (one per on-demand read)", got)
+	}
+}
+
+func TestRunnerMetricsAreLabeledByTunnel(t *testing.T) {
+	gatherTunnel := func(t *testing.T, key string) string {
+		t.Helper()
+		r := newTestRunner(t)
+		if _, err := r.cfgMgr.SaveTunnelProfile(key, config.TunnelProfileConfig{Key: key, Name: key}); err != nil {
+			t.Fatalf("SaveTunnelProfile: %v", err)
+		}
+		if _, err := r.cfgMgr.ActivateTunnelProfile(key); err != nil {
+			t.Fatalf("ActivateTunnelProfile: %v", err)
+		}
+		reg := prometheus.NewRegistry()
+		ha := prometheus.NewGauge(prometheus.GaugeOpts{Name: metricHAConnections})
+		reg.MustRegister(ha)
+		ha.Set(2)
+		r.gatherer = reg
+
+		families, err := r.MetricsGatherer().Gather()
+		if err != nil || len(families) != 1 {
+			t.Fatalf("Gather = %v, %v", families, err)
+		}
+		return metricLabel(families[0], TunnelMetricsLabel)
+	}
+
+	home, office := gatherTunnel(t, "home"), gatherTunnel(t, "office")
+	if home != "home" || office != "office" {
+		t.Fatalf("tunnel labels = %q and %q, want home and office", home, office)
+	}
+}
+
+func TestRunnerMetricsPollerFollowsTunnelLifecycle(t *testing.T) {
+	r := newTestRunner(t)
+	r.gatherer = prometheus.NewRegistry()
+	tickC := make(chan time.Time)
+	r.metrics.newTicker = func(time.Duration) (<-chan time.Time, func()) { return tickC, func() {} }
+	// Instances cannot run here, so the poller's view of them is faked.
+	var running atomic.Bool
+	r.metrics.active = running.Load
+
+	r.Initialize()
+	defer r.metrics.Stop()
+	if r.metrics.Polling() {
+		t.Fatal("poller runs with no tunnel started")
+	}
+	if !r.MetricsPolling() {
+		t.Fatal("MetricsPolling should report the configured interval, not the idle poller")
+	}
+
+	baseline := runtime.NumGoroutine()
+	for i := 0; i < 20; i++ {
+		running.Store(true)
+		r.instanceEvent("home", cloudflared.EventStart, "home")
+		if !r.metrics.Polling() {
+			t.Fatalf("cycle %d: start did not start the poller", i)
+		}
+		running.Store(false)
+		if i%2 == 0 {
+			// The stop path.
+			r.stopMetricsIfIdle()
+		} else {
+			// A tunnel that exited on its own: the next tick notices.
+			tickC <- time.Now()
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for r.metrics.Polling() {
+			if time.Now().After(deadline) {
+				t.Fatalf("cycle %d: poller still runs after the tunnel stopped", i)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d after start/stop cycles, want at most %d", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"cfui/internal/config"
+)
+
+// ProtocolRestartStopTimeout bounds the stop half of RestartForProtocol,
+// in place of the instance's default 30s wait.
+const ProtocolRestartStopTimeout = 5 * time.Second
+
+// RestartForProtocol saves a new transport protocol ("auto", "quic" or
+// "http2") for a profile ("" = active) and, if the tunnel is running,
+// restarts it right away. cloudflared cannot switch the transport of live
+// connections, so a restart is unavoidable; a protocol change usually
+// follows a transport failure, so the stop waits only
+// ProtocolRestartStopTimeout instead of a full graceful drain. A stopped
+// tunnel picks up the protocol on its next start.
+func (r *Runner) RestartForProtocol(key, protocol string) error {
+	switch protocol {
+	case "auto", "quic", "http2":
+	default:
+		return fmt.Errorf("%w: protocol must be auto, quic or http2, got %q", config.ErrInvalidConfig, protocol)
+	}
+	profile, ok := r.cfgMgr.Get().TunnelProfile(key)
+	if !ok {
+		return fmt.Errorf("tunnel profile %q not found", key)
+	}
+	profile.Protocol = protocol
+	if _, err := r.cfgMgr.SaveTunnelProfile(profile.Key, profile); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	inst := r.insts[profile.Key]
+	r.mu.Unlock()
+	if inst == nil {
+		return nil
+	}
+	if _, running := r.runningOptions(inst); !running {
+		return nil
+	}
+	return r.restart(profile.Key, ProtocolRestartStopTimeout)
+}
@@ -6,29 +6,124 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"cfui/internal/cloudflared"
 	"cfui/internal/config"
 	"cfui/internal/logger"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
 )
 
+// log returns the package logger, named cfui.runner so broadcast lines can
+// be filtered by component.
+func log() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentRunner)
+}
+
 // Runner manages cloudflared tunnel instances, one per tunnel profile.
 type Runner struct {
-	cfgMgr *config.Manager
+	cfgMgr  *config.Manager
+	metrics *MetricsPoller
+	// gatherer is the unlabeled metrics source; tests replace it.
+	gatherer prometheus.Gatherer
+	// runningOptions reads an instance's launch options; tests replace it
+	// because instances cannot run without the cloudflared edge.
+	runningOptions func(*cloudflared.Instance) (cloudflared.Options, bool)
+	// stopIdle stops a profile that exceeded its idle timeout; tests
+	// replace it to observe the stop.
+	stopIdle func(key string) error
+	// startLazy starts a woken lazy-start profile; tests replace it.
+	startLazy func(key string) error
+	// stopWithin stops a profile, waiting at most the given time (0 = the
+	// instance default) for its run to exit; tests replace it because
+	// instances cannot run without the cloudflared edge.
+	stopWithin func(key string, reason cloudflared.StopReason, timeout time.Duration) error
+	events     *eventLog
+	// stats accumulates the lifetime figures persisted in stats.json.
+	stats *statsStore
+
+	// bgCtx is canceled by Shutdown; every background goroutine started
+	// through goBackground derives from it and is tracked by bgWG. bgMu
+	// orders starts against the cancel. It is its own lock, not mu, because
+	// the metrics poller spawns under the instance lock, which mu-holders
+	// such as RunningCount take after mu.
+	bgMu     sync.Mutex
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
+	bgWG     sync.WaitGroup
 
 	mu    sync.Mutex
 	insts map[string]*cloudflared.Instance // keyed by canonical profile key
+	// conns tracks registered edge connections by connIndex, parsed from
+	// cloudflared's log output. Lines carry no tunnel name, so connections
+	// of parallel tunnels share this view.
+	conns map[int]cloudflared.Connection
+	// idle tracks traffic per running profile with an idle timeout.
+	idle      map[string]idleState
+	idleStopC chan struct{}
+	idleDoneC chan struct{}
+	// lazy holds the profiles armed to start on their next Wake.
+	lazy map[string]bool
+	// patterns are the error classification patterns last handed to
+	// cloudflared.SetErrorPatterns.
+	patterns cloudflared.ErrorPatterns
 }
 
 func NewRunner(cfgMgr *config.Manager) *Runner {
-	return &Runner{
-		cfgMgr: cfgMgr,
-		insts:  make(map[string]*cloudflared.Instance),
+	r := &Runner{
+		cfgMgr:         cfgMgr,
+		gatherer:       cloudflared.MetricsGatherer(),
+		runningOptions: (*cloudflared.Instance).RunningOptions,
+		insts:          make(map[string]*cloudflared.Instance),
+		conns:          make(map[int]cloudflared.Connection),
+		idle:           make(map[string]idleState),
+		lazy:           make(map[string]bool),
+		events:         newEventLog(),
+	}
+	stats, err := loadStats(filepath.Join(cfgMgr.Dir(), statsFile))
+	if err != nil {
+		log().Warnf("Ignoring unreadable tunnel stats, counting from zero: %v", err)
+	}
+	r.stats = stats
+	r.bgCtx, r.bgCancel = context.WithCancel(context.Background())
+	r.stopIdle = func(key string) error { return r.stopProfile(key, cloudflared.StopIdle, 0) }
+	r.startLazy = r.StartProfile
+	r.stopWithin = r.stopProfile
+	r.metrics = NewMetricsPoller(r.MetricsGatherer(), func() time.Duration {
+		return cfgMgr.Get().MetricsPollDuration()
+	})
+	r.metrics.active = func() bool { return r.RunningCount() > 0 }
+	r.metrics.spawn = r.goBackground
+	return r
+}
+
+// goBackground runs fn on a goroutine owned by the runner: its ctx is
+// canceled by Shutdown, which then waits for fn to return. After Shutdown
+// it does not start fn at all and reports false.
+func (r *Runner) goBackground(fn func(ctx context.Context)) bool {
+	r.bgMu.Lock()
+	defer r.bgMu.Unlock()
+	if r.bgCtx.Err() != nil {
+		return false
 	}
+	r.bgWG.Add(1)
+	go func() {
+		defer r.bgWG.Done()
+		fn(r.bgCtx)
+	}()
+	return true
 }
 
 // optionsFor derives launch options for one profile. It is re-evaluated on
@@ -36,6 +131,7 @@ func NewRunner(cfgMgr *config.Manager) *Runner {
 // deleted profiles stop restarting.
 func (r *Runner) optionsFor(key string) (cloudflared.Options, error) {
 	cfg := r.cfgMgr.Get()
+	r.applyErrorPatterns(cfg)
 	profile, ok := cfg.TunnelProfile(key)
 	if !ok {
 		return cloudflared.Options{}, fmt.Errorf("tunnel profile %q not found", key)
@@ -44,14 +140,29 @@ func (r *Runner) optionsFor(key string) (cloudflared.Options, error) {
 		return cloudflared.Options{}, fmt.Errorf("tunnel profile %q is not enabled for local running", profile.Key)
 	}
 	if profile.Token == "" {
-		return cloudflared.Options{}, fmt.Errorf("token is required")
+		return cloudflared.Options{}, cloudflared.ErrTokenMissing
 	}
-	return OptionsFromProfile(profile), nil
+	return launchOptions(cfg, profile), nil
+}
+
+// launchOptions is OptionsFromProfile with the global settings every run
+// of the profile is launched with.
+func launchOptions(cfg config.Config, profile config.TunnelProfileConfig) cloudflared.Options {
+	opts := OptionsFromProfile(profile)
+	opts.ProtocolOrder = cfg.ProtocolOrder
+	opts.MaxRestartsPerHour = cfg.MaxRestartsPerHour
+	opts.MaxRuntime = cfg.MaxRuntimeDuration()
+	opts.RestartJitter = cfg.RestartJitter
+	opts.SoleLogSink = cfg.SoleLogSink
+	opts.MaxProtocolSwitches = cfg.MaxProtocolSwitches
+	opts.EdgeIPFallback = cfg.EdgeIPFallback
+	return opts
 }
 
 // OptionsFromProfile maps a tunnel profile onto cloudflared launch options.
 func OptionsFromProfile(p config.TunnelProfileConfig) cloudflared.Options {
 	return cloudflared.Options{
+		TunnelName:      p.Name,
 		Token:           p.Token,
 		CustomTag:       p.CustomTag,
 		SoftwareName:    p.SoftwareName,
@@ -66,13 +177,29 @@ func OptionsFromProfile(p config.TunnelProfileConfig) cloudflared.Options {
 		LogJSON:         p.LogJSON,
 		EdgeIPVersion:   p.EdgeIPVersion,
 		EdgeBindAddress: p.EdgeBindAddress,
-		PostQuantum:     p.PostQuantum,
+		EdgeInterface:   p.EdgeInterface,
+		PostQuantumMode: p.PostQuantumMode,
 		NoTLSVerify:     p.NoTLSVerify,
 		ExtraArgs:       p.ExtraArgs,
+		Tags:            p.Tags,
 		AutoRestart:     p.AutoRestart,
 	}
 }
 
+// applyErrorPatterns hands changed operator error patterns to cloudflared.
+// It runs before every (re)start, so edits take effect with the next run's
+// restart decision without recompiling unchanged lists.
+func (r *Runner) applyErrorPatterns(cfg config.Config) {
+	next := cloudflared.ErrorPatterns{Retryable: cfg.RetryablePatterns, NonRetryable: cfg.NonRetryablePatterns}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.Equal(next.Retryable, r.patterns.Retryable) && slices.Equal(next.NonRetryable, r.patterns.NonRetryable) {
+		return
+	}
+	r.patterns = next
+	cloudflared.SetErrorPatterns(next)
+}
+
 // resolveKey maps a request key ("" means active) onto the canonical profile
 // key. Unknown keys are returned as-is so instances of just-deleted profiles
 // can still be stopped.
@@ -99,11 +226,25 @@ func (r *Runner) instanceFor(key string) (*cloudflared.Instance, error) {
 		inst = cloudflared.NewInstance(boundKey, func() (cloudflared.Options, error) {
 			return r.optionsFor(boundKey)
 		})
+		inst.OnEvent(func(typ cloudflared.EventType, detail string) {
+			r.instanceEvent(boundKey, typ, detail)
+		})
 		r.insts[canonical] = inst
 	}
 	return inst, nil
 }
 
+// instanceEvent records an instance's lifecycle event. Every start, manual
+// or auto-restart, starts the metrics poller; it stops again once no tunnel
+// runs. It runs under the instance lock, so it must not query instances.
+func (r *Runner) instanceEvent(key string, typ cloudflared.EventType, detail string) {
+	r.events.record(key, typ, detail)
+	r.stats.record(key, typ)
+	if typ == cloudflared.EventStart {
+		r.metrics.Start()
+	}
+}
+
 // StartProfile launches the tunnel for the given profile key ("" = active).
 func (r *Runner) StartProfile(key string) error {
 	inst, err := r.instanceFor(key)
@@ -113,6 +254,9 @@ func (r *Runner) StartProfile(key string) error {
 	if err := r.checkMetricsPortConflict(inst.Name()); err != nil {
 		return err
 	}
+	r.resetIdle(inst.Name())
+	r.disarmLazy(inst.Name())
+	r.resetGlobalState()
 	return inst.Start()
 }
 
@@ -139,14 +283,53 @@ func (r *Runner) checkMetricsPortConflict(key string) error {
 // StopProfile stops the tunnel for the given profile key ("" = active).
 // Stopping a profile that never started is a no-op.
 func (r *Runner) StopProfile(key string) error {
+	return r.stopProfile(key, cloudflared.StopUser, 0)
+}
+
+func (r *Runner) stopProfile(key string, reason cloudflared.StopReason, timeout time.Duration) error {
 	canonical := r.resolveKey(key)
+	r.disarmLazy(canonical)
 	r.mu.Lock()
 	inst := r.insts[canonical]
 	r.mu.Unlock()
 	if inst == nil {
 		return nil
 	}
-	return inst.Stop()
+	err := inst.StopWithin(reason, timeout)
+	r.clearConnectionsIfIdle()
+	r.stopMetricsIfIdle()
+	return err
+}
+
+// CancelRestart aborts the pending auto-restart of a profile. It returns
+// cloudflared.ErrNoPendingRestart when none is scheduled.
+func (r *Runner) CancelRestart(key string) error {
+	canonical := r.resolveKey(key)
+	r.mu.Lock()
+	inst := r.insts[canonical]
+	r.mu.Unlock()
+	if inst == nil {
+		return cloudflared.ErrNoPendingRestart
+	}
+	if err := inst.CancelRestart(); err != nil {
+		return err
+	}
+	r.clearConnectionsIfIdle()
+	r.stopMetricsIfIdle()
+	return nil
+}
+
+// CheckStop returns cloudflared.ErrNotRunning when stopping the profile
+// would be a no-op, so callers can report it instead of a false success.
+func (r *Runner) CheckStop(key string) error {
+	canonical := r.resolveKey(key)
+	r.mu.Lock()
+	inst := r.insts[canonical]
+	r.mu.Unlock()
+	if inst == nil || !inst.Stoppable() {
+		return cloudflared.ErrNotRunning
+	}
+	return nil
 }
 
 // RemoveProfile stops and forgets the instance of a (typically just deleted)
@@ -160,7 +343,9 @@ func (r *Runner) RemoveProfile(key string) error {
 	if inst == nil {
 		return nil
 	}
-	return inst.Stop()
+	err := inst.Stop()
+	r.stopMetricsIfIdle()
+	return err
 }
 
 // ProfileStatus reports the status of one profile's instance. exists is false
@@ -171,11 +356,68 @@ func (r *Runner) ProfileStatus(key string) (cloudflared.Status, bool) {
 	inst := r.insts[canonical]
 	r.mu.Unlock()
 	if inst == nil {
-		return cloudflared.Status{}, false
+		return cloudflared.Status{StopReason: cloudflared.StopNeverStarted}, false
 	}
 	return inst.Status(), true
 }
 
+// PendingRestart reports, per running profile, which saved settings differ
+// from the options that profile was started with and so wait for a restart.
+// Profiles without such changes are omitted.
+func (r *Runner) PendingRestart() map[string][]string {
+	cfg := r.cfgMgr.Get()
+	r.mu.Lock()
+	insts := make(map[string]*cloudflared.Instance, len(r.insts))
+	for key, inst := range r.insts {
+		insts[key] = inst
+	}
+	r.mu.Unlock()
+
+	pending := make(map[string][]string)
+	for key, inst := range insts {
+		started, running := r.runningOptions(inst)
+		if !running {
+			continue
+		}
+		profile, ok := cfg.TunnelProfile(key)
+		if !ok {
+			continue
+		}
+		if changed := started.RestartRequired(launchOptions(cfg, profile)); len(changed) > 0 {
+			pending[key] = changed
+		}
+	}
+	return pending
+}
+
+// RestartRecord is one auto-restart attempt of a tunnel profile.
+type RestartRecord struct {
+	Tunnel string `json:"tunnel"`
+	cloudflared.RestartAttempt
+}
+
+// RestartHistory returns the recorded auto-restart attempts of the profile
+// key, or of every profile when key is empty, oldest first.
+func (r *Runner) RestartHistory(key string) []RestartRecord {
+	r.mu.Lock()
+	insts := make(map[string]*cloudflared.Instance, len(r.insts))
+	for k, inst := range r.insts {
+		if key == "" || k == key {
+			insts[k] = inst
+		}
+	}
+	r.mu.Unlock()
+
+	records := []RestartRecord{}
+	for k, inst := range insts {
+		for _, attempt := range inst.RestartHistory() {
+			records = append(records, RestartRecord{Tunnel: k, RestartAttempt: attempt})
+		}
+	}
+	sort.SliceStable(records, func(a, b int) bool { return records[a].Time.Before(records[b].Time) })
+	return records
+}
+
 // RunningCount returns how many tunnel instances are currently running.
 func (r *Runner) RunningCount() int {
 	r.mu.Lock()
@@ -201,6 +443,31 @@ func (r *Runner) Stop() error {
 	return r.StopProfile("")
 }
 
+// Restart cycles the active profile's tunnel; see RestartProfile.
+func (r *Runner) Restart() error {
+	return r.RestartProfile("")
+}
+
+// RestartProfile stops the tunnel of a profile ("" = active), waiting for
+// its run to exit, then starts it with the current config. A stopped tunnel
+// is just started. It returns once the new run is launched, or the stop
+// error if the old run did not exit within the stop timeout; then nothing
+// is started.
+func (r *Runner) RestartProfile(key string) error {
+	return r.restart(key, 0)
+}
+
+// restart is RestartProfile with the stop bounded by stopTimeout (0 = the
+// instance default). A run that does not exit in time still holds
+// cloudflared's process-wide state and its config file, so nothing is
+// started beside it.
+func (r *Runner) restart(key string, stopTimeout time.Duration) error {
+	if err := r.stopWithin(key, cloudflared.StopUser, stopTimeout); err != nil {
+		return fmt.Errorf("restart: %w", err)
+	}
+	return r.StartProfile(key)
+}
+
 // Status reports whether the active profile's tunnel is running, its last
 // error, and the currently selected protocol.
 func (r *Runner) Status() (bool, error, string) {
@@ -214,25 +481,164 @@ func (r *Runner) GetMetricsRegistry() *prometheus.Registry {
 	return cloudflared.MetricsRegistry()
 }
 
-// Initialize auto-starts every local-enabled profile that requests it.
+// MetricsGatherer gathers the tunnel metrics with every sample labeled
+// "tunnel" so several cfui processes can share one Prometheus job.
+func (r *Runner) MetricsGatherer() prometheus.Gatherer {
+	return labelGatherer{
+		gatherer: prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) { return r.gatherer.Gather() }),
+		name:     TunnelMetricsLabel,
+		value:    r.metricsTunnelLabel,
+	}
+}
+
+// metricsTunnelLabel names the profiles the metrics belong to. cloudflared's
+// collectors are process-wide, so parallel tunnels share one set of samples;
+// the label then joins the running keys ("home+office"). With nothing
+// running it falls back to the active profile.
+func (r *Runner) metricsTunnelLabel() string {
+	r.mu.Lock()
+	keys := make([]string, 0, len(r.insts))
+	for key, inst := range r.insts {
+		if inst.Status().Running {
+			keys = append(keys, key)
+		}
+	}
+	r.mu.Unlock()
+	if len(keys) == 0 {
+		return r.cfgMgr.Get().ActiveTunnelKey
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "+")
+}
+
+// MetricsSnapshot returns the latest summary of the tunnel metrics, gathered
+// on demand when background polling is disabled.
+func (r *Runner) MetricsSnapshot() MetricsSnapshot {
+	return r.metrics.Snapshot()
+}
+
+// MetricsPolling reports whether metrics are gathered in the background
+// while a tunnel runs, which SubscribeMetrics relies on.
+func (r *Runner) MetricsPolling() bool {
+	return r.metrics.Enabled()
+}
+
+// SubscribeMetrics returns a channel receiving every snapshot the metrics
+// poller collects, and a function that ends the subscription.
+func (r *Runner) SubscribeMetrics() (<-chan MetricsSnapshot, func()) {
+	return r.metrics.Subscribe()
+}
+
+// ObserveLogLine updates the connection table from a cloudflared
+// connection (un)registration log line; other lines are ignored.
+func (r *Runner) ObserveLogLine(line string) {
+	ev, ok := cloudflared.ParseConnectionEvent(line, time.Now())
+	if !ok {
+		return
+	}
+	if ev.Registered {
+		r.recordConnected(ev.Connection)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ev.Registered {
+		r.conns[ev.Connection.Index] = ev.Connection
+	} else {
+		delete(r.conns, ev.Connection.Index)
+	}
+}
+
+// Connections returns the registered edge connections ordered by index.
+func (r *Runner) Connections() []cloudflared.Connection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conns := make([]cloudflared.Connection, 0, len(r.conns))
+	for _, conn := range r.conns {
+		conns = append(conns, conn)
+	}
+	sort.Slice(conns, func(a, b int) bool { return conns[a].Index < conns[b].Index })
+	return conns
+}
+
+// stopMetricsIfIdle stops the metrics poller once no tunnel is running. A
+// tunnel that exits on its own is caught by the poller's next tick instead.
+func (r *Runner) stopMetricsIfIdle() {
+	if r.RunningCount() == 0 {
+		r.metrics.Stop()
+	}
+}
+
+// resetGlobalState is best-effort cleanup of the embedded library's
+// process-wide state before a run: with no tunnel running, the collectors
+// earlier runs registered are marked stale so the next run's take their
+// place. Auto-restarts do not pass through here.
+func (r *Runner) resetGlobalState() {
+	if r.RunningCount() > 0 {
+		return
+	}
+	if n := cloudflared.ResetMetrics(); n > 0 {
+		log().Debugf("Marked %d metrics collectors of earlier tunnel runs as stale", n)
+	}
+}
+
+// clearConnectionsIfIdle drops stale connections once no tunnel is running,
+// since a stopped tunnel does not log unregistrations.
+func (r *Runner) clearConnectionsIfIdle() {
+	if r.RunningCount() > 0 {
+		return
+	}
+	r.mu.Lock()
+	r.conns = make(map[int]cloudflared.Connection)
+	r.mu.Unlock()
+}
+
+// Initialize starts the idle watch and the stats writer, hooks connection
+// tracking into the log stream, auto-starts every local-enabled profile that
+// requests it, and arms the lazy-start profiles instead of starting them.
 func (r *Runner) Initialize() {
+	r.startIdleWatch()
+	r.goBackground(r.persistStats)
+	if b := logger.GetBroadcaster(); b != nil {
+		b.Observe(r.ObserveLogLine)
+	}
+	if autoStartSuppressed() {
+		log().Warn("Tunnel auto-start suppressed by CFUI_NO_AUTOSTART for this boot")
+		return
+	}
 	cfg := r.cfgMgr.Get()
 	for _, profile := range cfg.Tunnels {
-		if !profile.LocalEnabled || !profile.AutoStart || profile.Token == "" {
+		if !profile.LocalEnabled || profile.Token == "" {
 			continue
 		}
-		logger.Sugar.Infof("Auto-starting tunnel %q...", profile.Key)
+		if profile.LazyStart {
+			r.armLazy(profile.Key)
+			log().Infof("Tunnel %q will start on its first wake request", profile.Key)
+			continue
+		}
+		if !profile.AutoStart {
+			continue
+		}
+		log().Infof("Auto-starting tunnel %q...", profile.Key)
 		if err := r.StartProfile(profile.Key); err != nil {
-			logger.Sugar.Errorf("Failed to auto-start tunnel %q: %v", profile.Key, err)
+			log().Errorf("Failed to auto-start tunnel %q: %v", profile.Key, err)
 		}
 	}
 }
 
-// Shutdown stops all tunnels concurrently and broadcasts a process-wide
-// graceful shutdown to the embedded cloudflared runtime. Call only on
-// application exit.
+// autoStartSuppressed reports whether CFUI_NO_AUTOSTART asks to boot with
+// every tunnel down. The persisted AutoStart settings are left untouched.
+func autoStartSuppressed() bool {
+	suppressed, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("CFUI_NO_AUTOSTART")))
+	return suppressed
+}
+
+// Shutdown stops all tunnels concurrently, then the runner's background
+// goroutines (idle watch, metrics poller, stats writer), waiting for each to
+// exit, writes the lifetime stats one last time, and broadcasts a
+// process-wide graceful shutdown to the embedded cloudflared runtime. Call
+// only on application exit.
 func (r *Runner) Shutdown() error {
-	logger.Sugar.Info("Shutting down runner...")
+	log().Info("Shutting down runner...")
 
 	r.mu.Lock()
 	insts := make([]*cloudflared.Instance, 0, len(r.insts))
@@ -246,14 +652,26 @@ func (r *Runner) Shutdown() error {
 		wg.Add(1)
 		go func(in *cloudflared.Instance) {
 			defer wg.Done()
-			if err := in.Stop(); err != nil {
-				logger.Sugar.Warnf("Error stopping tunnel %q during shutdown: %v", in.Name(), err)
+			if err := in.StopWithReason(cloudflared.StopShutdown); err != nil {
+				log().Warnf("Error stopping tunnel %q during shutdown: %v", in.Name(), err)
 			}
 		}(inst)
 	}
 	wg.Wait()
+
+	// Cancel the background goroutines together and wait for all of them,
+	// so none outlives the runner.
+	r.bgMu.Lock()
+	r.bgCancel()
+	r.bgMu.Unlock()
+	r.bgWG.Wait()
+	r.stopIdleWatch()
+	r.metrics.Stop()
+	if r.cfgMgr.Get().StatsPersistDuration() > 0 {
+		r.flushStats()
+	}
 	cloudflared.ShutdownProcess()
 
-	logger.Sugar.Info("Runner shutdown complete")
+	log().Info("Runner shutdown complete")
 	return nil
 }
@@ -0,0 +1,588 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/config"
+	"cfui/internal/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	cfgMgr, err := config.NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return NewRunner(cfgMgr)
+}
+
+func TestRunnerLogLinesCarryRunnerComponent(t *testing.T) {
+	t.Setenv("CFUI_NO_AUTOSTART", "true")
+	prev := logger.Sugar
+	if err := logger.Initialize(&logger.Config{LogDir: t.TempDir(), LogLevel: "info"}); err != nil {
+		t.Fatalf("Initialize logger: %v", err)
+	}
+	t.Cleanup(func() {
+		logger.Shutdown()
+		logger.Sugar = prev
+	})
+	broadcaster := logger.GetBroadcaster()
+
+	r := newTestRunner(t)
+	r.Initialize()
+	defer r.metrics.Stop()
+
+	for _, entry := range broadcaster.RecentEntries() {
+		if !strings.Contains(entry.Line, "CFUI_NO_AUTOSTART") {
+			continue
+		}
+		if entry.Component != logger.ComponentRunner || !strings.Contains(entry.Line, `"component":"cfui.runner"`) {
+			t.Fatalf("runner line tagged %q: %s", entry.Component, entry.Line)
+		}
+		return
+	}
+	t.Fatalf("runner line not broadcast: %v", broadcaster.GetRecentLogs())
+}
+
+func TestRunnerTracksConnectionsFromLogLines(t *testing.T) {
+	r := newTestRunner(t)
+	lines := []string{
+		"2026-03-01T10:00:00Z INF Starting tunnel tunnelID=abc",
+		"2026-03-01T10:00:01Z INF Registered tunnel connection connIndex=1 connection=bbbb event=0 ip=198.41.200.23 location=lax01 protocol=http2",
+		"2026-03-01T10:00:00Z INF Registered tunnel connection connIndex=0 connection=aaaa event=0 ip=198.41.192.7 location=sjc08 protocol=quic",
+		`{"level":"info","connIndex":2,"connection":"cccc","event":0,"ip":"198.41.200.33","location":"sjc07","protocol":"quic","time":"2026-03-01T10:00:02Z","message":"Registered tunnel connection"}`,
+		"2026-03-01T10:05:00Z INF Unregistered tunnel connection connIndex=2 event=0 ip=198.41.200.33",
+	}
+	for _, line := range lines {
+		r.ObserveLogLine(line)
+	}
+
+	want := []cloudflared.Connection{
+		{Index: 0, ID: "aaaa", Location: "sjc08", IP: "198.41.192.7", Protocol: "quic", EstablishedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{Index: 1, ID: "bbbb", Location: "lax01", IP: "198.41.200.23", Protocol: "http2", EstablishedAt: time.Date(2026, 3, 1, 10, 0, 1, 0, time.UTC)},
+	}
+	got := r.Connections()
+	if len(got) != len(want) {
+		t.Fatalf("Connections() = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if !got[i].EstablishedAt.Equal(want[i].EstablishedAt) {
+			t.Fatalf("connection %d established at %v, want %v", i, got[i].EstablishedAt, want[i].EstablishedAt)
+		}
+		got[i].EstablishedAt, want[i].EstablishedAt = time.Time{}, time.Time{}
+		if got[i] != want[i] {
+			t.Fatalf("connection %d = %#v, want %#v", i, got[i], want[i])
+		}
+	}
+
+	// With no tunnel running, the stale table is dropped.
+	r.clearConnectionsIfIdle()
+	if got := r.Connections(); len(got) != 0 {
+		t.Fatalf("connections not cleared when idle: %#v", got)
+	}
+}
+
+func TestInitializeHonorsNoAutoStartOverride(t *testing.T) {
+	t.Setenv("CFUI_NO_AUTOSTART", "true")
+	core, logs := observer.New(zap.WarnLevel)
+	prev := logger.Sugar
+	logger.Sugar = zap.New(core).Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	r := newTestRunner(t)
+	cfg := r.cfgMgr.Get()
+	cfg.Token = "auto-start-token"
+	cfg.AutoStart = true
+	if err := r.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save config: %v", err)
+	}
+
+	r.Initialize()
+	defer r.metrics.Stop()
+
+	r.mu.Lock()
+	started := len(r.insts)
+	r.mu.Unlock()
+	if started != 0 {
+		t.Fatalf("started %d tunnels despite CFUI_NO_AUTOSTART", started)
+	}
+	if logs.FilterMessageSnippet("CFUI_NO_AUTOSTART").Len() != 1 {
+		t.Fatalf("suppression was not logged: %v", logs.All())
+	}
+	if !r.cfgMgr.Get().AutoStart {
+		t.Fatal("override must not change the persisted AutoStart setting")
+	}
+}
+
+func TestPendingRestartReportsFieldsChangedWhileRunning(t *testing.T) {
+	r := newTestRunner(t)
+	cfg := r.cfgMgr.Get()
+	cfg.SoleLogSink = true
+	if err := r.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true, Protocol: "http2",
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	if _, err := r.instanceFor("home"); err != nil {
+		t.Fatalf("instanceFor: %v", err)
+	}
+	profile, _ := r.cfgMgr.Get().TunnelProfile("home")
+	started, err := r.optionsFor("home")
+	if err != nil {
+		t.Fatalf("optionsFor: %v", err)
+	}
+	r.runningOptions = func(*cloudflared.Instance) (cloudflared.Options, bool) { return started, true }
+
+	if pending := r.PendingRestart(); len(pending) != 0 {
+		t.Fatalf("unchanged config reported pending restart: %v", pending)
+	}
+
+	profile.Protocol = "quic"
+	profile.Name = "Home lab" // log label only, applies without a restart
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", profile); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	pending := r.PendingRestart()
+	if got := pending["home"]; len(got) != 1 || got[0] != "protocol" {
+		t.Fatalf("pending restart = %v, want home: [protocol]", pending)
+	}
+
+	r.runningOptions = func(*cloudflared.Instance) (cloudflared.Options, bool) { return cloudflared.Options{}, false }
+	if pending := r.PendingRestart(); len(pending) != 0 {
+		t.Fatalf("stopped tunnel reported pending restart: %v", pending)
+	}
+}
+
+func TestPendingRestartIgnoresUnchangedMaxRuntime(t *testing.T) {
+	r := newTestRunner(t)
+	cfg := r.cfgMgr.Get()
+	cfg.MaxRuntime = "24h"
+	if err := r.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true,
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	if _, err := r.instanceFor("home"); err != nil {
+		t.Fatalf("instanceFor: %v", err)
+	}
+	started, err := r.optionsFor("home")
+	if err != nil {
+		t.Fatalf("optionsFor: %v", err)
+	}
+	r.runningOptions = func(*cloudflared.Instance) (cloudflared.Options, bool) { return started, true }
+
+	if pending := r.PendingRestart(); len(pending) != 0 {
+		t.Fatalf("unchanged max_runtime reported pending restart: %v", pending)
+	}
+
+	cfg = r.cfgMgr.Get()
+	cfg.MaxRuntime = "48h"
+	if err := r.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	pending := r.PendingRestart()
+	if got := pending["home"]; len(got) != 1 || got[0] != "max_runtime" {
+		t.Fatalf("pending restart = %v, want home: [max_runtime]", pending)
+	}
+}
+
+func TestPendingRestartIgnoresUnchangedEdgeIPFallback(t *testing.T) {
+	r := newTestRunner(t)
+	cfg := r.cfgMgr.Get()
+	cfg.EdgeIPFallback = true
+	if err := r.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true, EdgeIPVersion: "6",
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	if _, err := r.instanceFor("home"); err != nil {
+		t.Fatalf("instanceFor: %v", err)
+	}
+	started, err := r.optionsFor("home")
+	if err != nil {
+		t.Fatalf("optionsFor: %v", err)
+	}
+	r.runningOptions = func(*cloudflared.Instance) (cloudflared.Options, bool) { return started, true }
+
+	if pending := r.PendingRestart(); len(pending) != 0 {
+		t.Fatalf("unchanged edge_ip_fallback reported pending restart: %v", pending)
+	}
+
+	cfg = r.cfgMgr.Get()
+	cfg.EdgeIPFallback = false
+	if err := r.cfgMgr.Save(cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	pending := r.PendingRestart()
+	if got := pending["home"]; len(got) != 1 || got[0] != "edge_ip_fallback" {
+		t.Fatalf("pending restart = %v, want home: [edge_ip_fallback]", pending)
+	}
+}
+
+func TestCheckIdleStopsTunnelWithoutTraffic(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	prev := logger.Sugar
+	logger.Sugar = zap.New(core).Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	r := newTestRunner(t)
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true, IdleTimeout: "5m",
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	if _, err := r.instanceFor("home"); err != nil {
+		t.Fatalf("instanceFor: %v", err)
+	}
+	r.runningOptions = func(*cloudflared.Instance) (cloudflared.Options, bool) { return cloudflared.Options{}, true }
+
+	reg := prometheus.NewRegistry()
+	requests := prometheus.NewCounter(prometheus.CounterOpts{Name: metricTotalRequests})
+	reg.MustRegister(requests)
+	r.gatherer = reg
+
+	var stopped []string
+	r.stopIdle = func(key string) error {
+		stopped = append(stopped, key)
+		return nil
+	}
+
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	r.checkIdle(start)
+	r.checkIdle(start.Add(4 * time.Minute))
+	requests.Inc() // traffic restarts the clock
+	r.checkIdle(start.Add(6 * time.Minute))
+	r.checkIdle(start.Add(10 * time.Minute))
+	if len(stopped) != 0 {
+		t.Fatalf("tunnel with recent traffic was stopped: %v", stopped)
+	}
+
+	r.checkIdle(start.Add(11 * time.Minute))
+	if len(stopped) != 1 || stopped[0] != "home" {
+		t.Fatalf("stopped = %v, want [home]", stopped)
+	}
+	if logs.FilterMessageSnippet("idle timeout").Len() != 1 {
+		t.Fatalf("idle stop was not logged: %v", logs.All())
+	}
+}
+
+func TestEventsKeepNewestWithinBound(t *testing.T) {
+	r := newTestRunner(t)
+	for i := 0; i < eventBufferSize+5; i++ {
+		r.events.record("home", cloudflared.EventRestart, fmt.Sprintf("attempt %d", i))
+	}
+	r.ObserveLogLine("2026-03-01T10:00:00Z INF Registered tunnel connection connIndex=0 connection=aaaa event=0 ip=198.41.192.7 location=sjc08 protocol=quic")
+
+	if got := len(r.Events(0)); got != eventBufferSize {
+		t.Fatalf("retained %d events, want %d", got, eventBufferSize)
+	}
+	last := r.Events(2)
+	if len(last) != 2 || last[0].Detail != fmt.Sprintf("attempt %d", eventBufferSize+4) {
+		t.Fatalf("Events(2) = %+v", last)
+	}
+	if last[1].Type != cloudflared.EventConnected || last[1].Tunnel != "" {
+		t.Fatalf("newest event = %+v, want an untagged connected event", last[1])
+	}
+}
+
+func TestLazyStartProfileStartsOnFirstWake(t *testing.T) {
+	t.Setenv("CFUI_NO_AUTOSTART", "")
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	r := newTestRunner(t)
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true, LazyStart: true,
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	var started []string
+	r.startLazy = func(key string) error {
+		started = append(started, key)
+		return nil
+	}
+
+	r.Initialize()
+	t.Cleanup(func() {
+		r.stopIdleWatch()
+		r.metrics.Stop()
+	})
+	if len(started) != 0 {
+		t.Fatalf("lazy profile started at launch: %v", started)
+	}
+	if ok, err := r.Wake("default"); ok || err != nil {
+		t.Fatalf("Wake(default) = %v, %v; want no start for a profile that is not lazy", ok, err)
+	}
+
+	if ok, err := r.Wake("home"); !ok || err != nil {
+		t.Fatalf("Wake(home) = %v, %v; want started", ok, err)
+	}
+	if ok, _ := r.Wake("home"); ok {
+		t.Fatal("second wake started the tunnel again")
+	}
+	if len(started) != 1 || started[0] != "home" {
+		t.Fatalf("started = %v, want [home]", started)
+	}
+}
+
+func TestRestartForProtocolSavesProtocolOfStoppedTunnel(t *testing.T) {
+	r := newTestRunner(t)
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true, Protocol: "quic",
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	r.stopWithin = func(key string, _ cloudflared.StopReason, _ time.Duration) error {
+		t.Errorf("stopped %q, want a stopped tunnel left alone", key)
+		return nil
+	}
+
+	if err := r.RestartForProtocol("home", "http2"); err != nil {
+		t.Fatalf("RestartForProtocol: %v", err)
+	}
+	if profile, _ := r.cfgMgr.Get().TunnelProfile("home"); profile.Protocol != "http2" {
+		t.Fatalf("saved protocol = %q, want http2", profile.Protocol)
+	}
+	if st, _ := r.ProfileStatus("home"); st.Running {
+		t.Fatal("RestartForProtocol started a stopped tunnel")
+	}
+	if err := r.RestartForProtocol("home", "udp"); !errors.Is(err, config.ErrInvalidConfig) {
+		t.Fatalf("unknown protocol error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestRestartForProtocolUsesShortStopWait(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	r := newTestRunner(t)
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true, Protocol: "quic",
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	inst, err := r.instanceFor("home")
+	if err != nil {
+		t.Fatalf("instanceFor: %v", err)
+	}
+	r.runningOptions = func(*cloudflared.Instance) (cloudflared.Options, bool) { return cloudflared.Options{}, true }
+	stuck := errors.New(`timeout waiting for tunnel "home" to stop`)
+	var gotTimeout time.Duration
+	var stops int
+	r.stopWithin = func(_ string, _ cloudflared.StopReason, timeout time.Duration) error {
+		stops++
+		gotTimeout = timeout
+		return stuck
+	}
+
+	if err := r.RestartForProtocol("home", "http2"); !errors.Is(err, stuck) {
+		t.Fatalf("RestartForProtocol error = %v, want the stop error", err)
+	}
+	if stops != 1 || gotTimeout != ProtocolRestartStopTimeout {
+		t.Fatalf("stopped %d times waiting %v, want once with %v", stops, gotTimeout, ProtocolRestartStopTimeout)
+	}
+	if inst.Status().Running {
+		t.Fatal("a new run was started while the old one had not exited")
+	}
+	for _, event := range r.Events(0) {
+		if event.Type == cloudflared.EventStart {
+			t.Fatalf("start event recorded after a stuck stop: %+v", event)
+		}
+	}
+}
+
+func TestRestartDoesNotStartWhenStopTimesOut(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	r := newTestRunner(t)
+	if _, err := r.cfgMgr.SaveTunnelProfile("home", config.TunnelProfileConfig{
+		Key: "home", Name: "Home", Token: "token", LocalEnabled: true,
+	}); err != nil {
+		t.Fatalf("SaveTunnelProfile: %v", err)
+	}
+	stuck := errors.New(`timeout waiting for tunnel "home" to stop`)
+	var gotTimeout time.Duration
+	r.stopWithin = func(_ string, _ cloudflared.StopReason, timeout time.Duration) error {
+		gotTimeout = timeout
+		return stuck
+	}
+
+	if err := r.restart("home", ProtocolRestartStopTimeout); !errors.Is(err, stuck) {
+		t.Fatalf("restart error = %v, want the stop error", err)
+	}
+	if gotTimeout != ProtocolRestartStopTimeout {
+		t.Fatalf("stop waited %v, want %v", gotTimeout, ProtocolRestartStopTimeout)
+	}
+	if err := r.RestartProfile("home"); !errors.Is(err, stuck) || gotTimeout != 0 {
+		t.Fatalf("RestartProfile = %v with stop timeout %v, want the stop error and the default wait", err, gotTimeout)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.insts["home"] != nil {
+		t.Fatal("a new run was started while the old one had not exited")
+	}
+}
+
+func TestShutdownStopsBackgroundGoroutines(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	r := newTestRunner(t)
+	baseline := runtime.NumGoroutine()
+	r.gatherer = prometheus.NewRegistry()
+	r.metrics.interval = func() time.Duration { return time.Hour }
+	r.metrics.active = func() bool { return true }
+
+	r.Initialize()
+	r.instanceEvent("home", cloudflared.EventStart, "home")
+	if !r.metrics.Polling() {
+		t.Fatal("metrics poller did not start")
+	}
+	if err := r.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d after Shutdown, want at most %d", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	// Nothing may start a new background goroutine once shut down.
+	r.startIdleWatch()
+	r.metrics.Start()
+	if r.metrics.Polling() || runtime.NumGoroutine() > baseline {
+		t.Fatalf("background work restarted after Shutdown (%d goroutines, want at most %d)", runtime.NumGoroutine(), baseline)
+	}
+}
+
+func TestGoBackgroundDoesNotTakeRunnerLock(t *testing.T) {
+	r := newTestRunner(t)
+	// The metrics poller spawns from instance events, which run under the
+	// instance lock; RunningCount takes that lock while holding r.mu.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.goBackground(func(context.Context) {})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("goBackground blocked on the runner lock")
+	}
+}
+
+func TestLifetimeStatsSurviveRestart(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	dir := t.TempDir()
+	newRunner := func() *Runner {
+		cfgMgr, err := config.NewManager(dir)
+		if err != nil {
+			t.Fatalf("NewManager: %v", err)
+		}
+		return NewRunner(cfgMgr)
+	}
+
+	r := newRunner()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	r.stats.now = func() time.Time { return now }
+	r.metrics.interval = func() time.Duration { return 0 }
+	r.instanceEvent("home", cloudflared.EventStart, "home")
+	now = now.Add(90 * time.Second)
+	r.instanceEvent("home", cloudflared.EventProtocolSwitch, "quic -> http2")
+	r.instanceEvent("home", cloudflared.EventError, "connection lost")
+	r.instanceEvent("home", cloudflared.EventRestart, "attempt 1 in 1s")
+	r.instanceEvent("home", cloudflared.EventStart, "home")
+	now = now.Add(30 * time.Second)
+	if err := r.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	// The tunnel never reported a stop, so the final write counts the uptime
+	// it had reached.
+	want := r.LifetimeStats()
+	if want.Restarts != 1 || want.ProtocolSwitches != 1 || want.UptimeSeconds != 120 {
+		t.Fatalf("stats before restart = %+v, want 1 restart, 1 protocol switch, 120s uptime", want)
+	}
+
+	reloaded := newRunner()
+	got := reloaded.LifetimeStats()
+	if got.Restarts != want.Restarts || got.ProtocolSwitches != want.ProtocolSwitches ||
+		got.UptimeSeconds != want.UptimeSeconds || !got.Since.Equal(want.Since) {
+		t.Fatalf("stats after reload = %+v, want %+v", got, want)
+	}
+
+	// Unchanged stats are not written again.
+	path := filepath.Join(dir, statsFile)
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove %s: %v", path, err)
+	}
+	if err := reloaded.stats.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("flush without changes wrote %s (stat err %v)", path, err)
+	}
+}
+
+func TestLoadStatsMovesCorruptFileAside(t *testing.T) {
+	path := filepath.Join(t.TempDir(), statsFile)
+	corrupt := []byte(`{"restarts": 12, "uptime_seconds":`)
+	if err := os.WriteFile(path, corrupt, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := loadStats(path)
+	if err == nil {
+		t.Fatal("loadStats accepted a truncated file")
+	}
+	if got := s.snapshot(); got.Restarts != 0 || got.Since.IsZero() {
+		t.Fatalf("stats = %+v, want fresh counters", got)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("corrupt file still at %s (stat err %v)", path, err)
+	}
+	if kept, err := os.ReadFile(path + corruptStatsSuffix); err != nil || string(kept) != string(corrupt) {
+		t.Fatalf("moved-aside file = %q, %v; want the original bytes", kept, err)
+	}
+
+	// The next flush writes a fresh file without touching the kept copy.
+	if err := s.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if _, err := loadStats(path); err != nil {
+		t.Fatalf("reload after flush: %v", err)
+	}
+}
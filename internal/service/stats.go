@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/config"
+)
+
+// statsFile is the name of the lifetime stats file in the data directory.
+const statsFile = "stats.json"
+
+// corruptStatsSuffix is appended to an unreadable stats file when it is moved
+// aside.
+const corruptStatsSuffix = ".corrupt"
+
+// LifetimeStats are cumulative tunnel figures across all profiles that
+// survive process restarts. Since is when counting began.
+type LifetimeStats struct {
+	Restarts         int64     `json:"restarts"`
+	ProtocolSwitches int64     `json:"protocol_switches"`
+	UptimeSeconds    float64   `json:"uptime_seconds"`
+	Since            time.Time `json:"since"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// statsStore accumulates LifetimeStats from instance events and writes them
+// to path. Writes are debounced: flush only touches the disk when something
+// changed since the last write.
+type statsStore struct {
+	path string
+	now  func() time.Time
+
+	mu    sync.Mutex
+	stats LifetimeStats
+	// running holds the start of the uncounted part of each running
+	// profile's uptime.
+	running map[string]time.Time
+	dirty   bool
+}
+
+// loadStats reads the stats persisted at path. A missing file starts the
+// counters from zero; an unreadable one does too, but is reported and moved
+// aside to path+".corrupt" so the next flush does not overwrite it.
+func loadStats(path string) (*statsStore, error) {
+	s := &statsStore{path: path, now: time.Now, running: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &s.stats)
+	}
+	if err != nil {
+		s.stats = LifetimeStats{}
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		} else if renameErr := os.Rename(path, path+corruptStatsSuffix); renameErr != nil {
+			err = errors.Join(err, renameErr)
+		} else {
+			err = fmt.Errorf("%w (moved to %s)", err, path+corruptStatsSuffix)
+		}
+	}
+	if s.stats.Since.IsZero() {
+		s.stats.Since = s.now().UTC()
+		s.dirty = true
+	}
+	return s, err
+}
+
+// record folds one instance event into the counters.
+func (s *statsStore) record(key string, typ cloudflared.EventType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	switch typ {
+	case cloudflared.EventRestart:
+		s.stats.Restarts++
+	case cloudflared.EventProtocolSwitch:
+		s.stats.ProtocolSwitches++
+	case cloudflared.EventStart:
+		s.countUptimeLocked(key, now)
+		s.running[key] = now
+	case cloudflared.EventStop, cloudflared.EventError, cloudflared.EventFatal:
+		s.countUptimeLocked(key, now)
+		delete(s.running, key)
+	default:
+		return
+	}
+	s.dirty = true
+}
+
+// countUptimeLocked adds the uncounted uptime of a running profile and
+// restarts its clock at now.
+func (s *statsStore) countUptimeLocked(key string, now time.Time) {
+	since, ok := s.running[key]
+	if !ok {
+		return
+	}
+	s.stats.UptimeSeconds += now.Sub(since).Seconds()
+	s.running[key] = now
+	s.dirty = true
+}
+
+// snapshot returns the current figures, including the uptime of tunnels
+// that are still running.
+func (s *statsStore) snapshot() LifetimeStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	now := s.now()
+	for _, since := range s.running {
+		stats.UptimeSeconds += now.Sub(since).Seconds()
+	}
+	return stats
+}
+
+// flush writes the stats if they changed since the last write. The file is
+// replaced atomically so a crash mid-write keeps the previous figures.
+func (s *statsStore) flush() error {
+	s.mu.Lock()
+	now := s.now()
+	for key := range s.running {
+		s.countUptimeLocked(key, now)
+	}
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stats.UpdatedAt = now.UTC()
+	data, err := json.MarshalIndent(s.stats, "", "  ")
+	s.dirty = false
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+statsFile+"-*.tmp")
+	if err == nil {
+		tmpPath := tmp.Name()
+		_, err = tmp.Write(data)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmpPath, s.path)
+		}
+		if err != nil {
+			_ = os.Remove(tmpPath)
+		}
+	}
+	if err != nil {
+		// Keep the changes pending so the next flush retries them.
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// LifetimeStats returns the cumulative restart, protocol switch and uptime
+// figures of all tunnels, including those from earlier runs of cfui.
+func (r *Runner) LifetimeStats() LifetimeStats {
+	return r.stats.snapshot()
+}
+
+// persistStats writes the lifetime stats every StatsPersistInterval until
+// ctx is canceled. The interval is re-read after each write, so a changed
+// setting takes effect without a restart; "0" suspends writing.
+func (r *Runner) persistStats(ctx context.Context) {
+	for {
+		interval := r.cfgMgr.Get().StatsPersistDuration()
+		wait := interval
+		if wait == 0 {
+			wait = config.DefaultStatsPersistInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if interval > 0 {
+			r.flushStats()
+		}
+	}
+}
+
+// flushStats writes pending lifetime stats, logging a failure.
+func (r *Runner) flushStats() {
+	if err := r.stats.flush(); err != nil {
+		log().Warnf("Failed to persist tunnel stats to %s: %v", r.stats.path, err)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/version"
+)
+
+// Kinds of telemetry reports.
+const (
+	TelemetryNonRetryableError = "non_retryable_error"
+	TelemetryProtocolFallback  = "protocol_fallback"
+)
+
+const telemetryTimeout = 10 * time.Second
+
+// TelemetryReport is the complete payload POSTed to CFUI_TELEMETRY_URL. It is
+// built only from fixed vocabularies: no token, hostname, tunnel name or
+// key, account ID, IP address, or error text is ever included.
+type TelemetryReport struct {
+	// Kind is TelemetryNonRetryableError or TelemetryProtocolFallback.
+	Kind string `json:"kind"`
+	// Reason is the matched non-retryable error pattern, e.g. "invalid token".
+	Reason string `json:"reason,omitempty"`
+	// FromProtocol and ToProtocol describe a fallback (quic or http2).
+	FromProtocol string `json:"from_protocol,omitempty"`
+	ToProtocol   string `json:"to_protocol,omitempty"`
+	Version      string `json:"version"`
+	OS           string `json:"os"`
+	Arch         string `json:"arch"`
+}
+
+// TelemetryReporter sends anonymized diagnostics for tunnel failures. It is
+// off unless CFUI_TELEMETRY is true and CFUI_TELEMETRY_URL is set.
+type TelemetryReporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// TelemetryFromEnv returns a reporter when telemetry is enabled, or nil.
+func TelemetryFromEnv() *TelemetryReporter {
+	enabled, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("CFUI_TELEMETRY")))
+	if !enabled {
+		return nil
+	}
+	endpoint := strings.TrimSpace(os.Getenv("CFUI_TELEMETRY_URL"))
+	if endpoint == "" {
+		log().Warn("CFUI_TELEMETRY is enabled but CFUI_TELEMETRY_URL is empty; telemetry stays off")
+		return nil
+	}
+	return &TelemetryReporter{endpoint: endpoint, client: &http.Client{Timeout: telemetryTimeout}}
+}
+
+// Observe reports qualifying lifecycle events in the background. Register it
+// with Runner.OnEvent.
+func (t *TelemetryReporter) Observe(ev Event) {
+	report, ok := telemetryReportFor(ev)
+	if !ok {
+		return
+	}
+	go func() {
+		if err := t.send(context.Background(), report); err != nil {
+			log().Debugf("Telemetry report failed: %v", err)
+		}
+	}()
+}
+
+// telemetryReportFor maps a lifecycle event onto a report. Only fatal errors
+// and protocol fallbacks qualify.
+func telemetryReportFor(ev Event) (TelemetryReport, bool) {
+	report := TelemetryReport{
+		Version: version.GetVersion(),
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+	}
+	switch ev.Type {
+	case cloudflared.EventFatal:
+		report.Kind = TelemetryNonRetryableError
+		report.Reason = cloudflared.NonRetryableReason(errors.New(ev.Detail))
+	case cloudflared.EventProtocolSwitch:
+		from, to, ok := strings.Cut(ev.Detail, " -> ")
+		if !ok || !telemetryProtocol(from) || !telemetryProtocol(to) {
+			return TelemetryReport{}, false
+		}
+		report.Kind = TelemetryProtocolFallback
+		report.FromProtocol, report.ToProtocol = from, to
+	default:
+		return TelemetryReport{}, false
+	}
+	return report, true
+}
+
+func telemetryProtocol(p string) bool {
+	return p == "quic" || p == "http2" || p == "auto"
+}
+
+func (t *TelemetryReporter) send(ctx context.Context, report TelemetryReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, telemetryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned %s", resp.Status)
+	}
+	return nil
+}
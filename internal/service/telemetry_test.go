@@ -0,0 +1,70 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cfui/internal/cloudflared"
+	"cfui/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestTelemetryReportsScrubbedPayloadOnlyWhenEnabled(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	bodies := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	defer srv.Close()
+	t.Setenv("CFUI_TELEMETRY_URL", srv.URL)
+
+	t.Setenv("CFUI_TELEMETRY", "")
+	if TelemetryFromEnv() != nil {
+		t.Fatal("telemetry must be off by default")
+	}
+	t.Setenv("CFUI_TELEMETRY", "false")
+	if TelemetryFromEnv() != nil {
+		t.Fatal("telemetry must stay off when disabled")
+	}
+
+	t.Setenv("CFUI_TELEMETRY", "true")
+	reporter := TelemetryFromEnv()
+	if reporter == nil {
+		t.Fatal("telemetry should be on with CFUI_TELEMETRY=true and an endpoint")
+	}
+	const token = "eyJhIjoiYWNjb3VudCIsInQiOiJ0dW5uZWwiLCJzIjoic2VjcmV0In0"
+	reporter.Observe(Event{Type: cloudflared.EventStart, Tunnel: "home"})
+	reporter.Observe(Event{
+		Type:   cloudflared.EventFatal,
+		Tunnel: "home",
+		Detail: "Provided Tunnel token is not valid: " + token + " for nas.example.com",
+	})
+
+	select {
+	case body := <-bodies:
+		for _, secret := range []string{token, "example.com", "home"} {
+			if strings.Contains(body, secret) {
+				t.Fatalf("payload %s leaks %q", body, secret)
+			}
+		}
+		if !strings.Contains(body, `"kind":"non_retryable_error"`) || !strings.Contains(body, `"reason":"token is not valid"`) {
+			t.Fatalf("payload = %s", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fatal event was not reported")
+	}
+	select {
+	case body := <-bodies:
+		t.Fatalf("start event was reported: %s", body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
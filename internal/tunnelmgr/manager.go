@@ -11,8 +11,15 @@ import (
 	"time"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
+	"go.uber.org/zap"
 )
 
+// log returns the package logger, named cfui.tunnelmgr so broadcast lines can
+// be filtered by component.
+func log() *zap.SugaredLogger {
+	return logger.Named(logger.ComponentTunnelMgr)
+}
+
 var (
 	ErrDisabled = stderrors.New("tunnel management is disabled")
 )
@@ -483,7 +490,7 @@ func (m *Manager) AddEntryFor(ctx context.Context, tunnelKey string, entry Ingre
 	})
 	if err == nil && strings.TrimSpace(entry.Hostname) != "" {
 		if dnsErr := m.syncDNSForHostnameWithCommentFor(ctx, tunnelKey, entry.Hostname, entry.Comment); dnsErr != nil {
-			logger.Sugar.Warnf("Tunnel config updated but DNS sync failed for %s: %v. Create a CNAME record manually: %s → %s.cfargotunnel.com", entry.Hostname, dnsErr, entry.Hostname, tunnelCfg.TunnelID)
+			log().Warnf("Tunnel config updated but DNS sync failed for %s: %v. Create a CNAME record manually: %s → %s.cfargotunnel.com", entry.Hostname, dnsErr, entry.Hostname, tunnelCfg.TunnelID)
 		}
 	}
 	return resp, err
@@ -511,7 +518,7 @@ func (m *Manager) UpdateEntryFor(ctx context.Context, tunnelKey string, index in
 	})
 	if err == nil && strings.TrimSpace(entry.Hostname) != "" {
 		if dnsErr := m.syncDNSForHostnameWithCommentFor(ctx, tunnelKey, entry.Hostname, entry.Comment); dnsErr != nil {
-			logger.Sugar.Warnf("Tunnel config updated but DNS sync failed for %s: %v. Create a CNAME record manually: %s → %s.cfargotunnel.com", entry.Hostname, dnsErr, entry.Hostname, tunnelCfg.TunnelID)
+			log().Warnf("Tunnel config updated but DNS sync failed for %s: %v. Create a CNAME record manually: %s → %s.cfargotunnel.com", entry.Hostname, dnsErr, entry.Hostname, tunnelCfg.TunnelID)
 		}
 	}
 	return resp, err
@@ -729,7 +736,7 @@ func (m *Manager) syncDNSForHostnameWithCommentFor(ctx context.Context, tunnelKe
 		return fmt.Errorf("failed to create/update DNS CNAME record: %w", err)
 	}
 
-	logger.Sugar.Infof("DNS CNAME record synced: %s → %s", hostname, target)
+	log().Infof("DNS CNAME record synced: %s → %s", hostname, target)
 	return nil
 }
 
@@ -844,7 +851,7 @@ func (m *Manager) refreshTunnelProfileName(ctx context.Context, tunnelKey string
 	tunnel, err := client.GetTunnel(ctx, cloudflare.AccountIdentifier(cfg.AccountID), cfg.TunnelID)
 	if err != nil {
 		if logger.Sugar != nil {
-			logger.Sugar.Debugf("Failed to fetch Cloudflare tunnel name for %s: %v", cfg.TunnelID, err)
+			log().Debugf("Failed to fetch Cloudflare tunnel name for %s: %v", cfg.TunnelID, err)
 		}
 		return ""
 	}
@@ -878,7 +885,7 @@ func (m *Manager) applyFetchedTunnelName(tunnelKey, tunnelID, name string) {
 		}
 		tunnel.Name = name
 		if _, err := m.cfgMgr.SaveTunnelProfile(tunnel.Key, tunnel); err != nil && logger.Sugar != nil {
-			logger.Sugar.Warnf("Failed to save Cloudflare tunnel name for profile %s: %v", tunnel.Key, err)
+			log().Warnf("Failed to save Cloudflare tunnel name for profile %s: %v", tunnel.Key, err)
 		}
 		return
 	}
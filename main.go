@@ -11,9 +11,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -48,6 +52,18 @@ func main() {
 	if configDir == "" {
 		configDir = "./data"
 	}
+	// Fail early, with a clear message, on a data or log directory cfui
+	// cannot write; config and logging would otherwise die with raw errors.
+	allowTmp, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("CFUI_ALLOW_TMP_FALLBACK")))
+	var dirWarnings []string
+	configDir, warning, err := writableDir("DATA_DIR", configDir, allowTmp)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitDirUnwritable)
+	}
+	if warning != "" {
+		dirWarnings = append(dirWarnings, warning)
+	}
 
 	// Initialize logger first
 	// Support separate LOG_DIR for Docker volume mounting
@@ -57,26 +73,57 @@ func main() {
 	if logDir == "" {
 		logDir = filepath.Join(configDir, "logs")
 	}
+	logDir, warning, err = writableDir("LOG_DIR", logDir, allowTmp)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitDirUnwritable)
+	}
+	if warning != "" {
+		dirWarnings = append(dirWarnings, warning)
+	}
 
 	logConfig := &logger.Config{
-		LogDir:     logDir,
-		MaxSize:    100,  // 100 MB
-		MaxBackups: 10,   // keep 10 backups
-		MaxAge:     30,   // 30 days
-		Compress:   true, // compress old logs
-		LogLevel:   os.Getenv("LOG_LEVEL"),
+		LogDir:      logDir,
+		LogFileName: os.Getenv("LOG_FILE_NAME"),
+		MaxSize:     100,  // 100 MB
+		MaxBackups:  10,   // keep 10 backups
+		MaxAge:      30,   // 30 days
+		Compress:    true, // compress old logs
+		LogLevel:    os.Getenv("LOG_LEVEL"),
 	}
 	if logConfig.LogLevel == "" {
 		logConfig.LogLevel = "info"
 	}
+	// LOG_MAX_FILE_AGE (e.g. "24h") rotates a quiet log file by age.
+	rawMaxFileAge, maxFileAge, maxFileAgeErr := envDuration("LOG_MAX_FILE_AGE")
+	logConfig.MaxFileAge = maxFileAge
+	// LOG_DEDUP_WINDOW (e.g. "10s") collapses repeated lines in the UI.
+	rawDedupWindow, dedupWindow, dedupWindowErr := envDuration("LOG_DEDUP_WINDOW")
+	logConfig.DedupWindow = dedupWindow
 
 	if err := logger.Initialize(logConfig); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
+	// cloudflared writes some diagnostics straight to stderr, bypassing
+	// zap; mirror both standard streams into the log stream.
+	if restoreStdio, err := logger.CaptureStdio(logger.GetBroadcaster()); err != nil {
+		logger.Sugar.Warnf("Failed to capture stdout/stderr: %v", err)
+	} else {
+		defer restoreStdio()
+	}
 
 	logger.Sugar.Infof("Starting Cloudflared Web Controller %s", version.GetFullVersion())
 	logger.Sugar.Infof("Data directory: %s", configDir)
 	logger.Sugar.Infof("Log directory: %s", logConfig.LogDir)
+	for _, warning := range dirWarnings {
+		logger.Sugar.Warn(warning)
+	}
+	if maxFileAgeErr != nil {
+		logger.Sugar.Warnf("Ignoring invalid LOG_MAX_FILE_AGE %q: %v", rawMaxFileAge, maxFileAgeErr)
+	}
+	if dedupWindowErr != nil {
+		logger.Sugar.Warnf("Ignoring invalid LOG_DEDUP_WINDOW %q: %v", rawDedupWindow, dedupWindowErr)
+	}
 	runModeSelection := config.RunModeFromEnv()
 	if runModeSelection.InvalidRaw != "" {
 		logger.Sugar.Warnf("Invalid CFUI_RUN_MODE %q; falling back to %s", runModeSelection.InvalidRaw, runModeSelection.Mode)
@@ -90,15 +137,32 @@ func main() {
 	}
 	logger.Sugar.Info("Configuration manager initialized")
 
+	// CFUI_PANIC_MODE=crash lets a panic in a tunnel run take the process
+	// down, so a supervisor or debugger sees it.
+	panicMode, err := cloudflared.ParsePanicMode(os.Getenv("CFUI_PANIC_MODE"))
+	if err != nil {
+		logger.Sugar.Warnf("%v; using %s", err, panicMode)
+	}
+	cloudflared.SetPanicMode(panicMode)
+	if panicMode == cloudflared.PanicCrash {
+		logger.Sugar.Warn("Panic mode crash: a panic in a tunnel run stops cfui")
+	}
+
 	runner := service.NewRunner(cfgMgr)
+	if telemetry := service.TelemetryFromEnv(); telemetry != nil {
+		runner.OnEvent(telemetry.Observe)
+		logger.Sugar.Info("Anonymized failure telemetry enabled")
+	}
 
 	// Claim SIGTERM/SIGINT before any tunnel can start: the embedded
 	// cloudflared installs its own signal handlers per tunnel run, and with
 	// several runs they crash the process on shutdown (double close of the
 	// shared shutdown channel). cfui owns process signals exclusively;
 	// internal/cloudflared keeps re-asserting this registration.
+	// diagnosticsSignals (SIGUSR1 outside Windows) arrive on the same channel
+	// and only dump diagnostics.
 	shutdown := make(chan os.Signal, 1)
-	cloudflared.OwnProcessSignals(shutdown, os.Interrupt, syscall.SIGTERM)
+	cloudflared.OwnProcessSignals(shutdown, append([]os.Signal{os.Interrupt, syscall.SIGTERM}, diagnosticsSignals...)...)
 
 	if runModeSelection.Mode.AutoStartsLocalRunner() {
 		runner.Initialize()
@@ -115,25 +179,21 @@ func main() {
 	logger.Sugar.Info("DDNS service check complete")
 	srv.StartS3WebDAV()
 	logger.Sugar.Info("S3 WebDAV service check complete")
-	// Bind Host
-	bindHost := os.Getenv("BIND_HOST")
-	if bindHost == "" {
-		bindHost = "0.0.0.0"
-	}
-	// Run
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "14333"
-	}
-
-	serveAddr := fmt.Sprintf("%s:%s", bindHost, port)
+	bindHost, port := listenAddress(os.Getenv, cfgMgr.Get())
+	serveAddr := net.JoinHostPort(bindHost, port)
+	socketPath := strings.TrimSpace(os.Getenv("CFUI_UNIX_SOCKET"))
 
 	fmt.Printf("Cloudflared Web Controller %s\n", version.GetFullVersion())
 	fmt.Printf("Run mode: %s\n", runModeSelection.Mode)
-	fmt.Printf("Server listening on %s\n", serveAddr)
-	fmt.Printf("Local access: http://localhost:%s\n", port)
-	fmt.Printf("Network access: http://<your-ip>:%s\n", port)
-	logger.Sugar.Infof("Server starting on %s", serveAddr)
+	if socketPath != "" {
+		fmt.Printf("Server listening on unix socket %s\n", socketPath)
+		logger.Sugar.Infof("Server starting on unix socket %s", socketPath)
+	} else {
+		fmt.Printf("Server listening on %s\n", serveAddr)
+		fmt.Printf("Local access: http://localhost:%s\n", port)
+		fmt.Printf("Network access: http://<your-ip>:%s\n", port)
+		logger.Sugar.Infof("Server starting on %s", serveAddr)
+	}
 
 	// Create HTTP server with explicit configuration.
 	// WriteTimeout stays unset because /api/logs/stream keeps an SSE
@@ -143,51 +203,212 @@ func main() {
 		Handler:           srv.GetHandler(),
 		ReadHeaderTimeout: 10 * time.Second,
 		IdleTimeout:       2 * time.Minute,
+		MaxHeaderBytes:    server.MaxHeaderBytesFromEnv(),
 	}
 
-	// Channel to signal when server has shut down
-	serverErrors := make(chan error, 1)
+	serve := httpServer.ListenAndServe
+	if socketPath != "" {
+		// A reverse proxy on the same host needs no TCP port at all.
+		serve = func() error {
+			ln, err := listenUnix(socketPath)
+			if err != nil {
+				return err
+			}
+			return httpServer.Serve(ln)
+		}
+	}
 
-	// Start server in goroutine
-	go func() {
-		serverErrors <- httpServer.ListenAndServe()
-	}()
+	err = serveUntilShutdown(serve, shutdown, srv.DumpDiagnostics,
+		func(ctx context.Context) {
+			// Close long-lived SSE streams first so Shutdown doesn't stall
+			// until its timeout.
+			logger.Sugar.Info("Shutting down HTTP server...")
+			srv.PrepareShutdown(server.ShutdownDrainFromEnv())
+			if err := httpServer.Shutdown(ctx); err != nil {
+				logger.Sugar.Errorf("HTTP server shutdown error: %v", err)
+				httpServer.Close()
+			}
+		},
+		func(ctx context.Context) {
+			srv.StopDDNS()
+			if err := srv.StopS3WebDAV(ctx); err != nil {
+				logger.Sugar.Errorf("S3 WebDAV server shutdown error: %v", err)
+			}
+			// Stops every tunnel, including ones auto-started before a
+			// failed bind.
+			if err := runner.Shutdown(); err != nil {
+				logger.Sugar.Errorf("Runner shutdown error: %v", err)
+			}
+		})
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger.Sugar.Info("Graceful shutdown complete")
+}
 
-	// Block until we receive a signal or server error
-	select {
-	case sig := <-shutdown:
-		logger.Sugar.Infof("Received shutdown signal: %v", sig)
-
-		// Create context with timeout for shutdown
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		// Shutdown HTTP server gracefully. Close long-lived SSE streams
-		// first so Shutdown doesn't stall until its timeout.
-		logger.Sugar.Info("Shutting down HTTP server...")
-		srv.PrepareShutdown()
-		if err := httpServer.Shutdown(ctx); err != nil {
-			logger.Sugar.Errorf("HTTP server shutdown error: %v", err)
-			httpServer.Close()
-		}
+// Listen defaults when neither the environment nor the config sets them.
+const (
+	defaultBindHost = "0.0.0.0"
+	defaultPort     = "14333"
+)
 
-		// Stop DDNS service
-		srv.StopDDNS()
-		if err := srv.StopS3WebDAV(ctx); err != nil {
-			logger.Sugar.Errorf("S3 WebDAV server shutdown error: %v", err)
-		}
+// exitDirUnwritable is the exit code for an unwritable DATA_DIR or LOG_DIR,
+// EX_CANTCREAT from sysexits.h.
+const exitDirUnwritable = 73
+
+// writableDir makes sure dir, named by the environment variable name, exists
+// and accepts new files. When it does not and allowTmp is set, it returns a
+// fresh temporary directory instead, plus a warning to log. Otherwise the
+// error says which directory failed and how to fix it.
+func writableDir(name, dir string, allowTmp bool) (usable, warning string, err error) {
+	probeErr := probeWritable(dir)
+	if probeErr == nil {
+		return dir, "", nil
+	}
+	if !allowTmp {
+		return "", "", fmt.Errorf("%s %q is not writable: %v. "+
+			"Grant write access to user %d (for example chown the directory or mount the volume read-write) and check free disk space, "+
+			"or set CFUI_ALLOW_TMP_FALLBACK=true to start with a temporary directory", name, dir, probeErr, os.Getuid())
+	}
+	tmp, err := os.MkdirTemp("", "cfui-"+strings.ToLower(name)+"-")
+	if err != nil {
+		return "", "", fmt.Errorf("%s %q is not writable (%v) and no temporary directory could be created: %w", name, dir, probeErr, err)
+	}
+	warning = fmt.Sprintf("%s %q is not writable (%v); using temporary directory %s instead. "+
+		"Data written there is lost when it is cleaned up", name, dir, probeErr, tmp)
+	log.Printf("WARNING: %s", warning)
+	return tmp, warning, nil
+}
+
+// probeWritable creates dir if needed and writes and removes a small file in
+// it, so permission and disk-space problems both show up.
+func probeWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".cfui-write-check-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("ok")
+	return errors.Join(err, f.Close())
+}
+
+// envDuration parses the Go duration in the environment variable name. It
+// returns the raw value for error messages, and zero on an empty, invalid,
+// or negative value.
+func envDuration(name string) (raw string, d time.Duration, err error) {
+	raw = strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return raw, 0, nil
+	}
+	d, err = time.ParseDuration(raw)
+	if err == nil && d < 0 {
+		err = errors.New("must not be negative")
+	}
+	if err != nil {
+		return raw, 0, err
+	}
+	return raw, d, nil
+}
 
-		// Shutdown runner (stops tunnel if running)
-		if err := runner.Shutdown(); err != nil {
-			logger.Sugar.Errorf("Runner shutdown error: %v", err)
+// listenAddress resolves the HTTP listen host and port. The environment
+// (CFUI_BIND_ADDR, or the older BIND_HOST, and PORT) wins over the config,
+// which wins over the defaults. One pair of brackets around an IPv6 host
+// ("[::]") is dropped, since the port is joined on afterwards.
+func listenAddress(getenv func(string) string, cfg config.Config) (host, port string) {
+	host = getenv("CFUI_BIND_ADDR")
+	if host == "" {
+		host = getenv("BIND_HOST")
+	}
+	if host == "" {
+		host = cfg.ListenAddr
+	}
+	if host == "" {
+		host = defaultBindHost
+	}
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+	port = getenv("PORT")
+	if port == "" && cfg.ListenPort > 0 {
+		port = strconv.Itoa(cfg.ListenPort)
+	}
+	if port == "" {
+		port = defaultPort
+	}
+	return host, port
+}
+
+// unixSocketMode lets the socket's owner and group, such as a reverse proxy
+// sharing the group, connect; other users cannot.
+const unixSocketMode = 0o660
+
+// listenUnix listens on the Unix socket at path. A socket file left behind
+// by a crashed run is removed first; a socket another process still serves
+// on, or a path that is not a socket, is an error. The listener unlinks the
+// file again when closed.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
 		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("unix socket %s is already in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale unix socket %s: %w", path, err)
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, unixSocketMode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("set unix socket permissions: %w", err)
+	}
+	return ln, nil
+}
 
-		logger.Sugar.Info("Graceful shutdown complete")
+// serveUntilShutdown runs serve until a shutdown signal arrives or serve
+// fails. One of diagnosticsSignals only calls dumpDiagnostics and keeps
+// serving. After a shutdown signal it drains the HTTP server with stopHTTP;
+// in both cases it then tears down the background services with
+// stopServices, so a failed bind does not exit with tunnels still running.
+// It returns serve's error, if any.
+func serveUntilShutdown(serve func() error, signals <-chan os.Signal, dumpDiagnostics func(), stopHTTP, stopServices func(context.Context)) error {
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- serve()
+	}()
 
-	case err := <-serverErrors:
-		if err != nil && !errors.Is(err, http.ErrServerClosed) {
-			logger.Sugar.Errorf("Server failed: %v", err)
-			log.Fatal(err)
+	var serveErr error
+	for {
+		select {
+		case sig := <-signals:
+			if slices.Contains(diagnosticsSignals, sig) {
+				logger.Sugar.Infof("Received %v, dumping diagnostics", sig)
+				dumpDiagnostics()
+				continue
+			}
+			logger.Sugar.Infof("Received shutdown signal: %v", sig)
+		case err := <-serverErrors:
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Sugar.Errorf("Server failed: %v", err)
+				serveErr = err
+			}
 		}
+		break
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if serveErr == nil {
+		stopHTTP(ctx)
 	}
+	stopServices(ctx)
+	return serveErr
 }
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"cfui/internal/config"
+	"cfui/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+func TestServeUntilShutdownStopsServicesAfterBindFailure(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	bindErr := errors.New("listen tcp 0.0.0.0:14333: bind: address already in use")
+	var calls []string
+	err := serveUntilShutdown(
+		func() error { return bindErr },
+		make(chan os.Signal),
+		func() {},
+		func(context.Context) { calls = append(calls, "http") },
+		func(context.Context) { calls = append(calls, "services") },
+	)
+	if !errors.Is(err, bindErr) {
+		t.Fatalf("serveUntilShutdown error = %v, want the bind error", err)
+	}
+	if len(calls) != 1 || calls[0] != "services" {
+		t.Fatalf("shutdown calls = %v, want [services]", calls)
+	}
+}
+
+func TestServeUntilShutdownDrainsHTTPOnSignal(t *testing.T) {
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	release := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGTERM
+	var calls []string
+	err := serveUntilShutdown(
+		func() error { <-release; return nil },
+		signals,
+		func() {},
+		func(context.Context) { calls = append(calls, "http"); close(release) },
+		func(context.Context) { calls = append(calls, "services") },
+	)
+	if err != nil {
+		t.Fatalf("serveUntilShutdown error = %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "http" || calls[1] != "services" {
+		t.Fatalf("shutdown calls = %v, want [http services]", calls)
+	}
+}
+
+func TestServeUntilShutdownDumpsDiagnosticsWithoutStopping(t *testing.T) {
+	if len(diagnosticsSignals) == 0 {
+		t.Skip("no diagnostics signal on this platform")
+	}
+	prev := logger.Sugar
+	logger.Sugar = zap.NewNop().Sugar()
+	t.Cleanup(func() { logger.Sugar = prev })
+
+	release := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signals <- diagnosticsSignals[0]
+	var calls []string
+	err := serveUntilShutdown(
+		func() error { <-release; return nil },
+		signals,
+		func() {
+			calls = append(calls, "diagnostics")
+			// Still serving: only the next signal shuts down.
+			signals <- syscall.SIGTERM
+		},
+		func(context.Context) { calls = append(calls, "http"); close(release) },
+		func(context.Context) { calls = append(calls, "services") },
+	)
+	if err != nil {
+		t.Fatalf("serveUntilShutdown error = %v", err)
+	}
+	if strings.Join(calls, " ") != "diagnostics http services" {
+		t.Fatalf("calls = %v, want [diagnostics http services]", calls)
+	}
+}
+
+func TestListenAddressPrecedence(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		cfg      config.Config
+		wantHost string
+		wantPort string
+	}{
+		{name: "defaults", wantHost: "0.0.0.0", wantPort: "14333"},
+		{name: "config", cfg: config.Config{ListenAddr: "127.0.0.1", ListenPort: 8080}, wantHost: "127.0.0.1", wantPort: "8080"},
+		{
+			name:     "env over config",
+			env:      map[string]string{"CFUI_BIND_ADDR": "::1", "PORT": "9090"},
+			cfg:      config.Config{ListenAddr: "127.0.0.1", ListenPort: 8080},
+			wantHost: "::1", wantPort: "9090",
+		},
+		{
+			name:     "legacy BIND_HOST over config",
+			env:      map[string]string{"BIND_HOST": "192.0.2.1"},
+			cfg:      config.Config{ListenAddr: "127.0.0.1", ListenPort: 8080},
+			wantHost: "192.0.2.1", wantPort: "8080",
+		},
+		{
+			name:     "CFUI_BIND_ADDR over BIND_HOST",
+			env:      map[string]string{"CFUI_BIND_ADDR": "127.0.0.2", "BIND_HOST": "192.0.2.1"},
+			wantHost: "127.0.0.2", wantPort: "14333",
+		},
+		{
+			name:     "bracketed IPv6 BIND_HOST",
+			env:      map[string]string{"BIND_HOST": "[::]"},
+			wantHost: "::", wantPort: "14333",
+		},
+		{
+			name:     "bracketed IPv6 config",
+			cfg:      config.Config{ListenAddr: "[::1]"},
+			wantHost: "::1", wantPort: "14333",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(key string) string { return tc.env[key] }
+			host, port := listenAddress(getenv, tc.cfg)
+			if host != tc.wantHost || port != tc.wantPort {
+				t.Fatalf("listenAddress = %s, %s; want %s, %s", host, port, tc.wantHost, tc.wantPort)
+			}
+		})
+	}
+}
+
+func TestListenUnixServesHTTPAndReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfui.sock")
+	// A socket file left behind by a crashed run.
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("create stale socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	ln, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != unixSocketMode {
+		t.Fatalf("socket mode = %v (%v), want %v", info.Mode().Perm(), err, os.FileMode(unixSocketMode))
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, "pong")
+	})}
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+
+	if _, err := listenUnix(path); err == nil {
+		t.Fatal("listenUnix took over a socket that is still served")
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://cfui/api/ping")
+	if err != nil {
+		t.Fatalf("request over unix socket: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "pong" {
+		t.Fatalf("response = %d %q, want 200 pong", resp.StatusCode, body)
+	}
+}
+
+func TestWritableDirReportsOrFallsBack(t *testing.T) {
+	// A directory below a regular file cannot be created, even as root.
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	unwritable := filepath.Join(blocker, "data")
+
+	_, _, err := writableDir("DATA_DIR", unwritable, false)
+	if err == nil {
+		t.Fatal("writableDir accepted an unwritable directory")
+	}
+	for _, want := range []string{"DATA_DIR", unwritable, "CFUI_ALLOW_TMP_FALLBACK"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	dir, warning, err := writableDir("DATA_DIR", unwritable, true)
+	if err != nil {
+		t.Fatalf("fallback: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if dir == unwritable || !strings.Contains(warning, dir) {
+		t.Fatalf("fallback dir %q, warning %q; want a temp dir named in the warning", dir, warning)
+	}
+	if err := probeWritable(dir); err != nil {
+		t.Fatalf("fallback dir is not writable: %v", err)
+	}
+
+	ok := filepath.Join(t.TempDir(), "data")
+	if dir, warning, err := writableDir("DATA_DIR", ok, true); err != nil || dir != ok || warning != "" {
+		t.Fatalf("writable dir = %q, %q, %v; want it unchanged", dir, warning, err)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// diagnosticsSignals make cfui log a diagnostics snapshot instead of
+// shutting down.
+var diagnosticsSignals = []os.Signal{syscall.SIGUSR1}
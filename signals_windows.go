@@ -0,0 +1,6 @@
+package main
+
+import "os"
+
+// diagnosticsSignals is empty on Windows, which has no SIGUSR1.
+var diagnosticsSignals []os.Signal
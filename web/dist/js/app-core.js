@@ -274,7 +274,14 @@
             headers: { 'Content-Type': 'application/json' },
             body: body == null ? undefined : JSON.stringify(body),
         });
-        if (!res.ok) throw new Error(await apiError(res));
+        if (!res.ok) {
+            /* Keep the machine-readable code (e.g. not_running) for callers. */
+            const d = await res.clone().json().catch(() => ({}));
+            const err = new Error(await apiError(res));
+            err.status = res.status;
+            err.code = d.code;
+            throw err;
+        }
         return res.json().catch(() => ({}));
     }
 
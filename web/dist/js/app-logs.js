@@ -222,6 +222,25 @@
             if (state.streamLines.length > 2000) state.streamLines.shift();
             renderStreamLine(e.data);
         };
+        /* Another dashboard saved the config; reload ours. */
+        es.addEventListener('config-changed', () => {
+            if (state.logStream !== es) return;
+            window.cfui.fetchConfig?.();
+        });
+        /* The server is going away; reconnect once it is back. */
+        es.addEventListener('shutdown', (e) => {
+            if (state.logStream !== es) return;
+            let retryAfter = 3000;
+            try { retryAfter = JSON.parse(e.data).retry_after_ms || retryAfter; } catch { /* keep default */ }
+            es.close();
+            state.logStream = null;
+            state.isStreamConnected = false;
+            state.isStreamConnecting = false;
+            setLogConnPill('loading', 'log_server_restarting');
+            updateStreamButton();
+            toast.info(t('log_server_restarting'));
+            setTimeout(connectLogStream, retryAfter);
+        });
         es.onerror = () => {
             if (state.logStream !== es) return;
             setLogConnPill('error', 'log_status_failed');
@@ -31,6 +31,11 @@
         show('tab-mcp', classic && !!data.mcp);
         show('tab-s3', classic && !!data.s3_webdav);
         show('tab-features', classic);
+        /* Deployment toggles (CFUI_HIDE_ADVANCED) only change what is rendered. */
+        const advanced = !data.ui?.hide_advanced;
+        const tlsField = $('tls-toggle-row')?.closest('.form-field');
+        if (tlsField) tlsField.hidden = !advanced;
+        show('restart-now', advanced);
         window.cfui.syncWorkspaceFromRoute?.();
     }
 
@@ -3,7 +3,7 @@
    ========================================================================= */
 (() => {
     'use strict';
-    const { state, $, t, apiGet, apiSend, toast, setBusy, flashField, setTokenVisible, sleep } = window.cfui;
+    const { state, $, t, apiGet, apiSend, toast, setBusy, flashField, setTokenVisible } = window.cfui;
 
     /* ---- Config form helpers ---- */
 
@@ -12,7 +12,7 @@
             cfg.token || '', cfg.protocol || 'auto', cfg.region || '',
             cfg.custom_tag || '', cfg.software_name || '',
             cfg.grace_period || '30s', String(cfg.retries ?? 5),
-            cfg.edge_bind_address || '', String(cfg.no_tls_verify || false),
+            cfg.edge_bind_address || '', cfg.edge_interface || '', String(cfg.no_tls_verify || false),
         ].join('\x1f');
     }
 
@@ -42,14 +42,17 @@
             custom_tag: $('custom-version-input').value.trim(),
             software_name: $('software-name-input').value.trim() || 'cfui',
             auto_start: $('autostart-toggle').checked,
+            lazy_start: $('lazystart-toggle').checked,
             auto_restart: $('autorestart-toggle').checked,
             protocol: $('protocol-select').value,
             grace_period: $('grace-period-input').value.trim() || '30s',
+            idle_timeout: $('idle-timeout-input').value.trim(),
             region: $('region-select').value,
             retries: parseInt($('retries-input').value, 10) || 0,
             metrics_enable: $('metrics-enable-toggle').checked,
             metrics_port: parseInt($('metrics-port-input').value, 10) || 60123,
             edge_bind_address: $('edge-bind-address-input').value.trim(),
+            edge_interface: $('edge-interface-input').value.trim(),
             no_tls_verify: $('no-tls-verify-toggle').checked,
         };
     }
@@ -62,14 +65,17 @@
         $('custom-version-input').value = source.custom_tag || '';
         $('software-name-input').value = source.software_name || 'cfui';
         $('autostart-toggle').checked = !!source.auto_start;
+        $('lazystart-toggle').checked = !!source.lazy_start;
         $('autorestart-toggle').checked = source.auto_restart !== false;
         $('protocol-select').value = source.protocol || 'auto';
         $('grace-period-input').value = source.grace_period || '30s';
+        $('idle-timeout-input').value = source.idle_timeout || '';
         $('region-select').value = source.region || '';
         $('retries-input').value = source.retries ?? 5;
         $('metrics-enable-toggle').checked = !!source.metrics_enable;
         $('metrics-port-input').value = source.metrics_port || 60123;
         $('edge-bind-address-input').value = source.edge_bind_address || '';
+        $('edge-interface-input').value = source.edge_interface || '';
         $('no-tls-verify-toggle').checked = !!source.no_tls_verify;
         updateMetricsVisibility();
         updateTunnelProfileUI();
@@ -82,14 +88,17 @@
             custom_tag: cfg.custom_tag || '',
             software_name: cfg.software_name || 'cfui',
             auto_start: !!cfg.auto_start,
+            lazy_start: !!cfg.lazy_start,
             auto_restart: cfg.auto_restart !== false,
             protocol: cfg.protocol || 'auto',
             grace_period: cfg.grace_period || '30s',
+            idle_timeout: cfg.idle_timeout || '',
             region: cfg.region || '',
             retries: numberOr(cfg.retries, 5),
             metrics_enable: !!cfg.metrics_enable,
             metrics_port: numberOr(cfg.metrics_port, 60123),
             edge_bind_address: cfg.edge_bind_address || '',
+            edge_interface: cfg.edge_interface || '',
             no_tls_verify: !!cfg.no_tls_verify,
         };
     }
@@ -152,9 +161,9 @@
                 state.localConfigSignature = localConfigSignature(readConfigFromForm());
                 if (showFeedback) {
                     ['tunnel-name-input','token-input','custom-version-input','software-name-input',
-                     'autostart-toggle','autorestart-toggle','protocol-select',
-                     'grace-period-input','region-select','retries-input',
-                     'metrics-enable-toggle','metrics-port-input','edge-bind-address-input',
+                     'autostart-toggle','lazystart-toggle','autorestart-toggle','protocol-select',
+                     'grace-period-input','idle-timeout-input','region-select','retries-input',
+                     'metrics-enable-toggle','metrics-port-input','edge-bind-address-input','edge-interface-input',
                      'no-tls-verify-toggle'].forEach((id) => $(id)?.classList.remove('field-saved'));
                     if (source !== 'button' && cfg.token !== undefined) flashField('token-input');
                     if (source !== 'button') toast.ok(t('config_saved'));
@@ -214,17 +223,30 @@
         hint.hidden = !fieldsChangedWhileRunning();
     }
 
+    /* With require_confirm on, a stop is refused (428) until the tunnel key is
+       typed back; ask once and retry. */
+    async function sendControl(key, action) {
+        const path = `/tunnels/${encodeURIComponent(key)}/control`;
+        try {
+            return await apiSend(path, 'POST', { action });
+        } catch (err) {
+            if (err.code !== 'confirmation_required') throw err;
+            const confirm = window.prompt(t('confirm_stop_prompt', { key }));
+            if (confirm == null) throw err;
+            return apiSend(path, 'POST', { action, confirm: confirm.trim() });
+        }
+    }
+
     async function restartTunnel() {
         const btn = $('restart-now');
-        const key = encodeURIComponent(selectedTunnelKey());
-        setBusy(btn, true, t('stopping'));
+        setBusy(btn, true, t('restarting'));
         try {
-            await apiSend(`/tunnels/${key}/control`, 'POST', { action: 'stop' });
-            await sleep(1200);
-            setBusy(btn, true, t('starting'));
             /* Re-save current config before restart */
             await saveConfig({ showFeedback: false, source: 'button' });
-            await apiSend(`/tunnels/${key}/control`, 'POST', { action: 'start' });
+            /* One request: the server waits for the old run to exit and
+               answers once the new one is launched (or starts a stopped
+               tunnel). */
+            await sendControl(selectedTunnelKey(), 'restart');
             toast.ok(t('tunnel_restarted'));
         } catch (err) {
             toast.err(err.message);
@@ -335,13 +357,13 @@
         }
         setBusy(btn, true, t(action === 'start' ? 'starting' : 'stopping'));
         try {
-            await apiSend(`/tunnels/${encodeURIComponent(key)}/control`, 'POST', { action });
+            await sendControl(key, action);
             toast.ok(t(action === 'start' ? 'tunnel_start_requested' : 'tunnel_stop_requested'));
             if (action === 'start' && key === selectedTunnelKey()) hideTunnelAlert();
             if (action === 'start') delete state.runningSigs[key];
             setTimeout(fetchStatus, 500);
         } catch (err) {
-            if (action === 'stop') {
+            if (action === 'stop' && err.code !== 'confirmation_required') {
                 toast.ok(t('tunnel_stop_requested'));
                 setTimeout(fetchStatus, 500);
             } else {
@@ -720,12 +742,15 @@
         $('software-name-input')?.addEventListener('change', sav('input'));
         $('protocol-select')?.addEventListener('change', sav('input'));
         $('grace-period-input')?.addEventListener('change', sav('input'));
+        $('idle-timeout-input')?.addEventListener('change', sav('input'));
         $('region-select')?.addEventListener('change', sav('input'));
         $('retries-input')?.addEventListener('change', sav('input'));
         $('edge-bind-address-input')?.addEventListener('change', sav('input'));
+        $('edge-interface-input')?.addEventListener('change', sav('input'));
         $('metrics-port-input')?.addEventListener('change', sav('input'));
         $('metrics-enable-toggle')?.addEventListener('change', () => { updateMetricsVisibility(); saveConfig({ source: 'toggle' }); });
         $('autostart-toggle')?.addEventListener('change', sav('toggle'));
+        $('lazystart-toggle')?.addEventListener('change', sav('toggle'));
         $('autorestart-toggle')?.addEventListener('change', sav('toggle'));
         $('no-tls-verify-toggle')?.addEventListener('change', async () => { await maybeConfirmTLS(); saveConfig({ source: 'toggle' }); });
 
@@ -11,15 +11,19 @@
         try {
             const res = await fetch(`/api/i18n/${lang}`);
             if (!res.ok) throw new Error('load failed');
-            state.translations = await res.json();
+            const { _meta: meta, ...translations } = await res.json();
+            state.translations = translations;
+            state.localeMeta = meta || { direction: 'ltr', date_format: 'YYYY-MM-DD' };
         } catch (err) {
             console.error('i18n load failed', lang, err);
             if (lang !== 'en') { await loadLanguage('en'); return; }
             state.translations = {};
+            state.localeMeta = { direction: 'ltr', date_format: 'YYYY-MM-DD' };
         }
         state.currentLang = lang;
         localStorage.setItem('lang', lang);
         document.documentElement.lang = lang;
+        document.documentElement.dir = state.localeMeta.direction;
         applyTranslations();
         applyLogTranslations();
         document.dispatchEvent(new CustomEvent('localechange', { detail: { lang } }));